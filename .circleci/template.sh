@@ -1,2 +0,0 @@
-#!/usr/bin/env bash
-export CIRCLECI_TEMPLATE=go-library-oss
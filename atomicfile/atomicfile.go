@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package atomicfile provides functions for writing files atomically. Content is written to a temporary file in the
+// same directory as the destination, and the temporary file is renamed to the destination only once all of the
+// content has been written successfully. Readers of the destination therefore observe either the previous content or
+// the new content, but never partially written content.
+package atomicfile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// File is a file that is being written atomically. Content written to the File is not visible at the destination path
+// until Close is called. Abort discards the written content.
+type File struct {
+	*os.File
+	path string
+	perm os.FileMode
+	done bool
+}
+
+// Create returns a new File that will be written to the provided path with the provided mode when it is closed. The
+// directory that contains the path must exist.
+func Create(path string, perm os.FileMode) (*File, error) {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	f, err := ioutil.TempFile(dir, "."+base+".tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file for %s: %v", path, err)
+	}
+	return &File{
+		File: f,
+		path: path,
+		perm: perm,
+	}, nil
+}
+
+// Close syncs and closes the temporary file and renames it to the destination path, replacing any existing file. If
+// any step fails, the temporary file is removed and the destination is not modified. Calling Close after Close or Abort
+// has been called is a no-op.
+func (f *File) Close() error {
+	if f.done {
+		return nil
+	}
+	f.done = true
+
+	tmpPath := f.File.Name()
+	if err := f.commit(); err != nil {
+		_ = f.File.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+func (f *File) commit() error {
+	if err := f.File.Sync(); err != nil {
+		return fmt.Errorf("failed to sync temporary file for %s: %v", f.path, err)
+	}
+	if err := f.File.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file for %s: %v", f.path, err)
+	}
+	if err := os.Chmod(f.File.Name(), f.perm); err != nil {
+		return fmt.Errorf("failed to set mode of temporary file for %s: %v", f.path, err)
+	}
+	if err := os.Rename(f.File.Name(), f.path); err != nil {
+		return fmt.Errorf("failed to rename temporary file to %s: %v", f.path, err)
+	}
+	return nil
+}
+
+// Abort closes and removes the temporary file without modifying the destination. Calling Abort after Close or Abort
+// has been called is a no-op.
+func (f *File) Abort() error {
+	if f.done {
+		return nil
+	}
+	f.done = true
+	_ = f.File.Close()
+	return os.Remove(f.File.Name())
+}
+
+// WriteFile atomically writes the provided data to the file at the provided path with the provided mode.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	f, err := Create(path, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Abort()
+		return fmt.Errorf("failed to write temporary file for %s: %v", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atomicfile_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/atomicfile"
+)
+
+func TestWriteFile(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	path := filepath.Join(tmpDir, "file.txt")
+	require.NoError(t, ioutil.WriteFile(path, []byte("old"), 0600))
+	require.NoError(t, atomicfile.WriteFile(path, []byte("new"), 0644))
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(content))
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0644), fi.Mode().Perm())
+	}
+	assertOnlyFiles(t, tmpDir, "file.txt")
+}
+
+func TestAbort(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	path := filepath.Join(tmpDir, "file.txt")
+	require.NoError(t, ioutil.WriteFile(path, []byte("old"), 0644))
+
+	f, err := atomicfile.Create(path, 0644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("partial"))
+	require.NoError(t, err)
+
+	// content is not visible until the file is closed
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(content))
+
+	require.NoError(t, f.Abort())
+	require.NoError(t, f.Close())
+
+	content, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(content))
+	assertOnlyFiles(t, tmpDir, "file.txt")
+}
+
+func assertOnlyFiles(t *testing.T, dir string, want ...string) {
+	infos, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	var got []string
+	for _, fi := range infos {
+		got = append(got, fi.Name())
+	}
+	assert.Equal(t, want, got)
+}
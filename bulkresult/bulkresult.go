@@ -0,0 +1,206 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bulkresult records the outcome of every item of a bulk operation (such as a command that processes many
+// files or resources) so that partial failures can be reported consistently: as a summary table for humans, as a
+// machine-readable JSON report and as an error whose presence is determined by a configurable policy.
+package bulkresult
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/palantir/pkg/safejson"
+	"github.com/palantir/pkg/tableprinter"
+)
+
+// Status is the outcome of an item.
+type Status string
+
+const (
+	// Succeeded indicates that the operation succeeded for an item.
+	Succeeded Status = "succeeded"
+	// Failed indicates that the operation failed for an item.
+	Failed Status = "failed"
+)
+
+// Item is the outcome of the operation for a single item.
+type Item struct {
+	// Name identifies the item (for example, a path or resource identifier).
+	Name string `json:"name"`
+	// Status is the outcome of the operation for the item.
+	Status Status `json:"status"`
+	// Detail is an optional description of the outcome.
+	Detail string `json:"detail,omitempty"`
+	// Error is the message of the error for items that failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Summary contains the number of items with each outcome.
+type Summary struct {
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+// Policy determines whether the result of a bulk operation with failures is an error.
+type Policy int
+
+const (
+	// FailIfAny treats the operation as failed if any item failed.
+	FailIfAny Policy = iota
+	// FailIfAll treats the operation as failed only if every item failed (and there is at least one item).
+	FailIfAll
+	// AlwaysSucceed never treats the operation as failed.
+	AlwaysSucceed
+)
+
+// Report accumulates the outcomes of the items of a bulk operation. The zero value is an empty report that is ready to
+// use. A Report is safe for concurrent use, so it can be updated by concurrent workers.
+type Report struct {
+	mu    sync.Mutex
+	items []Item
+}
+
+// Succeed records that the operation succeeded for the item with the provided name.
+func (r *Report) Succeed(name, detail string) {
+	r.add(Item{
+		Name:   name,
+		Status: Succeeded,
+		Detail: detail,
+	})
+}
+
+// Fail records that the operation failed for the item with the provided name.
+func (r *Report) Fail(name string, err error) {
+	item := Item{
+		Name:   name,
+		Status: Failed,
+	}
+	if err != nil {
+		item.Error = err.Error()
+	}
+	r.add(item)
+}
+
+// Record records the outcome of the item with the provided name based on the provided error: the item failed if err
+// is non-nil and succeeded otherwise.
+func (r *Report) Record(name string, err error) {
+	if err != nil {
+		r.Fail(name, err)
+		return
+	}
+	r.Succeed(name, "")
+}
+
+func (r *Report) add(item Item) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items = append(r.items, item)
+}
+
+// Items returns the recorded items in the order in which they were recorded.
+func (r *Report) Items() []Item {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Item(nil), r.items...)
+}
+
+// Summary returns the number of items with each outcome.
+func (r *Report) Summary() Summary {
+	var s Summary
+	for _, item := range r.Items() {
+		s.Total++
+		switch item.Status {
+		case Succeeded:
+			s.Succeeded++
+		case Failed:
+			s.Failed++
+		}
+	}
+	return s
+}
+
+// WriteTable writes a table with the name, status and detail or error of every item followed by a line that summarizes
+// the number of items that succeeded and failed.
+func (r *Report) WriteTable(w io.Writer) error {
+	items := r.Items()
+	rows := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, item)
+	}
+	printer := tableprinter.New(tabwriter.NewWriter(w, 0, 0, 2, ' ', 0), map[string]tableprinter.ColumnGetter{
+		"ITEM":   func(row interface{}) string { return row.(Item).Name },
+		"STATUS": func(row interface{}) string { return string(row.(Item).Status) },
+		"DETAIL": func(row interface{}) string {
+			item := row.(Item)
+			if item.Error != "" {
+				return item.Error
+			}
+			return item.Detail
+		},
+	}, false, true, false)
+	if err := printer.Print([]string{"ITEM", "STATUS", "DETAIL"}, rows); err != nil {
+		return err
+	}
+	s := r.Summary()
+	_, err := fmt.Fprintf(w, "\n%d succeeded, %d failed, %d total\n", s.Succeeded, s.Failed, s.Total)
+	return err
+}
+
+// report is the JSON representation of a Report.
+type report struct {
+	Summary Summary `json:"summary"`
+	Items   []Item  `json:"items"`
+}
+
+// MarshalJSON returns a JSON object with a "summary" field that contains the Summary and an "items" field that
+// contains the items.
+func (r *Report) MarshalJSON() ([]byte, error) {
+	items := r.Items()
+	if items == nil {
+		items = []Item{}
+	}
+	return safejson.Marshal(report{
+		Summary: r.Summary(),
+		Items:   items,
+	})
+}
+
+// WriteJSON writes the JSON representation of the report (see MarshalJSON) to the provided writer.
+func (r *Report) WriteJSON(w io.Writer) error {
+	return safejson.Encoder(w).Encode(r)
+}
+
+// Err returns an error if the outcome of the operation is a failure according to the provided policy. The returned
+// error is a *PartialFailureError.
+func (r *Report) Err(policy Policy) error {
+	s := r.Summary()
+	switch policy {
+	case FailIfAny:
+		if s.Failed == 0 {
+			return nil
+		}
+	case FailIfAll:
+		if s.Total == 0 || s.Failed < s.Total {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return &PartialFailureError{
+		Summary: s,
+	}
+}
+
+// PartialFailureError is returned by Report.Err when a bulk operation is considered failed.
+type PartialFailureError struct {
+	Summary Summary
+}
+
+func (e *PartialFailureError) Error() string {
+	return fmt.Sprintf("%d of %d items failed", e.Summary.Failed, e.Summary.Total)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bulkresult_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/bulkresult"
+)
+
+func TestReport(t *testing.T) {
+	var report bulkresult.Report
+	report.Succeed("a.txt", "uploaded 12 bytes")
+	report.Fail("b.txt", fmt.Errorf("permission denied"))
+	report.Record("c.txt", nil)
+
+	assert.Equal(t, bulkresult.Summary{Total: 3, Succeeded: 2, Failed: 1}, report.Summary())
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, report.WriteTable(buf))
+	assert.Equal(t, `ITEM   STATUS     DETAIL
+a.txt  succeeded  uploaded 12 bytes
+b.txt  failed     permission denied
+c.txt  succeeded  
+
+2 succeeded, 1 failed, 3 total
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, report.WriteJSON(buf))
+	assert.Equal(t, `{"summary":{"total":3,"succeeded":2,"failed":1},"items":[{"name":"a.txt","status":"succeeded","detail":"uploaded 12 bytes"},{"name":"b.txt","status":"failed","error":"permission denied"},{"name":"c.txt","status":"succeeded"}]}`+"\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, (&bulkresult.Report{}).WriteJSON(buf))
+	assert.Equal(t, `{"summary":{"total":0,"succeeded":0,"failed":0},"items":[]}`+"\n", buf.String())
+}
+
+func TestReportErr(t *testing.T) {
+	newReport := func(numSucceeded, numFailed int) *bulkresult.Report {
+		report := &bulkresult.Report{}
+		for i := 0; i < numSucceeded; i++ {
+			report.Succeed(fmt.Sprintf("ok-%d", i), "")
+		}
+		for i := 0; i < numFailed; i++ {
+			report.Fail(fmt.Sprintf("failed-%d", i), fmt.Errorf("failed"))
+		}
+		return report
+	}
+
+	for i, tc := range []struct {
+		numSucceeded, numFailed int
+		policy                  bulkresult.Policy
+		wantErr                 string
+	}{
+		{2, 0, bulkresult.FailIfAny, ""},
+		{2, 1, bulkresult.FailIfAny, "1 of 3 items failed"},
+		{2, 1, bulkresult.FailIfAll, ""},
+		{0, 3, bulkresult.FailIfAll, "3 of 3 items failed"},
+		{0, 0, bulkresult.FailIfAll, ""},
+		{0, 3, bulkresult.AlwaysSucceed, ""},
+	} {
+		err := newReport(tc.numSucceeded, tc.numFailed).Err(tc.policy)
+		if tc.wantErr == "" {
+			assert.NoError(t, err, "Case %d", i)
+			continue
+		}
+		assert.EqualError(t, err, tc.wantErr, "Case %d", i)
+		assert.IsType(t, &bulkresult.PartialFailureError{}, err, "Case %d", i)
+	}
+}
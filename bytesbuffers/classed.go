@@ -0,0 +1,268 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bytesbuffers
+
+import (
+	"bytes"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ClassedPool is a Pool that stores buffers in size classes. Buffers are requested using GetSize, which returns a
+// buffer from the smallest class that can hold the requested number of bytes, so that small requests do not hold on
+// to large buffers and large requests do not repeatedly grow small ones.
+//
+// When adaptive sizing is enabled using WithAdaptiveSizing, the pool records the sizes that are requested and
+// periodically replaces its class boundaries with boundaries derived from the observed distribution of sizes.
+//
+// Stats returns a snapshot of the hit, miss and oversize counts of the pool.
+type ClassedPool struct {
+	buffersPerClass int
+
+	mu      sync.RWMutex
+	classes []*sizeClass
+
+	oversize    uint64
+	adjustments uint64
+
+	// adaptive sizing: observed request sizes are recorded in a histogram of power-of-2 buckets
+	adaptiveClasses  int
+	adaptiveInterval uint64
+	requests         uint64
+	histogram        [64]uint64
+}
+
+type sizeClass struct {
+	size    int
+	buffers chan *bytes.Buffer
+	hits    uint64
+	misses  uint64
+}
+
+// ClassedPoolOption configures a ClassedPool.
+type ClassedPoolOption func(p *ClassedPool)
+
+// WithAdaptiveSizing enables adaptive sizing: after every interval calls to GetSize, the class boundaries of the pool
+// are recomputed so that there are (up to) numClasses classes that divide the observed request sizes into groups of
+// equal frequency. Class sizes are rounded up to a power of 2. Buffers stored in classes that no longer exist are
+// released.
+func WithAdaptiveSizing(numClasses, interval int) ClassedPoolOption {
+	return func(p *ClassedPool) {
+		p.adaptiveClasses = numClasses
+		p.adaptiveInterval = uint64(interval)
+	}
+}
+
+// NewClassedPool returns a new ClassedPool with the provided class sizes that stores up to buffersPerClass released
+// buffers in each class.
+func NewClassedPool(classSizes []int, buffersPerClass int, opts ...ClassedPoolOption) *ClassedPool {
+	p := &ClassedPool{
+		buffersPerClass: buffersPerClass,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.classes = p.newClasses(classSizes)
+	return p
+}
+
+func (p *ClassedPool) newClasses(sizes []int) []*sizeClass {
+	sorted := append([]int(nil), sizes...)
+	sort.Ints(sorted)
+	var classes []*sizeClass
+	for _, size := range sorted {
+		if size <= 0 || (len(classes) > 0 && classes[len(classes)-1].size == size) {
+			continue
+		}
+		classes = append(classes, &sizeClass{
+			size:    size,
+			buffers: make(chan *bytes.Buffer, p.buffersPerClass),
+		})
+	}
+	return classes
+}
+
+// Get returns a buffer from the smallest class of the pool.
+func (p *ClassedPool) Get() *bytes.Buffer {
+	return p.GetSize(0)
+}
+
+// GetSize returns an empty buffer that has a capacity of at least the provided size. If the size is larger than the
+// largest class of the pool, a new buffer is allocated and the request is counted as oversize.
+func (p *ClassedPool) GetSize(size int) *bytes.Buffer {
+	p.observe(size)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	idx := sort.Search(len(p.classes), func(i int) bool {
+		return p.classes[i].size >= size
+	})
+	if idx == len(p.classes) {
+		atomic.AddUint64(&p.oversize, 1)
+		return bytes.NewBuffer(make([]byte, 0, size))
+	}
+	class := p.classes[idx]
+	select {
+	case b := <-class.buffers:
+		atomic.AddUint64(&class.hits, 1)
+		return b
+	default:
+		atomic.AddUint64(&class.misses, 1)
+		return bytes.NewBuffer(make([]byte, 0, class.size))
+	}
+}
+
+// Put resets the provided buffer and stores it in the largest class whose size does not exceed the capacity of the
+// buffer. Buffers that are smaller than the smallest class or larger than the largest class are discarded, as are
+// buffers for classes that are full.
+func (p *ClassedPool) Put(buf *bytes.Buffer) {
+	buf.Reset()
+	capacity := buf.Cap()
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(p.classes) == 0 || capacity > p.classes[len(p.classes)-1].size {
+		return
+	}
+	idx := sort.Search(len(p.classes), func(i int) bool {
+		return p.classes[i].size > capacity
+	}) - 1
+	if idx < 0 {
+		return
+	}
+	select {
+	case p.classes[idx].buffers <- buf:
+	default:
+	}
+}
+
+func (p *ClassedPool) observe(size int) {
+	if p.adaptiveInterval == 0 {
+		return
+	}
+	atomic.AddUint64(&p.histogram[bucket(size)], 1)
+	if atomic.AddUint64(&p.requests, 1)%p.adaptiveInterval == 0 {
+		p.adapt()
+	}
+}
+
+// bucket returns the index of the histogram bucket for the provided size: bucket i holds sizes in (2^(i-1), 2^i].
+func bucket(size int) int {
+	if size <= 1 {
+		return 0
+	}
+	return bits.Len(uint(size - 1))
+}
+
+// adapt replaces the classes of the pool with classes derived from the observed request sizes.
+func (p *ClassedPool) adapt() {
+	var counts [64]uint64
+	var total uint64
+	for i := range p.histogram {
+		counts[i] = atomic.LoadUint64(&p.histogram[i])
+		total += counts[i]
+	}
+	if total == 0 {
+		return
+	}
+
+	// the class boundaries are the upper bounds of the buckets that contain the quantiles of the observed sizes
+	var sizes []int
+	var cumulative uint64
+	next := 1
+	for i, count := range counts {
+		cumulative += count
+		for next <= p.adaptiveClasses && cumulative*uint64(p.adaptiveClasses) >= total*uint64(next) {
+			sizes = append(sizes, 1<<uint(i))
+			next++
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if sameSizes(p.classes, sizes) {
+		return
+	}
+	p.classes = p.newClasses(sizes)
+	p.adjustments++
+}
+
+func sameSizes(classes []*sizeClass, sizes []int) bool {
+	deduped := make([]int, 0, len(sizes))
+	for _, size := range sizes {
+		if len(deduped) == 0 || deduped[len(deduped)-1] != size {
+			deduped = append(deduped, size)
+		}
+	}
+	if len(classes) != len(deduped) {
+		return false
+	}
+	for i := range classes {
+		if classes[i].size != deduped[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Stats is a snapshot of the statistics of a ClassedPool.
+type Stats struct {
+	// Classes contains the statistics of the current classes of the pool in order of increasing size. When adaptive
+	// sizing is enabled, the counts of a class start at 0 whenever the class boundaries are adjusted.
+	Classes []ClassStats
+	// Oversize is the number of requests for buffers that were larger than the largest class of the pool.
+	Oversize uint64
+	// Adjustments is the number of times the class boundaries of the pool were adjusted by adaptive sizing.
+	Adjustments uint64
+}
+
+// ClassStats are the statistics for a single class of a ClassedPool.
+type ClassStats struct {
+	// Size is the capacity of the buffers in the class.
+	Size int
+	// Hits is the number of requests that were served using a buffer stored in the class.
+	Hits uint64
+	// Misses is the number of requests for which a new buffer was allocated because the class was empty.
+	Misses uint64
+	// Pooled is the number of buffers currently stored in the class.
+	Pooled int
+}
+
+// Stats returns a snapshot of the statistics of the pool.
+func (p *ClassedPool) Stats() Stats {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	stats := Stats{
+		Oversize:    atomic.LoadUint64(&p.oversize),
+		Adjustments: p.adjustments,
+	}
+	for _, class := range p.classes {
+		stats.Classes = append(stats.Classes, ClassStats{
+			Size:   class.size,
+			Hits:   atomic.LoadUint64(&class.hits),
+			Misses: atomic.LoadUint64(&class.misses),
+			Pooled: len(class.buffers),
+		})
+	}
+	return stats
+}
+
+// String returns a summary of the statistics with one line per class that is suitable for display to users.
+func (s Stats) String() string {
+	var sb strings.Builder
+	for _, class := range s.Classes {
+		hitRate := 0.0
+		if total := class.Hits + class.Misses; total > 0 {
+			hitRate = 100 * float64(class.Hits) / float64(total)
+		}
+		_, _ = fmt.Fprintf(&sb, "class %d bytes: %d hits, %d misses (%.1f%% hit rate), %d pooled\n", class.Size, class.Hits, class.Misses, hitRate, class.Pooled)
+	}
+	_, _ = fmt.Fprintf(&sb, "oversize: %d, adjustments: %d", s.Oversize, s.Adjustments)
+	return sb.String()
+}
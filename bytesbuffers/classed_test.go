@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bytesbuffers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/bytesbuffers"
+)
+
+func TestClassedPool_Stats(t *testing.T) {
+	pool := bytesbuffers.NewClassedPool([]int{1024, 64, 256}, 2)
+
+	small := pool.GetSize(10)
+	assert.Equal(t, 64, small.Cap())
+	medium := pool.GetSize(65)
+	assert.Equal(t, 256, medium.Cap())
+	large := pool.GetSize(2048)
+	assert.Equal(t, 2048, large.Cap())
+
+	pool.Put(small)
+	pool.Put(medium)
+	pool.Put(large)
+	assert.True(t, small == pool.GetSize(64), "expected small buffer to get reused")
+
+	assert.Equal(t, bytesbuffers.Stats{
+		Classes: []bytesbuffers.ClassStats{
+			{Size: 64, Hits: 1, Misses: 1},
+			{Size: 256, Misses: 1, Pooled: 1},
+			{Size: 1024},
+		},
+		Oversize: 1,
+	}, pool.Stats())
+	assert.Equal(t, "class 64 bytes: 1 hits, 1 misses (50.0% hit rate), 0 pooled\n"+
+		"class 256 bytes: 0 hits, 1 misses (0.0% hit rate), 1 pooled\n"+
+		"class 1024 bytes: 0 hits, 0 misses (0.0% hit rate), 0 pooled\n"+
+		"oversize: 1, adjustments: 0", pool.Stats().String())
+}
+
+func TestClassedPool_PutFilesByCapacity(t *testing.T) {
+	pool := bytesbuffers.NewClassedPool([]int{64, 256, 1024}, 1)
+
+	// buffer that grew beyond its class is stored in the largest class it can serve
+	buf := pool.GetSize(64)
+	_, _ = buf.Write(make([]byte, 300))
+	pool.Put(buf)
+	assert.Equal(t, []int{0, 1, 0}, pooledCounts(pool.Stats()))
+
+	// buffer that grew beyond the largest class is discarded
+	buf = pool.GetSize(1024)
+	_, _ = buf.Write(make([]byte, 5000))
+	pool.Put(buf)
+	assert.Equal(t, []int{0, 1, 0}, pooledCounts(pool.Stats()))
+}
+
+func TestClassedPool_AdaptiveSizing(t *testing.T) {
+	pool := bytesbuffers.NewClassedPool([]int{16}, 4, bytesbuffers.WithAdaptiveSizing(2, 100))
+	for i := 0; i < 100; i++ {
+		size := 100
+		if i%2 == 0 {
+			size = 3000
+		}
+		pool.Put(pool.GetSize(size))
+	}
+
+	stats := pool.Stats()
+	assert.Equal(t, uint64(1), stats.Adjustments)
+	assert.Equal(t, []int{128, 4096}, classSizes(stats))
+
+	// subsequent requests are served by the new classes
+	assert.Equal(t, 4096, pool.GetSize(3000).Cap())
+	assert.Equal(t, uint64(1), pool.Stats().Classes[1].Misses)
+}
+
+func pooledCounts(stats bytesbuffers.Stats) []int {
+	var counts []int
+	for _, class := range stats.Classes {
+		counts = append(counts, class.Pooled)
+	}
+	return counts
+}
+
+func classSizes(stats bytesbuffers.Stats) []int {
+	var sizes []int
+	for _, class := range stats.Classes {
+		sizes = append(sizes, class.Size)
+	}
+	return sizes
+}
@@ -3,7 +3,10 @@
 // license that can be found in the LICENSE file.
 
 // Package bytesbuffers provides multiple implementations of a "byte buffer pool" allowing for reuse
-// of preallocated memory in the form of a *bytes.Buffer.
+// of preallocated memory in the form of a *bytes.Buffer. ClassedPool stores buffers in size classes, records
+// hit/miss statistics and can adapt its classes to the observed request sizes. This package also provides
+// SpillBuffer, a writer that buffers content in memory up to a threshold and then transparently spills it to a
+// temporary file.
 //
 // Example Usage: Marshal a JSON request body to a buffer, then put it back in the pool after the request.
 //
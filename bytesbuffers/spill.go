@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bytesbuffers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// SpillBuffer is an io.Writer that buffers content in memory until the amount of buffered content exceeds a threshold,
+// at which point all of the content is transparently moved to a temporary file and all subsequent writes go to that
+// file. This allows output to be buffered completely (for example, so that it can be sorted, paged or rendered after
+// the fact) without the risk of exhausting memory when the output is very large.
+//
+// Once writing is complete, the content can be read using Reader or WriteTo. Close must be called once the buffer is
+// no longer needed to remove the temporary file (if one was created).
+type SpillBuffer struct {
+	threshold int
+	dir       string
+	buf       *bytes.Buffer
+	file      *os.File
+	size      int64
+	closed    bool
+}
+
+// NewSpillBuffer returns a new SpillBuffer that spills to a temporary file once more than threshold bytes have been
+// written to it. The temporary file is created in the provided directory: if dir is empty, the default directory for
+// temporary files is used.
+func NewSpillBuffer(threshold int, dir string) *SpillBuffer {
+	return &SpillBuffer{
+		threshold: threshold,
+		dir:       dir,
+		buf:       &bytes.Buffer{},
+	}
+}
+
+// Write writes the provided bytes to the buffer, spilling to disk if the write causes the threshold to be exceeded.
+func (b *SpillBuffer) Write(p []byte) (int, error) {
+	if b.closed {
+		return 0, fmt.Errorf("write to closed SpillBuffer")
+	}
+	if b.file == nil && b.buf.Len()+len(p) > b.threshold {
+		if err := b.spill(); err != nil {
+			return 0, err
+		}
+	}
+
+	var n int
+	var err error
+	if b.file != nil {
+		// write at the end of the content explicitly because readers returned by Reader share the file
+		n, err = b.file.WriteAt(p, b.size)
+	} else {
+		n, err = b.buf.Write(p)
+	}
+	b.size += int64(n)
+	return n, err
+}
+
+func (b *SpillBuffer) spill() error {
+	f, err := ioutil.TempFile(b.dir, "spillbuffer-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for buffer: %v", err)
+	}
+	if _, err := b.buf.WriteTo(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return fmt.Errorf("failed to write buffer to temporary file: %v", err)
+	}
+	b.file = f
+	// release the memory used by the in-memory buffer
+	b.buf = &bytes.Buffer{}
+	return nil
+}
+
+// Len returns the total number of bytes written to the buffer.
+func (b *SpillBuffer) Len() int64 {
+	return b.size
+}
+
+// Spilled returns true if the content of the buffer has been moved to a temporary file.
+func (b *SpillBuffer) Spilled() bool {
+	return b.file != nil
+}
+
+// Reader returns a reader that reads all of the content that has been written to the buffer from the beginning. The
+// returned reader is only valid until the next call to Write, Reader, WriteTo or Close.
+func (b *SpillBuffer) Reader() (io.Reader, error) {
+	if b.closed {
+		return nil, fmt.Errorf("read from closed SpillBuffer")
+	}
+	if b.file == nil {
+		return bytes.NewReader(b.buf.Bytes()), nil
+	}
+	// a section reader does not modify the offset of the file, which is not used by Write
+	return io.NewSectionReader(b.file, 0, b.size), nil
+}
+
+// WriteTo writes all of the content that has been written to the buffer to the provided writer.
+func (b *SpillBuffer) WriteTo(w io.Writer) (int64, error) {
+	r, err := b.Reader()
+	if err != nil {
+		return 0, err
+	}
+	return io.Copy(w, r)
+}
+
+// Close releases the resources used by the buffer and removes the temporary file if one was created.
+func (b *SpillBuffer) Close() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	b.buf = nil
+	if b.file == nil {
+		return nil
+	}
+	closeErr := b.file.Close()
+	if err := os.Remove(b.file.Name()); err != nil {
+		return err
+	}
+	return closeErr
+}
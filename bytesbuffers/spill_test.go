@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bytesbuffers_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/bytesbuffers"
+)
+
+func TestSpillBuffer(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	buf := bytesbuffers.NewSpillBuffer(10, tmpDir)
+
+	_, err = buf.Write([]byte("hello"))
+	require.NoError(t, err)
+	assert.False(t, buf.Spilled())
+	assertDirEntries(t, tmpDir, 0)
+
+	_, err = buf.Write([]byte(", world!"))
+	require.NoError(t, err)
+	assert.True(t, buf.Spilled())
+	assertDirEntries(t, tmpDir, 1)
+	assert.Equal(t, int64(13), buf.Len())
+
+	out := &bytes.Buffer{}
+	_, err = buf.WriteTo(out)
+	require.NoError(t, err)
+	assert.Equal(t, "hello, world!", out.String())
+
+	// writes after reading are appended
+	_, err = buf.Write([]byte(" more"))
+	require.NoError(t, err)
+	r, err := buf.Reader()
+	require.NoError(t, err)
+	content, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "hello, world! more", string(content))
+
+	require.NoError(t, buf.Close())
+	assertDirEntries(t, tmpDir, 0)
+
+	_, err = buf.Write([]byte("closed"))
+	assert.EqualError(t, err, "write to closed SpillBuffer")
+}
+
+func TestSpillBufferWriteAfterUnreadReader(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	buf := bytesbuffers.NewSpillBuffer(4, tmpDir)
+	defer func() {
+		_ = buf.Close()
+	}()
+
+	_, err = buf.Write([]byte("hello, world!"))
+	require.NoError(t, err)
+	require.True(t, buf.Spilled())
+
+	// obtaining a reader without exhausting it must not cause subsequent writes to overwrite content
+	r, err := buf.Reader()
+	require.NoError(t, err)
+	partial := make([]byte, 5)
+	_, err = r.Read(partial)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(partial))
+
+	_, err = buf.Write([]byte(" more"))
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	_, err = buf.WriteTo(out)
+	require.NoError(t, err)
+	assert.Equal(t, "hello, world! more", out.String())
+}
+
+func TestSpillBufferInMemory(t *testing.T) {
+	buf := bytesbuffers.NewSpillBuffer(1024, "")
+	defer func() {
+		_ = buf.Close()
+	}()
+
+	content := strings.Repeat("a", 1024)
+	_, err := buf.Write([]byte(content))
+	require.NoError(t, err)
+	assert.False(t, buf.Spilled())
+
+	out := &bytes.Buffer{}
+	n, err := buf.WriteTo(out)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1024), n)
+	assert.Equal(t, content, out.String())
+}
+
+func assertDirEntries(t *testing.T, dir string, want int) {
+	entries, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, want)
+}
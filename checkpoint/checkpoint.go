@@ -0,0 +1,203 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package checkpoint records the items that a long-running bulk operation has completed so that the operation can be
+// resumed without repeating them if it is interrupted:
+//
+//	cp, err := checkpoint.Open(path)
+//	if err != nil {
+//		return err
+//	}
+//	defer cp.Close()
+//	for _, item := range items {
+//		if cp.IsDone(item) {
+//			continue
+//		}
+//		if err := process(item); err != nil {
+//			return err
+//		}
+//		if err := cp.MarkDone(item); err != nil {
+//			return err
+//		}
+//	}
+//	return cp.Remove()
+package checkpoint
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/palantir/pkg/atomicfile"
+	"github.com/palantir/pkg/fsutil"
+	"github.com/palantir/pkg/safejson"
+)
+
+// DefaultFlushInterval is the default minimum amount of time between writes of the checkpoint file by MarkDone.
+const DefaultFlushInterval = time.Second
+
+// Checkpoint is a set of completed items that is persisted to a file. The file is written atomically (see the
+// atomicfile package), so it is never left in a partially written state. Writes are throttled so that recording items
+// does not dominate the cost of operations on many small items: MarkDone writes the file at most once per flush
+// interval, and Flush or Close must be called to persist items recorded since the last write. A Checkpoint is safe
+// for concurrent use.
+type Checkpoint struct {
+	path          string
+	flushInterval time.Duration
+	now           func() time.Time
+
+	mu        sync.Mutex
+	done      map[string]struct{}
+	items     []string
+	dirty     bool
+	lastFlush time.Time
+}
+
+// Option configures a Checkpoint.
+type Option func(c *Checkpoint)
+
+// WithFlushInterval sets the minimum amount of time between writes of the checkpoint file by MarkDone. An interval of
+// 0 writes the file every time an item is recorded.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(c *Checkpoint) {
+		c.flushInterval = interval
+	}
+}
+
+// Open returns the checkpoint stored in the file at the provided path. If the file does not exist, the checkpoint is
+// empty: the file is created when the first item is persisted.
+func Open(path string, opts ...Option) (*Checkpoint, error) {
+	c := newCheckpoint(path, opts)
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %v", err)
+	}
+	var stored checkpointFile
+	if err := safejson.Unmarshal(b, &stored); err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint from %s: %v", path, err)
+	}
+	for _, item := range stored.Completed {
+		if _, ok := c.done[item]; ok {
+			continue
+		}
+		c.done[item] = struct{}{}
+		c.items = append(c.items, item)
+	}
+	return c, nil
+}
+
+// New returns an empty checkpoint that is stored in the file at the provided path. Any existing file at the path is
+// removed.
+func New(path string, opts ...Option) (*Checkpoint, error) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove checkpoint: %v", err)
+	}
+	return newCheckpoint(path, opts), nil
+}
+
+func newCheckpoint(path string, opts []Option) *Checkpoint {
+	c := &Checkpoint{
+		path:          path,
+		flushInterval: DefaultFlushInterval,
+		now:           time.Now,
+		done:          make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// checkpointFile is the content of a checkpoint file.
+type checkpointFile struct {
+	Completed []string `json:"completed"`
+}
+
+// Path returns the path of the checkpoint file.
+func (c *Checkpoint) Path() string {
+	return c.path
+}
+
+// IsDone returns true if the provided item has been recorded as completed.
+func (c *Checkpoint) IsDone(item string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.done[item]
+	return ok
+}
+
+// Len returns the number of items that have been recorded as completed.
+func (c *Checkpoint) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// MarkDone records the provided item as completed. The checkpoint file is written if the flush interval has elapsed
+// since it was last written.
+func (c *Checkpoint) MarkDone(item string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.done[item]; ok {
+		return nil
+	}
+	c.done[item] = struct{}{}
+	c.items = append(c.items, item)
+	c.dirty = true
+	if c.now().Sub(c.lastFlush) < c.flushInterval {
+		return nil
+	}
+	return c.flush()
+}
+
+// Flush writes the checkpoint file if any items have been recorded since it was last written.
+func (c *Checkpoint) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.flush()
+}
+
+// Close writes any items that have not been written to the checkpoint file. It is equivalent to Flush.
+func (c *Checkpoint) Close() error {
+	return c.Flush()
+}
+
+// Remove removes the checkpoint file and clears the recorded items. It should be called once the operation has
+// completed successfully.
+func (c *Checkpoint) Remove() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.done = make(map[string]struct{})
+	c.items = nil
+	c.dirty = false
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove checkpoint: %v", err)
+	}
+	return nil
+}
+
+func (c *Checkpoint) flush() error {
+	if !c.dirty {
+		return nil
+	}
+	b, err := safejson.Marshal(checkpointFile{Completed: c.items})
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+	if err := fsutil.MkdirAll(filepath.Dir(c.path), fsutil.SecretDirMode); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+	if err := atomicfile.WriteFile(c.path, append(b, '\n'), fsutil.SecretFileMode); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+	c.dirty = false
+	c.lastFlush = c.now()
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package checkpoint_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/checkpoint"
+)
+
+func TestCheckpoint(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	path := filepath.Join(tmpDir, "checkpoints", "op.json")
+
+	cp, err := checkpoint.Open(path, checkpoint.WithFlushInterval(0))
+	require.NoError(t, err)
+	assert.Equal(t, 0, cp.Len())
+	require.NoError(t, cp.MarkDone("a"))
+	require.NoError(t, cp.MarkDone("b"))
+	require.NoError(t, cp.MarkDone("a"))
+	assert.True(t, cp.IsDone("a"))
+	assert.False(t, cp.IsDone("c"))
+
+	// flush interval of 0 writes on every MarkDone
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"completed":["a","b"]}`+"\n", string(content))
+
+	reopened, err := checkpoint.Open(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reopened.Len())
+	assert.True(t, reopened.IsDone("b"))
+
+	fresh, err := checkpoint.New(path)
+	require.NoError(t, err)
+	assert.Equal(t, 0, fresh.Len())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCheckpointThrottlesWrites(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	path := filepath.Join(tmpDir, "op.json")
+
+	cp, err := checkpoint.Open(path, checkpoint.WithFlushInterval(time.Hour))
+	require.NoError(t, err)
+	// first item is written because the checkpoint has never been flushed
+	require.NoError(t, cp.MarkDone("a"))
+	require.NoError(t, cp.MarkDone("b"))
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"completed":["a"]}`+"\n", string(content))
+
+	require.NoError(t, cp.Close())
+	content, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"completed":["a","b"]}`+"\n", string(content))
+
+	require.NoError(t, cp.Remove())
+	assert.Equal(t, 0, cp.Len())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+	require.NoError(t, cp.Remove())
+}
+
+func TestOpenInvalidCheckpoint(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	path := filepath.Join(tmpDir, "op.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte("not JSON"), 0600))
+
+	_, err = checkpoint.Open(path)
+	assert.EqualError(t, err, "failed to read checkpoint from "+path+": invalid character 'o' in literal null (expecting 'u') (line 1, column 2)")
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clitest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/palantir/pkg/cli"
+)
+
+// SandboxPlaceholder replaces the path of the sandbox directory in the output compared by AssertParallelConsistent.
+const SandboxPlaceholder = "{{sandbox}}"
+
+// ParallelRun is the result of a single run of an application by RunParallel.
+type ParallelRun struct {
+	// Sandbox is the temporary directory that was created for the run.
+	Sandbox  string
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// RunParallel runs n instances of an application concurrently in the current process with the provided arguments (the
+// first argument is the name of the application, as with cli.App.Run). This is intended to be run using the race
+// detector ("go test -race") to detect data races on global state in command implementations.
+//
+// Every run uses a new application created by newApp, which is called with the path of a new temporary directory that
+// the run can use as a sandbox for any files it reads or writes. The Stdout and Stderr of every application are set
+// to dedicated buffers. All runs are started at the same time to maximize the overlap between them. The returned
+// cleanup function removes the sandbox directories.
+func RunParallel(n int, newApp func(sandbox string) *cli.App, args []string) (runs []ParallelRun, cleanup func(), err error) {
+	var sandboxes []string
+	cleanup = func() {
+		for _, sandbox := range sandboxes {
+			_ = os.RemoveAll(sandbox)
+		}
+	}
+
+	apps := make([]*cli.App, n)
+	stdouts := make([]*bytes.Buffer, n)
+	stderrs := make([]*bytes.Buffer, n)
+	for i := range apps {
+		sandbox, err := ioutil.TempDir("", "clitest-")
+		if err != nil {
+			cleanup()
+			return nil, func() {}, fmt.Errorf("failed to create sandbox directory: %v", err)
+		}
+		sandboxes = append(sandboxes, sandbox)
+
+		stdouts[i], stderrs[i] = &bytes.Buffer{}, &bytes.Buffer{}
+		apps[i] = newApp(sandbox)
+		apps[i].Stdout = stdouts[i]
+		apps[i].Stderr = stderrs[i]
+	}
+
+	runs = make([]ParallelRun, n)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range apps {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			runs[i].ExitCode = apps[i].Run(append([]string(nil), args...))
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	for i := range runs {
+		runs[i].Sandbox = sandboxes[i]
+		runs[i].Stdout = stdouts[i].String()
+		runs[i].Stderr = stderrs[i].String()
+	}
+	return runs, cleanup, nil
+}
+
+// AssertParallelConsistent runs n instances of an application concurrently using RunParallel and fails the test if
+// the runs do not all produce the same output and exit code. Occurrences of the path of the sandbox directory of a run
+// in its output are replaced with SandboxPlaceholder before the output is compared. Returns the runs so that callers
+// can make further assertions.
+func AssertParallelConsistent(t testing.TB, n int, newApp func(sandbox string) *cli.App, args []string) []ParallelRun {
+	runs, cleanup, err := RunParallel(n, newApp, args)
+	defer cleanup()
+	if err != nil {
+		t.Fatalf("failed to run %d instances of %v: %v", n, args, err)
+		return nil
+	}
+	for i := range runs {
+		runs[i].Stdout = strings.Replace(runs[i].Stdout, runs[i].Sandbox, SandboxPlaceholder, -1)
+		runs[i].Stderr = strings.Replace(runs[i].Stderr, runs[i].Sandbox, SandboxPlaceholder, -1)
+	}
+	for i := 1; i < len(runs); i++ {
+		if runs[i].ExitCode != runs[0].ExitCode || runs[i].Stdout != runs[0].Stdout || runs[i].Stderr != runs[0].Stderr {
+			t.Errorf("run %d of %v differs from run 0:\nrun 0: exit code %d\nstdout:\n%s\nstderr:\n%s\nrun %d: exit code %d\nstdout:\n%s\nstderr:\n%s",
+				i, args, runs[0].ExitCode, runs[0].Stdout, runs[0].Stderr, i, runs[i].ExitCode, runs[i].Stdout, runs[i].Stderr)
+		}
+	}
+	return runs
+}
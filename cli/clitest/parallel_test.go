@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clitest_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cli"
+	"github.com/palantir/pkg/cli/clitest"
+	"github.com/palantir/pkg/cli/flag"
+)
+
+func TestAssertParallelConsistent(t *testing.T) {
+	newApp := func(sandbox string) *cli.App {
+		app := cli.NewApp()
+		app.Name = "greet"
+		app.Flags = []flag.Flag{
+			flag.StringFlag{Name: "name"},
+		}
+		app.Action = func(ctx cli.Context) error {
+			path := filepath.Join(sandbox, "greeting.txt")
+			if err := ioutil.WriteFile(path, []byte("Hello, "+ctx.String("name")), 0644); err != nil {
+				return err
+			}
+			ctx.Printf("wrote %s\n", path)
+			return nil
+		}
+		return app
+	}
+
+	runs := clitest.AssertParallelConsistent(t, 8, newApp, []string{"greet", "--name", "world"})
+	require.Len(t, runs, 8)
+	for i, run := range runs {
+		assert.Equal(t, 0, run.ExitCode, "Case %d", i)
+		assert.Equal(t, fmt.Sprintf("wrote %s/greeting.txt\n", clitest.SandboxPlaceholder), run.Stdout, "Case %d", i)
+	}
+}
+
+func TestRunParallelIsolatesSandboxes(t *testing.T) {
+	newApp := func(sandbox string) *cli.App {
+		app := cli.NewApp()
+		app.Action = func(ctx cli.Context) error {
+			ctx.Printf("%s", sandbox)
+			return nil
+		}
+		return app
+	}
+
+	runs, cleanup, err := clitest.RunParallel(4, newApp, []string{"app"})
+	defer cleanup()
+	require.NoError(t, err)
+
+	seen := make(map[string]bool)
+	for i, run := range runs {
+		assert.Equal(t, run.Sandbox, run.Stdout, "Case %d", i)
+		assert.False(t, seen[run.Sandbox], "Case %d: sandbox %s used by multiple runs", i, run.Sandbox)
+		seen[run.Sandbox] = true
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package completion
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/palantir/pkg/clicache"
+)
+
+// Cached returns a provider that caches the completions returned by the provided provider in the provided cache for
+// the provided duration. Completions are cached separately for every combination of command and flag values, so the
+// provided provider should only depend on those (and not on the partial value, which is filtered by the shell).
+// Completions are not cached if the provider sets the exit code (for example, to request file path completion). If
+// the cache cannot be read or written, the provided provider is called directly.
+//
+// This is useful for providers that compute completions using slow operations such as network requests.
+func Cached(cache *clicache.Cache, ttl time.Duration, provider Provider) Provider {
+	return func(ctx *ProviderCtx) []string {
+		key := cacheKey(ctx)
+		if data, ok, err := cache.Get(key); err == nil && ok {
+			var completions []string
+			if err := json.Unmarshal(data, &completions); err == nil {
+				return completions
+			}
+		}
+
+		origExitCode := *ctx.ExitCode
+		completions := provider(ctx)
+		if *ctx.ExitCode != origExitCode {
+			return completions
+		}
+		if data, err := json.Marshal(completions); err == nil {
+			_ = cache.Set(key, data, ttl)
+		}
+		return completions
+	}
+}
+
+func cacheKey(ctx *ProviderCtx) string {
+	var flags []string
+	for k, v := range ctx.Flags {
+		flags = append(flags, k+"="+v)
+	}
+	sort.Strings(flags)
+	b, _ := json.Marshal([]interface{}{strings.Join(ctx.Command, " "), flags})
+	return "completion:" + string(b)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package completion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/clicache"
+)
+
+func TestCached(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	cache, err := clicache.New(tmpDir)
+	require.NoError(t, err)
+
+	calls := 0
+	provider := Cached(cache, time.Hour, func(ctx *ProviderCtx) []string {
+		calls++
+		if ctx.CommandIs("files") {
+			return Filepath(ctx)
+		}
+		return []string{"master", ctx.Flags["remote"]}
+	})
+
+	for i, tc := range []struct {
+		command   []string
+		flags     map[string]string
+		want      []string
+		wantCalls int
+	}{
+		{[]string{"checkout"}, map[string]string{"remote": "origin"}, []string{"master", "origin"}, 1},
+		{[]string{"checkout"}, map[string]string{"remote": "origin"}, []string{"master", "origin"}, 1},
+		{[]string{"checkout"}, map[string]string{"remote": "upstream"}, []string{"master", "upstream"}, 2},
+		{[]string{"files"}, nil, nil, 3},
+		{[]string{"files"}, nil, nil, 4},
+	} {
+		var exitCode uint8
+		got := provider(&ProviderCtx{
+			Command:  tc.command,
+			Flags:    tc.flags,
+			ExitCode: &exitCode,
+		})
+		assert.Equal(t, tc.want, got, "Case %d", i)
+		assert.Equal(t, tc.wantCalls, calls, "Case %d", i)
+	}
+}
@@ -0,0 +1,359 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package clicache provides a file-backed cache for command-line applications. Entries are stored in a directory (by
+// default, the cache directory of the application as returned by xdgdir.AppDirs), are keyed by strings and can
+// expire after a TTL. The total size of the cache can be capped, in which case the least recently used entries are
+// evicted when the cap is exceeded.
+//
+// Access to the cache directory is protected by a file lock, so a cache can safely be shared by concurrent
+// invocations of an application.
+package clicache
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/palantir/pkg/atomicfile"
+	"github.com/palantir/pkg/fsutil"
+	"github.com/palantir/pkg/internal/filelock"
+	"github.com/palantir/pkg/xdgdir"
+)
+
+const (
+	entryFileSuffix = ".entry"
+	lockFileName    = ".lock"
+)
+
+// Cache is a file-backed cache. A Cache is safe for concurrent use.
+type Cache struct {
+	dir     string
+	maxSize int64
+	now     func() time.Time
+	mutex   sync.Mutex
+}
+
+// Option configures a Cache.
+type Option func(*Cache)
+
+// WithMaxSize caps the total size (in bytes) of the entries in the cache. When an entry is stored and the total size
+// exceeds the cap, the least recently used entries are evicted until it does not. A value <= 0 specifies that the size
+// is not capped, which is the default.
+func WithMaxSize(maxSize int64) Option {
+	return func(c *Cache) {
+		c.maxSize = maxSize
+	}
+}
+
+// New returns a cache that stores its entries in the provided directory. The directory is created with permissions
+// that only allow access by the current user if it does not exist.
+func New(dir string, opts ...Option) (*Cache, error) {
+	if err := fsutil.MkdirAll(dir, fsutil.SecretDirMode); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %v", err)
+	}
+	c := &Cache{
+		dir: dir,
+		now: time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// ForApp returns a cache that stores its entries in the cache directory of the application with the provided name
+// (see xdgdir.AppDirs).
+func ForApp(appName string, opts ...Option) (*Cache, error) {
+	dirs, err := xdgdir.AppDirs(appName)
+	if err != nil {
+		return nil, err
+	}
+	return New(dirs.Cache, opts...)
+}
+
+// Dir returns the directory in which the entries of the cache are stored.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// entryHeader is the first line of an entry file.
+type entryHeader struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+// Get returns the value stored for the provided key. Returns false if there is no value for the key or if the value
+// has expired.
+func (c *Cache) Get(key string) ([]byte, bool, error) {
+	var value []byte
+	var ok bool
+	err := c.withLock(func() error {
+		path := c.entryPath(key)
+		header, data, err := readEntry(path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil || header.Key != key {
+			// corrupt entries and hash collisions are treated as misses
+			return nil
+		}
+		if c.expired(header) {
+			return removeIfExists(path)
+		}
+		now := c.now()
+		if err := os.Chtimes(path, now, now); err != nil {
+			return fmt.Errorf("failed to update access time of cache entry: %v", err)
+		}
+		value, ok = data, true
+		return nil
+	})
+	return value, ok, err
+}
+
+// Set stores the provided value for the provided key, replacing any existing value. If ttl > 0, the value expires
+// after the provided duration; otherwise, it does not expire. If the size of the cache is capped, the least recently
+// used entries are evicted as necessary.
+func (c *Cache) Set(key string, value []byte, ttl time.Duration) error {
+	return c.withLock(func() error {
+		header := entryHeader{Key: key}
+		if ttl > 0 {
+			header.Expires = c.now().Add(ttl)
+		}
+		headerBytes, err := json.Marshal(header)
+		if err != nil {
+			return fmt.Errorf("failed to marshal cache entry: %v", err)
+		}
+		content := append(append(headerBytes, '\n'), value...)
+		path := c.entryPath(key)
+		if err := atomicfile.WriteFile(path, content, fsutil.SecretFileMode); err != nil {
+			return fmt.Errorf("failed to write cache entry: %v", err)
+		}
+		now := c.now()
+		if err := os.Chtimes(path, now, now); err != nil {
+			return fmt.Errorf("failed to update access time of cache entry: %v", err)
+		}
+		return c.evict()
+	})
+}
+
+// Delete removes the value stored for the provided key. It is not an error if there is no value for the key.
+func (c *Cache) Delete(key string) error {
+	return c.withLock(func() error {
+		return removeIfExists(c.entryPath(key))
+	})
+}
+
+// Clean removes all of the entries in the cache.
+func (c *Cache) Clean() error {
+	return c.withLock(func() error {
+		entries, err := c.entries()
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := removeIfExists(e.path); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Prune removes the entries in the cache that have expired and returns the number of entries that were removed.
+func (c *Cache) Prune() (int, error) {
+	removed := 0
+	err := c.withLock(func() error {
+		entries, err := c.entries()
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if !e.expired {
+				continue
+			}
+			if err := removeIfExists(e.path); err != nil {
+				return err
+			}
+			removed++
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// Info describes the content of a cache.
+type Info struct {
+	// Dir is the directory in which the entries of the cache are stored.
+	Dir string
+	// Entries is the number of entries in the cache, including entries that have expired.
+	Entries int
+	// Expired is the number of entries in the cache that have expired.
+	Expired int
+	// Size is the total size of the entries in the cache in bytes.
+	Size int64
+	// MaxSize is the cap on the total size of the entries in bytes, or 0 if the size is not capped.
+	MaxSize int64
+}
+
+// Info returns information about the content of the cache.
+func (c *Cache) Info() (Info, error) {
+	info := Info{Dir: c.dir}
+	if c.maxSize > 0 {
+		info.MaxSize = c.maxSize
+	}
+	err := c.withLock(func() error {
+		entries, err := c.entries()
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			info.Entries++
+			info.Size += e.size
+			if e.expired {
+				info.Expired++
+			}
+		}
+		return nil
+	})
+	return info, err
+}
+
+type entryInfo struct {
+	path     string
+	size     int64
+	accessed time.Time
+	expired  bool
+}
+
+// entries returns information about all of the entries in the cache. Must be called while holding the lock.
+func (c *Cache) entries() ([]entryInfo, error) {
+	fis, err := ioutil.ReadDir(c.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %v", err)
+	}
+	var entries []entryInfo
+	for _, fi := range fis {
+		if fi.IsDir() || !strings.HasSuffix(fi.Name(), entryFileSuffix) {
+			continue
+		}
+		path := filepath.Join(c.dir, fi.Name())
+		header, err := readEntryHeader(path)
+		entries = append(entries, entryInfo{
+			path:     path,
+			size:     fi.Size(),
+			accessed: fi.ModTime(),
+			// entries that cannot be read are treated as expired so that they are removed
+			expired: err != nil || c.expired(header),
+		})
+	}
+	return entries, nil
+}
+
+// evict removes expired entries and then the least recently used entries until the total size of the cache does not
+// exceed its cap. Must be called while holding the lock.
+func (c *Cache) evict() error {
+	if c.maxSize <= 0 {
+		return nil
+	}
+	entries, err := c.entries()
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].expired != entries[j].expired {
+			return entries[i].expired
+		}
+		return entries[i].accessed.Before(entries[j].accessed)
+	})
+	var total int64
+	for _, e := range entries {
+		total += e.size
+	}
+	for _, e := range entries {
+		if total <= c.maxSize {
+			break
+		}
+		if err := removeIfExists(e.path); err != nil {
+			return err
+		}
+		total -= e.size
+	}
+	return nil
+}
+
+func (c *Cache) expired(header entryHeader) bool {
+	return !header.Expires.IsZero() && !c.now().Before(header.Expires)
+}
+
+func (c *Cache) entryPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+entryFileSuffix)
+}
+
+// withLock calls the provided function while holding both the in-process lock and the file lock of the cache.
+func (c *Cache) withLock(fn func() error) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := fsutil.MkdirAll(c.dir, fsutil.SecretDirMode); err != nil {
+		return fmt.Errorf("failed to create cache directory: %v", err)
+	}
+	return filelock.WithLock(filepath.Join(c.dir, lockFileName), fsutil.SecretFileMode, fn)
+}
+
+func readEntry(path string) (entryHeader, []byte, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return entryHeader{}, nil, err
+	}
+	idx := bytes.IndexByte(content, '\n')
+	if idx == -1 {
+		return entryHeader{}, nil, fmt.Errorf("invalid cache entry %s", path)
+	}
+	var header entryHeader
+	if err := json.Unmarshal(content[:idx], &header); err != nil {
+		return entryHeader{}, nil, fmt.Errorf("invalid cache entry %s: %v", path, err)
+	}
+	return header, content[idx+1:], nil
+}
+
+func readEntryHeader(path string) (entryHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return entryHeader{}, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	line, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil {
+		return entryHeader{}, fmt.Errorf("invalid cache entry %s: %v", path, err)
+	}
+	var header entryHeader
+	if err := json.Unmarshal(line, &header); err != nil {
+		return entryHeader{}, fmt.Errorf("invalid cache entry %s: %v", path, err)
+	}
+	return header, nil
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove cache entry: %v", err)
+	}
+	return nil
+}
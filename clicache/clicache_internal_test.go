@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clicache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestCache(t *testing.T, opts ...Option) (*Cache, *fakeClock, func()) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	require.NoError(t, err)
+	c, err := New(filepath.Join(tmpDir, "cache"), opts...)
+	require.NoError(t, err)
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c.now = clock.Now
+	return c, clock, cleanup
+}
+
+func TestGetSet(t *testing.T) {
+	c, clock, cleanup := newTestCache(t)
+	defer cleanup()
+
+	_, ok, err := c.Get("missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, c.Set("permanent", []byte("value\nwith newline"), 0))
+	require.NoError(t, c.Set("short", []byte("short-lived"), time.Minute))
+
+	got, ok, err := c.Get("permanent")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "value\nwith newline", string(got))
+
+	got, ok, err = c.Get("short")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "short-lived", string(got))
+
+	clock.now = clock.now.Add(time.Minute)
+	_, ok, err = c.Get("short")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	_, ok, err = c.Get("permanent")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, c.Delete("permanent"))
+	require.NoError(t, c.Delete("permanent"))
+	_, ok, err = c.Get("permanent")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestInfoPruneClean(t *testing.T) {
+	c, clock, cleanup := newTestCache(t)
+	defer cleanup()
+
+	require.NoError(t, c.Set("a", []byte("a"), time.Minute))
+	require.NoError(t, c.Set("b", []byte("b"), time.Hour))
+	require.NoError(t, c.Set("c", []byte("c"), 0))
+	clock.now = clock.now.Add(2 * time.Minute)
+
+	info, err := c.Info()
+	require.NoError(t, err)
+	assert.Equal(t, c.Dir(), info.Dir)
+	assert.Equal(t, 3, info.Entries)
+	assert.Equal(t, 1, info.Expired)
+	assert.True(t, info.Size > 0)
+
+	removed, err := c.Prune()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	require.NoError(t, c.Clean())
+	info, err = c.Info()
+	require.NoError(t, err)
+	assert.Equal(t, 0, info.Entries)
+	assert.Equal(t, int64(0), info.Size)
+
+	// lock file is not removed
+	_, err = os.Stat(filepath.Join(c.Dir(), lockFileName))
+	assert.NoError(t, err)
+}
+
+func TestLRUEviction(t *testing.T) {
+	c, clock, cleanup := newTestCache(t)
+	defer cleanup()
+
+	value := make([]byte, 100)
+	require.NoError(t, c.Set("a", value, 0))
+	fi, err := os.Stat(c.entryPath("a"))
+	require.NoError(t, err)
+	c.maxSize = 3 * fi.Size()
+
+	for _, key := range []string{"b", "c"} {
+		clock.now = clock.now.Add(time.Second)
+		require.NoError(t, c.Set(key, value, 0))
+	}
+	// access "a" so that "b" is the least recently used entry
+	clock.now = clock.now.Add(time.Second)
+	_, ok, err := c.Get("a")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	clock.now = clock.now.Add(time.Second)
+	require.NoError(t, c.Set("d", value, 0))
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true, "d": true} {
+		_, ok, err := c.Get(key)
+		require.NoError(t, err)
+		assert.Equal(t, want, ok, key)
+	}
+}
+
+func TestCorruptEntryIsMiss(t *testing.T) {
+	c, _, cleanup := newTestCache(t)
+	defer cleanup()
+
+	require.NoError(t, ioutil.WriteFile(c.entryPath("key"), []byte("not a header"), 0600))
+	_, ok, err := c.Get("key")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	removed, err := c.Prune()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	c, _, cleanup := newTestCache(t, WithMaxSize(1024))
+	defer cleanup()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				assert.NoError(t, c.Set("key", []byte("value"), 0))
+				_, _, err := c.Get("key")
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cliconfig stores the named contexts of a CLI: sets of an endpoint and the credentials used to access it,
+// one of which is the current context. This allows a single installation of a CLI to be used with multiple
+// environments (for example, "production" and "staging") and to switch between them in the manner of kubectl:
+//
+//	cfg, err := cliconfig.Load(path)
+//	if err != nil {
+//		return err
+//	}
+//	cfg.Contexts["staging"] = cliconfig.Context{Endpoint: "https://staging.example.com"}
+//	if err := cfg.SetCurrentContext("staging"); err != nil {
+//		return err
+//	}
+//	return cfg.Save(path)
+//
+// The cobracli package provides a "--context" flag and commands for switching contexts that are backed by this
+// package (see cobracli.ContextsParam).
+package cliconfig
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/palantir/pkg/atomicfile"
+	"github.com/palantir/pkg/fsutil"
+	"github.com/palantir/pkg/safejson"
+	"github.com/palantir/pkg/safeyaml"
+)
+
+// Context is a named set of an endpoint and the credentials used to access it.
+type Context struct {
+	// Endpoint is the base URL of the service that the context targets.
+	Endpoint string `json:"endpoint"`
+	// Credentials are the credentials used to access the endpoint (for example, {"token": "..."}). Their interpretation
+	// is determined by the CLI. The file that stores them is only accessible by the current user.
+	Credentials map[string]string `json:"credentials,omitempty"`
+}
+
+// Config is the configuration of the named contexts of a CLI. It is stored as YAML.
+type Config struct {
+	// CurrentContext is the name of the context that is used if no other context is selected.
+	CurrentContext string `json:"currentContext,omitempty"`
+	// Contexts are the contexts keyed by their names.
+	Contexts map[string]Context `json:"contexts,omitempty"`
+}
+
+// Load reads the configuration stored in the file at the provided path. Returns an empty configuration if the file
+// does not exist. Returns an error if the file is accessible by users other than the current user.
+func Load(path string) (*Config, error) {
+	cfg := &Config{
+		Contexts: make(map[string]Context),
+	}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contexts configuration: %v", err)
+	}
+	if err := fsutil.VerifySecretFile(path); err != nil {
+		return nil, err
+	}
+	jsonBytes, err := safeyaml.YAMLtoJSONBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse contexts configuration %s: %v", path, err)
+	}
+	if err := safejson.Unmarshal(jsonBytes, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse contexts configuration %s: %v", path, err)
+	}
+	if cfg.Contexts == nil {
+		cfg.Contexts = make(map[string]Context)
+	}
+	return cfg, nil
+}
+
+// Save writes the configuration to the file at the provided path, creating its directory if necessary. The file is
+// replaced atomically and is only accessible by the current user.
+func (c *Config) Save(path string) error {
+	jsonBytes, err := safejson.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal contexts configuration: %v", err)
+	}
+	b, err := safeyaml.JSONtoYAMLBytes(jsonBytes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal contexts configuration: %v", err)
+	}
+	if err := fsutil.MkdirAll(filepath.Dir(path), fsutil.SecretDirMode); err != nil {
+		return fmt.Errorf("failed to write contexts configuration: %v", err)
+	}
+	if err := atomicfile.WriteFile(path, b, fsutil.SecretFileMode); err != nil {
+		return fmt.Errorf("failed to write contexts configuration: %v", err)
+	}
+	return nil
+}
+
+// ContextNames returns the names of the contexts in sorted order.
+func (c *Config) ContextNames() []string {
+	names := make([]string, 0, len(c.Contexts))
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Context returns the context with the provided name. Returns an error that lists the available contexts if there is
+// no context with the provided name.
+func (c *Config) Context(name string) (Context, error) {
+	ctx, ok := c.Contexts[name]
+	if !ok {
+		if len(c.Contexts) == 0 {
+			return Context{}, fmt.Errorf("context %q does not exist: no contexts are configured", name)
+		}
+		return Context{}, fmt.Errorf("context %q does not exist: available contexts are %s", name, strings.Join(c.ContextNames(), ", "))
+	}
+	return ctx, nil
+}
+
+// SetCurrentContext sets the current context to the context with the provided name. Returns an error if there is no
+// context with the provided name.
+func (c *Config) SetCurrentContext(name string) error {
+	if _, err := c.Context(name); err != nil {
+		return err
+	}
+	c.CurrentContext = name
+	return nil
+}
+
+type contextKey struct{}
+
+type namedContext struct {
+	name string
+	ctx  Context
+}
+
+// WithContext returns a copy of the provided context.Context that carries the provided named CLI context, so that it
+// can be used to construct API clients. It can be retrieved using FromContext.
+func WithContext(ctx context.Context, name string, cliCtx Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, namedContext{name: name, ctx: cliCtx})
+}
+
+// FromContext returns the name of the CLI context carried by the provided context.Context, the CLI context and true,
+// or false if it does not carry one.
+func FromContext(ctx context.Context) (string, Context, bool) {
+	v, ok := ctx.Value(contextKey{}).(namedContext)
+	if !ok {
+		return "", Context{}, false
+	}
+	return v.name, v.ctx, true
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cliconfig_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cliconfig"
+)
+
+func TestLoadSave(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	path := filepath.Join(tmpDir, "config", "contexts.yml")
+
+	cfg, err := cliconfig.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, &cliconfig.Config{Contexts: map[string]cliconfig.Context{}}, cfg)
+
+	cfg.Contexts["prod"] = cliconfig.Context{
+		Endpoint: "https://prod.example.com",
+		Credentials: map[string]string{
+			"token": "prod-token",
+		},
+	}
+	cfg.Contexts["staging"] = cliconfig.Context{
+		Endpoint: "https://staging.example.com",
+	}
+	require.NoError(t, cfg.SetCurrentContext("staging"))
+	require.NoError(t, cfg.Save(path))
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `currentContext: staging
+contexts:
+  prod:
+    endpoint: https://prod.example.com
+    credentials:
+      token: prod-token
+  staging:
+    endpoint: https://staging.example.com
+`, string(content))
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	loaded, err := cliconfig.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, cfg, loaded)
+	assert.Equal(t, []string{"prod", "staging"}, loaded.ContextNames())
+
+	require.NoError(t, os.Chmod(path, 0644))
+	_, err = cliconfig.Load(path)
+	assert.Error(t, err)
+}
+
+func TestContext(t *testing.T) {
+	cfg := &cliconfig.Config{Contexts: map[string]cliconfig.Context{}}
+	_, err := cfg.Context("prod")
+	assert.EqualError(t, err, `context "prod" does not exist: no contexts are configured`)
+	assert.EqualError(t, cfg.SetCurrentContext("prod"), `context "prod" does not exist: no contexts are configured`)
+
+	cfg.Contexts["staging"] = cliconfig.Context{Endpoint: "https://staging.example.com"}
+	cfg.Contexts["dev"] = cliconfig.Context{Endpoint: "https://dev.example.com"}
+	_, err = cfg.Context("prod")
+	assert.EqualError(t, err, `context "prod" does not exist: available contexts are dev, staging`)
+
+	got, err := cfg.Context("dev")
+	require.NoError(t, err)
+	assert.Equal(t, "https://dev.example.com", got.Endpoint)
+}
+
+func TestWithContext(t *testing.T) {
+	_, _, ok := cliconfig.FromContext(context.Background())
+	assert.False(t, ok)
+
+	cliCtx := cliconfig.Context{Endpoint: "https://prod.example.com"}
+	name, got, ok := cliconfig.FromContext(cliconfig.WithContext(context.Background(), "prod", cliCtx))
+	require.True(t, ok)
+	assert.Equal(t, "prod", name)
+	assert.Equal(t, cliCtx, got)
+}
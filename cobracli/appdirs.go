@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/fsutil"
+	"github.com/palantir/pkg/specdir"
+	"github.com/palantir/pkg/xdgdir"
+)
+
+// AppDirSpecs are the layouts of the configuration, cache and state directories of an application. A nil layout
+// specifies that the directory has no required content. Layouts must be created with rootPartOfSpec set to false:
+// the root of each layout is the directory for the application in the corresponding XDG base directory.
+type AppDirSpecs struct {
+	Config specdir.LayoutSpec
+	Cache  specdir.LayoutSpec
+	State  specdir.LayoutSpec
+}
+
+// AppDirs are the resolved configuration, cache and state directories of an application. The paths named by the
+// aliases of a layout can be retrieved using the Path function of the corresponding SpecDir.
+type AppDirs struct {
+	Config specdir.SpecDir
+	Cache  specdir.SpecDir
+	State  specdir.SpecDir
+}
+
+type appDirsContextKey struct{}
+
+// AppDirsParam returns a Param that resolves the directories of the application with the provided name before a
+// command is run. Each directory is the directory with the provided name in the corresponding XDG base directory (see
+// the xdgdir package). Directories and the required parts of their layouts that do not exist are created with
+// permissions that only allow access by the current user. Returns an error without running the command if a
+// directory is accessible by other users or does not match its layout. The resolved directories can be retrieved from
+// the execution context of the command using AppDirsFromContext.
+func AppDirsParam(appName string, specs AppDirSpecs) Param {
+	return runEDecoratorParam(func(next RunEFunc) RunEFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			dirs, err := resolveAppDirs(appName, specs)
+			if err != nil {
+				return err
+			}
+			restoreCtx := setContext(cmd, context.WithValue(Context(cmd), appDirsContextKey{}, dirs))
+			defer restoreCtx()
+			return next(cmd, args)
+		}
+	})
+}
+
+// AppDirsFromContext returns the application directories resolved by AppDirsParam, or false if the provided context
+// does not carry them.
+func AppDirsFromContext(ctx context.Context) (AppDirs, bool) {
+	dirs, ok := ctx.Value(appDirsContextKey{}).(AppDirs)
+	return dirs, ok
+}
+
+func resolveAppDirs(appName string, specs AppDirSpecs) (AppDirs, error) {
+	xdgDirs, err := xdgdir.AppDirs(appName)
+	if err != nil {
+		return AppDirs{}, err
+	}
+	var dirs AppDirs
+	for _, curr := range []struct {
+		name string
+		root string
+		spec specdir.LayoutSpec
+		dst  *specdir.SpecDir
+	}{
+		{"configuration", xdgDirs.Config, specs.Config, &dirs.Config},
+		{"cache", xdgDirs.Cache, specs.Cache, &dirs.Cache},
+		{"state", xdgDirs.State, specs.State, &dirs.State},
+	} {
+		if err := fsutil.MkdirAll(curr.root, fsutil.SecretDirMode); err != nil {
+			return AppDirs{}, fmt.Errorf("failed to create %s directory: %v", curr.name, err)
+		}
+		if err := fsutil.VerifySecretDir(curr.root); err != nil {
+			return AppDirs{}, fmt.Errorf("invalid %s directory: %v", curr.name, err)
+		}
+		spec := curr.spec
+		if spec == nil {
+			spec = specdir.NewLayoutSpec(specdir.Dir(specdir.LiteralName(appName), ""), false)
+		}
+		specDir, err := specdir.New(curr.root, spec, nil, specdir.Create)
+		if err != nil {
+			return AppDirs{}, fmt.Errorf("invalid %s directory: %v", curr.name, err)
+		}
+		*curr.dst = specDir
+	}
+	return dirs, nil
+}
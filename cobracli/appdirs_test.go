@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package cobracli_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+	"github.com/palantir/pkg/specdir"
+)
+
+func TestAppDirsParam(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	for _, name := range []string{"XDG_CONFIG_HOME", "XDG_CACHE_HOME", "XDG_STATE_HOME"} {
+		val, ok := os.LookupEnv(name)
+		defer func(name, val string, ok bool) {
+			if ok {
+				_ = os.Setenv(name, val)
+			} else {
+				_ = os.Unsetenv(name)
+			}
+		}(name, val, ok)
+		require.NoError(t, os.Setenv(name, filepath.Join(tmpDir, name)))
+	}
+
+	specs := cobracli.AppDirSpecs{
+		State: specdir.NewLayoutSpec(specdir.Dir(specdir.LiteralName("state"), "",
+			specdir.Dir(specdir.LiteralName("logs"), "logs"),
+		), false),
+	}
+	var gotLogs, gotConfig string
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			appDirs, ok := cobracli.AppDirsFromContext(cobracli.Context(cmd))
+			if !ok {
+				return fmt.Errorf("app dirs not set")
+			}
+			gotLogs = appDirs.State.Path("logs")
+			gotConfig = appDirs.Config.Root()
+			return nil
+		},
+	}
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+
+	rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.AppDirsParam("my-app", specs))...)
+	require.Equal(t, 0, rv, buf.String())
+	assert.Equal(t, filepath.Join(tmpDir, "XDG_STATE_HOME", "my-app", "logs"), gotLogs)
+	assert.Equal(t, filepath.Join(tmpDir, "XDG_CONFIG_HOME", "my-app"), gotConfig)
+	fi, err := os.Stat(gotLogs)
+	require.NoError(t, err)
+	assert.True(t, fi.IsDir())
+	fi, err = os.Stat(gotConfig)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), fi.Mode().Perm())
+
+	// directory that is accessible by other users is rejected
+	require.NoError(t, os.Chmod(gotConfig, 0755))
+	buf.Reset()
+	rv = cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.AppDirsParam("my-app", specs))...)
+	assert.Equal(t, 1, rv)
+	assert.Contains(t, buf.String(), "Error: invalid configuration directory: permissions 0755 for "+gotConfig+" are too open")
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/console"
+	"github.com/palantir/pkg/safejson"
+	"github.com/palantir/pkg/safeyaml"
+)
+
+// Validator is implemented by resources that can validate themselves. ApplyCmd validates resources that implement it
+// before they are applied.
+type Validator interface {
+	Validate() error
+}
+
+// ApplySpec specifies how ApplyCmd validates and applies resources of type T.
+type ApplySpec[T any] struct {
+	// Validate validates the desired resource. Optional: if T (or *T) implements Validator, it is validated using its
+	// Validate method before this function is called.
+	Validate func(desired T) error
+	// Get returns the current state of the resource described by the desired resource and whether it exists.
+	// Optional: if it is nil, no diff is shown.
+	Get func(ctx context.Context, desired T) (current T, exists bool, err error)
+	// Apply applies the desired resource. Required.
+	Apply func(ctx context.Context, desired T) error
+}
+
+// ApplyCmd returns a command that applies a resource of type T that is read from the input specified by the flags
+// added by AddInputFileFlags ("-f" and "--input-format"). The command:
+//
+//  1. Decodes the input into a T (see DecodeInput).
+//  2. Validates the resource (see ApplySpec.Validate).
+//  3. Writes a diff between the current and desired state of the resource (rendered as YAML) to the output of the
+//     command if ApplySpec.Get is specified. If the resource exists and there are no differences, nothing is applied.
+//  4. Asks the user to confirm the changes unless "--yes" is specified. If standard input is not a terminal, the
+//     command fails unless "--yes" is specified.
+//  5. Applies the resource using ApplySpec.Apply.
+//
+// If "--dry-run" is specified, the command stops after writing the diff.
+func ApplyCmd[T any](use, short string, spec ApplySpec[T]) *cobra.Command {
+	return applyCmd(use, short, spec, os.Stdin, func() bool {
+		return console.IsTerminal(os.Stdin)
+	})
+}
+
+func applyCmd[T any](use, short string, spec ApplySpec[T], in io.Reader, interactive func() bool) *cobra.Command {
+	var yes, dryRun bool
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+	}
+	input := AddInputFileFlags(cmd)
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "apply the changes without asking for confirmation")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show the changes that would be applied without applying them")
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := Context(cmd)
+		var desired T
+		if err := input.Decode(&desired); err != nil {
+			return err
+		}
+		if err := validateResource(desired, spec.Validate); err != nil {
+			return fmt.Errorf("invalid input: %v", err)
+		}
+
+		if spec.Get != nil {
+			current, exists, err := spec.Get(ctx, desired)
+			if err != nil {
+				return err
+			}
+			diff, err := resourceDiff(current, exists, desired)
+			if err != nil {
+				return err
+			}
+			if diff == "" {
+				cmd.Println("No changes to apply")
+				return nil
+			}
+			_, _ = fmt.Fprint(cmd.OutOrStdout(), diff)
+		}
+		if dryRun {
+			return nil
+		}
+
+		if !yes {
+			if !interactive() {
+				return fmt.Errorf("confirmation is required to apply changes: run the command again with --yes to apply them without confirmation")
+			}
+			_, _ = fmt.Fprint(cmd.OutOrStderr(), "Apply these changes? [y/N] ")
+			answer, readErr := bufio.NewReader(in).ReadString('\n')
+			if readErr != nil && answer == "" {
+				_, _ = fmt.Fprintln(cmd.OutOrStderr())
+			}
+			if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+				return fmt.Errorf("changes were not applied")
+			}
+		}
+		if err := spec.Apply(ctx, desired); err != nil {
+			return err
+		}
+		cmd.Println("Changes applied")
+		return nil
+	}
+	return cmd
+}
+
+func validateResource[T any](desired T, validate func(T) error) error {
+	var v interface{} = desired
+	if validator, ok := v.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return err
+		}
+	} else if validator, ok := interface{}(&desired).(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return err
+		}
+	}
+	if validate != nil {
+		return validate(desired)
+	}
+	return nil
+}
+
+// resourceDiff returns a unified diff between the YAML representations of the current and desired resources. If the
+// resource does not exist, every line of the desired resource is an addition. Returns an empty string if there are no
+// differences.
+func resourceDiff[T any](current T, exists bool, desired T) (string, error) {
+	var currentYAML string
+	if exists {
+		var err error
+		if currentYAML, err = resourceYAML(current); err != nil {
+			return "", err
+		}
+	}
+	desiredYAML, err := resourceYAML(desired)
+	if err != nil {
+		return "", err
+	}
+	if exists && currentYAML == desiredYAML {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(currentYAML),
+		B:        splitLines(desiredYAML),
+		FromFile: "current",
+		ToFile:   "desired",
+		Context:  3,
+	})
+}
+
+func resourceYAML(v interface{}) (string, error) {
+	jsonBytes, err := safejson.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	yamlBytes, err := safeyaml.JSONtoYAMLBytes(jsonBytes)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlBytes), nil
+}
+
+// splitLines splits the provided content into lines that retain their line terminators. Unlike difflib.SplitLines, it
+// does not add an empty line after a trailing line terminator.
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testResource struct {
+	Name     string `json:"name"`
+	Replicas int    `json:"replicas"`
+}
+
+func (r testResource) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name must be specified")
+	}
+	return nil
+}
+
+func TestApplyCmd(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	writeInput := func(content string) string {
+		f, err := ioutil.TempFile(tmpDir, "*.yml")
+		require.NoError(t, err)
+		_, err = f.WriteString(content)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		return f.Name()
+	}
+	validInput := writeInput("name: svc\nreplicas: 3\n")
+
+	for i, tc := range []struct {
+		name        string
+		args        []string
+		current     *testResource
+		stdin       string
+		interactive bool
+		wantApplied bool
+		wantOutput  string
+		wantErr     string
+	}{
+		{
+			name:        "new resource with --yes",
+			args:        []string{"-f", validInput, "--yes"},
+			wantApplied: true,
+			wantOutput:  "--- current\n+++ desired\n@@ -0,0 +1,2 @@\n+name: svc\n+replicas: 3\nChanges applied\n",
+		},
+		{
+			name:        "changed resource confirmed interactively",
+			args:        []string{"-f", validInput},
+			current:     &testResource{Name: "svc", Replicas: 1},
+			stdin:       "y\n",
+			interactive: true,
+			wantApplied: true,
+			wantOutput:  "--- current\n+++ desired\n@@ -1,2 +1,2 @@\n name: svc\n-replicas: 1\n+replicas: 3\nApply these changes? [y/N] Changes applied\n",
+		},
+		{
+			name:        "declined",
+			args:        []string{"-f", validInput},
+			current:     &testResource{Name: "svc", Replicas: 1},
+			stdin:       "n\n",
+			interactive: true,
+			wantOutput:  "--- current\n+++ desired\n@@ -1,2 +1,2 @@\n name: svc\n-replicas: 1\n+replicas: 3\nApply these changes? [y/N] ",
+			wantErr:     "changes were not applied",
+		},
+		{
+			name:       "non-interactive without --yes",
+			args:       []string{"-f", validInput},
+			current:    &testResource{Name: "svc", Replicas: 1},
+			wantOutput: "--- current\n+++ desired\n@@ -1,2 +1,2 @@\n name: svc\n-replicas: 1\n+replicas: 3\n",
+			wantErr:    "confirmation is required to apply changes: run the command again with --yes to apply them without confirmation",
+		},
+		{
+			name:       "dry run",
+			args:       []string{"-f", validInput, "--dry-run"},
+			current:    &testResource{Name: "svc", Replicas: 1},
+			wantOutput: "--- current\n+++ desired\n@@ -1,2 +1,2 @@\n name: svc\n-replicas: 1\n+replicas: 3\n",
+		},
+		{
+			name:       "no changes",
+			args:       []string{"-f", validInput},
+			current:    &testResource{Name: "svc", Replicas: 3},
+			wantOutput: "No changes to apply\n",
+		},
+		{
+			name:    "invalid input",
+			args:    []string{"-f", writeInput("replicas: 3\n"), "--yes"},
+			wantErr: "invalid input: name must be specified",
+		},
+		{
+			name:    "spec validation",
+			args:    []string{"-f", writeInput("name: svc\nreplicas: -1\n"), "--yes"},
+			wantErr: "invalid input: replicas must not be negative",
+		},
+	} {
+		var applied *testResource
+		cmd := applyCmd("apply", "Apply a resource", ApplySpec[testResource]{
+			Validate: func(desired testResource) error {
+				if desired.Replicas < 0 {
+					return fmt.Errorf("replicas must not be negative")
+				}
+				return nil
+			},
+			Get: func(ctx context.Context, desired testResource) (testResource, bool, error) {
+				if tc.current == nil {
+					return testResource{}, false, nil
+				}
+				return *tc.current, true, nil
+			},
+			Apply: func(ctx context.Context, desired testResource) error {
+				applied = &desired
+				return nil
+			},
+		}, strings.NewReader(tc.stdin), func() bool {
+			return tc.interactive
+		})
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		outBuf := &bytes.Buffer{}
+		cmd.SetOutput(outBuf)
+		cmd.SetArgs(tc.args)
+
+		err := cmd.Execute()
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d: %s", i, tc.name)
+		} else {
+			assert.NoError(t, err, "Case %d: %s", i, tc.name)
+		}
+		assert.Equal(t, tc.wantOutput, outBuf.String(), "Case %d: %s", i, tc.name)
+		if tc.wantApplied {
+			assert.Equal(t, &testResource{Name: "svc", Replicas: 3}, applied, "Case %d: %s", i, tc.name)
+		} else {
+			assert.Nil(t, applied, "Case %d: %s", i, tc.name)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/cliconfig"
+)
+
+// DefaultAuthContext is the name of the authentication context used by the commands returned by AuthCmds if no context
+// is selected using ContextsParam.
+const DefaultAuthContext = "default"
+
+// authNow returns the current time. It is a variable so that it can be replaced in tests.
+var authNow = time.Now
+
+// AuthSession describes the authenticated session of a context.
+type AuthSession struct {
+	// Subject identifies the authenticated user or service (for example, a user name or email address).
+	Subject string
+	// Issuer identifies the service that issued the credentials of the session. Optional.
+	Issuer string
+	// Scopes are the scopes granted to the session. Optional.
+	Scopes []string
+	// ExpiresAt is the time at which the credentials of the session expire. The zero value indicates that the
+	// credentials do not expire.
+	ExpiresAt time.Time
+}
+
+// AuthProvider implements authentication for the commands returned by AuthCmds. Providers are responsible for
+// obtaining credentials and for storing them (for example, in a keyring or a token cache) separately for every named
+// context, so that a user can be logged in to multiple environments at the same time.
+type AuthProvider interface {
+	// Login obtains and stores credentials for the context with the provided name and returns the resulting session.
+	// Providers that use the OAuth 2.0 device authorization flow should use PromptDeviceCode to show the code to the
+	// user.
+	Login(cmd *cobra.Command, context string) (AuthSession, error)
+	// Logout removes the stored credentials of the context with the provided name.
+	Logout(cmd *cobra.Command, context string) error
+	// Session returns the session of the context with the provided name and true, or false if there are no stored
+	// credentials for the context.
+	Session(cmd *cobra.Command, context string) (AuthSession, bool, error)
+}
+
+// DeviceCode is the information that a user needs to complete an OAuth 2.0 device authorization flow.
+type DeviceCode struct {
+	// VerificationURI is the URI that the user should open in a browser.
+	VerificationURI string
+	// UserCode is the code that the user should enter.
+	UserCode string
+	// ExpiresAt is the time at which the code expires. Optional.
+	ExpiresAt time.Time
+}
+
+// PromptDeviceCode writes instructions for completing a device authorization flow with the provided code to the status
+// writer of the provided command (see Status).
+func PromptDeviceCode(cmd *cobra.Command, code DeviceCode) {
+	w := Status(cmd)
+	_, _ = fmt.Fprintf(w, "To log in, open %s in a browser and enter the code %s\n", code.VerificationURI, code.UserCode)
+	if !code.ExpiresAt.IsZero() {
+		_, _ = fmt.Fprintf(w, "The code expires in %v.\n", roundAuthDuration(code.ExpiresAt.Sub(authNow())))
+	}
+	_, _ = fmt.Fprintln(w, "Waiting for authorization...")
+}
+
+// AuthCmdsParam returns a Param that adds the commands returned by AuthCmds for the provided provider to the root
+// command.
+func AuthCmdsParam(provider AuthProvider) Param {
+	return ConfigureCmdParam(func(cmd *cobra.Command) {
+		cmd.AddCommand(AuthCmds(provider)...)
+	})
+}
+
+// AuthCmds returns "login", "logout" and "whoami" commands that are implemented by the provided provider. The commands
+// use the context selected by ContextsParam (using its "--context" flag or the current context), so the context that
+// they authenticate is always the context that other commands use. If ContextsParam is not used or does not select a
+// context, DefaultAuthContext is used.
+//
+//   - "login" logs in to the context and reports the subject and when the credentials expire.
+//   - "logout" logs out of the context. Logging out of a context that is not logged in is not an error.
+//   - "whoami" writes the session of the context (subject, issuer, scopes and expiry) using WriteResult, so it supports
+//     the output formats of OutputFormatParam. Fails if the context is not logged in or its credentials have expired.
+func AuthCmds(provider AuthProvider) []*cobra.Command {
+	loginCmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loginContext := authContext(cmd)
+			session, err := provider.Login(cmd, loginContext)
+			if err != nil {
+				return fmt.Errorf("failed to log in to context %q: %v", loginContext, err)
+			}
+			msg := fmt.Sprintf("Logged in to context %q as %s", loginContext, session.Subject)
+			if !session.ExpiresAt.IsZero() {
+				msg += fmt.Sprintf(" (expires in %v)", roundAuthDuration(session.ExpiresAt.Sub(authNow())))
+			}
+			_, _ = fmt.Fprintln(Status(cmd), msg)
+			return nil
+		},
+	}
+	logoutCmd := &cobra.Command{
+		Use:   "logout",
+		Short: "Log out",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logoutContext := authContext(cmd)
+			if _, ok, err := provider.Session(cmd, logoutContext); err != nil {
+				return fmt.Errorf("failed to determine session of context %q: %v", logoutContext, err)
+			} else if !ok {
+				_, _ = fmt.Fprintf(Status(cmd), "Not logged in to context %q\n", logoutContext)
+				return nil
+			}
+			if err := provider.Logout(cmd, logoutContext); err != nil {
+				return fmt.Errorf("failed to log out of context %q: %v", logoutContext, err)
+			}
+			_, _ = fmt.Fprintf(Status(cmd), "Logged out of context %q\n", logoutContext)
+			return nil
+		},
+	}
+	whoamiCmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Print the identity of the logged in user",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			whoamiContext := authContext(cmd)
+			session, ok, err := provider.Session(cmd, whoamiContext)
+			if err != nil {
+				return fmt.Errorf("failed to determine session of context %q: %v", whoamiContext, err)
+			}
+			if !ok {
+				return fmt.Errorf("not logged in to context %q: run %q to log in", whoamiContext, loginCommandLine(cmd, loginCmd, whoamiContext))
+			}
+			now := authNow()
+			if !session.ExpiresAt.IsZero() && !session.ExpiresAt.After(now) {
+				return fmt.Errorf("the credentials of context %q expired %v ago: run %q to log in again", whoamiContext, roundAuthDuration(now.Sub(session.ExpiresAt)), loginCommandLine(cmd, loginCmd, whoamiContext))
+			}
+			result := whoamiResult{
+				Context: whoamiContext,
+				Subject: session.Subject,
+				Issuer:  session.Issuer,
+				Scopes:  session.Scopes,
+			}
+			if !session.ExpiresAt.IsZero() {
+				result.ExpiresAt = session.ExpiresAt.UTC().Format(time.RFC3339)
+				result.ExpiresIn = roundAuthDuration(session.ExpiresAt.Sub(now)).String()
+			}
+			return WriteResult(cmd, result)
+		},
+	}
+	return []*cobra.Command{loginCmd, logoutCmd, whoamiCmd}
+}
+
+type whoamiResult struct {
+	Context   string   `json:"context"`
+	Subject   string   `json:"subject"`
+	Issuer    string   `json:"issuer,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
+	ExpiresAt string   `json:"expiresAt,omitempty"`
+	ExpiresIn string   `json:"expiresIn,omitempty"`
+}
+
+func (r whoamiResult) RenderText(w io.Writer) error {
+	lines := []string{
+		fmt.Sprintf("Context:  %s", r.Context),
+		fmt.Sprintf("Subject:  %s", r.Subject),
+	}
+	if r.Issuer != "" {
+		lines = append(lines, fmt.Sprintf("Issuer:   %s", r.Issuer))
+	}
+	if len(r.Scopes) > 0 {
+		lines = append(lines, fmt.Sprintf("Scopes:   %s", strings.Join(r.Scopes, ", ")))
+	}
+	if r.ExpiresAt != "" {
+		lines = append(lines, fmt.Sprintf("Expires:  %s (in %s)", r.ExpiresAt, r.ExpiresIn))
+	} else {
+		lines = append(lines, "Expires:  never")
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// authContext returns the name of the context selected by ContextsParam for the provided command, or
+// DefaultAuthContext if no context is selected.
+func authContext(cmd *cobra.Command) string {
+	if name, _, ok := cliconfig.FromContext(Context(cmd)); ok {
+		return name
+	}
+	return DefaultAuthContext
+}
+
+// loginCommandLine returns the command line that runs the provided login command for the provided context. The
+// context is only specified if it was specified for the provided command.
+func loginCommandLine(cmd, loginCmd *cobra.Command, context string) string {
+	cmdLine := loginCmd.CommandPath()
+	if flag := cmd.Flags().Lookup("context"); flag != nil && flag.Changed {
+		cmdLine += " --context " + context
+	}
+	return cmdLine
+}
+
+// roundAuthDuration rounds the provided duration to a precision that is appropriate for displaying credential expiry.
+func roundAuthDuration(d time.Duration) time.Duration {
+	if d > time.Hour {
+		return d.Round(time.Minute)
+	}
+	return d.Round(time.Second)
+}
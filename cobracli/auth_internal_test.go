@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cliconfig"
+)
+
+type testAuthProvider struct {
+	sessions map[string]AuthSession
+}
+
+func (p *testAuthProvider) Login(cmd *cobra.Command, context string) (AuthSession, error) {
+	PromptDeviceCode(cmd, DeviceCode{
+		VerificationURI: "https://example.com/device",
+		UserCode:        "ABCD-EFGH",
+		ExpiresAt:       authNow().Add(15 * time.Minute),
+	})
+	session := AuthSession{
+		Subject:   context + "-user@example.com",
+		Issuer:    "https://example.com",
+		Scopes:    []string{"read", "write"},
+		ExpiresAt: authNow().Add(2 * time.Hour),
+	}
+	p.sessions[context] = session
+	return session, nil
+}
+
+func (p *testAuthProvider) Logout(cmd *cobra.Command, context string) error {
+	delete(p.sessions, context)
+	return nil
+}
+
+func (p *testAuthProvider) Session(cmd *cobra.Command, context string) (AuthSession, bool, error) {
+	session, ok := p.sessions[context]
+	return session, ok, nil
+}
+
+func TestAuthCmds(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	origAuthNow := authNow
+	authNow = func() time.Time {
+		return now
+	}
+	defer func() {
+		authNow = origAuthNow
+	}()
+
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	configPath := filepath.Join(tmpDir, "contexts.yml")
+	cfg, err := cliconfig.Load(configPath)
+	require.NoError(t, err)
+	for _, name := range []string{"expired", "service", "staging"} {
+		cfg.Contexts[name] = cliconfig.Context{Endpoint: "https://" + name + ".example.com"}
+	}
+	require.NoError(t, cfg.Save(configPath))
+
+	provider := &testAuthProvider{
+		sessions: map[string]AuthSession{
+			"expired": {
+				Subject:   "old@example.com",
+				ExpiresAt: now.Add(-5 * time.Minute),
+			},
+			"service": {
+				Subject: "robot",
+			},
+		},
+	}
+	for i, tc := range []struct {
+		args       []string
+		wantRV     int
+		wantOutput string
+	}{
+		{[]string{"whoami"}, 1, "Error: not logged in to context \"default\": run \"my-app login\" to log in\n"},
+		{[]string{"login"}, 0, "To log in, open https://example.com/device in a browser and enter the code ABCD-EFGH\n" +
+			"The code expires in 15m0s.\n" +
+			"Waiting for authorization...\n" +
+			"Logged in to context \"default\" as default-user@example.com (expires in 2h0m0s)\n"},
+		{[]string{"whoami"}, 0, "Context:  default\n" +
+			"Subject:  default-user@example.com\n" +
+			"Issuer:   https://example.com\n" +
+			"Scopes:   read, write\n" +
+			"Expires:  2026-01-02T05:04:05Z (in 2h0m0s)\n"},
+		{[]string{"whoami", "--output-format", "json"}, 0, `{
+  "context": "default",
+  "subject": "default-user@example.com",
+  "issuer": "https://example.com",
+  "scopes": [
+    "read",
+    "write"
+  ],
+  "expiresAt": "2026-01-02T05:04:05Z",
+  "expiresIn": "2h0m0s"
+}
+`},
+		{[]string{"whoami", "--context", "service"}, 0, "Context:  service\nSubject:  robot\nExpires:  never\n"},
+		{[]string{"whoami", "--context", "expired"}, 1, "Error: the credentials of context \"expired\" expired 5m0s ago: run \"my-app login --context expired\" to log in again\n"},
+		{[]string{"logout"}, 0, "Logged out of context \"default\"\n"},
+		{[]string{"logout"}, 0, "Not logged in to context \"default\"\n"},
+		{[]string{"whoami", "--context", "staging"}, 1, "Error: not logged in to context \"staging\": run \"my-app login --context staging\" to log in\n"},
+		{[]string{"context", "use", "staging"}, 0, "Switched to context \"staging\"\n"},
+		// the current context is used if the flag is not specified
+		{[]string{"login"}, 0, "To log in, open https://example.com/device in a browser and enter the code ABCD-EFGH\n" +
+			"The code expires in 15m0s.\n" +
+			"Waiting for authorization...\n" +
+			"Logged in to context \"staging\" as staging-user@example.com (expires in 2h0m0s)\n"},
+		{[]string{"whoami", "--context", "expired"}, 1, "Error: the credentials of context \"expired\" expired 5m0s ago: run \"my-app login --context expired\" to log in again\n"},
+		{[]string{"whoami", "--context", "unknown"}, 1, "Error: context \"unknown\" does not exist: available contexts are expired, service, staging\n"},
+	} {
+		rootCmd := &cobra.Command{Use: "my-app"}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := Execute(rootCmd, append(DefaultParams(nil), OutputFormatParam(OutputFormatText), ContextsParam(configPath), AuthCmdsParam(provider))...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/palantir/pkg/console"
+)
+
+// AutocorrectMode specifies how AutocorrectParam handles a mistyped command.
+type AutocorrectMode int
+
+const (
+	// AutocorrectPrompt asks whether the suggested command should be run and runs it if the answer is yes. The prompt
+	// is only shown if standard input is a terminal.
+	AutocorrectPrompt AutocorrectMode = iota
+	// AutocorrectRun prints a warning and runs the suggested command without asking.
+	AutocorrectRun
+)
+
+var unknownCommandRegexp = regexp.MustCompile(`^unknown command ("(?:[^"\\]|\\.)*") for `)
+
+// AutocorrectParam returns a Param that corrects mistyped commands in the manner of git's "help.autocorrect" setting.
+// If the command that is invoked does not exist and Cobra suggests exactly one command in its place, the suggested
+// command is run with the same arguments instead, either after confirming with the user or immediately with a warning
+// depending on the provided mode. If there is no suggestion, more than one suggestion or the user declines, the
+// original error is returned. Only the argument that names the command is replaced: the arguments are those provided
+// using ArgsParam or those of the process (see ArgsParam), and flags and their values are left unchanged.
+//
+// The mode is typically determined from user configuration, so that running corrected commands without confirmation
+// is opt-in.
+func AutocorrectParam(mode AutocorrectMode) Param {
+	return autocorrectParam(mode, os.Stdin, func() bool {
+		return console.IsTerminal(os.Stdin)
+	})
+}
+
+func autocorrectParam(mode AutocorrectMode, in io.Reader, interactive func() bool) Param {
+	return paramFunc(func(executor *executor) {
+		executor.rerunHandlers = append(executor.rerunHandlers, func(rootCmd, executedCmd *cobra.Command, args []string, err error) ([]string, bool) {
+			match := unknownCommandRegexp.FindStringSubmatch(err.Error())
+			if match == nil || executedCmd == nil || executedCmd.DisableSuggestions {
+				return nil, false
+			}
+			typed, unquoteErr := strconv.Unquote(match[1])
+			if unquoteErr != nil {
+				return nil, false
+			}
+			suggestions := executedCmd.SuggestionsFor(typed)
+			if len(suggestions) != 1 {
+				return nil, false
+			}
+			idx := commandTokenIndex(executedCmd, args, len(strings.Fields(relativeCommandPath(executedCmd))))
+			if idx == -1 || args[idx] != typed {
+				return nil, false
+			}
+			corrected := append(append(append([]string{}, args[:idx]...), suggestions[0]), args[idx+1:]...)
+			suggested := strings.TrimSpace(relativeCommandPath(executedCmd) + " " + suggestions[0])
+
+			out := executedCmd.OutOrStderr()
+			switch mode {
+			case AutocorrectRun:
+				_, _ = fmt.Fprintf(out, "WARNING: You called a command named '%s', which does not exist.\nContinuing under the assumption that you meant '%s'.\n", typed, suggested)
+			default:
+				if !interactive() {
+					return nil, false
+				}
+				_, _ = fmt.Fprintf(out, "Did you mean '%s'? [Y/n] ", suggested)
+				answer, readErr := bufio.NewReader(in).ReadString('\n')
+				if readErr != nil && answer == "" {
+					return nil, false
+				}
+				if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "" && answer != "y" && answer != "yes" {
+					return nil, false
+				}
+			}
+			return corrected, true
+		})
+	})
+}
+
+// commandTokenIndex returns the index in args of the positional argument at the provided position, or -1 if there is
+// no such argument. Flags (and the values of flags of the provided command that take a value) are skipped, as are all
+// arguments after "--", which are never commands.
+func commandTokenIndex(cmd *cobra.Command, args []string, position int) int {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return -1
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			if strings.Contains(arg, "=") {
+				continue
+			}
+			var flag *pflag.Flag
+			if strings.HasPrefix(arg, "--") {
+				flag = cmd.Flags().Lookup(arg[2:])
+			} else {
+				// the last shorthand of a group of shorthands may take the next argument as its value
+				flag = cmd.Flags().ShorthandLookup(arg[len(arg)-1:])
+			}
+			if flag != nil && flag.NoOptDefVal == "" {
+				i++
+			}
+		case position == 0:
+			return i
+		default:
+			position--
+		}
+	}
+	return -1
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutocorrectParam(t *testing.T) {
+	for i, tc := range []struct {
+		mode        AutocorrectMode
+		interactive bool
+		input       string
+		args        []string
+		wantRV      int
+		wantOutput  string
+	}{
+		{AutocorrectPrompt, true, "\n", []string{"biuld", "--verbose", "target"}, 0, "Did you mean 'build'? [Y/n] build [target] true\n"},
+		{AutocorrectPrompt, true, "yes\n", []string{"biuld"}, 0, "Did you mean 'build'? [Y/n] build [] false\n"},
+		{AutocorrectPrompt, true, "n\n", []string{"biuld"}, 1, "Did you mean 'build'? [Y/n] Error: unknown command \"biuld\" for \"my-app\"\n\nDid you mean this?\n\tbuild\n\n"},
+		{AutocorrectPrompt, false, "", []string{"biuld"}, 1, "Error: unknown command \"biuld\" for \"my-app\"\n\nDid you mean this?\n\tbuild\n\n"},
+		{AutocorrectRun, false, "", []string{"biuld"}, 0, "WARNING: You called a command named 'biuld', which does not exist.\nContinuing under the assumption that you meant 'build'.\nbuild [] false\n"},
+		// flag values that match the mistyped command are not replaced
+		{AutocorrectRun, false, "", []string{"--name", "biuld", "biuld", "biuld"}, 0, "WARNING: You called a command named 'biuld', which does not exist.\nContinuing under the assumption that you meant 'build'.\nbuild [biuld] false\n"},
+		{AutocorrectRun, false, "", []string{"--name=biuld", "-n", "biuld", "biuld"}, 0, "WARNING: You called a command named 'biuld', which does not exist.\nContinuing under the assumption that you meant 'build'.\nbuild [] false\n"},
+		// ambiguous suggestions are not corrected
+		{AutocorrectRun, false, "", []string{"tes"}, 1, "Error: unknown command \"tes\" for \"my-app\"\n\nDid you mean this?\n\ttest\n\ttext\n\n"},
+	} {
+		rootCmd := &cobra.Command{Use: "my-app"}
+		rootCmd.PersistentFlags().StringP("name", "n", "", "")
+		buildCmd := &cobra.Command{
+			Use: "build",
+			Run: func(cmd *cobra.Command, args []string) {
+				verbose, _ := cmd.Flags().GetBool("verbose")
+				cmd.Println(cmd.Name(), args, verbose)
+			},
+		}
+		buildCmd.Flags().Bool("verbose", false, "")
+		rootCmd.AddCommand(buildCmd)
+		for _, name := range []string{"test", "text"} {
+			rootCmd.AddCommand(&cobra.Command{
+				Use: name,
+				Run: func(cmd *cobra.Command, args []string) {},
+			})
+		}
+
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+
+		interactive := tc.interactive
+		rv := Execute(rootCmd,
+			ArgsParam(tc.args),
+			ConfigureCmdParam(SilenceErrorsConfigurer),
+			ErrorHandlerParam(ErrorPrinterWithDebugHandler(nil, nil)),
+			autocorrectParam(tc.mode, strings.NewReader(tc.input), func() bool { return interactive }),
+		)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// BudgetWarningThreshold is the fraction of the memory budget above which BudgetParam prints a warning.
+const BudgetWarningThreshold = 0.9
+
+// memoryObtained returns the number of bytes of memory obtained from the operating system by the Go runtime. It is a
+// variable so that it can be replaced in tests.
+var memoryObtained = func() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.Sys
+}
+
+// BudgetParam returns a Param that limits the memory and CPUs used by every command that is run and adds the
+// "--memory-limit" and "--max-procs" persistent flags that configure the limits. This is intended for CLIs that run in
+// containers with tight resource limits, where exceeding the memory limit kills the process and using more threads than
+// the CPU quota allows causes throttling.
+//
+// The memory budget is the value of the "--memory-limit" flag (a number of bytes with an optional B, KiB, MiB, GiB or
+// TiB suffix, as for the GOMEMLIMIT environment variable). If the flag is not specified, the budget is the cgroup
+// memory limit of the process, unless the GOMEMLIMIT environment variable is set. The budget is set as the soft memory
+// limit of the Go runtime (see debug.SetMemoryLimit). The CPU budget is the value of the "--max-procs" flag or, if it
+// is not specified (or is not positive), the cgroup CPU quota of the process rounded up, and is set as
+// runtime.GOMAXPROCS. The previous values are restored when the command returns.
+//
+// When the command returns, a warning is written to the status writer (see Status) if the memory obtained from the
+// operating system by the Go runtime exceeds BudgetWarningThreshold of the memory budget.
+func BudgetParam() Param {
+	var memoryLimitFlag string
+	var maxProcsFlag int
+	return multiParam(
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			cmd.PersistentFlags().StringVar(&memoryLimitFlag, "memory-limit", "", "soft limit on the memory used by the command, such as 512MiB (default based on the cgroup memory limit)")
+			cmd.PersistentFlags().IntVar(&maxProcsFlag, "max-procs", 0, "maximum number of CPUs used by the command (default based on the cgroup CPU quota)")
+		}),
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				memoryLimit, err := memoryBudget(memoryLimitFlag)
+				if err != nil {
+					return err
+				}
+				if memoryLimit > 0 {
+					prevMemoryLimit := debug.SetMemoryLimit(memoryLimit)
+					defer debug.SetMemoryLimit(prevMemoryLimit)
+				}
+				maxProcs := maxProcsFlag
+				if maxProcs <= 0 {
+					maxProcs, _ = runtimeEnv.CPULimit()
+				}
+				if maxProcs > 0 {
+					prevMaxProcs := runtime.GOMAXPROCS(maxProcs)
+					defer runtime.GOMAXPROCS(prevMaxProcs)
+				}
+
+				runErr := next(cmd, args)
+				if memoryLimit > 0 {
+					if obtained := memoryObtained(); float64(obtained) > BudgetWarningThreshold*float64(memoryLimit) {
+						_, _ = fmt.Fprintf(Status(cmd), "Warning: the command used %s of memory, which is close to its budget of %s\n", formatBytes(int64(obtained)), formatBytes(memoryLimit))
+					}
+				}
+				return runErr
+			}
+		}),
+	)
+}
+
+// memoryBudget returns the memory budget in bytes determined by the provided flag value, the GOMEMLIMIT environment
+// variable and the cgroup memory limit. Returns 0 if the memory budget should not be changed.
+func memoryBudget(flagVal string) (int64, error) {
+	if flagVal != "" {
+		limit, err := parseBytes(flagVal)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value %q for --memory-limit: %v", flagVal, err)
+		}
+		return limit, nil
+	}
+	if os.Getenv("GOMEMLIMIT") != "" {
+		// the runtime has already applied the limit
+		return 0, nil
+	}
+	limit, _ := runtimeEnv.MemoryLimit()
+	return limit, nil
+}
+
+var byteUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+	{"B", 1},
+}
+
+// parseBytes parses a positive number of bytes with an optional B, KiB, MiB, GiB or TiB suffix.
+func parseBytes(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	size := int64(1)
+	for _, unit := range byteUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			s, size = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix)), unit.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("must be a positive number of bytes with an optional B, KiB, MiB, GiB or TiB suffix")
+	}
+	if n > math.MaxInt64/size {
+		return 0, fmt.Errorf("value is too large")
+	}
+	return n * size, nil
+}
+
+// formatBytes formats the provided number of bytes using the largest unit in which it is at least 1.
+func formatBytes(n int64) string {
+	for _, unit := range byteUnits {
+		if n >= unit.size && unit.size > 1 {
+			return strconv.FormatFloat(float64(n)/float64(unit.size), 'f', 1, 64) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(n, 10) + "B"
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/runtimeenv"
+)
+
+func TestParseBytes(t *testing.T) {
+	for i, tc := range []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"1024", 1024, false},
+		{"1024B", 1024, false},
+		{"512MiB", 512 << 20, false},
+		{"2 GiB", 2 << 30, false},
+		{"1TiB", 1 << 40, false},
+		{"0", 0, true},
+		{"-1MiB", 0, true},
+		{"1MB", 0, true},
+		{"9999999TiB", 0, true},
+	} {
+		got, err := parseBytes(tc.in)
+		if tc.wantErr {
+			assert.Error(t, err, "Case %d", i)
+			continue
+		}
+		require.NoError(t, err, "Case %d", i)
+		assert.Equal(t, tc.want, got, "Case %d", i)
+	}
+}
+
+func TestBudgetParam(t *testing.T) {
+	origRuntimeEnv, origMemoryObtained := runtimeEnv, memoryObtained
+	defer func() {
+		runtimeEnv, memoryObtained = origRuntimeEnv, origMemoryObtained
+	}()
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	cgroupDir := filepath.Join(tmpDir, "sys", "fs", "cgroup")
+	require.NoError(t, os.MkdirAll(cgroupDir, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(cgroupDir, "memory.max"), []byte("1073741824\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(cgroupDir, "cpu.max"), []byte("100000 100000\n"), 0644))
+	runtimeEnv = runtimeenv.Detector{Root: tmpDir}
+
+	prevMemoryLimit, prevMaxProcs := debug.SetMemoryLimit(-1), runtime.GOMAXPROCS(0)
+	for i, tc := range []struct {
+		name     string
+		args     []string
+		obtained uint64
+		want     string
+	}{
+		{"detected from cgroup", []string{"sub"}, 64 << 20, "memory limit: 1073741824, max procs: 1\n"},
+		{"flags", []string{"sub", "--memory-limit", "256MiB", "--max-procs", "3"}, 64 << 20, "memory limit: 268435456, max procs: 3\n"},
+		{"warning", []string{"sub", "--memory-limit", "64MiB"}, 60 << 20, "memory limit: 67108864, max procs: 1\nWarning: the command used 60.0MiB of memory, which is close to its budget of 64.0MiB\n"},
+	} {
+		memoryObtained = func() uint64 {
+			return tc.obtained
+		}
+		rootCmd := &cobra.Command{Use: "my-app"}
+		rootCmd.AddCommand(&cobra.Command{
+			Use: "sub",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "memory limit: %d, max procs: %d\n", debug.SetMemoryLimit(-1), runtime.GOMAXPROCS(0))
+				return nil
+			},
+		})
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := Execute(rootCmd, BudgetParam())
+		assert.Equal(t, 0, rv, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, buf.String(), "Case %d: %s", i, tc.name)
+		assert.Equal(t, prevMemoryLimit, debug.SetMemoryLimit(-1), "Case %d: %s", i, tc.name)
+		assert.Equal(t, prevMaxProcs, runtime.GOMAXPROCS(0), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestBudgetParamInvalidMemoryLimit(t *testing.T) {
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		Run: func(cmd *cobra.Command, args []string) {},
+	}
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"--memory-limit", "lots"})
+
+	rv := Execute(rootCmd, BudgetParam(), ConfigureCmdParam(SilenceErrorsConfigurer), ErrorHandlerParam(ErrorPrinterWithDebugHandler(nil, nil)))
+	assert.Equal(t, 1, rv)
+	assert.Equal(t, "Error: invalid value \"lots\" for --memory-limit: must be a positive number of bytes with an optional B, KiB, MiB, GiB or TiB suffix\n", buf.String())
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/clicache"
+)
+
+// CacheCmdParam returns a Param that adds the command returned by CacheCmd to the root command.
+func CacheCmdParam(cache *clicache.Cache) Param {
+	return ConfigureCmdParam(func(cmd *cobra.Command) {
+		cmd.AddCommand(CacheCmd(cache))
+	})
+}
+
+// CacheCmd returns a "cache" command with "info" and "clean" subcommands that allow users to inspect and clear the
+// provided cache.
+func CacheCmd(cache *clicache.Cache) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect or clear the cache of the application",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "info",
+		Short: "Print information about the cache",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info, err := cache.Info()
+			if err != nil {
+				return err
+			}
+			cmd.Printf("Location: %s\n", info.Dir)
+			cmd.Printf("Entries:  %d (%d expired)\n", info.Entries, info.Expired)
+			if info.MaxSize > 0 {
+				cmd.Printf("Size:     %d bytes (max %d bytes)\n", info.Size, info.MaxSize)
+			} else {
+				cmd.Printf("Size:     %d bytes\n", info.Size)
+			}
+			return nil
+		},
+	})
+
+	var expiredOnly bool
+	cleanCmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Remove the entries in the cache",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if expiredOnly {
+				removed, err := cache.Prune()
+				if err != nil {
+					return err
+				}
+				cmd.Printf("Removed %d expired entries\n", removed)
+				return nil
+			}
+			info, err := cache.Info()
+			if err != nil {
+				return err
+			}
+			if err := cache.Clean(); err != nil {
+				return err
+			}
+			cmd.Printf("Removed %d entries\n", info.Entries)
+			return nil
+		},
+	}
+	cleanCmd.Flags().BoolVar(&expiredOnly, "expired", false, "only remove entries that have expired")
+	cmd.AddCommand(cleanCmd)
+	return cmd
+}
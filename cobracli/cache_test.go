@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/clicache"
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestCacheCmd(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	cache, err := clicache.New(tmpDir)
+	require.NoError(t, err)
+	require.NoError(t, cache.Set("a", []byte("a"), 0))
+	require.NoError(t, cache.Set("b", []byte("b"), time.Nanosecond))
+	time.Sleep(time.Millisecond)
+	info, err := cache.Info()
+	require.NoError(t, err)
+
+	for i, tc := range []struct {
+		args       []string
+		wantOutput string
+	}{
+		{[]string{"cache", "info"}, fmt.Sprintf("Location: %s\nEntries:  2 (1 expired)\nSize:     %d bytes\n", tmpDir, info.Size)},
+		{[]string{"cache", "clean", "--expired"}, "Removed 1 expired entries\n"},
+		{[]string{"cache", "clean"}, "Removed 1 entries\n"},
+		{[]string{"cache", "info"}, fmt.Sprintf("Location: %s\nEntries:  0 (0 expired)\nSize:     0 bytes\n", tmpDir)},
+	} {
+		rootCmd := &cobra.Command{Use: "my-app"}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+		rv := cobracli.Execute(rootCmd, cobracli.CacheCmdParam(cache))
+		assert.Equal(t, 0, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cobraclitest provides utilities for testing CLIs that are built using cobracli. Commands are run through
+// cobracli.Execute, so tests exercise the same code path as the CLI. Flags keep the values set by previous invocations,
+// so tests that run multiple invocations typically create a new command tree for each of them:
+//
+//	for i, tc := range []struct {
+//		args       []string
+//		wantStdout string
+//	}{
+//		{[]string{"greet", "--name", "world"}, "Hello, world\n"},
+//		{[]string{"greet"}, "Hello, stranger\n"},
+//	} {
+//		result := cobraclitest.RunCommand(t, newRootCmd(), tc.args...)
+//		assert.Equal(t, tc.wantStdout, result.Stdout, "Case %d", i)
+//	}
+package cobraclitest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+// Result is the result of running a command.
+type Result struct {
+	// Stdout is the content written to standard output.
+	Stdout string
+	// Stderr is the content written to standard error, which includes status output (see cobracli.Status) and the
+	// errors printed by the error handlers.
+	Stderr string
+	// ExitCode is the exit code returned by cobracli.Execute.
+	ExitCode int
+	// Err is the error returned by the command, or nil if the command succeeded. It is the error before it is handled
+	// or transformed by any error handlers.
+	Err error
+}
+
+// RunCommand runs the provided root command with the provided arguments using cobracli.Execute with the Params
+// returned by cobracli.DefaultParams and returns the result. See RunCommandWithParams.
+func RunCommand(t testing.TB, rootCmd *cobra.Command, args ...string) Result {
+	return RunCommandWithParams(t, rootCmd, cobracli.DefaultParams(nil), args...)
+}
+
+// RunCommandWithParams runs the provided root command with the provided arguments (see cobracli.ArgsParam) using
+// cobracli.Execute with the provided Params and returns the result.
+//
+// Standard output and standard error are captured in buffers using cobracli.OutputWritersParam, so commands must write
+// their output using the writers of the command (such as cmd.OutOrStdout and cobracli.Status) for it to be captured.
+// os.Stdout and os.Stderr are not modified, so tests that use this function can run in parallel.
+func RunCommandWithParams(t testing.TB, rootCmd *cobra.Command, params []cobracli.Param, args ...string) Result {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	var cmdErr error
+	params = append([]cobracli.Param{
+		cobracli.ArgsParam(args),
+		cobracli.ErrorHandlerChainParam(func(cmd *cobra.Command, err error) error {
+			cmdErr = err
+			return err
+		}),
+	}, params...)
+	// the output writers are set last so that they are not overridden by the provided Params
+	params = append(params, cobracli.OutputWritersParam(&stdout, &stderr))
+	exitCode := cobracli.Execute(rootCmd, params...)
+
+	return Result{
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		ExitCode: exitCode,
+		Err:      cmdErr,
+	}
+}
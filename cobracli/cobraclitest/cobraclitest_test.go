@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobraclitest_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/cobracli"
+	"github.com/palantir/pkg/cobracli/cobraclitest"
+)
+
+func TestRunCommand(t *testing.T) {
+	newRootCmd := func() *cobra.Command {
+		var name string
+		var tags []string
+		rootCmd := &cobra.Command{Use: "my-app"}
+		greetCmd := &cobra.Command{
+			Use: "greet",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if name == "nobody" {
+					return cobracli.NewExitCodeError(3, fmt.Errorf("cannot greet nobody"))
+				}
+				_, _ = fmt.Fprintln(cobracli.Status(cmd), "Greeting...")
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Hello, %s [%s]\n", name, strings.Join(tags, ","))
+				return nil
+			},
+		}
+		greetCmd.Flags().StringVar(&name, "name", "stranger", "name to greet")
+		greetCmd.Flags().StringSliceVar(&tags, "tag", []string{"default"}, "tags")
+		rootCmd.AddCommand(greetCmd)
+		return rootCmd
+	}
+
+	for i, tc := range []struct {
+		args         []string
+		wantStdout   string
+		wantStderr   string
+		wantExitCode int
+		wantErr      string
+	}{
+		{[]string{"greet", "--name", "world", "--tag", "a", "--tag", "b"}, "Hello, world [a,b]\n", "Greeting...\n", 0, ""},
+		{[]string{"greet"}, "Hello, stranger [default]\n", "Greeting...\n", 0, ""},
+		{[]string{"greet", "--tag", "c"}, "Hello, stranger [c]\n", "Greeting...\n", 0, ""},
+		{[]string{"greet", "--name", "nobody"}, "", "Error: cannot greet nobody\n", 3, "cannot greet nobody"},
+	} {
+		result := cobraclitest.RunCommand(t, newRootCmd(), tc.args...)
+		assert.Equal(t, tc.wantStdout, result.Stdout, "Case %d", i)
+		assert.Equal(t, tc.wantStderr, result.Stderr, "Case %d", i)
+		assert.Equal(t, tc.wantExitCode, result.ExitCode, "Case %d", i)
+		if tc.wantErr == "" {
+			assert.NoError(t, result.Err, "Case %d", i)
+		} else {
+			assert.EqualError(t, result.Err, tc.wantErr, "Case %d", i)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/console"
+)
+
+const (
+	// NoColorFlagName is the name of the persistent flag added by NoColorParam.
+	NoColorFlagName = "no-color"
+
+	ansiRed   = "\x1b[31m"
+	ansiReset = "\x1b[0m"
+)
+
+// supportsColor returns true if the provided writer supports color output. It is a variable so that it can be replaced
+// in tests.
+var supportsColor = console.SupportsColor
+
+// NoColorParam returns a Param that adds the "--no-color" persistent flag. If the flag is specified, ColorEnabled
+// returns false, so commands and error handlers that use it do not write ANSI escape sequences for color.
+func NoColorParam() Param {
+	return ConfigureCmdParam(func(cmd *cobra.Command) {
+		cmd.PersistentFlags().Bool(NoColorFlagName, false, "disable color output")
+	})
+}
+
+// ColorEnabled returns true if output written by the running command to the provided writer may contain ANSI escape
+// sequences for color. This is the case if all of the following are true:
+//
+//   - the writer is a terminal that supports ANSI escape sequences (see console.IsTerminal and console.SupportsANSI);
+//   - the NO_COLOR environment variable is not set (see console.NoColorEnvVar);
+//   - the "--no-color" flag (see NoColorParam) is not specified;
+//   - deterministic output is not enabled (see DeterministicParam).
+func ColorEnabled(cmd *cobra.Command, w io.Writer) bool {
+	if IsDeterministic(cmd) || !supportsColor(w) {
+		return false
+	}
+	if flag := cmd.Flags().Lookup(NoColorFlagName); flag != nil && flag.Value.String() == "true" {
+		return false
+	}
+	return true
+}
+
+// ErrorPrinterColorHandler returns an error handler that behaves like ErrorPrinterWithDebugHandler, but prints the
+// "Error:" prefix in red if color is enabled for the output of the command (see ColorEnabled). Otherwise, the output is
+// the same as that of ErrorPrinterWithDebugHandler.
+func ErrorPrinterColorHandler(debugVar *bool, debugErrTransform func(error) string) func(*cobra.Command, error) {
+	plain := ErrorPrinterWithDebugHandler(debugVar, debugErrTransform)
+	return func(command *cobra.Command, err error) {
+		w := command.OutOrStderr()
+		if !ColorEnabled(command, w) {
+			plain(command, err)
+			return
+		}
+		errStr := err.Error()
+		if errStr == "" {
+			return
+		}
+		if debugVar != nil && *debugVar && debugErrTransform != nil {
+			errStr = debugErrTransform(err)
+		}
+		_, _ = fmt.Fprintf(w, "%sError:%s %s\n", ansiRed, ansiReset, errStr)
+	}
+}
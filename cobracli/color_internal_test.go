@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorPrinterColorHandler(t *testing.T) {
+	origSupportsColor := supportsColor
+	defer func() {
+		supportsColor = origSupportsColor
+	}()
+
+	for i, tc := range []struct {
+		name          string
+		args          []string
+		supportsColor bool
+		want          string
+	}{
+		{"color supported", nil, true, "\x1b[31mError:\x1b[0m failed\n"},
+		{"color not supported", nil, false, "Error: failed\n"},
+		{"no-color flag", []string{"--no-color"}, true, "Error: failed\n"},
+	} {
+		supportsColor = func(io.Writer) bool {
+			return tc.supportsColor
+		}
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return fmt.Errorf("failed")
+			},
+		}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := Execute(rootCmd,
+			NoColorParam(),
+			ConfigureCmdParam(SilenceErrorsConfigurer),
+			ErrorHandlerParam(ErrorPrinterColorHandler(nil, nil)),
+		)
+		assert.Equal(t, 1, rv, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, buf.String(), "Case %d: %s", i, tc.name)
+	}
+}
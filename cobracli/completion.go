@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// CompletionShells are the shells for which the command returned by CompletionCmd can generate completion scripts.
+var CompletionShells = []string{"bash", "zsh"}
+
+// CompletionCommandParam returns a Param that adds the command returned by CompletionCmd to the root command. If hidden
+// is true, the command is not shown in the help output of the root command.
+func CompletionCommandParam(hidden bool) Param {
+	return ConfigureCmdParam(func(cmd *cobra.Command) {
+		completionCmd := CompletionCmd(cmd)
+		completionCmd.Hidden = hidden
+		cmd.AddCommand(completionCmd)
+	})
+}
+
+// CompletionCmd returns a "completion [bash|zsh]" command that writes the shell completion script for the provided
+// root command to its output. The help text of the command describes how to load the script in the current shell and
+// how to load it for every session.
+func CompletionCmd(rootCmd *cobra.Command) *cobra.Command {
+	name := rootCmd.Name()
+	completionCmd := &cobra.Command{
+		Use:   fmt.Sprintf("completion [%s]", strings.Join(CompletionShells, "|")),
+		Short: "Generate the shell completion script",
+		Long: fmt.Sprintf(`Generate the shell completion script for %[1]s.
+
+Bash:
+
+  To load completions in the current shell session:
+
+    source <(%[1]s completion bash)
+
+  To load completions for every new session, add the line above to your ~/.bashrc file.
+
+Zsh:
+
+  To load completions for every new session, write the script to a directory in your $fpath:
+
+    %[1]s completion zsh > "${fpath[1]}/_%[1]s"
+
+  You need to start a new shell for this setup to take effect.
+`, name),
+		ValidArgs: CompletionShells,
+		Args:      cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			switch args[0] {
+			case "bash":
+				return cmd.Root().GenBashCompletion(out)
+			case "zsh":
+				return cmd.Root().GenZshCompletion(out)
+			default:
+				return fmt.Errorf("unsupported shell %q: must be one of %s", args[0], strings.Join(CompletionShells, ", "))
+			}
+		},
+	}
+	SkipFirstRun(completionCmd)
+	return completionCmd
+}
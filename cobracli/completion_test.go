@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestCompletionCommandParam(t *testing.T) {
+	for i, tc := range []struct {
+		name         string
+		args         []string
+		wantCode     int
+		wantContains []string
+	}{
+		{
+			name:         "bash",
+			args:         []string{"completion", "bash"},
+			wantContains: []string{"# bash completion for my-app"},
+		},
+		{
+			name:         "zsh",
+			args:         []string{"completion", "zsh"},
+			wantContains: []string{"#compdef my-app"},
+		},
+		{
+			name:         "help",
+			args:         []string{"completion", "--help"},
+			wantContains: []string{"completion [bash|zsh]", "source <(my-app completion bash)", `my-app completion zsh > "${fpath[1]}/_my-app"`},
+		},
+		{
+			name:         "unsupported shell",
+			args:         []string{"completion", "tcsh"},
+			wantCode:     1,
+			wantContains: []string{`unsupported shell "tcsh": must be one of bash, zsh`},
+		},
+		{
+			name:         "missing shell",
+			args:         []string{"completion"},
+			wantCode:     1,
+			wantContains: []string{"accepts 1 arg(s), received 0"},
+		},
+		{
+			name:         "visible in help",
+			args:         []string{"--help"},
+			wantContains: []string{"completion  Generate the shell completion script"},
+		},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+		}
+		rootCmd.AddCommand(&cobra.Command{
+			Use: "run",
+			Run: func(cmd *cobra.Command, args []string) {},
+		})
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, cobracli.CompletionCommandParam(false))
+		require.Equal(t, tc.wantCode, rv, "Case %d: %s\nOutput:\n%s", i, tc.name, buf.String())
+		for _, want := range tc.wantContains {
+			assert.Contains(t, buf.String(), want, "Case %d: %s", i, tc.name)
+		}
+	}
+}
+
+func TestCompletionCommandParamHidden(t *testing.T) {
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+	}
+	rootCmd.AddCommand(&cobra.Command{
+		Use: "run",
+		Run: func(cmd *cobra.Command, args []string) {},
+	})
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"--help"})
+
+	rv := cobracli.Execute(rootCmd, cobracli.CompletionCommandParam(true))
+	require.Equal(t, 0, rv, buf.String())
+	assert.NotContains(t, buf.String(), "completion")
+
+	buf.Reset()
+	rootCmd.SetArgs([]string{"completion", "bash"})
+	rv = cobracli.Execute(rootCmd, cobracli.CompletionCommandParam(true))
+	require.Equal(t, 0, rv, buf.String())
+	assert.Contains(t, buf.String(), "# bash completion for my-app")
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/runtimeenv"
+)
+
+const (
+	// MaxConcurrencyAnnotation is the key of the command annotation that declares the maximum concurrency of a command.
+	// The value is a positive integer. If a command and its ancestors declare multiple maximums, the smallest applies.
+	MaxConcurrencyAnnotation = "cobracli_max_concurrency"
+
+	concurrencyValueName = "concurrency"
+)
+
+// runtimeEnv detects the container environment and cgroup limits of the process. It is a variable so that it can be
+// replaced in tests.
+var runtimeEnv runtimeenv.Detector
+
+// SetMaxConcurrency sets the MaxConcurrencyAnnotation of the provided command to the provided value. This should be
+// used for commands whose operations are expensive enough that running more of them concurrently than the provided
+// number would overwhelm the machine or the services that they call.
+func SetMaxConcurrency(cmd *cobra.Command, maxConcurrency int) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[MaxConcurrencyAnnotation] = strconv.Itoa(maxConcurrency)
+}
+
+// ConcurrencyParam returns a Param that determines the concurrency of every command that is run and adds the
+// "--concurrency" persistent flag that configures it. If the flag is not specified (or is not positive), the
+// concurrency is detected from the environment: it is the number of CPUs available to the process (taking cgroup CPU
+// quotas into account) and, if memoryPerWorker is positive, at most the memory available to the process (taking cgroup
+// memory limits into account) divided by memoryPerWorker. The concurrency is then limited to the smallest value of the
+// MaxConcurrencyAnnotation of the command and its ancestors.
+//
+// Commands retrieve the concurrency using Concurrency and use it to size their internal concurrency (for example,
+// using workpool.WithWorkers and httpclient.ConfigureMaxConnections). This Param does not change runtime.GOMAXPROCS:
+// use BudgetParam to limit the number of CPUs used by the process.
+func ConcurrencyParam(memoryPerWorker int64) Param {
+	var concurrencyFlag int
+	return multiParam(
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			cmd.PersistentFlags().IntVar(&concurrencyFlag, "concurrency", 0, "maximum number of operations to run concurrently (default based on the available CPUs and memory)")
+		}),
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				concurrency := concurrencyFlag
+				if concurrency <= 0 {
+					concurrency = detectConcurrency(memoryPerWorker)
+				}
+				if maxConcurrency, ok := maxConcurrencyAnnotation(cmd); ok && maxConcurrency < concurrency {
+					concurrency = maxConcurrency
+				}
+				restore := setInvocationValue(cmd, concurrencyValueName, concurrency)
+				defer restore()
+				return next(cmd, args)
+			}
+		}),
+	)
+}
+
+// Concurrency returns the maximum number of operations that the running command should run concurrently as determined
+// by ConcurrencyParam. Returns runtime.GOMAXPROCS if the command is not being run with ConcurrencyParam.
+func Concurrency(cmd *cobra.Command) int {
+	if v, ok := invocationValue(cmd, concurrencyValueName); ok {
+		return v.(int)
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// maxConcurrencyAnnotation returns the smallest valid MaxConcurrencyAnnotation of the provided command and its
+// ancestors and true, or false if none of them have one.
+func maxConcurrencyAnnotation(cmd *cobra.Command) (int, bool) {
+	maxConcurrency, found := 0, false
+	for curr := cmd; curr != nil; curr = curr.Parent() {
+		v, err := strconv.Atoi(strings.TrimSpace(curr.Annotations[MaxConcurrencyAnnotation]))
+		if err != nil || v <= 0 {
+			continue
+		}
+		if !found || v < maxConcurrency {
+			maxConcurrency, found = v, true
+		}
+	}
+	return maxConcurrency, found
+}
+
+// detectConcurrency returns the concurrency supported by the CPUs and memory available to the process. The result is
+// always at least 1.
+func detectConcurrency(memoryPerWorker int64) int {
+	concurrency := runtime.NumCPU()
+	if cpus, ok := runtimeEnv.CPULimit(); ok && cpus < concurrency {
+		concurrency = cpus
+	}
+	if memoryPerWorker > 0 {
+		if memory, ok := runtimeEnv.MemoryLimit(); ok {
+			if byMemory := int(memory / memoryPerWorker); byMemory < concurrency {
+				concurrency = byMemory
+			}
+		}
+	}
+	if concurrency < 1 {
+		return 1
+	}
+	return concurrency
+}
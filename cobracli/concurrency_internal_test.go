@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/runtimeenv"
+)
+
+func TestDetectConcurrency(t *testing.T) {
+	origRuntimeEnv := runtimeEnv
+	defer func() {
+		runtimeEnv = origRuntimeEnv
+	}()
+
+	minCPUs := func(n int) int {
+		if cpus := runtime.NumCPU(); cpus < n {
+			return cpus
+		}
+		return n
+	}
+	for i, tc := range []struct {
+		name            string
+		files           map[string]string
+		memoryPerWorker int64
+		want            int
+	}{
+		{"no limits", nil, 1 << 20, runtime.NumCPU()},
+		{"cgroup v2 unlimited", map[string]string{"cpu.max": "max 100000\n", "memory.max": "max\n"}, 1 << 20, runtime.NumCPU()},
+		{"cgroup v2 CPU quota", map[string]string{"cpu.max": "150000 100000\n"}, 0, minCPUs(2)},
+		{"cgroup v2 memory limit", map[string]string{"memory.max": "1073741824\n"}, 512 << 20, minCPUs(2)},
+		{"memory limit ignored without memory per worker", map[string]string{"memory.max": "1073741824\n"}, 0, runtime.NumCPU()},
+		{"memory limit smaller than memory per worker", map[string]string{"memory.max": "1048576\n"}, 512 << 20, 1},
+		{"cgroup v1 limits", map[string]string{
+			"cpu/cpu.cfs_quota_us":         "400000\n",
+			"cpu/cpu.cfs_period_us":        "100000\n",
+			"memory/memory.limit_in_bytes": "9223372036854771712\n",
+		}, 1 << 20, minCPUs(4)},
+		{"cgroup v1 unlimited", map[string]string{"cpu/cpu.cfs_quota_us": "-1\n", "cpu/cpu.cfs_period_us": "100000\n"}, 0, runtime.NumCPU()},
+	} {
+		tmpDir, cleanup, err := dirs.TempDir("", "")
+		require.NoError(t, err)
+		for name, content := range tc.files {
+			path := filepath.Join(tmpDir, "sys", "fs", "cgroup", name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+		}
+		runtimeEnv = runtimeenv.Detector{Root: tmpDir}
+		assert.Equal(t, tc.want, detectConcurrency(tc.memoryPerWorker), "Case %d: %s", i, tc.name)
+		cleanup()
+	}
+}
+
+func TestConcurrencyParam(t *testing.T) {
+	origRuntimeEnv := runtimeEnv
+	defer func() {
+		runtimeEnv = origRuntimeEnv
+	}()
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	runtimeEnv = runtimeenv.Detector{Root: tmpDir}
+
+	for i, tc := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"detected", []string{"sub"}, fmt.Sprintf("concurrency: %d\n", runtime.NumCPU())},
+		{"flag", []string{"sub", "--concurrency", "64"}, "concurrency: 64\n"},
+		{"annotation limits flag", []string{"sub", "limited", "--concurrency", "64"}, "concurrency: 2\n"},
+		{"flag within annotation", []string{"sub", "limited", "--concurrency", "1"}, "concurrency: 1\n"},
+	} {
+		prevMaxProcs := runtime.GOMAXPROCS(0)
+		run := func(cmd *cobra.Command, args []string) {
+			concurrency := Concurrency(cmd)
+			cmd.Printf("concurrency: %d\n", concurrency)
+			assert.Equal(t, prevMaxProcs, runtime.GOMAXPROCS(0), "Case %d: %s: GOMAXPROCS was changed", i, tc.name)
+		}
+		rootCmd := &cobra.Command{Use: "my-app"}
+		subCmd := &cobra.Command{Use: "sub", Run: run}
+		limitedCmd := &cobra.Command{Use: "limited", Run: run}
+		SetMaxConcurrency(limitedCmd, 2)
+		subCmd.AddCommand(limitedCmd)
+		rootCmd.AddCommand(subCmd)
+
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+		rv := Execute(rootCmd, append(DefaultParams(nil), ConcurrencyParam(0))...)
+		assert.Equal(t, 0, rv, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, buf.String(), "Case %d: %s", i, tc.name)
+	}
+}
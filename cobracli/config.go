@@ -0,0 +1,252 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/palantir/pkg/flagtypes"
+	"github.com/palantir/pkg/safejson"
+	"github.com/palantir/pkg/safeyaml"
+)
+
+// ConfigFormat is a format of configuration files supported by ConfigFileParam.
+type ConfigFormat string
+
+const (
+	// ConfigFormatYAML is the format of configuration files with the extension ".yml" or ".yaml".
+	ConfigFormatYAML ConfigFormat = "yaml"
+	// ConfigFormatJSON is the format of configuration files with the extension ".json".
+	ConfigFormatJSON ConfigFormat = "json"
+)
+
+// ValueSource is the source of the value of a flag.
+type ValueSource string
+
+const (
+	// ValueSourceDefault indicates that a flag has its default value.
+	ValueSourceDefault ValueSource = "default"
+	// ValueSourceFlag indicates that the value of a flag was specified on the command line.
+	ValueSourceFlag ValueSource = "flag"
+	// ValueSourceEnv indicates that the value of a flag was set from an environment variable by EnvVarPrefixParam.
+	ValueSourceEnv ValueSource = "env"
+	// ValueSourceConfig indicates that the value of a flag was set from a configuration file by ConfigFileParam.
+	ValueSourceConfig ValueSource = "config"
+)
+
+const configKeysValueName = "configKeys"
+
+// ConfigFileParam returns a Param that adds a persistent flag with the provided name (or "config" if it is empty) that
+// specifies the path of a configuration file. If the flag is specified, the file is loaded after the flags of a command
+// are parsed and before its pre-run functions are called, and its values are applied to the flags of the command, which
+// are marked as changed so that they satisfy required flags. The format of the file is determined by its extension and
+// must be one of the provided formats (or any supported format if none are provided), except that files without an
+// extension are accepted as any of the provided formats.
+//
+// Nested keys of the file are joined with "." (for example, the key "port" of the map "server" is "server.port"). The
+// value of a key is applied to the flag whose configuration key was recorded using flagtypes.SetConfigKey or, if there
+// is no such flag, to the flag with the same name as the key. Lists are applied as comma-separated values. Keys that do
+// not correspond to a flag are ignored. Values specified on the command line take precedence over values from the
+// environment (see EnvVarPrefixParam), which take precedence over values from the configuration file. The path of the
+// file can also be provided using an environment variable if EnvVarPrefixParam is used.
+func ConfigFileParam(pathFlagName string, formats ...ConfigFormat) Param {
+	if pathFlagName == "" {
+		pathFlagName = "config"
+	}
+	if len(formats) == 0 {
+		formats = []ConfigFormat{ConfigFormatYAML, ConfigFormatJSON}
+	}
+	var configPath string
+	return multiParam(
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			cmd.PersistentFlags().StringVar(&configPath, pathFlagName, "", "path of the configuration file")
+		}),
+		// called after the hook of EnvVarPrefixParam so that the path can be set from the environment
+		stagedPreRunHookParam(stageEarly, func(cmd *cobra.Command, args []string) (func(), error) {
+			setFromConfig := make(map[string]string)
+			if configPath != "" {
+				values, err := loadConfigFile(configPath, formats)
+				if err != nil {
+					return nil, err
+				}
+				if err := applyConfigValues(cmd, values, setFromConfig); err != nil {
+					return nil, fmt.Errorf("invalid configuration file %s: %v", configPath, err)
+				}
+			}
+			return setInvocationValue(cmd, configKeysValueName, setFromConfig), nil
+		}),
+	)
+}
+
+// FlagValueSource returns the source of the value of the flag with the provided name of the running command. Returns
+// ValueSourceDefault if the command does not have a flag with the provided name.
+func FlagValueSource(cmd *cobra.Command, name string) ValueSource {
+	if _, ok := FlagEnvVar(cmd, name); ok {
+		return ValueSourceEnv
+	}
+	if _, ok := FlagConfigKey(cmd, name); ok {
+		return ValueSourceConfig
+	}
+	if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+		return ValueSourceFlag
+	}
+	return ValueSourceDefault
+}
+
+// FlagConfigKey returns the configuration key from which the value of the flag with the provided name was set by
+// ConfigFileParam for the running command and true, or "" and false if the flag was not set from the configuration
+// file. Returns false if the value was subsequently overridden by an environment variable.
+func FlagConfigKey(cmd *cobra.Command, name string) (string, bool) {
+	if _, ok := FlagEnvVar(cmd, name); ok {
+		return "", false
+	}
+	v, ok := invocationValue(cmd, configKeysValueName)
+	if !ok {
+		return "", false
+	}
+	key, ok := v.(map[string]string)[name]
+	return key, ok
+}
+
+func loadConfigFile(path string, formats []ConfigFormat) (map[string]interface{}, error) {
+	format, err := configFileFormat(path, formats)
+	if err != nil {
+		return nil, err
+	}
+	b, err := ReadInput(path, maxInputSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %v", err)
+	}
+	if format == ConfigFormatYAML {
+		if b, err = safeyaml.YAMLtoJSONBytes(b); err != nil {
+			return nil, fmt.Errorf("failed to parse configuration file %s as YAML: %v", path, err)
+		}
+	}
+	var values map[string]interface{}
+	if err := safejson.Unmarshal(b, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration file %s as %s: %v", path, strings.ToUpper(string(format)), err)
+	}
+	flattened := make(map[string]interface{})
+	flattenConfigValues("", values, flattened)
+	return flattened, nil
+}
+
+// configFileFormat returns the format of the configuration file with the provided path based on its extension.
+// Returns an error if the format is not one of the provided formats. Files without an extension are treated as YAML
+// if YAML is permitted (JSON is a subset of YAML) and as JSON otherwise.
+func configFileFormat(path string, formats []ConfigFormat) (ConfigFormat, error) {
+	permitted := func(format ConfigFormat) bool {
+		for _, f := range formats {
+			if f == format {
+				return true
+			}
+		}
+		return false
+	}
+	var format ConfigFormat
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yml", ".yaml":
+		format = ConfigFormatYAML
+	case ".json":
+		format = ConfigFormatJSON
+	case "":
+		if permitted(ConfigFormatYAML) {
+			return ConfigFormatYAML, nil
+		}
+		return ConfigFormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported configuration file extension %q", ext)
+	}
+	if !permitted(format) {
+		return "", fmt.Errorf("unsupported configuration file format %s", format)
+	}
+	return format, nil
+}
+
+func flattenConfigValues(prefix string, values map[string]interface{}, out map[string]interface{}) {
+	for k, v := range values {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			flattenConfigValues(key, nested, out)
+			continue
+		}
+		out[key] = v
+	}
+}
+
+// applyConfigValues sets the flags of the provided command that were not set on the command line or from the
+// environment to the corresponding configuration values, marks them as changed and records the keys of the values that
+// were applied in the provided map.
+func applyConfigValues(cmd *cobra.Command, values map[string]interface{}, setFromConfig map[string]string) error {
+	var flags []*pflag.Flag
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		flags = append(flags, f)
+	})
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Name < flags[j].Name
+	})
+	for _, f := range flags {
+		if f.Changed {
+			continue
+		}
+		if _, ok := FlagEnvVar(cmd, f.Name); ok {
+			continue
+		}
+		key := flagtypes.FlagMetadata(f).ConfigKey
+		if key == "" {
+			key = f.Name
+		}
+		v, ok := values[key]
+		if !ok || v == nil {
+			continue
+		}
+		val, err := configValueString(v)
+		if err != nil {
+			return fmt.Errorf("invalid value for key %q: %v", key, err)
+		}
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("invalid value %q for key %q of flag --%s: %v", val, key, f.Name, err)
+		}
+		f.Changed = true
+		setFromConfig[f.Name] = key
+	}
+	return nil
+}
+
+func configValueString(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return fmt.Sprint(v), nil
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, elem := range v {
+			part, err := configValueString(elem)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = part
+		}
+		return strings.Join(parts, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", v)
+	}
+}
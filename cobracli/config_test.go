@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+	"github.com/palantir/pkg/flagtypes"
+)
+
+func TestConfigFileParam(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	yamlPath := filepath.Join(tmpDir, "config.yml")
+	require.NoError(t, ioutil.WriteFile(yamlPath, []byte(`server:
+  port: 9090
+log-level: debug
+tags: [a, b]
+verbose: true
+unknown: ignored
+`), 0644))
+	jsonPath := filepath.Join(tmpDir, "config.json")
+	require.NoError(t, ioutil.WriteFile(jsonPath, []byte(`{"server": {"port": 7070}}`), 0644))
+	invalidPath := filepath.Join(tmpDir, "invalid.yml")
+	require.NoError(t, ioutil.WriteFile(invalidPath, []byte("server:\n  port: not-a-number\n"), 0644))
+	tomlPath := filepath.Join(tmpDir, "config.toml")
+	require.NoError(t, ioutil.WriteFile(tomlPath, []byte("port = 1\n"), 0644))
+
+	for i, tc := range []struct {
+		name       string
+		env        map[string]string
+		args       []string
+		formats    []cobracli.ConfigFormat
+		wantRV     int
+		wantOutput string
+	}{
+		{
+			name:       "no config file",
+			args:       []string{"serve"},
+			wantOutput: "port=8080 (default) log-level=info (default) tags=[] (default) verbose=false (default)\n",
+		},
+		{
+			name:       "YAML config file",
+			args:       []string{"serve", "--config", yamlPath},
+			wantOutput: "port=9090 (config) log-level=debug (config) tags=[a,b] (config) verbose=true (config)\n",
+		},
+		{
+			name:       "JSON config file",
+			args:       []string{"serve", "--config", jsonPath},
+			wantOutput: "port=7070 (config) log-level=info (default) tags=[] (default) verbose=false (default)\n",
+		},
+		{
+			name: "flags take precedence over environment over config",
+			env: map[string]string{
+				"MYAPP_LOG_LEVEL": "warn",
+				"MYAPP_PORT":      "6060",
+			},
+			args:       []string{"serve", "--port", "5050", "--config", yamlPath},
+			wantOutput: "port=5050 (flag) log-level=warn (env) tags=[a,b] (config) verbose=true (config)\n",
+		},
+		{
+			name: "config path from environment",
+			env: map[string]string{
+				"MYAPP_CONFIG": jsonPath,
+			},
+			args:       []string{"serve"},
+			wantOutput: "port=7070 (config) log-level=info (default) tags=[] (default) verbose=false (default)\n",
+		},
+		{
+			name:       "invalid value",
+			args:       []string{"serve", "--config", invalidPath},
+			wantRV:     1,
+			wantOutput: fmt.Sprintf(`Error: invalid configuration file %s: invalid value "not-a-number" for key "server.port" of flag --port: strconv.ParseInt: parsing "not-a-number": invalid syntax`+"\n", invalidPath),
+		},
+		{
+			name:       "unsupported extension",
+			args:       []string{"serve", "--config", tomlPath},
+			wantRV:     1,
+			wantOutput: "Error: unsupported configuration file extension \".toml\"\n",
+		},
+		{
+			name:       "format not permitted",
+			args:       []string{"serve", "--config", jsonPath},
+			formats:    []cobracli.ConfigFormat{cobracli.ConfigFormatYAML},
+			wantRV:     1,
+			wantOutput: "Error: unsupported configuration file format json\n",
+		},
+		{
+			name:       "missing file",
+			args:       []string{"serve", "--config", filepath.Join(tmpDir, "missing.yml")},
+			wantRV:     1,
+			wantOutput: "Error: failed to read configuration file: ",
+		},
+	} {
+		for k, v := range tc.env {
+			require.NoError(t, os.Setenv(k, v), "Case %d: %s", i, tc.name)
+		}
+
+		rootCmd := &cobra.Command{Use: "my-app"}
+		rootCmd.PersistentFlags().String("log-level", "info", "log level")
+		serveCmd := &cobra.Command{
+			Use: "serve",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				var parts []string
+				for _, name := range []string{"port", "log-level", "tags", "verbose"} {
+					parts = append(parts, fmt.Sprintf("%s=%s (%s)", name, cmd.Flag(name).Value.String(), cobracli.FlagValueSource(cmd, name)))
+				}
+				cmd.Println(strings.Join(parts, " "))
+				return nil
+			},
+		}
+		serveCmd.Flags().Int("port", 8080, "port to listen on")
+		require.NoError(t, flagtypes.SetConfigKey(serveCmd.Flags(), "port", "server.port"))
+		serveCmd.Flags().StringSlice("tags", nil, "tags")
+		serveCmd.Flags().Bool("verbose", false, "verbose output")
+		rootCmd.AddCommand(serveCmd)
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil),
+			cobracli.EnvVarPrefixParam("MYAPP"),
+			cobracli.ConfigFileParam("", tc.formats...),
+		)...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d: %s", i, tc.name)
+		if tc.wantRV == 0 {
+			assert.Equal(t, tc.wantOutput, buf.String(), "Case %d: %s", i, tc.name)
+		} else {
+			assert.True(t, strings.HasPrefix(buf.String(), tc.wantOutput), "Case %d: %s\nOutput:\n%s", i, tc.name, buf.String())
+		}
+
+		for k := range tc.env {
+			require.NoError(t, os.Unsetenv(k), "Case %d: %s", i, tc.name)
+		}
+	}
+}
+
+func TestConfigFileParamAppliedBeforePreRun(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	configPath := filepath.Join(tmpDir, "config.yml")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte("token: secret\n"), 0644))
+
+	var token string
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			cmd.Println("pre-run token=" + token)
+		},
+	}
+	loginCmd := &cobra.Command{
+		Use: "login",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Println("run token="+token, cobracli.FlagValueSource(cmd, "token"))
+		},
+	}
+	loginCmd.Flags().StringVar(&token, "token", "", "token")
+	require.NoError(t, loginCmd.MarkFlagRequired("token"))
+	rootCmd.AddCommand(loginCmd)
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"login", "--config", configPath})
+
+	rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.ConfigFileParam(""))...)
+	assert.Equal(t, 0, rv)
+	assert.Equal(t, "pre-run token=secret\nrun token=secret config\n", buf.String())
+}
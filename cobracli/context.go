@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"context"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// invocation values are values that are scoped to a single invocation of Execute. Because commands do not have a
+// reference to the invocation that is running them, values are keyed by the root command of the command tree.
+var (
+	invocationValuesMutex sync.Mutex
+	invocationValues      = make(map[invocationKey]interface{})
+)
+
+type invocationKey struct {
+	root *cobra.Command
+	name string
+}
+
+const (
+	argsValueName     = "args"
+	contextValueName  = "context"
+	exitCodeValueName = "exitCode"
+	statusValueName   = "status"
+)
+
+// ContextParam sets the context used as the parent of the execution context of the invocation. If this param is not
+// provided, context.Background() is used.
+func ContextParam(ctx context.Context) Param {
+	return paramFunc(func(executor *executor) {
+		executor.ctx = ctx
+	})
+}
+
+// Context returns the execution context of the invocation of Execute that is running the provided command. The context
+// is cancelled when Execute returns and may have a deadline (for example, if GlobalTimeoutParam is used). Commands
+// should use this context for any operations that should be bounded by the invocation. Returns context.Background()
+// if the command is not being run by Execute.
+func Context(cmd *cobra.Command) context.Context {
+	if ctx, ok := invocationValue(cmd, contextValueName); ok {
+		return ctx.(context.Context)
+	}
+	return context.Background()
+}
+
+// setContext sets the execution context for the command tree that contains the provided command and returns a
+// function that restores the previous context.
+func setContext(cmd *cobra.Command, ctx context.Context) (restore func()) {
+	return setInvocationValue(cmd, contextValueName, ctx)
+}
+
+func invocationValue(cmd *cobra.Command, name string) (interface{}, bool) {
+	invocationValuesMutex.Lock()
+	defer invocationValuesMutex.Unlock()
+	v, ok := invocationValues[invocationKey{root: cmd.Root(), name: name}]
+	return v, ok
+}
+
+// setInvocationValue sets the value with the provided name for the command tree that contains the provided command and
+// returns a function that restores the previous value.
+func setInvocationValue(cmd *cobra.Command, name string, v interface{}) (restore func()) {
+	key := invocationKey{root: cmd.Root(), name: name}
+	invocationValuesMutex.Lock()
+	defer invocationValuesMutex.Unlock()
+	prev, hadPrev := invocationValues[key]
+	invocationValues[key] = v
+	return func() {
+		invocationValuesMutex.Lock()
+		defer invocationValuesMutex.Unlock()
+		if hadPrev {
+			invocationValues[key] = prev
+		} else {
+			delete(invocationValues, key)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestContext(t *testing.T) {
+	type ctxKey struct{}
+	parentCtx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	var cmdCtx context.Context
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+	}
+	rootCmd.AddCommand(&cobra.Command{
+		Use: "sub",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdCtx = cobracli.Context(cmd)
+		},
+	})
+	rootCmd.SetArgs([]string{"sub"})
+
+	rv := cobracli.Execute(rootCmd, cobracli.ContextParam(parentCtx))
+	assert.Equal(t, 0, rv)
+	require.NotNil(t, cmdCtx)
+	assert.Equal(t, "value", cmdCtx.Value(ctxKey{}))
+	// context is cancelled once Execute returns
+	assert.Equal(t, context.Canceled, cmdCtx.Err())
+	assert.Equal(t, context.Background(), cobracli.Context(rootCmd))
+}
+
+func TestExecuteWithContextCancelsOnSignal(t *testing.T) {
+	type ctxKey struct{}
+	parentCtx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	var gotValue interface{}
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cobracli.Context(cmd)
+			gotValue = ctx.Value(ctxKey{})
+
+			proc, err := os.FindProcess(os.Getpid())
+			if err != nil {
+				return err
+			}
+			if err := proc.Signal(os.Interrupt); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(5 * time.Second):
+				return fmt.Errorf("context was not cancelled")
+			}
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	var gotErr error
+	rv := cobracli.ExecuteWithContext(parentCtx, rootCmd, cobracli.ErrorHandlerParam(func(cmd *cobra.Command, err error) {
+		gotErr = err
+	}))
+	assert.Equal(t, 1, rv)
+	assert.Equal(t, context.Canceled, gotErr)
+	assert.Equal(t, "value", gotValue)
+}
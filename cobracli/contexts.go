@@ -0,0 +1,192 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/cliconfig"
+	"github.com/palantir/pkg/tableprinter"
+)
+
+// ContextsParam returns a Param that supports switching between the named contexts stored in the cliconfig file at the
+// provided path. It adds a "--context" persistent flag that selects the context used by a command and the command
+// returned by ContextCmd. Before a command runs, the selected context (or the current context of the configuration if
+// the flag is not specified) is added to its execution context (see Context), from which it can be retrieved using
+// cliconfig.FromContext to construct API clients. Returns an error without running the command if the flag specifies
+// a context that does not exist. If the flag is not specified and there is no current context (or the current context
+// does not exist), no context is added.
+func ContextsParam(configPath string) Param {
+	var contextName string
+	return multiParam(
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			cmd.PersistentFlags().StringVar(&contextName, "context", "", "name of the context to use (default is the current context)")
+			cmd.AddCommand(ContextCmd(configPath))
+		}),
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				cfg, err := cliconfig.Load(configPath)
+				if err != nil {
+					return err
+				}
+				name := contextName
+				if name == "" {
+					if _, ok := cfg.Contexts[cfg.CurrentContext]; !ok {
+						return next(cmd, args)
+					}
+					name = cfg.CurrentContext
+				}
+				cliCtx, err := cfg.Context(name)
+				if err != nil {
+					return err
+				}
+				restoreCtx := setContext(cmd, cliconfig.WithContext(Context(cmd), name, cliCtx))
+				defer restoreCtx()
+				return next(cmd, args)
+			}
+		}),
+	)
+}
+
+// ContextCmd returns a "context" command with subcommands that manage the named contexts stored in the cliconfig file
+// at the provided path:
+//
+//   - "context list" lists the contexts and marks the current context.
+//   - "context use <name>" sets the current context.
+//   - "context show [name]" shows the context with the provided name (or the current context). The values of
+//     credentials are never shown.
+//
+// The "list" and "show" subcommands write their results using WriteResult, so they support the output formats of
+// OutputFormatParam.
+func ContextCmd(configPath string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "Manage the contexts that specify the endpoints and credentials used by commands",
+	}
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "list",
+			Short: "List the contexts",
+			Args:  cobra.NoArgs,
+			RunE: RunR(func(cmd *cobra.Command, args []string) (interface{}, error) {
+				cfg, err := cliconfig.Load(configPath)
+				if err != nil {
+					return nil, err
+				}
+				result := contextList{}
+				for _, name := range cfg.ContextNames() {
+					result = append(result, newContextInfo(name, cfg))
+				}
+				return result, nil
+			}),
+		},
+		&cobra.Command{
+			Use:   "use <name>",
+			Short: "Set the current context",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				cfg, err := cliconfig.Load(configPath)
+				if err != nil {
+					return err
+				}
+				if err := cfg.SetCurrentContext(args[0]); err != nil {
+					return err
+				}
+				if err := cfg.Save(configPath); err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintf(Status(cmd), "Switched to context %q\n", args[0])
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "show [name]",
+			Short: "Show a context (default is the current context)",
+			Args:  cobra.MaximumNArgs(1),
+			RunE: RunR(func(cmd *cobra.Command, args []string) (interface{}, error) {
+				cfg, err := cliconfig.Load(configPath)
+				if err != nil {
+					return nil, err
+				}
+				name := cfg.CurrentContext
+				if len(args) > 0 {
+					name = args[0]
+				} else if name == "" {
+					return nil, fmt.Errorf("no current context is set: specify the name of a context or run %q to set one", cmd.Parent().CommandPath()+" use <name>")
+				}
+				if _, err := cfg.Context(name); err != nil {
+					return nil, err
+				}
+				return newContextInfo(name, cfg), nil
+			}),
+		},
+	)
+	return cmd
+}
+
+type contextInfo struct {
+	Name        string   `json:"name"`
+	Current     bool     `json:"current"`
+	Endpoint    string   `json:"endpoint"`
+	Credentials []string `json:"credentials,omitempty"`
+}
+
+func newContextInfo(name string, cfg *cliconfig.Config) contextInfo {
+	cliCtx := cfg.Contexts[name]
+	var credentials []string
+	for k := range cliCtx.Credentials {
+		credentials = append(credentials, k)
+	}
+	sort.Strings(credentials)
+	return contextInfo{
+		Name:        name,
+		Current:     name == cfg.CurrentContext,
+		Endpoint:    cliCtx.Endpoint,
+		Credentials: credentials,
+	}
+}
+
+func (c contextInfo) RenderText(w io.Writer) error {
+	lines := []string{
+		fmt.Sprintf("Name:         %s", c.Name),
+		fmt.Sprintf("Current:      %v", c.Current),
+		fmt.Sprintf("Endpoint:     %s", c.Endpoint),
+	}
+	if len(c.Credentials) > 0 {
+		lines = append(lines, fmt.Sprintf("Credentials:  %s", strings.Join(c.Credentials, ", ")))
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+type contextList []contextInfo
+
+func (l contextList) RenderText(w io.Writer) error {
+	if len(l) == 0 {
+		_, err := fmt.Fprintln(w, "No contexts are configured")
+		return err
+	}
+	rows := make([]interface{}, len(l))
+	for i, c := range l {
+		rows[i] = c
+	}
+	printer := tableprinter.New(tabwriter.NewWriter(w, 0, 0, 2, ' ', 0), map[string]tableprinter.ColumnGetter{
+		"CURRENT": func(row interface{}) string {
+			if row.(contextInfo).Current {
+				return "*"
+			}
+			return ""
+		},
+		"NAME":     func(row interface{}) string { return row.(contextInfo).Name },
+		"ENDPOINT": func(row interface{}) string { return row.(contextInfo).Endpoint },
+	}, false, true, false)
+	return printer.Print([]string{"CURRENT", "NAME", "ENDPOINT"}, rows)
+}
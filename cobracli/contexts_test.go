@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cliconfig"
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestContextsParam(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	path := filepath.Join(tmpDir, "contexts.yml")
+
+	for i, tc := range []struct {
+		args       []string
+		wantRV     int
+		wantOutput string
+	}{
+		{[]string{"context", "list"}, 0, "No contexts are configured\n"},
+		{[]string{"endpoint"}, 0, "no context\n"},
+		{[]string{"setup"}, 0, ""},
+		{[]string{"context", "show"}, 1, "Error: no current context is set: specify the name of a context or run \"my-app context use <name>\" to set one\n"},
+		{[]string{"context", "list"}, 0, "CURRENT  NAME     ENDPOINT\n" +
+			"         prod     https://prod.example.com\n" +
+			"         staging  https://staging.example.com\n"},
+		{[]string{"context", "use", "dev"}, 1, "Error: context \"dev\" does not exist: available contexts are prod, staging\n"},
+		{[]string{"context", "use", "staging"}, 0, "Switched to context \"staging\"\n"},
+		{[]string{"context", "list"}, 0, "CURRENT  NAME     ENDPOINT\n" +
+			"         prod     https://prod.example.com\n" +
+			"*        staging  https://staging.example.com\n"},
+		{[]string{"context", "show"}, 0, "Name:         staging\n" +
+			"Current:      true\n" +
+			"Endpoint:     https://staging.example.com\n"},
+		{[]string{"context", "show", "prod", "--output-format", "json"}, 0, `{
+  "name": "prod",
+  "current": false,
+  "endpoint": "https://prod.example.com",
+  "credentials": [
+    "token"
+  ]
+}
+`},
+		{[]string{"endpoint"}, 0, "staging https://staging.example.com\n"},
+		{[]string{"endpoint", "--context", "prod"}, 0, "prod https://prod.example.com\n"},
+		{[]string{"endpoint", "--context", "dev"}, 1, "Error: context \"dev\" does not exist: available contexts are prod, staging\n"},
+	} {
+		rootCmd := &cobra.Command{Use: "my-app"}
+		rootCmd.AddCommand(
+			&cobra.Command{
+				Use: "endpoint",
+				Run: func(cmd *cobra.Command, args []string) {
+					name, cliCtx, ok := cliconfig.FromContext(cobracli.Context(cmd))
+					if !ok {
+						cmd.Println("no context")
+						return
+					}
+					cmd.Println(name, cliCtx.Endpoint)
+				},
+			},
+			&cobra.Command{
+				Use: "setup",
+				RunE: func(cmd *cobra.Command, args []string) error {
+					cfg, err := cliconfig.Load(path)
+					if err != nil {
+						return err
+					}
+					cfg.Contexts["prod"] = cliconfig.Context{
+						Endpoint:    "https://prod.example.com",
+						Credentials: map[string]string{"token": "secret"},
+					}
+					cfg.Contexts["staging"] = cliconfig.Context{Endpoint: "https://staging.example.com"}
+					return cfg.Save(path)
+				},
+			},
+		)
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.OutputFormatParam(cobracli.OutputFormatText), cobracli.ContextsParam(path))...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+	}
+}
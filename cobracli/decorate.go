@@ -0,0 +1,191 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// RunEFunc is the signature of the RunE function of a *cobra.Command.
+type RunEFunc func(cmd *cobra.Command, args []string) error
+
+// CommandMiddlewareParam returns a Param that wraps the Run or RunE function of every runnable command in the command
+// tree (including commands added by other Params) with the provided middleware before the root command is executed.
+// This allows cross-cutting concerns such as timing, logging and authentication to be implemented once rather than in
+// every command. The middleware is provided with the function that runs the command, which it must call for the
+// command to run. The original functions of the commands are restored when Execute returns.
+//
+// Middleware and the other Params that wrap the functions of commands are applied in the order in which they are
+// provided: the first one provided is the outermost one. Params that establish state that other Params read while the
+// command runs (such as DeterministicParam, QuietFlagParam, GlobalTimeoutParam, LogFileParam and OutputFormatParam)
+// are always applied outside of all other Params and middleware, regardless of the order in which they are provided.
+func CommandMiddlewareParam(mw func(next RunEFunc) RunEFunc) Param {
+	return runEDecoratorParam(mw)
+}
+
+// stage determines the order in which the decorators and pre-run hooks on the executor are applied, so that Params
+// whose decorators or hooks depend on the state established by those of other Params work regardless of the order in
+// which the Params are provided. Decorators and hooks of an earlier stage are applied first (decorators of an earlier
+// stage are outside of those of a later stage), and those of the same stage are applied in the order in which they
+// were provided.
+type stage int
+
+const (
+	// stageFirst is the stage of decorators and hooks whose state is read by those of stageEarly.
+	stageFirst stage = iota
+	// stageEarly is the stage of decorators and hooks whose state is read by those of stageDefault.
+	stageEarly
+	// stageDefault is the stage of all other decorators and hooks.
+	stageDefault
+
+	stageCount
+)
+
+// runEDecoratorParam adds the provided decorator to the executor in stageDefault. Before the root command is executed,
+// the Run or RunE function of every runnable command in the command tree is decorated using all of the decorators on
+// the executor.
+func runEDecoratorParam(decorator func(RunEFunc) RunEFunc) Param {
+	return stagedRunEDecoratorParam(stageDefault, decorator)
+}
+
+// stagedRunEDecoratorParam adds the provided decorator to the executor in the provided stage.
+func stagedRunEDecoratorParam(s stage, decorator func(RunEFunc) RunEFunc) Param {
+	return paramFunc(func(executor *executor) {
+		executor.runEDecorators[s] = append(executor.runEDecorators[s], decorator)
+	})
+}
+
+// decorateRunEs replaces the Run/RunE function of every runnable command in the tree rooted at the provided command
+// with a RunE function that is decorated by the provided decorators. The first decorator is the outermost one. Returns
+// a function that restores the original Run and RunE functions of all of the commands.
+func decorateRunEs(rootCmd *cobra.Command, decorators []func(RunEFunc) RunEFunc) (restore func()) {
+	if len(decorators) == 0 {
+		return func() {}
+	}
+
+	type origRuns struct {
+		cmd  *cobra.Command
+		run  func(*cobra.Command, []string)
+		runE func(*cobra.Command, []string) error
+	}
+	var origs []origRuns
+	visitCommands(rootCmd, func(cmd *cobra.Command) {
+		if !cmd.Runnable() {
+			return
+		}
+		origs = append(origs, origRuns{
+			cmd:  cmd,
+			run:  cmd.Run,
+			runE: cmd.RunE,
+		})
+
+		var runE RunEFunc
+		if cmd.RunE != nil {
+			runE = cmd.RunE
+		} else {
+			run := cmd.Run
+			runE = func(cmd *cobra.Command, args []string) error {
+				run(cmd, args)
+				return nil
+			}
+		}
+		for i := len(decorators) - 1; i >= 0; i-- {
+			runE = decorators[i](runE)
+		}
+		cmd.Run = nil
+		cmd.RunE = runE
+	})
+
+	return func() {
+		for _, orig := range origs {
+			orig.cmd.Run = orig.run
+			orig.cmd.RunE = orig.runE
+		}
+	}
+}
+
+// preRunHookParam adds the provided hook to the executor in stageDefault. Hooks are called in the order of their stages
+// (and in the order in which they are provided within a stage) after the flags and arguments of the executed command
+// are parsed and validated but before any of its pre-run functions are called and before Cobra checks that its required
+// flags are set. The function returned by a hook (if it is not nil) is called when Execute returns to undo the effects
+// of the hook. If a hook returns an error, the command is not run and the error is returned.
+func preRunHookParam(hook func(cmd *cobra.Command, args []string) (restore func(), err error)) Param {
+	return stagedPreRunHookParam(stageDefault, hook)
+}
+
+// stagedPreRunHookParam adds the provided hook to the executor in the provided stage.
+func stagedPreRunHookParam(s stage, hook func(cmd *cobra.Command, args []string) (restore func(), err error)) Param {
+	return paramFunc(func(executor *executor) {
+		executor.preRunHooks[s] = append(executor.preRunHooks[s], hook)
+	})
+}
+
+// installPreRunHooks replaces the persistent pre-run function of every command in the tree rooted at the provided
+// command with a function that calls the provided hooks and then the persistent pre-run function that Cobra would have
+// called (that of the nearest ancestor of the executed command, including the command itself, that has one). Hooks are
+// not called for commands that are added to the tree afterwards, such as the help command that Cobra adds. Returns a
+// function that restores the original persistent pre-run functions and undoes the effects of the hooks.
+func installPreRunHooks(rootCmd *cobra.Command, hooks []func(*cobra.Command, []string) (func(), error)) (restore func()) {
+	if len(hooks) == 0 {
+		return func() {}
+	}
+
+	type origPreRuns struct {
+		preRun  func(*cobra.Command, []string)
+		preRunE func(*cobra.Command, []string) error
+	}
+	origs := make(map[*cobra.Command]origPreRuns)
+	var hookRestores []func()
+	preRunE := func(cmd *cobra.Command, args []string) error {
+		if _, ok := origs[cmd]; ok {
+			for _, hook := range hooks {
+				hookRestore, err := hook(cmd, args)
+				if hookRestore != nil {
+					hookRestores = append(hookRestores, hookRestore)
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+		for curr := cmd; curr != nil; curr = curr.Parent() {
+			orig := origs[curr]
+			if orig.preRunE != nil {
+				return orig.preRunE(cmd, args)
+			}
+			if orig.preRun != nil {
+				orig.preRun(cmd, args)
+				return nil
+			}
+		}
+		return nil
+	}
+	visitCommands(rootCmd, func(cmd *cobra.Command) {
+		origs[cmd] = origPreRuns{
+			preRun:  cmd.PersistentPreRun,
+			preRunE: cmd.PersistentPreRunE,
+		}
+		cmd.PersistentPreRun = nil
+		cmd.PersistentPreRunE = preRunE
+	})
+
+	return func() {
+		for cmd, orig := range origs {
+			cmd.PersistentPreRun = orig.preRun
+			cmd.PersistentPreRunE = orig.preRunE
+		}
+		for i := len(hookRestores) - 1; i >= 0; i-- {
+			hookRestores[i]()
+		}
+	}
+}
+
+// visitCommands calls the provided function on the provided command and all of its descendants.
+func visitCommands(cmd *cobra.Command, fn func(*cobra.Command)) {
+	fn(cmd)
+	for _, child := range cmd.Commands() {
+		visitCommands(child, fn)
+	}
+}
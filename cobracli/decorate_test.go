@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestCommandMiddlewareParam(t *testing.T) {
+	middleware := func(name string) func(next cobracli.RunEFunc) cobracli.RunEFunc {
+		return func(next cobracli.RunEFunc) cobracli.RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				cmd.Printf("%s: before %s\n", name, cmd.Name())
+				err := next(cmd, args)
+				cmd.Printf("%s: after %s (err: %v)\n", name, cmd.Name(), err)
+				return err
+			}
+		}
+	}
+
+	for i, tc := range []struct {
+		args       []string
+		wantRV     int
+		wantOutput string
+	}{
+		{[]string{"parent", "child"}, 0, "outer: before child\n" +
+			"inner: before child\n" +
+			"ran child\n" +
+			"inner: after child (err: <nil>)\n" +
+			"outer: after child (err: <nil>)\n"},
+		{[]string{"fail"}, 1, "outer: before fail\n" +
+			"inner: before fail\n" +
+			"inner: after fail (err: failed)\n" +
+			"outer: after fail (err: failed)\n" +
+			"Error: failed\n"},
+	} {
+		rootCmd := &cobra.Command{Use: "my-app"}
+		parentCmd := &cobra.Command{Use: "parent"}
+		parentCmd.AddCommand(&cobra.Command{
+			Use: "child",
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Println("ran child")
+			},
+		})
+		rootCmd.AddCommand(parentCmd, &cobra.Command{
+			Use: "fail",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return fmt.Errorf("failed")
+			},
+		})
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil),
+			cobracli.CommandMiddlewareParam(middleware("outer")),
+			cobracli.CommandMiddlewareParam(middleware("inner")),
+		)...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+		assert.NotNil(t, parentCmd.Commands()[0].Run, "Case %d: original Run function was not restored", i)
+	}
+}
+
+func TestParamsIndependentOfOrder(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	configPath := filepath.Join(tmpDir, "config.yml")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte("name: from-config\n"), 0644))
+	logFile := filepath.Join(tmpDir, "cli.log")
+
+	for k, v := range map[string]string{
+		"MYAPP_CONFIG":               configPath,
+		cobracli.DeterministicEnvVar: "true",
+	} {
+		require.NoError(t, os.Setenv(k, v))
+		defer func(k string) {
+			_ = os.Unsetenv(k)
+		}(k)
+	}
+
+	var name string
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, hasDeadline := cobracli.Context(cmd).Deadline()
+			_, _ = fmt.Fprintln(cobracli.Status(cmd), "Using token Bearer secret-token")
+			cmd.Printf("name=%s deterministic=%v deadline=%v\n", name, cobracli.IsDeterministic(cmd), hasDeadline)
+			return nil
+		},
+	}
+	rootCmd.Flags().StringVar(&name, "name", "", "name")
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"--quiet", "--timeout", "1h", "--log-file", logFile})
+
+	// every Param is provided before the Params whose state it depends on
+	rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil),
+		cobracli.RedactOutputParam(),
+		cobracli.DeadlineWarningParam(0.5),
+		cobracli.LogFileParam(),
+		cobracli.QuietFlagParam(),
+		cobracli.DeterministicParam(),
+		cobracli.GlobalTimeoutParam(),
+		cobracli.ConfigFileParam(""),
+		cobracli.EnvVarPrefixParam("MYAPP"),
+	)...)
+	assert.Equal(t, 0, rv)
+	assert.Equal(t, "name=from-config deterministic=true deadline=true\n", buf.String())
+
+	content, err := ioutil.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, "2000-01-01T00:00:00Z Using token Bearer [REDACTED]\n", string(content))
+}
@@ -41,22 +41,49 @@ func ExecuteWithDebugVarAndDefaultParams(rootCmd *cobra.Command, debugVar *bool,
 //
 // * Sets SilenceErrors and SilenceUsage to true, which disables Cobra's built-in error and usage behavior. This
 //   prevents the behavior where usage is printed on any error returned by the command.
-// * Registers a custom flag usage error handler that appends Cobra's command usage string to the errors encountered
-//   while parsing flags. This makes it such that errors that occur due to invalid flags do print the usage.
+// * Configures the command so that errors encountered while parsing flags or validating positional arguments are
+//   returned as a *UsageError (see UsageErrorsConfigurer). The usage of the command is printed after such errors, so
+//   errors that occur due to invalid flags or arguments do print the usage.
 // * Registers an error printer that prints top-level errors as "Error: <error.Error()>" unless <error.Error()> is the
 //   empty string, in which case no error is printed. If the "debugVar" pointer is non-nil and its underlying value is
 //   true, then <error.Error()> is printed as a full verbose stack trace if it is a pkg/errors error. This printer is
-//   also configured to print the usage output for a command if the command returns an error that indicates that a
-//   required flag was not provided.
+//   also configured to print the usage output for a command if the command returns a *UsageError or an error that
+//   indicates that a required flag was not provided.
 func DefaultParams(debugVar *bool) []Param {
+	return defaultParams(debugVar, errorstringer.StackWithInterleavedMessages)
+}
+
+// DebugFlagParam returns a Param that configures the same behavior as DefaultParams and that adds "--debug" as a
+// boolean persistent flag on the command (unless the command already has a flag with that name). If the command exits
+// with an error and the flag was specified, the error is printed using the provided transform function. If the
+// transform function is nil, full stack traces are printed if available (as they are by DefaultParams). This allows
+// the debug-aware error printer to be used without declaring and registering a debug variable:
+//
+//	os.Exit(cobracli.Execute(rootCmd, cobracli.DebugFlagParam(nil)))
+func DebugFlagParam(debugErrTransform func(error) string) Param {
+	if debugErrTransform == nil {
+		debugErrTransform = errorstringer.StackWithInterleavedMessages
+	}
+	var debug bool
+	return multiParam(append(
+		defaultParams(&debug, debugErrTransform),
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			if cmd.Flag("debug") == nil {
+				cmd.PersistentFlags().BoolVar(&debug, "debug", false, "run in debug mode")
+			}
+		}),
+	)...)
+}
+
+func defaultParams(debugVar *bool, debugErrTransform func(error) string) []Param {
 	return []Param{
 		// silence default error and usage printing provided by cobra CLI
 		ConfigureCmdParam(SilenceErrorsConfigurer),
-		// if error is encountered while parsing a flag, include the usage for the command as part of the error
-		ConfigureCmdParam(FlagErrorsUsageErrorConfigurer),
+		// if error is encountered while parsing a flag or validating arguments, return it as a *UsageError
+		ConfigureCmdParam(UsageErrorsConfigurer),
 		// set error handler that prints "Error: <error content>" (unless error content is empty, in which case nothing
 		// is printed). If the value of the provided debug boolean pointer is true, then if the error is a pkg/errors
 		// error, the full stack trace is printed.
-		ErrorHandlerParam(PrintUsageOnRequiredFlagErrorHandlerDecorator(ErrorPrinterWithDebugHandler(debugVar, errorstringer.StackWithInterleavedMessages))),
+		ErrorHandlerParam(PrintUsageOnUsageErrorHandlerDecorator(PrintUsageOnRequiredFlagErrorHandlerDecorator(ErrorPrinterWithDebugHandler(debugVar, debugErrTransform)))),
 	}
 }
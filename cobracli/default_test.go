@@ -168,3 +168,38 @@ Global Flags:
 		}()
 	}
 }
+
+func TestDebugFlagParam(t *testing.T) {
+	transform := func(err error) string {
+		return "debug: " + err.Error()
+	}
+	for i, tc := range []struct {
+		args       []string
+		transform  func(error) string
+		wantOutput interface{}
+	}{
+		{nil, transform, "Error: hello-error\n"},
+		{[]string{"--debug"}, transform, "Error: debug: hello-error\n"},
+		{[]string{"--debug"}, nil, regexp.MustCompile(`(?s)^Error: hello-error
+	github.com/palantir/pkg/cobracli_test.TestDebugFlagParam.+`)},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return errors.Errorf("hello-error")
+			},
+		}
+		outBuf := &bytes.Buffer{}
+		rootCmd.SetOutput(outBuf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, cobracli.DebugFlagParam(tc.transform))
+		assert.Equal(t, 1, rv, "Case %d", i)
+		switch want := tc.wantOutput.(type) {
+		case string:
+			assert.Equal(t, want, outBuf.String(), "Case %d", i)
+		case *regexp.Regexp:
+			assert.Regexp(t, want, outBuf.String(), "Case %d", i)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/uuid"
+)
+
+const (
+	// DeterministicEnvVar is the environment variable that enables deterministic output when DeterministicParam is
+	// provided. Deterministic output is enabled if its value is a true boolean value (such as "1" or "true").
+	DeterministicEnvVar = "COBRACLI_DETERMINISTIC"
+	// DeterministicSeed is the seed of the source of randomness returned by Rand when deterministic output is enabled.
+	DeterministicSeed = 1
+
+	deterministicValueName = "deterministic"
+)
+
+// DeterministicTime is the time returned by Now when deterministic output is enabled.
+var DeterministicTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// DeterministicParam returns a Param that makes the output of commands deterministic when the DeterministicEnvVar
+// environment variable is set to true, so that golden-file tests of the output of commands are not flaky. When
+// deterministic output is enabled:
+//
+//   - Now returns DeterministicTime, which is also used for the timestamps of the log file written by LogFileParam;
+//   - ColorEnabled returns false, so output does not contain ANSI escape sequences;
+//   - LocaleParam uses locale.C regardless of the environment and the "--locale" flag;
+//   - Rand and NewUUID use a source of randomness that is seeded with DeterministicSeed for every invocation.
+//
+// Commands must use these functions rather than the standard library for their output to be deterministic.
+func DeterministicParam() Param {
+	// applied outside of LocaleParam and LogFileParam, which determine whether deterministic output is enabled
+	return stagedRunEDecoratorParam(stageFirst, func(next RunEFunc) RunEFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			if enabled, _ := strconv.ParseBool(os.Getenv(DeterministicEnvVar)); !enabled {
+				return next(cmd, args)
+			}
+			restore := setInvocationValue(cmd, deterministicValueName, rand.New(rand.NewSource(DeterministicSeed)))
+			defer restore()
+			return next(cmd, args)
+		}
+	})
+}
+
+// IsDeterministic returns true if deterministic output is enabled for the running command (see DeterministicParam).
+func IsDeterministic(cmd *cobra.Command) bool {
+	_, ok := invocationValue(cmd, deterministicValueName)
+	return ok
+}
+
+// Now returns the current time, or DeterministicTime if deterministic output is enabled for the running command.
+func Now(cmd *cobra.Command) time.Time {
+	if IsDeterministic(cmd) {
+		return DeterministicTime
+	}
+	return time.Now()
+}
+
+// Rand returns a source of pseudo-random numbers for the running command. If deterministic output is enabled, the same
+// source, which is seeded with DeterministicSeed, is returned for every call during an invocation, so the sequence of
+// numbers that it produces is the same for every invocation. Otherwise, a new source seeded with the current time is
+// returned. The returned source is not safe for concurrent use.
+func Rand(cmd *cobra.Command) *rand.Rand {
+	if v, ok := invocationValue(cmd, deterministicValueName); ok {
+		return v.(*rand.Rand)
+	}
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// NewUUID returns a new random UUID for the running command. If deterministic output is enabled, its random bits are
+// read from the source returned by Rand. Otherwise, it is equivalent to uuid.NewUUID.
+func NewUUID(cmd *cobra.Command) uuid.UUID {
+	if !IsDeterministic(cmd) {
+		return uuid.NewUUID()
+	}
+	u, err := uuid.NewUUIDFromReader(Rand(cmd))
+	if err != nil {
+		// reading from a *rand.Rand never fails
+		panic(err)
+	}
+	return u
+}
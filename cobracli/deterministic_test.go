@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+	"github.com/palantir/pkg/locale"
+)
+
+func TestDeterministicParam(t *testing.T) {
+	runCmd := func(args ...string) string {
+		var l locale.Locale
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Printf("deterministic: %v\n", cobracli.IsDeterministic(cmd))
+				cmd.Printf("now: %s\n", cobracli.Now(cmd).Format(time.RFC3339))
+				cmd.Printf("number: %s\n", l.FormatFloat(1234.5, 1))
+				cmd.Printf("rand: %d\n", cobracli.Rand(cmd).Intn(1000000))
+				cmd.Printf("uuids: %s %s\n", cobracli.NewUUID(cmd), cobracli.NewUUID(cmd))
+				cmd.Printf("color: %v\n", cobracli.ColorEnabled(cmd, os.Stdout))
+			},
+		}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(args)
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil),
+			cobracli.DeterministicParam(),
+			cobracli.LocaleParam(&l),
+		)...)
+		require.Equal(t, 0, rv)
+		return buf.String()
+	}
+
+	require.NoError(t, os.Setenv(cobracli.DeterministicEnvVar, "true"))
+	defer func() {
+		_ = os.Unsetenv(cobracli.DeterministicEnvVar)
+	}()
+	output := runCmd("--locale", "de-DE")
+	assert.Equal(t, "deterministic: true\n"+
+		"now: 2000-01-01T00:00:00Z\n"+
+		"number: 1234.5\n"+
+		"rand: 498081\n"+
+		"uuids: 4f163f5f-0f9a-421d-b295-66c74d10037c 4d7bbb04-07d1-42c6-8981-855ad8681d0d\n"+
+		"color: false\n", output)
+	assert.Equal(t, output, runCmd("--locale", "de-DE"), "output should be the same for every invocation")
+
+	require.NoError(t, os.Unsetenv(cobracli.DeterministicEnvVar))
+	output = runCmd("--locale", "de-DE")
+	assert.Contains(t, output, "deterministic: false\n")
+	assert.Contains(t, output, "number: 1.234,5\n")
+	assert.NotContains(t, output, "now: 2000-01-01T00:00:00Z\n")
+}
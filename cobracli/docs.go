@@ -0,0 +1,316 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/flagtypes"
+)
+
+// DocsFormat is a format in which documentation for a command tree can be generated.
+type DocsFormat string
+
+const (
+	// DocsFormatMarkdown generates a Markdown file for every command.
+	DocsFormatMarkdown DocsFormat = "markdown"
+	// DocsFormatMan generates a man page in section 1 for every command.
+	DocsFormatMan DocsFormat = "man"
+	// DocsFormatReST generates a reStructuredText file for every command.
+	DocsFormatReST DocsFormat = "rest"
+	// DocsFormatDOT generates a single Graphviz DOT file that contains the graph of the command tree.
+	DocsFormatDOT DocsFormat = "dot"
+	// DocsFormatMermaid generates a single Mermaid file that contains the graph of the command tree.
+	DocsFormatMermaid DocsFormat = "mermaid"
+)
+
+// DocsCommandParam returns a Param that adds the command returned by DocsCmd to the root command.
+func DocsCommandParam() Param {
+	return ConfigureCmdParam(func(cmd *cobra.Command) {
+		cmd.AddCommand(DocsCmd())
+	})
+}
+
+// DocsCmd returns a hidden "docs" command that generates documentation for the full command tree of the root command
+// using GenerateDocs. The command has the following flags:
+//
+//   - "--format": the format of the documentation ("markdown", "man", "rest", "dot" or "mermaid"). Defaults to
+//     "markdown".
+//   - "--dir": the directory to which the documentation is written. Required.
+//   - "--flag-counts": include the number of flags of every command in graphs (see GraphOptions).
+//   - "--stability": include the stability level of every command in graphs (see GraphOptions).
+func DocsCmd() *cobra.Command {
+	var (
+		format     string
+		dir        string
+		flagCounts bool
+		stability  bool
+	)
+	cmd := &cobra.Command{
+		Use:    "docs",
+		Short:  "Generate documentation for all commands",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if dir == "" {
+				return fmt.Errorf("the --dir flag must be specified")
+			}
+			var paths []string
+			var err error
+			if isGraphDocsFormat(DocsFormat(format)) {
+				paths, err = generateGraphDocs(cmd.Root(), DocsFormat(format), dir, GraphOptions{
+					FlagCounts: flagCounts,
+					Stability:  stability,
+				})
+			} else {
+				if flagCounts || stability {
+					return fmt.Errorf("the --flag-counts and --stability flags are only supported for the %q and %q formats", DocsFormatDOT, DocsFormatMermaid)
+				}
+				paths, err = GenerateDocs(cmd.Root(), DocsFormat(format), dir)
+			}
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(Status(cmd), "Wrote %d file(s) to %s\n", len(paths), dir)
+			return nil
+		},
+	}
+	flagtypes.EnumVar(cmd.Flags(), &format, "format", string(DocsFormatMarkdown), "format of the documentation",
+		string(DocsFormatMarkdown), string(DocsFormatMan), string(DocsFormatReST), string(DocsFormatDOT), string(DocsFormatMermaid))
+	cmd.Flags().StringVar(&dir, "dir", "", "directory to which the documentation is written")
+	cmd.Flags().BoolVar(&flagCounts, "flag-counts", false, "include the number of flags of every command in graphs")
+	cmd.Flags().BoolVar(&stability, "stability", false, "include the stability level of every command in graphs")
+	return cmd
+}
+
+// GenerateDocs writes documentation in the provided format for the provided command and all of its available
+// descendants to the provided directory, which is created if it does not exist, and returns the paths of the files
+// that were written. Hidden and deprecated commands and help commands are omitted, and commands are documented in
+// order of their names so that the output does not depend on the order in which commands were added. The output does
+// not contain the time at which it was generated, so regenerating the documentation for an unchanged command tree
+// produces identical files.
+//
+// The graph formats (DocsFormatDOT and DocsFormatMermaid) write a single file that contains the graph of the command
+// tree without any details: use WriteCommandGraph to include flag counts or stability levels.
+func GenerateDocs(cmd *cobra.Command, format DocsFormat, dir string) ([]string, error) {
+	if isGraphDocsFormat(format) {
+		return generateGraphDocs(cmd, format, dir, GraphOptions{})
+	}
+	var gen docsGenerator
+	switch format {
+	case DocsFormatMarkdown:
+		gen = markdownDocsGenerator{}
+	case DocsFormatMan:
+		gen = manDocsGenerator{}
+	case DocsFormatReST:
+		gen = restDocsGenerator{}
+	default:
+		return nil, fmt.Errorf("unsupported documentation format %q", format)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %v", dir, err)
+	}
+
+	var paths []string
+	var visit func(cmd *cobra.Command) error
+	visit = func(cmd *cobra.Command) error {
+		// the help flag is only added to commands when they are executed, so add it so that it is documented
+		cmd.InitDefaultHelpFlag()
+		path := filepath.Join(dir, gen.filename(cmd))
+		if err := ioutil.WriteFile(path, gen.generate(cmd), 0644); err != nil {
+			return fmt.Errorf("failed to write documentation for %q: %v", cmd.CommandPath(), err)
+		}
+		paths = append(paths, path)
+		for _, child := range docsChildren(cmd) {
+			if err := visit(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := visit(cmd); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+type docsGenerator interface {
+	filename(cmd *cobra.Command) string
+	generate(cmd *cobra.Command) []byte
+}
+
+// docsChildren returns the children of the provided command that are documented in order of their names.
+func docsChildren(cmd *cobra.Command) []*cobra.Command {
+	var children []*cobra.Command
+	for _, child := range cmd.Commands() {
+		if child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
+			children = append(children, child)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].Name() < children[j].Name()
+	})
+	return children
+}
+
+// docsBaseName returns the name of the documentation file for the provided command without an extension: the path of
+// the command with spaces replaced by the provided separator.
+func docsBaseName(cmd *cobra.Command, sep string) string {
+	return strings.Replace(cmd.CommandPath(), " ", sep, -1)
+}
+
+func docsDescription(cmd *cobra.Command) string {
+	if cmd.Long != "" {
+		return cmd.Long
+	}
+	return cmd.Short
+}
+
+type markdownDocsGenerator struct{}
+
+func (markdownDocsGenerator) filename(cmd *cobra.Command) string {
+	return docsBaseName(cmd, "_") + ".md"
+}
+
+func (g markdownDocsGenerator) generate(cmd *cobra.Command) []byte {
+	buf := &bytes.Buffer{}
+	_, _ = fmt.Fprintf(buf, "## %s\n\n%s\n\n", cmd.CommandPath(), cmd.Short)
+	_, _ = fmt.Fprintf(buf, "### Synopsis\n\n%s\n\n", docsDescription(cmd))
+	if cmd.Runnable() {
+		_, _ = fmt.Fprintf(buf, "```\n%s\n```\n\n", cmd.UseLine())
+	}
+	if cmd.HasExample() {
+		_, _ = fmt.Fprintf(buf, "### Examples\n\n```\n%s\n```\n\n", cmd.Example)
+	}
+	if flags := cmd.NonInheritedFlags(); flags.HasAvailableFlags() {
+		_, _ = fmt.Fprintf(buf, "### Options\n\n```\n%s```\n\n", flags.FlagUsages())
+	}
+	if flags := cmd.InheritedFlags(); flags.HasAvailableFlags() {
+		_, _ = fmt.Fprintf(buf, "### Options inherited from parent commands\n\n```\n%s```\n\n", flags.FlagUsages())
+	}
+	if seeAlso := docsSeeAlso(cmd); len(seeAlso) > 0 {
+		buf.WriteString("### SEE ALSO\n\n")
+		for _, c := range seeAlso {
+			_, _ = fmt.Fprintf(buf, "* [%s](%s)\t - %s\n", c.CommandPath(), g.filename(c), c.Short)
+		}
+		buf.WriteString("\n")
+	}
+	return append(bytes.TrimRight(buf.Bytes(), "\n"), '\n')
+}
+
+type restDocsGenerator struct{}
+
+func (restDocsGenerator) filename(cmd *cobra.Command) string {
+	return docsBaseName(cmd, "_") + ".rst"
+}
+
+func (restDocsGenerator) generate(cmd *cobra.Command) []byte {
+	buf := &bytes.Buffer{}
+	restHeading(buf, cmd.CommandPath(), "-")
+	_, _ = fmt.Fprintf(buf, "%s\n\n", cmd.Short)
+	restHeading(buf, "Synopsis", "~")
+	_, _ = fmt.Fprintf(buf, "%s\n\n", docsDescription(cmd))
+	if cmd.Runnable() {
+		_, _ = fmt.Fprintf(buf, "::\n\n%s\n", restIndent(cmd.UseLine()))
+	}
+	if cmd.HasExample() {
+		restHeading(buf, "Examples", "~")
+		_, _ = fmt.Fprintf(buf, "::\n\n%s\n", restIndent(cmd.Example))
+	}
+	if flags := cmd.NonInheritedFlags(); flags.HasAvailableFlags() {
+		restHeading(buf, "Options", "~")
+		_, _ = fmt.Fprintf(buf, "::\n\n%s\n", restIndent(flags.FlagUsages()))
+	}
+	if flags := cmd.InheritedFlags(); flags.HasAvailableFlags() {
+		restHeading(buf, "Options inherited from parent commands", "~")
+		_, _ = fmt.Fprintf(buf, "::\n\n%s\n", restIndent(flags.FlagUsages()))
+	}
+	if seeAlso := docsSeeAlso(cmd); len(seeAlso) > 0 {
+		restHeading(buf, "SEE ALSO", "~")
+		for _, c := range seeAlso {
+			_, _ = fmt.Fprintf(buf, "* `%s <%s>`_ \t - %s\n", c.CommandPath(), docsBaseName(c, "_")+".rst", c.Short)
+		}
+		buf.WriteString("\n")
+	}
+	return append(bytes.TrimRight(buf.Bytes(), "\n"), '\n')
+}
+
+func restHeading(buf *bytes.Buffer, title, underline string) {
+	_, _ = fmt.Fprintf(buf, "%s\n%s\n\n", title, strings.Repeat(underline, len(title)))
+}
+
+// restIndent indents every non-empty line of the provided text so that it can be used as a literal block.
+func restIndent(s string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "  " + line
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+type manDocsGenerator struct{}
+
+func (manDocsGenerator) filename(cmd *cobra.Command) string {
+	return docsBaseName(cmd, "-") + ".1"
+}
+
+func (manDocsGenerator) generate(cmd *cobra.Command) []byte {
+	buf := &bytes.Buffer{}
+	_, _ = fmt.Fprintf(buf, ".TH \"%s\" \"1\" \"\" \"%s\" \"\"\n", strings.ToUpper(docsBaseName(cmd, "-")), manEscape(cmd.Root().Name()))
+	_, _ = fmt.Fprintf(buf, ".SH NAME\n%s \\- %s\n", manEscape(docsBaseName(cmd, "-")), manEscape(cmd.Short))
+	_, _ = fmt.Fprintf(buf, ".SH SYNOPSIS\n.PP\n\\fB%s\\fP\n", manEscape(cmd.UseLine()))
+	_, _ = fmt.Fprintf(buf, ".SH DESCRIPTION\n.PP\n%s\n", manEscape(docsDescription(cmd)))
+	if flags := cmd.NonInheritedFlags(); flags.HasAvailableFlags() {
+		_, _ = fmt.Fprintf(buf, ".SH OPTIONS\n.nf\n%s.fi\n", manEscape(flags.FlagUsages()))
+	}
+	if flags := cmd.InheritedFlags(); flags.HasAvailableFlags() {
+		_, _ = fmt.Fprintf(buf, ".SH OPTIONS INHERITED FROM PARENT COMMANDS\n.nf\n%s.fi\n", manEscape(flags.FlagUsages()))
+	}
+	if cmd.HasExample() {
+		_, _ = fmt.Fprintf(buf, ".SH EXAMPLE\n.nf\n%s\n.fi\n", manEscape(cmd.Example))
+	}
+	if seeAlso := docsSeeAlso(cmd); len(seeAlso) > 0 {
+		refs := make([]string, len(seeAlso))
+		for i, c := range seeAlso {
+			refs[i] = fmt.Sprintf("\\fB%s(1)\\fP", manEscape(docsBaseName(c, "-")))
+		}
+		_, _ = fmt.Fprintf(buf, ".SH SEE ALSO\n.PP\n%s\n", strings.Join(refs, ", "))
+	}
+	return buf.Bytes()
+}
+
+// manEscape escapes the provided text for use in a man page: backslashes and hyphens are escaped and lines that start
+// with a control character are prefixed with a zero-width space.
+func manEscape(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, "-", `\-`, -1)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// docsSeeAlso returns the commands that are referenced by the documentation of the provided command: its parent
+// followed by its documented children.
+func docsSeeAlso(cmd *cobra.Command) []*cobra.Command {
+	var cmds []*cobra.Command
+	if cmd.HasParent() {
+		cmds = append(cmds, cmd.Parent())
+	}
+	return append(cmds, docsChildren(cmd)...)
+}
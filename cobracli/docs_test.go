@@ -0,0 +1,330 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func newDocsTestCmd() *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:   "my-app",
+		Short: "Manages things",
+	}
+	rootCmd.PersistentFlags().Bool("debug", false, "run in debug mode")
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List things",
+		Long:    "List all of the things.",
+		Example: "my-app list --all",
+		Run:     func(cmd *cobra.Command, args []string) {},
+	}
+	listCmd.Flags().Bool("all", false, "list all things")
+	rootCmd.AddCommand(
+		listCmd,
+		&cobra.Command{
+			Use:   "delete",
+			Short: "Delete things",
+			Run:   func(cmd *cobra.Command, args []string) {},
+		},
+		&cobra.Command{
+			Use:    "internal",
+			Short:  "Internal command",
+			Hidden: true,
+			Run:    func(cmd *cobra.Command, args []string) {},
+		},
+	)
+	return rootCmd
+}
+
+func TestDocsCommandParam(t *testing.T) {
+	for i, tc := range []struct {
+		format    cobracli.DocsFormat
+		wantFiles []string
+	}{
+		{cobracli.DocsFormatMarkdown, []string{"my-app.md", "my-app_delete.md", "my-app_list.md"}},
+		{cobracli.DocsFormatMan, []string{"my-app-delete.1", "my-app-list.1", "my-app.1"}},
+		{cobracli.DocsFormatReST, []string{"my-app.rst", "my-app_delete.rst", "my-app_list.rst"}},
+	} {
+		tmpDir, cleanup, err := dirs.TempDir("", "")
+		defer cleanup()
+		require.NoError(t, err)
+
+		rootCmd := newDocsTestCmd()
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs([]string{"docs", "--format", string(tc.format), "--dir", tmpDir})
+		rv := cobracli.Execute(rootCmd, cobracli.DocsCommandParam())
+		require.Equal(t, 0, rv, "Case %d\nOutput:\n%s", i, buf.String())
+		assert.Equal(t, "Wrote 3 file(s) to "+tmpDir+"\n", buf.String(), "Case %d", i)
+
+		fis, err := ioutil.ReadDir(tmpDir)
+		require.NoError(t, err, "Case %d", i)
+		var gotFiles []string
+		for _, fi := range fis {
+			gotFiles = append(gotFiles, fi.Name())
+		}
+		assert.Equal(t, tc.wantFiles, gotFiles, "Case %d", i)
+	}
+}
+
+func TestGenerateDocsMarkdown(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	paths, err := cobracli.GenerateDocs(newDocsTestCmd(), cobracli.DocsFormatMarkdown, tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(tmpDir, "my-app.md"),
+		filepath.Join(tmpDir, "my-app_delete.md"),
+		filepath.Join(tmpDir, "my-app_list.md"),
+	}, paths)
+
+	content, err := ioutil.ReadFile(filepath.Join(tmpDir, "my-app_list.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "## my-app list\n"+
+		"\n"+
+		"List things\n"+
+		"\n"+
+		"### Synopsis\n"+
+		"\n"+
+		"List all of the things.\n"+
+		"\n"+
+		"```\n"+
+		"my-app list [flags]\n"+
+		"```\n"+
+		"\n"+
+		"### Examples\n"+
+		"\n"+
+		"```\n"+
+		"my-app list --all\n"+
+		"```\n"+
+		"\n"+
+		"### Options\n"+
+		"\n"+
+		"```\n"+
+		"      --all    list all things\n"+
+		"  -h, --help   help for list\n"+
+		"```\n"+
+		"\n"+
+		"### Options inherited from parent commands\n"+
+		"\n"+
+		"```\n"+
+		"      --debug   run in debug mode\n"+
+		"```\n"+
+		"\n"+
+		"### SEE ALSO\n"+
+		"\n"+
+		"* [my-app](my-app.md)\t - Manages things\n", string(content))
+
+	content, err = ioutil.ReadFile(filepath.Join(tmpDir, "my-app.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "### SEE ALSO\n\n"+
+		"* [my-app delete](my-app_delete.md)\t - Delete things\n"+
+		"* [my-app list](my-app_list.md)\t - List things\n")
+	assert.NotContains(t, string(content), "internal")
+	assert.NotContains(t, string(content), "Auto generated")
+}
+
+func TestGenerateDocsMan(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	_, err = cobracli.GenerateDocs(newDocsTestCmd(), cobracli.DocsFormatMan, tmpDir)
+	require.NoError(t, err)
+
+	content, err := ioutil.ReadFile(filepath.Join(tmpDir, "my-app-list.1"))
+	require.NoError(t, err)
+	assert.Equal(t, `.TH "MY-APP-LIST" "1" "" "my\-app" ""
+.SH NAME
+my\-app\-list \- List things
+.SH SYNOPSIS
+.PP
+\fBmy\-app list [flags]\fP
+.SH DESCRIPTION
+.PP
+List all of the things.
+.SH OPTIONS
+.nf
+      \-\-all    list all things
+  \-h, \-\-help   help for list
+.fi
+.SH OPTIONS INHERITED FROM PARENT COMMANDS
+.nf
+      \-\-debug   run in debug mode
+.fi
+.SH EXAMPLE
+.nf
+my\-app list \-\-all
+.fi
+.SH SEE ALSO
+.PP
+\fBmy\-app(1)\fP
+`, string(content))
+}
+
+func TestGenerateDocsReST(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	_, err = cobracli.GenerateDocs(newDocsTestCmd(), cobracli.DocsFormatReST, tmpDir)
+	require.NoError(t, err)
+
+	content, err := ioutil.ReadFile(filepath.Join(tmpDir, "my-app_delete.rst"))
+	require.NoError(t, err)
+	assert.Equal(t, "my-app delete\n"+
+		"-------------\n"+
+		"\n"+
+		"Delete things\n"+
+		"\n"+
+		"Synopsis\n"+
+		"~~~~~~~~\n"+
+		"\n"+
+		"Delete things\n"+
+		"\n"+
+		"::\n"+
+		"\n"+
+		"  my-app delete [flags]\n"+
+		"\n"+
+		"Options\n"+
+		"~~~~~~~\n"+
+		"\n"+
+		"::\n"+
+		"\n"+
+		"    -h, --help   help for delete\n"+
+		"\n"+
+		"Options inherited from parent commands\n"+
+		"~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~\n"+
+		"\n"+
+		"::\n"+
+		"\n"+
+		"        --debug   run in debug mode\n"+
+		"\n"+
+		"SEE ALSO\n"+
+		"~~~~~~~~\n"+
+		"\n"+
+		"* `my-app <my-app.rst>`_ \t - Manages things\n", string(content))
+}
+
+func TestGenerateDocsUnsupportedFormat(t *testing.T) {
+	_, err := cobracli.GenerateDocs(newDocsTestCmd(), "html", "")
+	assert.EqualError(t, err, `unsupported documentation format "html"`)
+}
+
+func TestWriteCommandGraph(t *testing.T) {
+	rootCmd := newDocsTestCmd()
+	listCmd, _, err := rootCmd.Find([]string{"list"})
+	require.NoError(t, err)
+	cobracli.SetCommandMeta(listCmd, cobracli.CommandMeta{Stability: cobracli.StabilityBeta})
+
+	for i, tc := range []struct {
+		name   string
+		format cobracli.DocsFormat
+		opts   cobracli.GraphOptions
+		want   string
+	}{
+		{
+			"DOT",
+			cobracli.DocsFormatDOT,
+			cobracli.GraphOptions{},
+			`digraph "my-app" {
+	node [shape=box];
+	"my-app" [label="my-app"];
+	"my-app delete" [label="delete"];
+	"my-app list" [label="list"];
+	"my-app" -> "my-app delete";
+	"my-app" -> "my-app list";
+}
+`,
+		},
+		{
+			"DOT with details",
+			cobracli.DocsFormatDOT,
+			cobracli.GraphOptions{FlagCounts: true, Stability: true},
+			`digraph "my-app" {
+	node [shape=box];
+	"my-app" [label="my-app\n1 flag"];
+	"my-app delete" [label="delete\n0 flags"];
+	"my-app list" [label="list\n1 flag\nbeta"];
+	"my-app" -> "my-app delete";
+	"my-app" -> "my-app list";
+}
+`,
+		},
+		{
+			"Mermaid",
+			cobracli.DocsFormatMermaid,
+			cobracli.GraphOptions{},
+			`flowchart TD
+	n0["my-app"]
+	n1["delete"]
+	n2["list"]
+	n0 --> n1
+	n0 --> n2
+`,
+		},
+		{
+			"Mermaid with details",
+			cobracli.DocsFormatMermaid,
+			cobracli.GraphOptions{FlagCounts: true, Stability: true},
+			`flowchart TD
+	n0["my-app<br/>1 flag"]
+	n1["delete<br/>0 flags"]
+	n2["list<br/>1 flag<br/>beta"]
+	n0 --> n1
+	n0 --> n2
+`,
+		},
+	} {
+		buf := &bytes.Buffer{}
+		err := cobracli.WriteCommandGraph(buf, rootCmd, tc.format, tc.opts)
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.want, buf.String(), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestWriteCommandGraphUnsupportedFormat(t *testing.T) {
+	err := cobracli.WriteCommandGraph(&bytes.Buffer{}, newDocsTestCmd(), cobracli.DocsFormatMarkdown, cobracli.GraphOptions{})
+	assert.EqualError(t, err, `unsupported graph format "markdown"`)
+}
+
+func TestDocsCommandParamGraph(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	rootCmd := newDocsTestCmd()
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"docs", "--format", "mermaid", "--dir", tmpDir, "--flag-counts"})
+	rv := cobracli.Execute(rootCmd, cobracli.DocsCommandParam())
+	require.Equal(t, 0, rv, "Output:\n%s", buf.String())
+	assert.Equal(t, "Wrote 1 file(s) to "+tmpDir+"\n", buf.String())
+
+	content, err := ioutil.ReadFile(filepath.Join(tmpDir, "my-app.mmd"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `n2["list<br/>1 flag"]`)
+
+	rootCmd = newDocsTestCmd()
+	buf.Reset()
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"docs", "--dir", tmpDir, "--stability"})
+	rv = cobracli.Execute(rootCmd, cobracli.DocsCommandParam())
+	assert.Equal(t, 1, rv)
+	assert.Contains(t, buf.String(), `the --flag-counts and --stability flags are only supported for the "dot" and "mermaid" formats`)
+}
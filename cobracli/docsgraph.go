@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// GraphOptions configures the details that are included in the nodes of a command graph.
+type GraphOptions struct {
+	// FlagCounts includes the number of flags defined by every command. Inherited, hidden and deprecated flags and the
+	// help flag are not counted.
+	FlagCounts bool
+	// Stability includes the stability level of every command that has one (see CommandMeta).
+	Stability bool
+}
+
+// WriteCommandGraph writes the graph of the provided command and all of its available descendants to the provided
+// writer in the provided format, which must be DocsFormatDOT or DocsFormatMermaid. Every command is a node labeled
+// with its name (and the details configured by the provided options) and has an edge to each of its children. As for
+// GenerateDocs, hidden and deprecated commands and help commands are omitted and commands are written in order of
+// their names, so the output for an unchanged command tree is identical and changes to the tree produce minimal diffs.
+func WriteCommandGraph(w io.Writer, cmd *cobra.Command, format DocsFormat, opts GraphOptions) error {
+	var gen graphGenerator
+	switch format {
+	case DocsFormatDOT:
+		gen = dotGraphGenerator{}
+	case DocsFormatMermaid:
+		gen = mermaidGraphGenerator{}
+	default:
+		return fmt.Errorf("unsupported graph format %q", format)
+	}
+	_, err := w.Write(gen.generate(commandGraphNodes(cmd, opts)))
+	return err
+}
+
+func isGraphDocsFormat(format DocsFormat) bool {
+	return format == DocsFormatDOT || format == DocsFormatMermaid
+}
+
+// generateGraphDocs writes the graph of the provided command tree to a file in the provided directory, which is
+// created if it does not exist, and returns the path of the file.
+func generateGraphDocs(cmd *cobra.Command, format DocsFormat, dir string, opts GraphOptions) ([]string, error) {
+	ext := ".dot"
+	if format == DocsFormatMermaid {
+		ext = ".mmd"
+	}
+	buf := &bytes.Buffer{}
+	if err := WriteCommandGraph(buf, cmd, format, opts); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %v", dir, err)
+	}
+	path := filepath.Join(dir, docsBaseName(cmd, "_")+ext)
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write command graph for %q: %v", cmd.CommandPath(), err)
+	}
+	return []string{path}, nil
+}
+
+// graphNode is a command in a command graph.
+type graphNode struct {
+	// id is the path of the command, which uniquely identifies it in the graph.
+	id string
+	// lines are the lines of the label of the node.
+	lines []string
+	// parent is the index of the node of the parent of the command, or -1 for the root of the graph.
+	parent int
+}
+
+// commandGraphNodes returns the nodes of the graph of the provided command tree in depth-first order.
+func commandGraphNodes(cmd *cobra.Command, opts GraphOptions) []graphNode {
+	var nodes []graphNode
+	var visit func(cmd *cobra.Command, parent int)
+	visit = func(cmd *cobra.Command, parent int) {
+		node := graphNode{
+			id:     cmd.CommandPath(),
+			lines:  []string{cmd.Name()},
+			parent: parent,
+		}
+		if opts.FlagCounts {
+			count := graphFlagCount(cmd)
+			if count == 1 {
+				node.lines = append(node.lines, "1 flag")
+			} else {
+				node.lines = append(node.lines, fmt.Sprintf("%d flags", count))
+			}
+		}
+		if opts.Stability {
+			if stability := GetCommandMeta(cmd).Stability; stability != "" {
+				node.lines = append(node.lines, string(stability))
+			}
+		}
+		nodes = append(nodes, node)
+		idx := len(nodes) - 1
+		for _, child := range docsChildren(cmd) {
+			visit(child, idx)
+		}
+	}
+	visit(cmd, -1)
+	return nodes
+}
+
+// graphFlagCount returns the number of flags defined by the provided command that are shown in its usage, excluding
+// the help flag (which every command has once it is executed).
+func graphFlagCount(cmd *cobra.Command) int {
+	count := 0
+	cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+		if !flag.Hidden && flag.Deprecated == "" && flag.Name != "help" {
+			count++
+		}
+	})
+	return count
+}
+
+type graphGenerator interface {
+	generate(nodes []graphNode) []byte
+}
+
+type dotGraphGenerator struct{}
+
+func (dotGraphGenerator) generate(nodes []graphNode) []byte {
+	buf := &bytes.Buffer{}
+	_, _ = fmt.Fprintf(buf, "digraph %s {\n", dotQuote(nodes[0].id))
+	buf.WriteString("\tnode [shape=box];\n")
+	for _, node := range nodes {
+		_, _ = fmt.Fprintf(buf, "\t%s [label=%s];\n", dotQuote(node.id), dotQuote(strings.Join(node.lines, "\n")))
+	}
+	for _, node := range nodes {
+		if node.parent >= 0 {
+			_, _ = fmt.Fprintf(buf, "\t%s -> %s;\n", dotQuote(nodes[node.parent].id), dotQuote(node.id))
+		}
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+// dotQuote returns the provided text as a quoted DOT identifier. Newlines are written as "\n", which DOT renders as
+// line breaks in labels.
+func dotQuote(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	s = strings.Replace(s, "\n", `\n`, -1)
+	return `"` + s + `"`
+}
+
+type mermaidGraphGenerator struct{}
+
+func (mermaidGraphGenerator) generate(nodes []graphNode) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString("flowchart TD\n")
+	// command paths contain spaces and may contain characters that are not valid in Mermaid node IDs, so nodes are
+	// identified by their index
+	for i, node := range nodes {
+		_, _ = fmt.Fprintf(buf, "\tn%d[\"%s\"]\n", i, mermaidEscape(strings.Join(node.lines, "\n")))
+	}
+	for i, node := range nodes {
+		if node.parent >= 0 {
+			_, _ = fmt.Fprintf(buf, "\tn%d --> n%d\n", node.parent, i)
+		}
+	}
+	return buf.Bytes()
+}
+
+// mermaidEscape escapes the provided text for use in a quoted Mermaid label. Newlines are written as "<br/>".
+func mermaidEscape(s string) string {
+	s = strings.Replace(s, "&", "#amp;", -1)
+	s = strings.Replace(s, `"`, "#quot;", -1)
+	s = strings.Replace(s, "<", "#lt;", -1)
+	s = strings.Replace(s, ">", "#gt;", -1)
+	return strings.Replace(s, "\n", "<br/>", -1)
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/palantir/pkg/flagtypes"
+	"github.com/palantir/pkg/transform"
+)
+
+const flagEnvVarsValueName = "flagEnvVars"
+
+// EnvVarPrefixParam returns a Param that allows the value of every flag to be provided using an environment variable.
+// The name of the environment variable for a flag is the provided prefix followed by an underscore and the name of the
+// flag in SCREAMING_SNAKE_CASE (see transform.ScreamingSnakeCase): for example, "MYAPP_OUTPUT_FORMAT" for
+// "--output-format" with the prefix "MYAPP". If the environment variable of a flag was already recorded using
+// flagtypes.SetEnvVar, that variable is used instead. The "help" and "version" flags are never set from the
+// environment.
+//
+// The environment variables of the flags of all commands (including flags added by other Params) are recorded using
+// flagtypes.SetEnvVar so that they are included in verbose help output (see HelpFullParam).
+// After the flags of a command are parsed and before its pre-run functions are called, every flag of the command that
+// was not set on the command line is set to the value of its environment variable if that variable is set. Flags that
+// are set from the environment are marked as changed, so they satisfy required flags. The flags that were set from the
+// environment can be determined using FlagEnvVar.
+func EnvVarPrefixParam(prefix string) Param {
+	return multiParam(
+		// flags are annotated right before the root command is executed, once all of the Params have added their flags
+		invocationHookParam(func(rootCmd *cobra.Command) func() {
+			visitCommands(rootCmd, func(cmd *cobra.Command) {
+				annotate := func(f *pflag.Flag) {
+					if !envVarFlag(f) || flagtypes.FlagMetadata(f).EnvVar != "" {
+						return
+					}
+					if f.Annotations == nil {
+						f.Annotations = make(map[string][]string)
+					}
+					f.Annotations[flagtypes.EnvVarAnnotation] = []string{flagEnvVarName(prefix, f.Name)}
+				}
+				cmd.PersistentFlags().VisitAll(annotate)
+				cmd.LocalNonPersistentFlags().VisitAll(annotate)
+			})
+			return func() {}
+		}),
+		// called before the hook of ConfigFileParam so that values from the environment take precedence
+		stagedPreRunHookParam(stageFirst, func(cmd *cobra.Command, args []string) (func(), error) {
+			setFromEnv := make(map[string]string)
+			var setErr error
+			cmd.Flags().VisitAll(func(f *pflag.Flag) {
+				if setErr != nil || f.Changed || !envVarFlag(f) {
+					return
+				}
+				envVar := flagtypes.FlagMetadata(f).EnvVar
+				if envVar == "" {
+					envVar = flagEnvVarName(prefix, f.Name)
+				}
+				val, ok := os.LookupEnv(envVar)
+				if !ok {
+					return
+				}
+				if err := f.Value.Set(val); err != nil {
+					setErr = fmt.Errorf("invalid value %q for environment variable %s of flag --%s: %v", val, envVar, f.Name, err)
+					return
+				}
+				f.Changed = true
+				setFromEnv[f.Name] = envVar
+			})
+			if setErr != nil {
+				return nil, setErr
+			}
+			return setInvocationValue(cmd, flagEnvVarsValueName, setFromEnv), nil
+		}),
+	)
+}
+
+// FlagEnvVar returns the name of the environment variable from which the value of the flag with the provided name was
+// set by EnvVarPrefixParam for the running command and true, or "" and false if the flag was not set from the
+// environment.
+func FlagEnvVar(cmd *cobra.Command, name string) (string, bool) {
+	v, ok := invocationValue(cmd, flagEnvVarsValueName)
+	if !ok {
+		return "", false
+	}
+	envVar, ok := v.(map[string]string)[name]
+	return envVar, ok
+}
+
+func envVarFlag(f *pflag.Flag) bool {
+	return f.Name != "help" && f.Name != "version"
+}
+
+func flagEnvVarName(prefix, flagName string) string {
+	return strings.ToUpper(prefix) + "_" + transform.ScreamingSnakeCase(flagName)
+}
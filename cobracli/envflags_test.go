@@ -0,0 +1,140 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+	"github.com/palantir/pkg/flagtypes"
+)
+
+func TestEnvVarPrefixParam(t *testing.T) {
+	for i, tc := range []struct {
+		name       string
+		env        map[string]string
+		args       []string
+		wantRV     int
+		wantOutput string
+	}{
+		{
+			name:       "defaults",
+			args:       []string{"serve"},
+			wantOutput: "port=8080 (default) log-level=info (default) tags=[] (default) region=local (default)\n",
+		},
+		{
+			name: "values from environment",
+			env: map[string]string{
+				"MYAPP_PORT":      "9090",
+				"MYAPP_LOG_LEVEL": "debug",
+				"MYAPP_TAGS":      "a,b",
+				"APP_REGION":      "us-east-1",
+			},
+			args:       []string{"serve"},
+			wantOutput: "port=9090 (MYAPP_PORT) log-level=debug (MYAPP_LOG_LEVEL) tags=[a,b] (MYAPP_TAGS) region=us-east-1 (APP_REGION)\n",
+		},
+		{
+			name: "command line takes precedence",
+			env: map[string]string{
+				"MYAPP_PORT": "9090",
+				"MYAPP_TAGS": "a,b",
+			},
+			args:       []string{"--log-level", "warn", "serve", "--port", "7070", "--tags", "c"},
+			wantOutput: "port=7070 (default) log-level=warn (default) tags=[c] (default) region=local (default)\n",
+		},
+		{
+			name: "invalid value",
+			env: map[string]string{
+				"MYAPP_PORT": "not-a-number",
+			},
+			args:       []string{"serve"},
+			wantRV:     1,
+			wantOutput: `Error: invalid value "not-a-number" for environment variable MYAPP_PORT of flag --port: strconv.ParseInt: parsing "not-a-number": invalid syntax` + "\n",
+		},
+	} {
+		for k, v := range tc.env {
+			require.NoError(t, os.Setenv(k, v), "Case %d: %s", i, tc.name)
+		}
+
+		rootCmd := &cobra.Command{Use: "my-app"}
+		rootCmd.PersistentFlags().String("log-level", "info", "log level")
+		serveCmd := &cobra.Command{
+			Use: "serve",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				var parts []string
+				for _, name := range []string{"port", "log-level", "tags", "region"} {
+					source := "default"
+					if envVar, ok := cobracli.FlagEnvVar(cmd, name); ok {
+						source = envVar
+					}
+					parts = append(parts, fmt.Sprintf("%s=%s (%s)", name, cmd.Flag(name).Value.String(), source))
+				}
+				cmd.Println(strings.Join(parts, " "))
+				return nil
+			},
+		}
+		serveCmd.Flags().Int("port", 8080, "port to listen on")
+		serveCmd.Flags().StringSlice("tags", nil, "tags")
+		serveCmd.Flags().String("region", "local", "region")
+		require.NoError(t, flagtypes.SetEnvVar(serveCmd.Flags(), "region", "APP_REGION"))
+		rootCmd.AddCommand(serveCmd)
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.EnvVarPrefixParam("MYAPP"))...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d: %s", i, tc.name)
+
+		assert.Equal(t, "MYAPP_PORT", flagtypes.FlagMetadata(serveCmd.Flags().Lookup("port")).EnvVar, "Case %d: %s", i, tc.name)
+		assert.Equal(t, "MYAPP_LOG_LEVEL", flagtypes.FlagMetadata(rootCmd.PersistentFlags().Lookup("log-level")).EnvVar, "Case %d: %s", i, tc.name)
+		assert.Equal(t, "APP_REGION", flagtypes.FlagMetadata(serveCmd.Flags().Lookup("region")).EnvVar, "Case %d: %s", i, tc.name)
+
+		for k := range tc.env {
+			require.NoError(t, os.Unsetenv(k), "Case %d: %s", i, tc.name)
+		}
+	}
+}
+
+func TestEnvVarPrefixParamAppliedBeforePreRun(t *testing.T) {
+	require.NoError(t, os.Setenv("MYAPP_TOKEN", "secret"))
+	defer func() {
+		_ = os.Unsetenv("MYAPP_TOKEN")
+	}()
+
+	var token string
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			cmd.Println("pre-run token=" + token)
+		},
+	}
+	loginCmd := &cobra.Command{
+		Use: "login",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Println("run token=" + token)
+		},
+	}
+	loginCmd.Flags().StringVar(&token, "token", "", "token")
+	require.NoError(t, loginCmd.MarkFlagRequired("token"))
+	rootCmd.AddCommand(loginCmd)
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"login"})
+
+	rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.EnvVarPrefixParam("MYAPP"))...)
+	assert.Equal(t, 0, rv)
+	assert.Equal(t, "pre-run token=secret\nrun token=secret\n", buf.String())
+	assert.NotNil(t, rootCmd.PersistentPreRun)
+	assert.Nil(t, rootCmd.PersistentPreRunE)
+}
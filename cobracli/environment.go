@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/runtimeenv"
+)
+
+// RedactedValue is the value reported by EnvVarsCollector for variables whose values are redacted.
+const RedactedValue = "<redacted>"
+
+// DefaultRedactedEnvVars are the patterns of the names of the environment variables whose values are redacted by
+// EnvVarsCollector if no redaction patterns are provided.
+var DefaultRedactedEnvVars = []string{"*TOKEN*", "*SECRET*", "*PASSWORD*", "*PASSWD*", "*CREDENTIAL*", "*KEY*", "*AUTH*"}
+
+// EnvironmentEntry is a single piece of information about the environment in which a command runs.
+type EnvironmentEntry struct {
+	Name  string
+	Value string
+}
+
+// EnvironmentCollector returns information about the environment in which the provided command runs. Collectors must
+// not return sensitive information.
+type EnvironmentCollector func(cmd *cobra.Command) ([]EnvironmentEntry, error)
+
+// EnvironmentParam returns a Param that registers the provided collectors. The information that they collect is
+// included in the crash reports written by RecoverPanicsParam and in the support bundles created by the command added
+// by SupportBundleCmdParam (as "environment.txt"). May be provided multiple times, in which case the collectors are
+// run in the order in which they were provided.
+func EnvironmentParam(collectors ...EnvironmentCollector) Param {
+	return paramFunc(func(executor *executor) {
+		executor.environmentCollectors = append(executor.environmentCollectors, collectors...)
+	})
+}
+
+// WriteEnvironment runs the provided collectors and writes the entries that they return to the provided writer as
+// "name: value" lines. A collector that returns an error does not prevent the other collectors from running: instead,
+// the error is written as an "error" entry.
+func WriteEnvironment(w io.Writer, cmd *cobra.Command, collectors ...EnvironmentCollector) error {
+	for _, collector := range collectors {
+		entries, err := collector(cmd)
+		if err != nil {
+			entries = append(entries, EnvironmentEntry{Name: "error", Value: err.Error()})
+		}
+		for _, entry := range entries {
+			if _, err := fmt.Fprintf(w, "%s: %s\n", entry.Name, entry.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// EnvironmentArtifact returns an artifact named "environment.txt" that contains the information returned by the
+// provided collectors as written by WriteEnvironment.
+func EnvironmentArtifact(collectors ...EnvironmentCollector) SupportBundleArtifact {
+	return SupportBundleArtifact{
+		Name: "environment.txt",
+		Collect: func(cmd *cobra.Command) ([]byte, error) {
+			buf := &bytes.Buffer{}
+			if err := WriteEnvironment(buf, cmd, collectors...); err != nil {
+				return nil, err
+			}
+			return buf.Bytes(), nil
+		},
+	}
+}
+
+// RuntimeCollector returns a collector that reports the operating system, architecture, number of CPUs and Go version.
+func RuntimeCollector() EnvironmentCollector {
+	return func(cmd *cobra.Command) ([]EnvironmentEntry, error) {
+		return []EnvironmentEntry{
+			{Name: "os", Value: runtime.GOOS},
+			{Name: "arch", Value: runtime.GOARCH},
+			{Name: "cpus", Value: fmt.Sprint(runtime.NumCPU())},
+			{Name: "go", Value: runtime.Version()},
+		}, nil
+	}
+}
+
+// ContainerCollector returns a collector that reports the container environment of the command as detected by the
+// runtimeenv package. The "container" entry is the container runtime ("kubernetes", "docker", "podman", "containerd" or
+// "lxc") or "none", the "cpu_limit" and "memory_limit" entries are the cgroup CPU and memory limits (in CPUs and bytes)
+// or "none" and the "pid1" entry is whether the process is the init process of its PID namespace.
+func ContainerCollector() EnvironmentCollector {
+	return func(cmd *cobra.Command) ([]EnvironmentEntry, error) {
+		container := runtimeEnv.Container()
+		if container == "" {
+			container = "none"
+		}
+		cpuLimit, memoryLimit := "none", "none"
+		if cpus, ok := runtimeEnv.CPULimit(); ok {
+			cpuLimit = strconv.Itoa(cpus)
+		}
+		if memory, ok := runtimeEnv.MemoryLimit(); ok {
+			memoryLimit = strconv.FormatInt(memory, 10)
+		}
+		return []EnvironmentEntry{
+			{Name: "container", Value: container},
+			{Name: "cpu_limit", Value: cpuLimit},
+			{Name: "memory_limit", Value: memoryLimit},
+			{Name: "pid1", Value: strconv.FormatBool(runtimeenv.IsPID1())},
+		}, nil
+	}
+}
+
+// EnvVarsCollector returns a collector that reports the environment variables whose names match any of the provided
+// include patterns, in order of their names. Patterns use the syntax of path.Match (for example, "MY_APP_*") and are
+// matched case-insensitively. The values of variables whose names match any of the provided redaction patterns are
+// reported as RedactedValue. If redact is nil, DefaultRedactedEnvVars is used.
+func EnvVarsCollector(include, redact []string) EnvironmentCollector {
+	if redact == nil {
+		redact = DefaultRedactedEnvVars
+	}
+	return func(cmd *cobra.Command) ([]EnvironmentEntry, error) {
+		var entries []EnvironmentEntry
+		for _, kv := range os.Environ() {
+			parts := strings.SplitN(kv, "=", 2)
+			if len(parts) != 2 || !matchesEnvVarPattern(parts[0], include) {
+				continue
+			}
+			value := parts[1]
+			if matchesEnvVarPattern(parts[0], redact) {
+				value = RedactedValue
+			}
+			entries = append(entries, EnvironmentEntry{Name: "env." + parts[0], Value: value})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].Name < entries[j].Name
+		})
+		return entries, nil
+	}
+}
+
+func matchesEnvVarPattern(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToUpper(pattern), strings.ToUpper(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ToolVersionCollector returns a collector that reports the version of an external tool as a "<tool> version" entry.
+// The version is the first line of the output of running the tool with the provided arguments (for example,
+// ToolVersionCollector("git", "--version")). If the tool is not installed, the version is reported as "not found".
+func ToolVersionCollector(tool string, args ...string) EnvironmentCollector {
+	return func(cmd *cobra.Command) ([]EnvironmentEntry, error) {
+		name := tool + " version"
+		if _, err := exec.LookPath(tool); err != nil {
+			return []EnvironmentEntry{{Name: name, Value: "not found"}}, nil
+		}
+		output, err := exec.CommandContext(Context(cmd), tool, args...).Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine version of %s: %v", tool, err)
+		}
+		version := strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0])
+		return []EnvironmentEntry{{Name: name, Value: version}}, nil
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/runtimeenv"
+)
+
+func TestWriteEnvironment(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := WriteEnvironment(buf, &cobra.Command{Use: "my-app"},
+		func(cmd *cobra.Command) ([]EnvironmentEntry, error) {
+			return []EnvironmentEntry{{Name: "a", Value: "1"}}, nil
+		},
+		func(cmd *cobra.Command) ([]EnvironmentEntry, error) {
+			return nil, fmt.Errorf("collector failed")
+		},
+		func(cmd *cobra.Command) ([]EnvironmentEntry, error) {
+			return []EnvironmentEntry{{Name: "b", Value: "2"}}, nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "a: 1\nerror: collector failed\nb: 2\n", buf.String())
+}
+
+func TestEnvVarsCollector(t *testing.T) {
+	for k, v := range map[string]string{
+		"COBRACLI_TEST_REGION":    "us-east-1",
+		"COBRACLI_TEST_API_TOKEN": "s3cr3t",
+		"COBRACLI_TEST_DB_URL":    "postgres://host",
+		"COBRACLI_OTHER":          "ignored",
+	} {
+		require.NoError(t, os.Setenv(k, v))
+		defer func(k string) {
+			_ = os.Unsetenv(k)
+		}(k)
+	}
+
+	for i, tc := range []struct {
+		redact []string
+		want   []EnvironmentEntry
+	}{
+		{nil, []EnvironmentEntry{
+			{Name: "env.COBRACLI_TEST_API_TOKEN", Value: RedactedValue},
+			{Name: "env.COBRACLI_TEST_DB_URL", Value: "postgres://host"},
+			{Name: "env.COBRACLI_TEST_REGION", Value: "us-east-1"},
+		}},
+		{[]string{"*_url"}, []EnvironmentEntry{
+			{Name: "env.COBRACLI_TEST_API_TOKEN", Value: "s3cr3t"},
+			{Name: "env.COBRACLI_TEST_DB_URL", Value: RedactedValue},
+			{Name: "env.COBRACLI_TEST_REGION", Value: "us-east-1"},
+		}},
+	} {
+		got, err := EnvVarsCollector([]string{"cobracli_test_*"}, tc.redact)(&cobra.Command{})
+		require.NoError(t, err, "Case %d", i)
+		assert.Equal(t, tc.want, got, "Case %d", i)
+	}
+}
+
+func TestContainerCollector(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	origRuntimeEnv := runtimeEnv
+	defer func() {
+		runtimeEnv = origRuntimeEnv
+	}()
+	origK8sHost, hadK8sHost := os.LookupEnv("KUBERNETES_SERVICE_HOST")
+	require.NoError(t, os.Unsetenv("KUBERNETES_SERVICE_HOST"))
+	defer func() {
+		if hadK8sHost {
+			_ = os.Setenv("KUBERNETES_SERVICE_HOST", origK8sHost)
+		}
+	}()
+
+	for i, tc := range []struct {
+		files map[string]string
+		want  []EnvironmentEntry
+	}{
+		{
+			nil,
+			[]EnvironmentEntry{
+				{Name: "container", Value: "none"},
+				{Name: "cpu_limit", Value: "none"},
+				{Name: "memory_limit", Value: "none"},
+				{Name: "pid1", Value: "false"},
+			},
+		},
+		{
+			map[string]string{
+				".dockerenv":               "",
+				"sys/fs/cgroup/cpu.max":    "200000 100000\n",
+				"sys/fs/cgroup/memory.max": "536870912\n",
+			},
+			[]EnvironmentEntry{
+				{Name: "container", Value: "docker"},
+				{Name: "cpu_limit", Value: "2"},
+				{Name: "memory_limit", Value: "536870912"},
+				{Name: "pid1", Value: "false"},
+			},
+		},
+	} {
+		caseDir := filepath.Join(tmpDir, fmt.Sprint(i))
+		for name, content := range tc.files {
+			path := filepath.Join(caseDir, filepath.FromSlash(name))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755), "Case %d", i)
+			require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644), "Case %d", i)
+		}
+		runtimeEnv = runtimeenv.Detector{Root: caseDir}
+
+		got, err := ContainerCollector()(&cobra.Command{})
+		require.NoError(t, err, "Case %d", i)
+		assert.Equal(t, tc.want, got, "Case %d", i)
+	}
+}
+
+func TestToolVersionCollector(t *testing.T) {
+	got, err := ToolVersionCollector("cobracli-tool-that-does-not-exist", "--version")(&cobra.Command{})
+	require.NoError(t, err)
+	assert.Equal(t, []EnvironmentEntry{{Name: "cobracli-tool-that-does-not-exist version", Value: "not found"}}, got)
+
+	got, err = ToolVersionCollector("go", "version")(&cobra.Command{})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "go version", got[0].Name)
+	assert.Regexp(t, `^go version go`, got[0].Value)
+}
+
+func TestEnvironmentParam(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	collector := func(cmd *cobra.Command) ([]EnvironmentEntry, error) {
+		return []EnvironmentEntry{{Name: "region", Value: "us-east-1"}}, nil
+	}
+
+	// crash reports include the environment
+	var gotErr error
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+	}
+	rootCmd.AddCommand(&cobra.Command{
+		Use: "boom",
+		Run: func(cmd *cobra.Command, args []string) {
+			panic("something went wrong")
+		},
+	})
+	rootCmd.SetArgs([]string{"boom"})
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	rv := Execute(rootCmd,
+		RecoverPanicsParam(filepath.Join(tmpDir, "crashes")),
+		ErrorHandlerParam(func(cmd *cobra.Command, err error) {
+			gotErr = err
+		}),
+		EnvironmentParam(collector),
+	)
+	require.Equal(t, PanicExitCode, rv)
+	panicErr, ok := gotErr.(*PanicError)
+	require.True(t, ok, "expected *PanicError, was %T", gotErr)
+	report, err := ioutil.ReadFile(panicErr.ReportPath)
+	require.NoError(t, err)
+	assert.Regexp(t, `(?s)\nGo: [^\n]+\n\nEnvironment:\nregion: us-east-1\n\npanic: something went wrong\n`, string(report))
+
+	// support bundles include the environment
+	outputPath := filepath.Join(tmpDir, "bundle.tar.gz")
+	rootCmd = &cobra.Command{
+		Use: "my-app",
+	}
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"support-bundle", "--output", outputPath})
+	rv = Execute(rootCmd, SupportBundleCmdParam(), EnvironmentParam(collector))
+	require.Equal(t, 0, rv, buf.String())
+
+	f, err := os.Open(outputPath)
+	require.NoError(t, err)
+	defer func() {
+		_ = f.Close()
+	}()
+	gzipReader, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+	got := make(map[string]string)
+	for {
+		hdr, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := ioutil.ReadAll(tarReader)
+		require.NoError(t, err)
+		got[hdr.Name] = string(content)
+	}
+	assert.Equal(t, map[string]string{
+		"environment.txt": "region: us-east-1\n",
+	}, got)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"github.com/nmiyake/pkg/errorstringer"
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/safejson"
+)
+
+// errorJSON is the JSON representation of an error written by ErrorJSONPrinterHandler.
+type errorJSON struct {
+	Error string `json:"error"`
+	Code  int    `json:"code"`
+	Stack string `json:"stack,omitempty"`
+	// the fields of the *UsageError in the chain of the error, if any
+	Command    string `json:"command,omitempty"`
+	Flag       string `json:"flag,omitempty"`
+	Arg        string `json:"arg,omitempty"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// ErrorJSONPrinterHandler returns an error handler that prints the provided error as a single-line JSON object of the
+// form {"error":"<error.Error()>","code":<exit code>} unless "error.Error()" is empty, in which case nothing is
+// printed. When the handler is run by Execute, the code is the exit code that Execute returns for the error (see
+// ExitCodeExtractorParam). Otherwise, it is the exit code provided by the error (see ExitCoderExtractor) or 1 if it
+// does not provide one. If the error is a *UsageError (see AsUsageError), the object also has the "command", "flag",
+// "arg" and "suggestion" fields of the error that are not empty. If the provided boolean variable pointer is non-nil
+// and the value is true, the object also has a "stack" field that contains the full stack trace of the error if it is
+// available. The output is written to the output of the command (stderr by default), so it can be parsed by tools that
+// invoke the command.
+//
+// The handler should be set using ErrorHandlerParam (which replaces the error printer set by DefaultParams) rather
+// than ErrorHandlerChainParam, so that errors are not also printed as text.
+func ErrorJSONPrinterHandler(debugVar *bool) func(*cobra.Command, error) {
+	return func(command *cobra.Command, err error) {
+		errStr := err.Error()
+		if errStr == "" {
+			return
+		}
+		out := errorJSON{
+			Error: errStr,
+			Code:  commandExitCode(command, err),
+		}
+		if usageErr, ok := AsUsageError(err); ok {
+			out.Command = usageErr.Command
+			out.Flag = usageErr.Flag
+			out.Arg = usageErr.Arg
+			out.Suggestion = usageErr.Suggestion
+		}
+		if debugVar != nil && *debugVar {
+			if stack := errorstringer.StackWithInterleavedMessages(err); stack != errStr {
+				out.Stack = stack
+			}
+		}
+		_ = safejson.Encoder(command.OutOrStderr()).Encode(out)
+	}
+}
+
+// OutputFormatErrorPrinterHandler returns an error handler that prints errors using ErrorJSONPrinterHandler if the
+// "--output-format" flag added by OutputFormatParam is "json" and using the provided handler otherwise. This allows
+// commands that write JSON results to also report failures as JSON.
+func OutputFormatErrorPrinterHandler(debugVar *bool, textHandler func(*cobra.Command, error)) func(*cobra.Command, error) {
+	jsonHandler := ErrorJSONPrinterHandler(debugVar)
+	return func(command *cobra.Command, err error) {
+		if flag := command.Flag("output-format"); flag != nil && flag.Value.String() == string(OutputFormatJSON) {
+			jsonHandler(command, err)
+			return
+		}
+		textHandler(command, err)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestErrorJSONPrinterHandler(t *testing.T) {
+	for i, tc := range []struct {
+		err       error
+		debug     bool
+		want      string
+		wantStack bool
+	}{
+		{errors.New(""), false, "", false},
+		{errors.New("failure"), false, `{"error":"failure","code":1}` + "\n", false},
+		{cobracli.NewExitCodeError(3, errors.New("not <found>")), false, `{"error":"not <found>","code":3}` + "\n", false},
+		{errors.New("failure"), true, "", true},
+	} {
+		debug := tc.debug
+		outBuf := &bytes.Buffer{}
+		cmd := &cobra.Command{
+			Use: "my-app",
+		}
+		cmd.SetOutput(outBuf)
+		cobracli.ErrorJSONPrinterHandler(&debug)(cmd, tc.err)
+
+		if !tc.wantStack {
+			assert.Equal(t, tc.want, outBuf.String(), "Case %d", i)
+			continue
+		}
+		var got map[string]interface{}
+		require.NoError(t, json.Unmarshal(outBuf.Bytes(), &got), "Case %d", i)
+		assert.Equal(t, "failure", got["error"], "Case %d", i)
+		assert.Contains(t, got["stack"], "TestErrorJSONPrinterHandler", "Case %d", i)
+	}
+}
+
+func TestOutputFormatErrorPrinterHandler(t *testing.T) {
+	for i, tc := range []struct {
+		args []string
+		want string
+	}{
+		{nil, "Error: failure\n"},
+		{[]string{"--output-format", "json"}, `{"error":"failure","code":1}` + "\n"},
+		// flag parse errors are printed in the requested format if the format flag was parsed
+		{[]string{"--output-format", "json", "--unknown"}, `{"error":"unknown flag: --unknown","code":1}` + "\n"},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return errors.New("failure")
+			},
+		}
+		outBuf := &bytes.Buffer{}
+		rootCmd.SetOutput(outBuf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd,
+			cobracli.ConfigureCmdParam(cobracli.SilenceErrorsConfigurer),
+			cobracli.OutputFormatParam(cobracli.OutputFormatText),
+			cobracli.ErrorHandlerParam(cobracli.OutputFormatErrorPrinterHandler(nil, cobracli.ErrorPrinterWithDebugHandler(nil, nil))),
+		)
+		assert.Equal(t, 1, rv, "Case %d", i)
+		assert.Equal(t, tc.want, outBuf.String(), "Case %d", i)
+	}
+}
+
+func TestErrorJSONPrinterHandlerWithDefaultParams(t *testing.T) {
+	rootCmd := &cobra.Command{
+		Use:  "my-app",
+		Args: cobra.NoArgs,
+		Run:  func(cmd *cobra.Command, args []string) {},
+	}
+	rootCmd.Flags().String("name", "", "name")
+	outBuf := &bytes.Buffer{}
+	rootCmd.SetOutput(outBuf)
+
+	rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil),
+		cobracli.ArgsParam([]string{"--output-format", "json", "--nmae", "x"}),
+		cobracli.OutputFormatParam(cobracli.OutputFormatText),
+		cobracli.ExitCodeExtractorParam(func(err error) int {
+			if _, ok := cobracli.AsUsageError(err); ok {
+				return 64
+			}
+			return cobracli.NoExitCode
+		}),
+		cobracli.ErrorHandlerParam(cobracli.OutputFormatErrorPrinterHandler(nil, cobracli.ErrorPrinterWithDebugHandler(nil, nil))),
+	)...)
+	assert.Equal(t, 64, rv)
+	assert.Equal(t, `{"error":"unknown flag: --nmae (did you mean \"--name\"?)","code":64,"command":"my-app","flag":"nmae","suggestion":"name"}`+"\n", outBuf.String())
+}
@@ -5,9 +5,16 @@
 package cobracli
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
 	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 )
@@ -26,30 +33,235 @@ func Execute(rootCmd *cobra.Command, params ...Param) int {
 	for _, configureCmd := range executor.rootCmdConfigurers {
 		configureCmd(rootCmd)
 	}
+	defer func() {
+		for i := len(executor.restoreFuncs) - 1; i >= 0; i-- {
+			executor.restoreFuncs[i]()
+		}
+	}()
 
-	executedCmd, err := rootCmd.ExecuteC()
-	if err == nil {
-		// command ran successfully: return 0
-		return 0
+	var runEDecorators []func(RunEFunc) RunEFunc
+	var preRunHooks []func(*cobra.Command, []string) (func(), error)
+	for s := stage(0); s < stageCount; s++ {
+		runEDecorators = append(runEDecorators, executor.runEDecorators[s]...)
+		preRunHooks = append(preRunHooks, executor.preRunHooks[s]...)
 	}
+	restoreRunEs := decorateRunEs(rootCmd, runEDecorators)
+	defer restoreRunEs()
+	restorePreRuns := installPreRunHooks(rootCmd, preRunHooks)
+	defer restorePreRuns()
 
-	// print error if error-printing function is defined
-	if executor.errorHandler != nil {
-		executor.errorHandler(executedCmd, err)
+	parentCtx := executor.ctx
+	if parentCtx == nil {
+		parentCtx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parentCtx)
+	defer cancel()
+	if len(executor.providers) > 0 {
+		ctx = context.WithValue(ctx, containerKey{}, newContainer(executor.providers))
+	}
+	restoreCtx := setContext(rootCmd, ctx)
+	defer restoreCtx()
+	if executor.stdout != nil {
+		restoreStdout := setTreeOutput(rootCmd, executor.stdout)
+		defer restoreStdout()
+	}
+	if executor.stderr != nil {
+		restoreStatus := setStatus(rootCmd, executor.stderr)
+		defer restoreStatus()
 	}
 
-	// extract custom exit code if exit code extractor is defined
-	if executor.exitCodeExtractor != nil {
-		return executor.exitCodeExtractor(err)
+	for _, hook := range executor.invocationHooks {
+		restoreHook := hook(rootCmd)
+		defer restoreHook()
+	}
+
+	args := executor.invocationArgs()
+	argsSet := executor.args != nil
+	setArgs := func(newArgs []string) {
+		args = newArgs
+		argsSet = true
+		rootCmd.SetArgs(args)
+	}
+	if argsSet {
+		setArgs(args)
+	}
+	defer func() {
+		if argsSet {
+			rootCmd.SetArgs(nil)
+		}
+	}()
+	executeC := func() (*cobra.Command, error) {
+		restoreArgs := setInvocationValue(rootCmd, argsValueName, args)
+		defer restoreArgs()
+		return rootCmd.ExecuteC()
+	}
+
+	executedCmd := rootCmd
+	var err error
+	run := true
+	for _, rewrite := range executor.argsRewriters {
+		var rewritten []string
+		if rewritten, run, err = rewrite(rootCmd, args); err != nil || !run {
+			break
+		}
+		if !reflect.DeepEqual(rewritten, args) {
+			setArgs(rewritten)
+		}
+	}
+	if run && err == nil {
+		executedCmd, err = executeC()
+	}
+	for _, rerun := range executor.rerunHandlers {
+		if err == nil {
+			break
+		}
+		if rerunArgs, ok := rerun(rootCmd, executedCmd, args, err); ok {
+			setArgs(rerunArgs)
+			executedCmd, err = executeC()
+		}
+	}
+	if executedCmd == nil {
+		executedCmd = rootCmd
+	}
+	for _, completion := range executor.completionHandlers {
+		completion(executedCmd, err)
+	}
+
+	exitCode := 0
+	if err != nil {
+		exitCode = executor.handleError(rootCmd, executedCmd, err)
+	}
+	for _, exit := range executor.exitHandlers {
+		exit(executedCmd, err, exitCode)
+	}
+	return exitCode
+}
+
+// handleError runs the error handlers on the provided error, which must be non-nil, and returns the exit code.
+func (e *executor) handleError(rootCmd, executedCmd *cobra.Command, err error) int {
+	if e.stderr != nil {
+		// error handlers print errors and usage using the output of the command, which is standard error
+		restoreStderr := setTreeOutput(rootCmd, e.stderr)
+		defer restoreStderr()
+	}
+
+	// error handlers determine the exit code of errors in the same manner as the executor (see commandExitCode)
+	restoreExitCode := setInvocationValue(rootCmd, exitCodeValueName, e.exitCode)
+	defer restoreExitCode()
+
+	// run error handlers in order until the error is handled. A handler may return a different error, which is
+	// provided to the subsequent handlers and used to determine the exit code.
+	handled := false
+	for _, handler := range e.errorHandlers {
+		handledErr := handler(executedCmd, err)
+		if handledErr == nil {
+			handled = true
+			break
+		}
+		err = handledErr
+	}
+	if !handled && e.errorHandler != nil {
+		e.errorHandler(executedCmd, err)
+	}
+	return e.exitCode(err)
+}
+
+// exitCode returns the exit code for the provided error, which must be non-nil.
+func (e *executor) exitCode(err error) int {
+	// use the exit code returned by the first exit code extractor that extracts one
+	for _, extractor := range e.exitCodeExtractors {
+		if code := extractor(err); code != NoExitCode {
+			return code
+		}
+	}
+
+	// use exit code provided by error (or an error that it wraps) if it provides one
+	if code := ExitCoderExtractor(err); code != NoExitCode {
+		return code
 	}
 
 	return 1
 }
 
+// commandExitCode returns the exit code for the provided error, which must be non-nil, that is returned by the
+// invocation of Execute that executes the command tree that contains the provided command. If the command tree is not
+// being executed, the exit code provided by the error is returned, or 1 if it does not provide one.
+func commandExitCode(cmd *cobra.Command, err error) int {
+	if exitCode, ok := invocationValue(cmd, exitCodeValueName); ok {
+		return exitCode.(func(error) int)(err)
+	}
+	if code := ExitCoderExtractor(err); code != NoExitCode {
+		return code
+	}
+	return 1
+}
+
+// ExecuteWithContext is like Execute, but uses the provided context as the parent of the execution context of the
+// invocation (see Context) and cancels the execution context when the process receives SIGINT or SIGTERM. Commands
+// that use the execution context can use this to stop long-running operations and clean up when the user interrupts
+// the application. Only the first signal is handled: another signal terminates the process as usual. The provided
+// context takes precedence over a context provided using ContextParam.
+func ExecuteWithContext(ctx context.Context, rootCmd *cobra.Command, params ...Param) int {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+			// restore the default behavior so that another signal terminates the process if the command does not return
+			signal.Stop(signals)
+		case <-ctx.Done():
+		}
+	}()
+	return Execute(rootCmd, append(params, ContextParam(ctx))...)
+}
+
 type executor struct {
-	rootCmdConfigurers []func(*cobra.Command)
-	errorHandler       func(*cobra.Command, error)
-	exitCodeExtractor  func(error) int
+	ctx                   context.Context
+	args                  []string
+	argsRewriters         []func(rootCmd *cobra.Command, args []string) (rewritten []string, run bool, err error)
+	providers             map[reflect.Type]func(context.Context) (interface{}, error)
+	rootCmdConfigurers    []func(*cobra.Command)
+	restoreFuncs          []func()
+	runEDecorators        [stageCount][]func(RunEFunc) RunEFunc
+	preRunHooks           [stageCount][]func(cmd *cobra.Command, args []string) (restore func(), err error)
+	invocationHooks       []func(rootCmd *cobra.Command) (restore func())
+	rerunHandlers         []func(rootCmd, executedCmd *cobra.Command, args []string, err error) (rerunArgs []string, ok bool)
+	completionHandlers    []func(executedCmd *cobra.Command, err error)
+	exitHandlers          []func(executedCmd *cobra.Command, err error, exitCode int)
+	errorHandler          func(*cobra.Command, error)
+	errorHandlers         []func(*cobra.Command, error) error
+	exitCodeExtractors    []func(error) int
+	environmentCollectors []EnvironmentCollector
+	redactor              *Redactor
+	stdout                io.Writer
+	stderr                io.Writer
+}
+
+// invocationArgs returns the arguments with which the root command is executed: the arguments provided using ArgsParam
+// or, if it was not provided, the arguments of the process. Mirrors the logic of cobra.Command.ExecuteC, which does
+// not use the arguments of the process when running the tests of Cobra itself.
+func (e *executor) invocationArgs() []string {
+	if e.args != nil {
+		return e.args
+	}
+	if filepath.Base(os.Args[0]) == "cobra.test" {
+		return []string{}
+	}
+	return os.Args[1:]
+}
+
+// commandArgs returns the arguments with which the invocation of Execute that is running the provided command executed
+// the root command, or the arguments of the process if the command is not being run by Execute.
+func commandArgs(cmd *cobra.Command) []string {
+	if args, ok := invocationValue(cmd, argsValueName); ok {
+		return args.([]string)
+	}
+	return os.Args[1:]
 }
 
 type Param interface {
@@ -62,22 +274,59 @@ func (f paramFunc) apply(e *executor) {
 	f(e)
 }
 
-// ExitCodeExtractorParam sets the exit code extractor function for the executor. If executing the root command returns
-// an error, the error is provided to the function and the code returned by the extractor is used as the exit code.
+// ArgsParam sets the arguments with which the root command is executed, which are the arguments of the process
+// (os.Args[1:]) if this Param is not provided. Params that execute the root command with different arguments (such as
+// AutocorrectParam and InteractivePickerParam) derive the new arguments from these arguments, so this Param must be
+// used rather than cobra.Command.SetArgs to provide arguments when such Params are used: Cobra does not expose the
+// arguments set using SetArgs. If this Param is provided or the arguments are changed by such Params, the arguments of
+// the root command are reset using SetArgs(nil) when Execute returns.
+func ArgsParam(args []string) Param {
+	return paramFunc(func(executor *executor) {
+		executor.args = append([]string{}, args...)
+	})
+}
+
+// NoExitCode is returned by an exit code extractor to indicate that it does not determine the exit code for an error.
+const NoExitCode = -1
+
+// ExitCodeExtractorParam adds an exit code extractor function to the executor. If executing the root command returns an
+// error, the error is provided to the extractors in the order in which they were provided and the first code that is
+// not NoExitCode is used as the exit code. If every extractor returns NoExitCode, the exit code provided by the error
+// is used if it or an error that it wraps implements ExitCoder (see ExitCoderExtractor); otherwise, the exit code is 1.
 func ExitCodeExtractorParam(extractor func(error) int) Param {
 	return paramFunc(func(executor *executor) {
-		executor.exitCodeExtractor = extractor
+		executor.exitCodeExtractors = append(executor.exitCodeExtractors, extractor)
 	})
 }
 
 // ErrorHandlerParam sets the error handler for the command. If executing the root command returns an error, the command
-// that was executed is provided to the error handler.
+// that was executed is provided to the error handler. The handler replaces any handler that was previously set using
+// this function (such as the error printer set by DefaultParams) and is run after the handlers added using
+// ErrorHandlerChainParam, with the error returned by the last of them, unless one of them handles the error.
 func ErrorHandlerParam(handler func(*cobra.Command, error)) Param {
 	return paramFunc(func(executor *executor) {
 		executor.errorHandler = handler
 	})
 }
 
+// ErrorHandlerChainParam adds an error handler to the chain of error handlers for the command. If executing the root
+// command returns an error, the handlers are run in the order in which they were provided, followed by the handler set
+// using ErrorHandlerParam. Each handler is provided with the command that was executed and the error returned by the
+// previous handler (or the error returned by the command for the first handler) and can:
+//
+//   - Handle the error by returning nil, in which case the subsequent handlers are not run.
+//   - Transform the error by returning a different error, which is provided to the subsequent handlers.
+//   - Pass the error along by returning it unchanged.
+//
+// The exit code is determined using the last non-nil error in the chain. This allows handlers that log, report and
+// print errors to be provided by separate Param bundles: for example, a handler that adds context to errors should be
+// provided before the handler that prints them.
+func ErrorHandlerChainParam(handler func(*cobra.Command, error) error) Param {
+	return paramFunc(func(executor *executor) {
+		executor.errorHandlers = append(executor.errorHandlers, handler)
+	})
+}
+
 // ErrorPrinterWithDebugHandler returns an error handler that prints the provided error as "Error: <error.Error()>"
 // unless "error.Error()" is empty, in which case nothing is printed. If the provided boolean variable pointer is
 // non-nil and the value is true, then the error output is provided to the specified error transform function before
@@ -155,6 +404,25 @@ func ConfigureCmdParam(configureCmd func(*cobra.Command)) Param {
 	})
 }
 
+// restorableConfigureCmdParam adds the provided configuration function to the executor. The function that it returns
+// is called when Execute returns to restore the parts of the command tree that it changed.
+func restorableConfigureCmdParam(configureCmd func(*cobra.Command) (restore func())) Param {
+	return paramFunc(func(executor *executor) {
+		executor.rootCmdConfigurers = append(executor.rootCmdConfigurers, func(rootCmd *cobra.Command) {
+			executor.restoreFuncs = append(executor.restoreFuncs, configureCmd(rootCmd))
+		})
+	})
+}
+
+// invocationHookParam adds the provided hook to the executor. Hooks are called in the order in which they are provided
+// right before the root command is executed, once the execution context and output of the invocation are set up. The
+// function returned by a hook is called when Execute returns.
+func invocationHookParam(hook func(rootCmd *cobra.Command) (restore func())) Param {
+	return paramFunc(func(executor *executor) {
+		executor.invocationHooks = append(executor.invocationHooks, hook)
+	})
+}
+
 // RemoveHelpCommandConfigurer removes the "help" subcommand from the provided command.
 func RemoveHelpCommandConfigurer(command *cobra.Command) {
 	// set help command to be empty hidden command to effectively remove the built-in help command. Needs to be done in
@@ -199,9 +467,10 @@ func SilenceErrorsConfigurer(command *cobra.Command) {
 }
 
 // FlagErrorsUsageErrorConfigurer configures the provided command such that, when it encounters an error processing a
-// flag, the returned error includes the usage string for the command.
+// flag, the returned error includes the usage string for the command. If the error is for an unknown flag, the error
+// message also suggests the flag of the command whose name is closest to the unknown flag (see SuggestionsConfigurer).
 func FlagErrorsUsageErrorConfigurer(command *cobra.Command) {
 	command.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
-		return fmt.Errorf("%s\n%s", err.Error(), strings.TrimSuffix(c.UsageString(), "\n"))
+		return fmt.Errorf("%s\n%s", newFlagUsageError(c, err).Error(), strings.TrimSuffix(c.UsageString(), "\n"))
 	})
 }
@@ -6,6 +6,7 @@ package cobracli_test
 
 import (
 	"bytes"
+	"fmt"
 	"regexp"
 	"testing"
 
@@ -132,6 +133,28 @@ Flags:
 	github.com/palantir/pkg/cobracli_test.TestExecuteWithParams.func9.+
 $`),
 		},
+		{
+			"version param adds version command",
+			func(cmd *cobra.Command, args []string) error {
+				return errors.Errorf("hello-error")
+			},
+			nil,
+			[]string{"version"},
+			append(cobracli.DefaultParams(nil), cobracli.VersionParam("1.0.0")),
+			0,
+			"my-app version 1.0.0\n",
+		},
+		{
+			"version param adds version flag",
+			func(cmd *cobra.Command, args []string) error {
+				return errors.Errorf("hello-error")
+			},
+			nil,
+			[]string{"--version"},
+			append(cobracli.DefaultParams(nil), cobracli.VersionParam("1.0.0")),
+			0,
+			"my-app version 1.0.0\n",
+		},
 	} {
 		func() {
 			// reset value of the variable on each run
@@ -163,6 +186,127 @@ $`),
 	}
 }
 
+func TestErrorHandlerChain(t *testing.T) {
+	var handled []string
+	recordHandler := func(name string) cobracli.Param {
+		return cobracli.ErrorHandlerChainParam(func(cmd *cobra.Command, err error) error {
+			handled = append(handled, name+": "+err.Error())
+			return err
+		})
+	}
+	printHandler := func(name string) cobracli.Param {
+		return cobracli.ErrorHandlerParam(func(cmd *cobra.Command, err error) {
+			handled = append(handled, name+": "+err.Error())
+		})
+	}
+
+	for i, tc := range []struct {
+		name        string
+		params      []cobracli.Param
+		wantRV      int
+		wantHandled []string
+	}{
+		{
+			"all handlers run in order",
+			[]cobracli.Param{recordHandler("log"), recordHandler("print")},
+			1,
+			[]string{"log: failure", "print: failure"},
+		},
+		{
+			"transformed error is provided to subsequent handlers and determines exit code",
+			[]cobracli.Param{
+				recordHandler("log"),
+				cobracli.ErrorHandlerChainParam(func(cmd *cobra.Command, err error) error {
+					return &exitCodeError{error: errors.Wrapf(err, "wrapped"), code: 3}
+				}),
+				recordHandler("print"),
+			},
+			3,
+			[]string{"log: failure", "print: wrapped: failure"},
+		},
+		{
+			"error handler replaces previous error handler and runs after chain",
+			[]cobracli.Param{printHandler("default"), printHandler("print"), recordHandler("log")},
+			1,
+			[]string{"log: failure", "print: failure"},
+		},
+		{
+			"handled error stops chain",
+			[]cobracli.Param{
+				cobracli.ErrorHandlerChainParam(func(cmd *cobra.Command, err error) error {
+					return nil
+				}),
+				printHandler("print"),
+			},
+			1,
+			nil,
+		},
+	} {
+		handled = nil
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return errors.New("failure")
+			},
+		}
+		rootCmd.SetArgs([]string{})
+		rootCmd.SilenceErrors = true
+		rootCmd.SilenceUsage = true
+
+		rv := cobracli.Execute(rootCmd, tc.params...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantHandled, handled, "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestExitCodeExtractorChain(t *testing.T) {
+	extractor := func(msg string, code int) cobracli.Param {
+		return cobracli.ExitCodeExtractorParam(func(err error) int {
+			if err.Error() != msg {
+				return cobracli.NoExitCode
+			}
+			return code
+		})
+	}
+
+	for i, tc := range []struct {
+		name   string
+		err    error
+		params []cobracli.Param
+		wantRV int
+	}{
+		{"no extractors", errors.New("failure"), nil, 1},
+		{"first matching extractor wins", errors.New("usage"), []cobracli.Param{extractor("other", 5), extractor("usage", 64), extractor("usage", 2)}, 64},
+		{"falls back to exit code of error", &exitCodeError{error: errors.New("failure"), code: 3}, []cobracli.Param{extractor("other", 5)}, 3},
+		{"falls back to 1", errors.New("failure"), []cobracli.Param{extractor("other", 5)}, 1},
+		{"extractor takes precedence over exit code of error", &exitCodeError{error: errors.New("usage"), code: 3}, []cobracli.Param{extractor("usage", 64)}, 64},
+		{"uses exit code of wrapped error", fmt.Errorf("context: %w", cobracli.NewExitCodeError(4, errors.New("failure"))), nil, 4},
+		{"uses exit code of error wrapped by pkg/errors", errors.Wrap(cobracli.NewExitCodeError(5, nil), "context"), nil, 5},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return tc.err
+			},
+		}
+		rootCmd.SetArgs([]string{})
+		rootCmd.SilenceErrors = true
+		rootCmd.SilenceUsage = true
+
+		rv := cobracli.Execute(rootCmd, tc.params...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d: %s", i, tc.name)
+	}
+}
+
+type exitCodeError struct {
+	error
+	code int
+}
+
+func (e *exitCodeError) ExitCode() int {
+	return e.code
+}
+
 func boolVar(b bool) *bool {
 	return &b
 }
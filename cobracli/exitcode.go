@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+)
+
+// ExitCoder is implemented by errors that specify the exit code that should be used when they are returned by a
+// command.
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// ExitCodeError is an error that specifies the exit code that should be used when it is returned by a command. It
+// wraps an underlying error that describes the failure.
+type ExitCodeError struct {
+	// Code is the exit code.
+	Code int
+	// Err is the underlying error. If it is nil, the error message is "exit status <Code>".
+	Err error
+}
+
+// NewExitCodeError returns an *ExitCodeError with the provided exit code that wraps the provided error.
+func NewExitCodeError(code int, err error) *ExitCodeError {
+	return &ExitCodeError{
+		Code: code,
+		Err:  err,
+	}
+}
+
+func (e *ExitCodeError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+// ExitCode returns the exit code of the error.
+func (e *ExitCodeError) ExitCode() int {
+	return e.Code
+}
+
+// Unwrap returns the underlying error.
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCoderExtractor is an exit code extractor (see ExitCodeExtractorParam) that returns the exit code of the first
+// error in the chain of the provided error (see ErrorChain) that implements ExitCoder. Returns NoExitCode if no error
+// in the chain implements ExitCoder. Execute uses this extractor if no other extractor determines the exit code.
+func ExitCoderExtractor(err error) int {
+	for _, curr := range ErrorChain(err) {
+		if exitCoder, ok := curr.(ExitCoder); ok {
+			return exitCoder.ExitCode()
+		}
+	}
+	return NoExitCode
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestExitCodeError(t *testing.T) {
+	err := cobracli.NewExitCodeError(3, fmt.Errorf("not found"))
+	assert.EqualError(t, err, "not found")
+	assert.Equal(t, 3, err.ExitCode())
+	assert.EqualError(t, err.Unwrap(), "not found")
+
+	assert.EqualError(t, cobracli.NewExitCodeError(2, nil), "exit status 2")
+}
+
+func TestExitCoderExtractor(t *testing.T) {
+	for i, tc := range []struct {
+		err  error
+		want int
+	}{
+		{nil, cobracli.NoExitCode},
+		{fmt.Errorf("failure"), cobracli.NoExitCode},
+		{cobracli.NewExitCodeError(3, nil), 3},
+		{fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", cobracli.NewExitCodeError(4, nil))), 4},
+		{errors.Wrap(fmt.Errorf("inner: %w", cobracli.NewExitCodeError(5, nil)), "outer"), 5},
+		{fmt.Errorf("outer: %w", cobracli.NewExitCodeError(6, cobracli.NewExitCodeError(7, nil))), 6},
+		{fmt.Errorf("outer: %v", cobracli.NewExitCodeError(8, nil)), cobracli.NoExitCode},
+	} {
+		assert.Equal(t, tc.want, cobracli.ExitCoderExtractor(tc.err), "Case %d", i)
+	}
+}
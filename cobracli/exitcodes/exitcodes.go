@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package exitcodes defines exit codes with conventional meanings and an exit code extractor that maps common errors to
+// them, so that tools built using cobracli have consistent exit semantics that scripts can rely on. The codes from 64
+// to 78 are those defined by the BSD sysexits.h header. Typical usage:
+//
+//	os.Exit(cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil),
+//		cobracli.ExitCodeExtractorParam(exitcodes.DefaultExitCodeExtractor),
+//	)...))
+package exitcodes
+
+import (
+	"context"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/palantir/pkg/cobracli"
+	"github.com/palantir/pkg/httpclient"
+)
+
+const (
+	// OK indicates success.
+	OK = 0
+	// GeneralError indicates a failure that does not have a more specific exit code.
+	GeneralError = 1
+	// UsageError indicates that the command was used incorrectly: for example, with the wrong number of arguments, an
+	// unknown flag or an invalid flag value.
+	UsageError = 64
+	// DataError indicates that the input data was incorrect.
+	DataError = 65
+	// NoInput indicates that an input file did not exist or was not readable.
+	NoInput = 66
+	// NoUser indicates that a specified user did not exist.
+	NoUser = 67
+	// NoHost indicates that a specified host did not exist.
+	NoHost = 68
+	// Unavailable indicates that a service was unavailable.
+	Unavailable = 69
+	// SoftwareError indicates an internal error in the software.
+	SoftwareError = 70
+	// OSError indicates an error in the operating system, such as the failure to fork a process.
+	OSError = 71
+	// OSFileError indicates that a system file did not exist, could not be opened or had an invalid format.
+	OSFileError = 72
+	// CantCreate indicates that an output file could not be created.
+	CantCreate = 73
+	// IOError indicates an error while reading or writing a file.
+	IOError = 74
+	// TempFailure indicates a temporary failure: the operation may succeed if it is retried later.
+	TempFailure = 75
+	// ProtocolError indicates that a remote system returned something that violates its protocol.
+	ProtocolError = 76
+	// NoPermission indicates that the user did not have sufficient permission to perform the operation.
+	NoPermission = 77
+	// ConfigError indicates that the configuration was invalid.
+	ConfigError = 78
+	// Timeout indicates that the operation did not complete before its deadline. It matches cobracli.TimeoutExitCode
+	// and the exit code of the timeout utility of GNU coreutils.
+	Timeout = 124
+	// Interrupted indicates that the operation was cancelled because the process was interrupted. It matches the exit
+	// code that shells use for processes terminated by SIGINT.
+	Interrupted = 130
+)
+
+// usageErrorPrefixes are the prefixes of the messages of the errors that cobra and pflag return when a command is used
+// incorrectly.
+var usageErrorPrefixes = []string{
+	"unknown command ",
+	"unknown flag: ",
+	"unknown shorthand flag: ",
+	"flag needs an argument: ",
+	"invalid argument ",
+	"bad flag syntax: ",
+	"required flag(s) ",
+}
+
+// DefaultExitCodeExtractor is an exit code extractor (see cobracli.ExitCodeExtractorParam) that classifies common
+// errors. The errors in the chain of the provided error (see cobracli.ExitCoderExtractor) are examined in order:
+//
+//   - The exit code of an error that implements cobracli.ExitCoder is always used.
+//   - A *cobracli.UsageError or an error returned by cobra or pflag for invalid flags or arguments is a UsageError.
+//   - context.DeadlineExceeded is a Timeout and context.Canceled is Interrupted.
+//   - A file that does not exist is NoInput and a permission error is NoPermission.
+//   - A network error that is a timeout is a TempFailure, a DNS error is NoHost and any other network error is
+//     Unavailable.
+//   - A *httpclient.ResponseError for a 401 or 403 status is NoPermission, for a 404 status is NoInput and for a 5xx
+//     status is Unavailable (see httpclient.ResponseError.ExitCode).
+//
+// Returns cobracli.NoExitCode for other errors.
+func DefaultExitCodeExtractor(err error) int {
+	if code := cobracli.ExitCoderExtractor(err); code != cobracli.NoExitCode {
+		return code
+	}
+	fallback := cobracli.NoExitCode
+	for _, curr := range cobracli.ErrorChain(err) {
+		switch code := classify(curr); code {
+		case cobracli.NoExitCode:
+		case Unavailable:
+			// network errors often wrap more specific errors (such as a *net.DNSError)
+			if fallback == cobracli.NoExitCode {
+				fallback = code
+			}
+		default:
+			return code
+		}
+	}
+	return fallback
+}
+
+func classify(err error) int {
+	if _, ok := err.(*cobracli.UsageError); ok {
+		return UsageError
+	}
+	msg := err.Error()
+	for _, prefix := range usageErrorPrefixes {
+		if strings.HasPrefix(msg, prefix) {
+			return UsageError
+		}
+	}
+	switch {
+	case err == context.DeadlineExceeded:
+		return Timeout
+	case err == context.Canceled:
+		return Interrupted
+	case os.IsNotExist(err):
+		return NoInput
+	case os.IsPermission(err):
+		return NoPermission
+	}
+	switch err := err.(type) {
+	case *httpclient.ResponseError:
+		return err.ExitCode()
+	case *net.DNSError:
+		return NoHost
+	case net.Error:
+		if err.Timeout() {
+			return TempFailure
+		}
+		return Unavailable
+	}
+	return cobracli.NoExitCode
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package exitcodes_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/cobracli"
+	"github.com/palantir/pkg/cobracli/exitcodes"
+	"github.com/palantir/pkg/httpclient"
+)
+
+func TestDefaultExitCodeExtractor(t *testing.T) {
+	_, notExistErr := os.Open("/does/not/exist")
+	dnsErr := &net.DNSError{Err: "no such host", Name: "invalid.example.com", IsNotFound: true}
+	for i, tc := range []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"unclassified", fmt.Errorf("failed"), cobracli.NoExitCode},
+		{"exit coder", cobracli.NewExitCodeError(3, context.DeadlineExceeded), 3},
+		{"usage error", &cobracli.UsageError{Err: fmt.Errorf("invalid")}, exitcodes.UsageError},
+		{"unknown flag", fmt.Errorf("unknown flag: --foo"), exitcodes.UsageError},
+		{"required flag", fmt.Errorf(`required flag(s) "name" not set`), exitcodes.UsageError},
+		{"deadline", fmt.Errorf("request failed: %w", context.DeadlineExceeded), exitcodes.Timeout},
+		{"canceled", errors.Wrap(context.Canceled, "request failed"), exitcodes.Interrupted},
+		{"not exist", notExistErr, exitcodes.NoInput},
+		{"permission", fmt.Errorf("failed: %w", os.ErrPermission), exitcodes.NoPermission},
+		{"DNS error", &net.OpError{Op: "dial", Net: "tcp", Err: dnsErr}, exitcodes.NoHost},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")}, exitcodes.Unavailable},
+		{"unauthorized", fmt.Errorf("request failed: %w", &httpclient.ResponseError{StatusCode: 401}), exitcodes.NoPermission},
+		{"forbidden", &httpclient.ResponseError{StatusCode: 403}, exitcodes.NoPermission},
+		{"not found", &httpclient.ResponseError{StatusCode: 404}, exitcodes.NoInput},
+		{"server error", &httpclient.ResponseError{StatusCode: 503}, exitcodes.Unavailable},
+		{"other status", &httpclient.ResponseError{StatusCode: 400}, exitcodes.GeneralError},
+	} {
+		assert.Equal(t, tc.want, exitcodes.DefaultExitCodeExtractor(tc.err), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestDefaultExitCodeExtractorExecute(t *testing.T) {
+	for i, tc := range []struct {
+		args   []string
+		wantRV int
+	}{
+		{[]string{"--unknown"}, exitcodes.UsageError},
+		{[]string{"extra-arg"}, exitcodes.UsageError},
+		{nil, exitcodes.Timeout},
+	} {
+		rootCmd := &cobra.Command{
+			Use:  "my-app",
+			Args: cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return fmt.Errorf("request failed: %w", context.DeadlineExceeded)
+			},
+		}
+		rootCmd.SetOutput(&bytes.Buffer{})
+		rootCmd.SetArgs(tc.args)
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.ExitCodeExtractorParam(exitcodes.DefaultExitCodeExtractor))...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/console"
+	"github.com/palantir/pkg/fsutil"
+	"github.com/palantir/pkg/internal/filelock"
+	"github.com/palantir/pkg/xdgdir"
+)
+
+// SkipFirstRunAnnotation is the key of the command annotation that marks a command as a utility command for which the
+// first-run setup of FirstRunParam is not performed (see SkipFirstRun). The value must be "true".
+const SkipFirstRunAnnotation = "cobracli_skip_first_run"
+
+const (
+	// firstRunMarkerFileName is the name of the file in the state directory of an application whose presence
+	// indicates that the first-run setup has been completed.
+	firstRunMarkerFileName = "first-run-complete"
+	// firstRunLockFileName is the name of the file in the state directory of an application that is locked while the
+	// first-run setup is performed so that concurrent invocations do not perform it more than once.
+	firstRunLockFileName = "first-run.lock"
+)
+
+// SkipFirstRun marks the provided command as a utility command (such as the command returned by CompletionCmd) for
+// which the first-run setup of FirstRunParam is not performed. The setup is also not performed for the descendants of
+// the command.
+func SkipFirstRun(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[SkipFirstRunAnnotation] = "true"
+}
+
+// FirstRunParam returns a Param that runs the provided onboarding function before the first command that is run by the
+// application with the provided name. The onboarding function can perform one-time setup such as creating a default
+// configuration, offering to install shell completion or printing a quickstart guide. It is provided with the command
+// that is being run.
+//
+// Completion of the setup is recorded by a marker file in the state directory of the application (see
+// xdgdir.AppDirs), so the onboarding function runs on the first invocation in which the state directory or the marker
+// file does not exist. The marker file is checked and written while holding a lock on a file in the state directory,
+// so concurrent invocations run the onboarding function at most once. If the onboarding function returns an error,
+// the command is not run and the setup is attempted again on the next invocation.
+//
+// The setup is not performed (and is not recorded as complete) if standard input is not a terminal, since the
+// onboarding function may need to interact with the user, or for utility commands marked using SkipFirstRun (such as
+// the commands returned by CompletionCmd and VersionCmd).
+//
+// The Param also adds the "--skip-setup" persistent flag: if it is specified, the onboarding function is not run but
+// the setup is recorded as complete.
+func FirstRunParam(appName string, onboard func(cmd *cobra.Command) error) Param {
+	return firstRunParam(appName, onboard, func() bool {
+		return console.IsTerminal(os.Stdin)
+	})
+}
+
+func firstRunParam(appName string, onboard func(cmd *cobra.Command) error, interactive func() bool) Param {
+	var skipSetup bool
+	return multiParam(
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			cmd.PersistentFlags().BoolVar(&skipSetup, "skip-setup", false, "skip the first-run setup of the application")
+		}),
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				if skipsFirstRun(cmd) || !skipSetup && !interactive() {
+					return next(cmd, args)
+				}
+				dirs, err := xdgdir.AppDirs(appName)
+				if err != nil {
+					return err
+				}
+				markerPath := filepath.Join(dirs.State, firstRunMarkerFileName)
+				if complete, err := firstRunComplete(markerPath); err != nil {
+					return err
+				} else if complete {
+					return next(cmd, args)
+				}
+
+				if err := fsutil.MkdirAll(dirs.State, fsutil.SecretDirMode); err != nil {
+					return fmt.Errorf("failed to create state directory: %v", err)
+				}
+				if err := filelock.WithLock(filepath.Join(dirs.State, firstRunLockFileName), fsutil.SecretFileMode, func() error {
+					// another invocation may have completed the setup while the lock was acquired
+					if complete, err := firstRunComplete(markerPath); err != nil || complete {
+						return err
+					}
+					if !skipSetup {
+						if err := onboard(cmd); err != nil {
+							return fmt.Errorf("first-run setup failed: %v", err)
+						}
+					}
+					if err := fsutil.WriteFile(markerPath, nil, fsutil.SecretFileMode); err != nil {
+						return fmt.Errorf("failed to record completion of first-run setup: %v", err)
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+				return next(cmd, args)
+			}
+		}),
+	)
+}
+
+// skipsFirstRun returns true if the provided command or any of its ancestors is marked using SkipFirstRun or is the
+// help command.
+func skipsFirstRun(cmd *cobra.Command) bool {
+	for curr := cmd; curr != nil; curr = curr.Parent() {
+		if curr.Annotations[SkipFirstRunAnnotation] == "true" || curr.Name() == "help" && curr.HasParent() {
+			return true
+		}
+	}
+	return false
+}
+
+func firstRunComplete(markerPath string) (bool, error) {
+	_, err := os.Stat(markerPath)
+	if err == nil {
+		return true, nil
+	}
+	if !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to determine whether first-run setup is complete: %v", err)
+	}
+	return false, nil
+}
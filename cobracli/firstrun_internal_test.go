@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstRunParam(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	orig, ok := os.LookupEnv("XDG_STATE_HOME")
+	defer func() {
+		if ok {
+			_ = os.Setenv("XDG_STATE_HOME", orig)
+		} else {
+			_ = os.Unsetenv("XDG_STATE_HOME")
+		}
+	}()
+	require.NoError(t, os.Setenv("XDG_STATE_HOME", tmpDir))
+
+	for i, tc := range []struct {
+		appName     string
+		args        []string
+		interactive bool
+		onboardErr  error
+		wantRV      int
+		wantOutput  string
+	}{
+		// setup is not performed for utility commands or if standard input is not a terminal
+		{"my-app", []string{"completion", "bash"}, true, nil, 0, ""},
+		{"my-app", nil, false, nil, 0, "ran\n"},
+		{"my-app", nil, true, fmt.Errorf("no network"), 1, "onboarding\nError: first-run setup failed: no network\n"},
+		{"my-app", nil, true, nil, 0, "onboarding\nran\n"},
+		{"my-app", nil, true, nil, 0, "ran\n"},
+		{"other-app", []string{"--skip-setup"}, false, nil, 0, "ran\n"},
+		{"other-app", nil, true, nil, 0, "ran\n"},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Println("ran")
+			},
+		}
+		completionCmd := CompletionCmd(rootCmd)
+		completionCmd.RunE = func(cmd *cobra.Command, args []string) error {
+			return nil
+		}
+		rootCmd.AddCommand(completionCmd)
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(append([]string{}, tc.args...))
+
+		interactive := tc.interactive
+		rv := Execute(rootCmd,
+			ConfigureCmdParam(SilenceErrorsConfigurer),
+			ErrorHandlerParam(ErrorPrinterWithDebugHandler(nil, nil)),
+			firstRunParam(tc.appName, func(cmd *cobra.Command) error {
+				cmd.Println("onboarding")
+				return tc.onboardErr
+			}, func() bool { return interactive }),
+		)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+	}
+}
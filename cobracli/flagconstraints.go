@@ -0,0 +1,219 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// FlagConstraintsAnnotation is the key of the command annotation that stores the flag constraints declared using
+// AddFlagConstraints. Its value is an encoded form of the constraints and should not be modified directly.
+const FlagConstraintsAnnotation = "cobracli_flag_constraints"
+
+type flagConstraintKind string
+
+const (
+	requireOneOfConstraint      flagConstraintKind = "requireOneOf"
+	mutuallyExclusiveConstraint flagConstraintKind = "mutuallyExclusive"
+	requiredIfConstraint        flagConstraintKind = "requiredIf"
+	allOrNoneConstraint         flagConstraintKind = "allOrNone"
+)
+
+// FlagConstraint is a constraint on the combination of flags that may be specified for a command. A flag is considered
+// to be specified if its value was provided on the command line, from the environment (see EnvVarPrefixParam) or from
+// a configuration file (see ConfigFileParam).
+type FlagConstraint struct {
+	kind  flagConstraintKind
+	flags []string
+}
+
+// RequireOneOf returns a constraint that requires at least one of the flags with the provided names to be specified.
+func RequireOneOf(flags ...string) FlagConstraint {
+	return FlagConstraint{kind: requireOneOfConstraint, flags: flags}
+}
+
+// MutuallyExclusive returns a constraint that permits at most one of the flags with the provided names to be specified.
+func MutuallyExclusive(flags ...string) FlagConstraint {
+	return FlagConstraint{kind: mutuallyExclusiveConstraint, flags: flags}
+}
+
+// RequiredIf returns a constraint that requires the flag with the provided name to be specified if the flag with the
+// name conditionFlag is specified.
+func RequiredIf(flag, conditionFlag string) FlagConstraint {
+	return FlagConstraint{kind: requiredIfConstraint, flags: []string{flag, conditionFlag}}
+}
+
+// AllOrNone returns a constraint that requires either all or none of the flags with the provided names to be specified.
+func AllOrNone(flags ...string) FlagConstraint {
+	return FlagConstraint{kind: allOrNoneConstraint, flags: flags}
+}
+
+// String returns a description of the constraint suitable for help output.
+func (c FlagConstraint) String() string {
+	switch c.kind {
+	case requireOneOfConstraint:
+		return "at least one of " + joinFlagNames(c.flags) + " is required"
+	case mutuallyExclusiveConstraint:
+		return "at most one of " + joinFlagNames(c.flags) + " may be specified"
+	case requiredIfConstraint:
+		return fmt.Sprintf("--%s is required if --%s is specified", c.flags[0], c.flags[1])
+	case allOrNoneConstraint:
+		return "either all or none of " + joinFlagNames(c.flags) + " must be specified"
+	default:
+		return string(c.kind)
+	}
+}
+
+// violation returns a description of how the constraint is violated by the provided command, or an empty string if
+// the constraint is satisfied.
+func (c FlagConstraint) violation(cmd *cobra.Command) string {
+	var specified, unspecified []string
+	for _, name := range c.flags {
+		if flagSpecified(cmd, name) {
+			specified = append(specified, name)
+		} else {
+			unspecified = append(unspecified, name)
+		}
+	}
+	switch c.kind {
+	case requireOneOfConstraint:
+		if len(specified) == 0 {
+			return "at least one of " + joinFlagNames(c.flags) + " is required"
+		}
+	case mutuallyExclusiveConstraint:
+		if len(specified) > 1 {
+			return joinFlagNames(specified) + " are mutually exclusive"
+		}
+	case requiredIfConstraint:
+		if flagSpecified(cmd, c.flags[1]) && !flagSpecified(cmd, c.flags[0]) {
+			return fmt.Sprintf("--%s is required because --%s is specified", c.flags[0], c.flags[1])
+		}
+	case allOrNoneConstraint:
+		if len(specified) > 0 && len(unspecified) > 0 {
+			return fmt.Sprintf("%s must be specified together with %s", joinFlagNames(unspecified), joinFlagNames(specified))
+		}
+	}
+	return ""
+}
+
+// AddFlagConstraints adds the provided constraints to the FlagConstraintsAnnotation of the provided command. The
+// constraints only apply to the command itself (not its descendants) and are enforced by FlagConstraintsParam.
+func AddFlagConstraints(cmd *cobra.Command, constraints ...FlagConstraint) {
+	encoded := make([]string, len(constraints))
+	for i, c := range constraints {
+		encoded[i] = string(c.kind) + ":" + strings.Join(c.flags, ",")
+	}
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	if existing := cmd.Annotations[FlagConstraintsAnnotation]; existing != "" {
+		encoded = append([]string{existing}, encoded...)
+	}
+	cmd.Annotations[FlagConstraintsAnnotation] = strings.Join(encoded, ";")
+}
+
+// FlagConstraints returns the constraints declared on the provided command using AddFlagConstraints.
+func FlagConstraints(cmd *cobra.Command) []FlagConstraint {
+	var constraints []FlagConstraint
+	for _, encoded := range strings.Split(cmd.Annotations[FlagConstraintsAnnotation], ";") {
+		parts := strings.SplitN(encoded, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		constraints = append(constraints, FlagConstraint{
+			kind:  flagConstraintKind(parts[0]),
+			flags: strings.Split(parts[1], ","),
+		})
+	}
+	return constraints
+}
+
+// FlagConstraintsParam returns a Param that enforces the flag constraints declared using AddFlagConstraints. Before a
+// command is run, all of its constraints are verified and, if any are violated, the command is not run and an error
+// that lists every violation is returned. The constraints of a command are also appended to its long description so
+// that they are included in help output and generated documentation. Flags that are set from environment variables (see
+// EnvVarPrefixParam) or configuration files (see ConfigFileParam) are taken into account. The returned error is a
+// *UsageError. Panics when the Param is applied if a constraint refers to a flag that is not defined for its command.
+func FlagConstraintsParam() Param {
+	return multiParam(
+		ConfigureCmdParam(func(rootCmd *cobra.Command) {
+			visitCommands(rootCmd, func(cmd *cobra.Command) {
+				for _, c := range FlagConstraints(cmd) {
+					for _, name := range c.flags {
+						if cmd.Flags().Lookup(name) == nil && cmd.InheritedFlags().Lookup(name) == nil {
+							panic(fmt.Sprintf("flag constraint %q of %q refers to undefined flag --%s", c.String(), cmd.CommandPath(), name))
+						}
+					}
+				}
+				section := flagConstraintsSection(cmd)
+				if section == "" {
+					return
+				}
+				long := cmd.Long
+				if long == "" {
+					long = cmd.Short
+				}
+				if !strings.HasSuffix(long, section) {
+					cmd.Long = strings.TrimRight(long, "\n") + "\n\n" + section
+				}
+			})
+		}),
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				if err := VerifyFlagConstraints(cmd); err != nil {
+					return err
+				}
+				return next(cmd, args)
+			}
+		}),
+	)
+}
+
+// VerifyFlagConstraints verifies the flag constraints declared on the provided command. Returns a *UsageError that
+// lists all of the constraints that are violated, or nil if all of the constraints are satisfied.
+func VerifyFlagConstraints(cmd *cobra.Command) error {
+	var problems []string
+	for _, c := range FlagConstraints(cmd) {
+		if problem := c.violation(cmd); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return &UsageError{
+		Command: cmd.CommandPath(),
+		Err:     fmt.Errorf("invalid flags for %q:\n  %s", cmd.CommandPath(), strings.Join(problems, "\n  ")),
+	}
+}
+
+// flagConstraintsSection returns the description of the flag constraints of the provided command, or an empty string
+// if it does not have any constraints.
+func flagConstraintsSection(cmd *cobra.Command) string {
+	constraints := FlagConstraints(cmd)
+	if len(constraints) == 0 {
+		return ""
+	}
+	lines := make([]string, len(constraints))
+	for i, c := range constraints {
+		lines[i] = "  " + c.String()
+	}
+	return "Flag constraints:\n" + strings.Join(lines, "\n")
+}
+
+func flagSpecified(cmd *cobra.Command, name string) bool {
+	return FlagValueSource(cmd, name) != ValueSourceDefault
+}
+
+func joinFlagNames(names []string) string {
+	flags := make([]string, len(names))
+	for i, name := range names {
+		flags[i] = "--" + name
+	}
+	return strings.Join(flags, ", ")
+}
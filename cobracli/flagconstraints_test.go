@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestFlagConstraintsParam(t *testing.T) {
+	for i, tc := range []struct {
+		name       string
+		args       []string
+		wantRV     int
+		wantOutput string
+	}{
+		{"satisfied", []string{"sub", "--file", "in.txt"}, 0, "ran\n"},
+		{"all violated", []string{"sub", "--file", "in.txt", "--url", "https://example.com", "--user", "admin", "--cert", "cert.pem"}, 1, `Error: invalid flags for "my-app sub":
+  --file, --url are mutually exclusive
+  --password is required because --user is specified
+  --key must be specified together with --cert
+Usage:
+  my-app sub [flags]
+
+Flags:
+      --cert string       certificate
+      --file string       file
+  -h, --help              help for sub
+      --key string        key
+      --password string   password
+      --url string        url
+      --user string       user
+`},
+		{"none specified", []string{"sub"}, 1, `Error: invalid flags for "my-app sub":
+  at least one of --file, --url is required
+Usage:
+  my-app sub [flags]
+
+Flags:
+      --cert string       certificate
+      --file string       file
+  -h, --help              help for sub
+      --key string        key
+      --password string   password
+      --url string        url
+      --user string       user
+`},
+		{"help", []string{"sub", "--help"}, 0, `Runs the subcommand
+
+Flag constraints:
+  at least one of --file, --url is required
+  at most one of --file, --url may be specified
+  --password is required if --user is specified
+  either all or none of --cert, --key must be specified
+
+Usage:
+  my-app sub [flags]
+
+Flags:
+      --cert string       certificate
+      --file string       file
+  -h, --help              help for sub
+      --key string        key
+      --password string   password
+      --url string        url
+      --user string       user
+`},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+		}
+		subCmd := &cobra.Command{
+			Use:   "sub",
+			Short: "Runs the subcommand",
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Println("ran")
+			},
+		}
+		for _, name := range []string{"file", "url", "user", "password", "key"} {
+			subCmd.Flags().String(name, "", name)
+		}
+		subCmd.Flags().String("cert", "", "certificate")
+		rootCmd.AddCommand(subCmd)
+		cobracli.AddFlagConstraints(subCmd,
+			cobracli.RequireOneOf("file", "url"),
+			cobracli.MutuallyExclusive("file", "url"),
+		)
+		cobracli.AddFlagConstraints(subCmd,
+			cobracli.RequiredIf("password", "user"),
+			cobracli.AllOrNone("cert", "key"),
+		)
+
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.FlagConstraintsParam())...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestVerifyFlagConstraintsUsageError(t *testing.T) {
+	cmd := &cobra.Command{
+		Use: "my-app",
+	}
+	cmd.Flags().String("file", "", "file")
+	cmd.Flags().String("url", "", "url")
+	cobracli.AddFlagConstraints(cmd, cobracli.RequireOneOf("file", "url"))
+
+	err := cobracli.VerifyFlagConstraints(cmd)
+	usageErr, ok := cobracli.AsUsageError(err)
+	require.True(t, ok)
+	assert.Equal(t, "my-app", usageErr.Command)
+	assert.EqualError(t, err, "invalid flags for \"my-app\":\n  at least one of --file, --url is required")
+}
+
+func TestFlagConstraintsParamUndefinedFlag(t *testing.T) {
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		Run: func(cmd *cobra.Command, args []string) {},
+	}
+	rootCmd.PersistentFlags().String("file", "", "file")
+	subCmd := &cobra.Command{
+		Use: "sub",
+		Run: func(cmd *cobra.Command, args []string) {},
+	}
+	rootCmd.AddCommand(subCmd)
+	cobracli.AddFlagConstraints(subCmd, cobracli.RequireOneOf("file", "url"))
+
+	assert.PanicsWithValue(t, `flag constraint "at least one of --file, --url is required" of "my-app sub" refers to undefined flag --url`, func() {
+		cobracli.Execute(rootCmd, cobracli.ArgsParam([]string{"sub"}), cobracli.FlagConstraintsParam())
+	})
+}
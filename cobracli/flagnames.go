@@ -0,0 +1,25 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/palantir/pkg/transform"
+)
+
+// NormalizeFlagNamesConfigurer configures the provided command and all of its subcommands to normalize flag names to
+// kebab-case (see transform.KebabCase), so that flags can be specified using any of the common naming conventions: for
+// example, the "--output-format" flag can also be specified as "--output_format", "--outputFormat" or
+// "--OUTPUT_FORMAT". Flags that are not defined in kebab-case are renamed, so they are listed in kebab-case in help
+// output.
+func NormalizeFlagNamesConfigurer(command *cobra.Command) {
+	command.SetGlobalNormalizationFunc(kebabCaseFlagName)
+}
+
+func kebabCaseFlagName(f *pflag.FlagSet, name string) pflag.NormalizedName {
+	return pflag.NormalizedName(transform.KebabCase(name))
+}
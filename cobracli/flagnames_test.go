@@ -0,0 +1,46 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestNormalizeFlagNamesConfigurer(t *testing.T) {
+	for i, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"sub", "--output-format", "json"}, "json"},
+		{[]string{"sub", "--output_format", "json"}, "json"},
+		{[]string{"sub", "--outputFormat", "json"}, "json"},
+		{[]string{"sub", "--OUTPUT_FORMAT", "json"}, "json"},
+		{[]string{"sub", "--user-id", "admin"}, "admin"},
+	} {
+		var got string
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+		}
+		rootCmd.PersistentFlags().String("output-format", "", "output format")
+		subCmd := &cobra.Command{
+			Use: "sub",
+			Run: func(cmd *cobra.Command, args []string) {
+				got = cmd.Flag("output-format").Value.String() + cmd.Flag("userID").Value.String()
+			},
+		}
+		subCmd.Flags().String("userID", "", "user ID")
+		rootCmd.AddCommand(subCmd)
+
+		rv := cobracli.Execute(rootCmd, cobracli.ArgsParam(tc.args), cobracli.ConfigureCmdParam(cobracli.NormalizeFlagNamesConfigurer))
+		assert.Equal(t, 0, rv, "Case %d", i)
+		assert.Equal(t, tc.want, got, "Case %d", i)
+		assert.Equal(t, "user-id", subCmd.Flag("userID").Name, "Case %d", i)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/matcher"
+)
+
+// ExpandGlobArgsParam returns a Param that expands glob patterns in the positional arguments provided to commands
+// using ExpandGlobArgs. Shells on Windows do not expand glob patterns, so this makes invocations such as
+// "mytool fmt *.go" behave the same on all platforms. Is a no-op on platforms other than Windows, where the shell is
+// responsible for expansion.
+func ExpandGlobArgsParam() Param {
+	if runtime.GOOS != "windows" {
+		return nil
+	}
+	return runEDecoratorParam(func(next RunEFunc) RunEFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			return next(cmd, ExpandGlobArgs(args))
+		}
+	})
+}
+
+// ExpandGlobArgs returns the provided arguments with every argument that is a glob pattern replaced by the paths that
+// match the pattern (in lexical order). Patterns use the syntax of filepath.Match (so both "/" and "\" are separators
+// on Windows) and every path component is matched using the matcher package. Consistent with the behavior of common
+// shells, an argument that is not a valid pattern or that does not match any paths is retained as-is.
+func ExpandGlobArgs(args []string) []string {
+	var expanded []string
+	for _, arg := range args {
+		matches := globMatches(arg)
+		if len(matches) == 0 {
+			expanded = append(expanded, arg)
+			continue
+		}
+		expanded = append(expanded, matches...)
+	}
+	return expanded
+}
+
+func globMatches(pattern string) []string {
+	if !strings.ContainsAny(pattern, globMetaChars) {
+		return nil
+	}
+	vol := filepath.VolumeName(pattern)
+	parts := strings.Split(filepath.ToSlash(pattern[len(vol):]), "/")
+	for _, part := range parts {
+		if _, err := filepath.Match(part, ""); err != nil {
+			// not a valid pattern
+			return nil
+		}
+	}
+
+	// expand the pattern one path component at a time so that only the directories that can contain matches are read
+	paths := []string{vol}
+	if len(parts) > 1 && parts[0] == "" {
+		// pattern is an absolute path
+		paths, parts = []string{vol + string(filepath.Separator)}, parts[1:]
+	}
+	for _, part := range parts {
+		var next []string
+		for _, dir := range paths {
+			if !strings.ContainsAny(part, globMetaChars) {
+				next = append(next, filepath.Join(dir, part))
+				continue
+			}
+			listDir := dir
+			if listDir == "" {
+				listDir = "."
+			}
+			fis, err := ioutil.ReadDir(listDir)
+			if err != nil {
+				continue
+			}
+			// the name of an entry does not contain separators, so the matcher only matches it against the pattern
+			partMatcher := matcher.Path(part)
+			for _, fi := range fis {
+				if partMatcher.Match(fi.Name()) {
+					next = append(next, filepath.Join(dir, fi.Name()))
+				}
+			}
+		}
+		paths = next
+	}
+
+	var matches []string
+	for _, p := range paths {
+		// literal components that follow the last pattern component are not verified while expanding
+		if _, err := os.Lstat(p); err == nil {
+			matches = append(matches, p)
+		}
+	}
+	return matches
+}
+
+const globMetaChars = `*?[`
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestExpandGlobArgs(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	for _, p := range []string{
+		"a.go",
+		"b.go",
+		"c.txt",
+		"sub/d.go",
+		"sub/e.txt",
+		"sub/nested/f.go",
+		"dir.go/g.go",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, filepath.Dir(p)), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(tmpDir, p), nil, 0644))
+	}
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() {
+		require.NoError(t, os.Chdir(wd))
+	}()
+
+	for i, tc := range []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"literal", "a.go"}, []string{"literal", "a.go"}},
+		{[]string{"*.go"}, []string{"a.go", "b.go", "dir.go"}},
+		{[]string{"*.txt", "*.go"}, []string{"c.txt", "a.go", "b.go", "dir.go"}},
+		{[]string{"sub/*.go"}, []string{"sub/d.go"}},
+		{[]string{"*/*.go"}, []string{"dir.go/g.go", "sub/d.go"}},
+		{[]string{"*/nested/*.go"}, []string{filepath.Join("sub", "nested", "f.go")}},
+		{[]string{"*/missing.go"}, []string{"*/missing.go"}},
+		{[]string{"?.txt"}, []string{"c.txt"}},
+		{[]string{"*.md"}, []string{"*.md"}},
+		{[]string{"[.go"}, []string{"[.go"}},
+		{[]string{filepath.Join(tmpDir, "sub", "*.txt")}, []string{filepath.Join(tmpDir, "sub", "e.txt")}},
+	} {
+		got := cobracli.ExpandGlobArgs(tc.args)
+		assert.Equal(t, tc.want, got, "Case %d", i)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/palantir/pkg/flagtypes"
+)
+
+// HelpFullParam returns a Param that adds a persistent "--help-full" flag to the root command. Invoking a command with
+// the flag prints the help for the command in which the description of every flag is augmented with the metadata
+// recorded for the flag using the annotations of the flagtypes package: the environment variable and configuration key
+// that can provide its value, the source of its default value and its allowed values. The command itself is not run.
+func HelpFullParam() Param {
+	return paramFunc(func(executor *executor) {
+		var full bool
+		multiParam(
+			ConfigureCmdParam(func(rootCmd *cobra.Command) {
+				rootCmd.PersistentFlags().BoolVar(&full, "help-full", false, "print help including flag metadata such as environment variables and allowed values")
+				helpFunc := rootCmd.HelpFunc()
+				rootCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+					if full {
+						defer augmentFlagUsages(cmd)()
+					}
+					helpFunc(cmd, args)
+				})
+			}),
+			runEDecoratorParam(func(runE RunEFunc) RunEFunc {
+				return func(cmd *cobra.Command, args []string) error {
+					if full {
+						cmd.HelpFunc()(cmd, args)
+						return nil
+					}
+					return runE(cmd, args)
+				}
+			}),
+		).apply(executor)
+	})
+}
+
+// augmentFlagUsages appends the metadata of every flag of the provided command to its usage string. Returns a function
+// that restores the original usage strings.
+func augmentFlagUsages(cmd *cobra.Command) (restore func()) {
+	origUsages := make(map[*pflag.Flag]string)
+	augment := func(f *pflag.Flag) {
+		if _, ok := origUsages[f]; ok {
+			return
+		}
+		origUsages[f] = f.Usage
+		if details := flagMetadataDetails(flagtypes.FlagMetadata(f)); details != "" {
+			f.Usage += " [" + details + "]"
+		}
+	}
+	cmd.LocalFlags().VisitAll(augment)
+	cmd.InheritedFlags().VisitAll(augment)
+	return func() {
+		for f, usage := range origUsages {
+			f.Usage = usage
+		}
+	}
+}
+
+func flagMetadataDetails(m flagtypes.Metadata) string {
+	var parts []string
+	if m.EnvVar != "" {
+		parts = append(parts, "env: $"+m.EnvVar)
+	}
+	if m.ConfigKey != "" {
+		parts = append(parts, "config: "+m.ConfigKey)
+	}
+	if m.DefaultSource != "" {
+		parts = append(parts, "default from: "+m.DefaultSource)
+	}
+	if len(m.AllowedValues) > 0 {
+		parts = append(parts, "allowed: "+strings.Join(m.AllowedValues, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
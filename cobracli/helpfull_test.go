@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+	"github.com/palantir/pkg/flagtypes"
+)
+
+func TestHelpFullParam(t *testing.T) {
+	for i, tc := range []struct {
+		args       []string
+		wantOutput string
+	}{
+		{[]string{"serve", "--help"}, `Usage:
+  my-app serve [flags]
+
+Flags:
+      --format string   output format (default "json")
+  -h, --help            help for serve
+      --port int        port to listen on (default 8080)
+
+Global Flags:
+      --help-full   print help including flag metadata such as environment variables and allowed values
+`},
+		{[]string{"serve", "--help-full"}, `Usage:
+  my-app serve [flags]
+
+Flags:
+      --format string   output format [allowed: json, yaml] (default "json")
+  -h, --help            help for serve
+      --port int        port to listen on [env: $APP_PORT; config: server.port; default from: server.yml] (default 8080)
+
+Global Flags:
+      --help-full   print help including flag metadata such as environment variables and allowed values
+`},
+		{[]string{"serve"}, "serving\n"},
+	} {
+		rootCmd := &cobra.Command{Use: "my-app"}
+		serveCmd := &cobra.Command{
+			Use: "serve",
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Println("serving")
+			},
+		}
+		serveCmd.Flags().Int("port", 8080, "port to listen on")
+		require.NoError(t, flagtypes.SetEnvVar(serveCmd.Flags(), "port", "APP_PORT"))
+		require.NoError(t, flagtypes.SetConfigKey(serveCmd.Flags(), "port", "server.port"))
+		require.NoError(t, flagtypes.SetDefaultSource(serveCmd.Flags(), "port", "server.yml"))
+		var format string
+		flagtypes.EnumVar(serveCmd.Flags(), &format, "format", "json", "output format", "json", "yaml")
+		rootCmd.AddCommand(serveCmd)
+
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, cobracli.HelpFullParam())
+		assert.Equal(t, 0, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+	}
+}
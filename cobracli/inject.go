@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/palantir/pkg/typenames"
+)
+
+// ProvideParam returns a Param that registers the provided function as the provider of values of type T. Values are
+// resolved from the execution context of a command (see Context) using Resolve. The provider is called lazily the
+// first time a value of type T is resolved during an invocation of Execute and its result (including any error) is
+// memoized for the rest of the invocation. If multiple providers are registered for the same type, the last one is
+// used.
+//
+// Providers may resolve other values from the context that they are provided, but must not depend on themselves
+// (directly or indirectly): resolving a value whose provider depends on itself returns an error. This provides a
+// consistent way to wire dependencies such as configuration, API clients and loggers into commands:
+//
+//	cobracli.Execute(rootCmd,
+//		cobracli.ProvideParam(func(ctx context.Context) (*Config, error) {
+//			return loadConfig(cfgPath)
+//		}),
+//		cobracli.ProvideParam(func(ctx context.Context) (*Client, error) {
+//			cfg, err := cobracli.Resolve[*Config](ctx)
+//			if err != nil {
+//				return nil, err
+//			}
+//			return NewClient(cfg.URL), nil
+//		}),
+//	)
+func ProvideParam[T any](provider func(ctx context.Context) (T, error)) Param {
+	return paramFunc(func(executor *executor) {
+		if executor.providers == nil {
+			executor.providers = make(map[reflect.Type]func(context.Context) (interface{}, error))
+		}
+		executor.providers[typeOf[T]()] = func(ctx context.Context) (interface{}, error) {
+			return provider(ctx)
+		}
+	})
+}
+
+// ProvideValueParam returns a Param that registers the provided value as the value of type T (see ProvideParam).
+func ProvideValueParam[T any](v T) Param {
+	return ProvideParam(func(ctx context.Context) (T, error) {
+		return v, nil
+	})
+}
+
+// Resolve returns the value of type T provided by the provider registered using ProvideParam. Returns an error if no
+// provider is registered for the type, if the provided context is not derived from the execution context of an
+// invocation of Execute, if the provider depends on itself or if the provider returns an error.
+func Resolve[T any](ctx context.Context) (T, error) {
+	var zero T
+	t := typeOf[T]()
+	c, ok := ctx.Value(containerKey{}).(*container)
+	if !ok {
+		return zero, fmt.Errorf("no provider registered for %s", typenames.Name(t))
+	}
+	v, err := c.resolve(ctx, t)
+	if err != nil {
+		return zero, err
+	}
+	// the value is nil if the provider returned a nil interface value
+	val, _ := v.(T)
+	return val, nil
+}
+
+// MustResolve is like Resolve, but panics if the value cannot be resolved.
+func MustResolve[T any](ctx context.Context) T {
+	v, err := Resolve[T](ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+type containerKey struct{}
+
+// resolvingKey is the key of the context value that contains the types whose providers are running in the context,
+// starting with the outermost one.
+type resolvingKey struct{}
+
+// container memoizes the values returned by providers for the duration of one invocation of Execute.
+type container struct {
+	mutex   sync.Mutex
+	entries map[reflect.Type]*providerEntry
+}
+
+type providerEntry struct {
+	once    sync.Once
+	provide func(context.Context) (interface{}, error)
+	val     interface{}
+	err     error
+}
+
+func newContainer(providers map[reflect.Type]func(context.Context) (interface{}, error)) *container {
+	entries := make(map[reflect.Type]*providerEntry, len(providers))
+	for t, provide := range providers {
+		entries[t] = &providerEntry{
+			provide: provide,
+		}
+	}
+	return &container{
+		entries: entries,
+	}
+}
+
+func (c *container) resolve(ctx context.Context, t reflect.Type) (interface{}, error) {
+	c.mutex.Lock()
+	entry, ok := c.entries[t]
+	c.mutex.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no provider registered for %s", typenames.Name(t))
+	}
+	// a provider that depends on itself would otherwise wait for its own completion forever
+	resolving, _ := ctx.Value(resolvingKey{}).([]reflect.Type)
+	for i, curr := range resolving {
+		if curr != t {
+			continue
+		}
+		var names []string
+		for _, dep := range append(resolving[i:], t) {
+			names = append(names, typenames.Name(dep))
+		}
+		return nil, fmt.Errorf("provider of %s depends on itself: %s", typenames.Name(t), strings.Join(names, " -> "))
+	}
+	entry.once.Do(func() {
+		entry.val, entry.err = entry.provide(context.WithValue(ctx, resolvingKey{}, append(resolving[:len(resolving):len(resolving)], t)))
+		if entry.err != nil {
+			entry.err = fmt.Errorf("failed to provide %s: %v", typenames.Name(t), entry.err)
+		}
+	})
+	return entry.val, entry.err
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+type testConfig struct {
+	URL string
+}
+
+type testClient struct {
+	URL string
+}
+
+func TestProvideParam(t *testing.T) {
+	configCalls := 0
+	var gotClient *testClient
+	var gotErr error
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cobracli.Context(cmd)
+			gotClient = cobracli.MustResolve[*testClient](ctx)
+			cfg, err := cobracli.Resolve[*testConfig](ctx)
+			if err != nil {
+				return err
+			}
+			if cfg.URL != gotClient.URL {
+				return fmt.Errorf("unexpected URL %s", cfg.URL)
+			}
+			_, gotErr = cobracli.Resolve[string](ctx)
+			return nil
+		},
+	}
+	rootCmd.SetArgs([]string{})
+
+	rv := cobracli.Execute(rootCmd,
+		cobracli.ProvideParam(func(ctx context.Context) (*testConfig, error) {
+			configCalls++
+			return &testConfig{URL: "https://example.com"}, nil
+		}),
+		cobracli.ProvideParam(func(ctx context.Context) (*testClient, error) {
+			cfg, err := cobracli.Resolve[*testConfig](ctx)
+			if err != nil {
+				return nil, err
+			}
+			return &testClient{URL: cfg.URL}, nil
+		}),
+	)
+	require.Equal(t, 0, rv)
+	assert.Equal(t, &testClient{URL: "https://example.com"}, gotClient)
+	assert.Equal(t, 1, configCalls)
+	assert.EqualError(t, gotErr, "no provider registered for string")
+
+	// values are memoized per invocation
+	rv = cobracli.Execute(rootCmd,
+		cobracli.ProvideParam(func(ctx context.Context) (*testConfig, error) {
+			configCalls++
+			return &testConfig{URL: "https://example.com"}, nil
+		}),
+		cobracli.ProvideValueParam(&testClient{URL: "https://example.com"}),
+	)
+	require.Equal(t, 0, rv)
+	assert.Equal(t, 2, configCalls)
+}
+
+func TestResolveErrors(t *testing.T) {
+	_, err := cobracli.Resolve[*testConfig](context.Background())
+	assert.EqualError(t, err, "no provider registered for *cobracli_test.testConfig")
+
+	var gotErr error
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, gotErr = cobracli.Resolve[*testConfig](cobracli.Context(cmd))
+			return gotErr
+		},
+	}
+	rootCmd.SetArgs([]string{})
+	rootCmd.SilenceErrors = true
+	rv := cobracli.Execute(rootCmd, cobracli.ProvideParam(func(ctx context.Context) (*testConfig, error) {
+		return nil, fmt.Errorf("config file not found")
+	}))
+	assert.Equal(t, 1, rv)
+	assert.EqualError(t, gotErr, "failed to provide *cobracli_test.testConfig: config file not found")
+}
+
+func TestResolveNilInterface(t *testing.T) {
+	var gotErr error
+	var gotWriter io.Writer
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		Run: func(cmd *cobra.Command, args []string) {
+			gotWriter, gotErr = cobracli.Resolve[io.Writer](cobracli.Context(cmd))
+		},
+	}
+	rv := cobracli.Execute(rootCmd, cobracli.ArgsParam([]string{}), cobracli.ProvideValueParam[io.Writer](nil))
+	require.Equal(t, 0, rv)
+	require.NoError(t, gotErr)
+	assert.Nil(t, gotWriter)
+}
+
+func TestResolveCyclicProviders(t *testing.T) {
+	type first struct{}
+	type second struct{}
+
+	var gotErr error
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, gotErr = cobracli.Resolve[*first](cobracli.Context(cmd))
+			return gotErr
+		},
+	}
+	rootCmd.SilenceErrors = true
+	rv := cobracli.Execute(rootCmd,
+		cobracli.ArgsParam([]string{}),
+		cobracli.ProvideParam(func(ctx context.Context) (*first, error) {
+			_, err := cobracli.Resolve[*second](ctx)
+			return &first{}, err
+		}),
+		cobracli.ProvideParam(func(ctx context.Context) (*second, error) {
+			_, err := cobracli.Resolve[*first](ctx)
+			return &second{}, err
+		}),
+	)
+	assert.Equal(t, 1, rv)
+	require.Error(t, gotErr)
+	assert.Contains(t, gotErr.Error(), "depends on itself")
+	assert.Contains(t, gotErr.Error(), "first -> ")
+}
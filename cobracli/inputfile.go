@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/flagtypes"
+	"github.com/palantir/pkg/safejson"
+	"github.com/palantir/pkg/safeyaml"
+)
+
+// InputFormat is the format of structured input read by DecodeInput.
+type InputFormat string
+
+const (
+	// InputFormatAuto detects the format of the input: input with a ".json" extension is JSON and input with a ".yml"
+	// or ".yaml" extension is YAML. Otherwise, input whose first non-whitespace character is "{" or "[" is JSON and
+	// other input is YAML.
+	InputFormatAuto InputFormat = "auto"
+	// InputFormatJSON decodes the input as JSON.
+	InputFormatJSON InputFormat = "json"
+	// InputFormatYAML decodes the input as YAML.
+	InputFormatYAML InputFormat = "yaml"
+)
+
+// maxInputSize is the maximum size of the input read by DecodeInput. It matches the maximum size of YAML documents
+// accepted by safeyaml.
+var maxInputSize = int64(safeyaml.DefaultLimits.MaxBytes)
+
+// InputFile is the source of the structured input of a command as specified by the flags added by AddInputFileFlags.
+// It implements the common "apply -f" pattern consistently across commands.
+type InputFile struct {
+	// Path is the path of the input file (see DecodeInput).
+	Path string
+	// Format is the format of the input.
+	Format InputFormat
+}
+
+// AddInputFileFlags adds the "--filename" (shorthand "-f") and "--input-format" flags to the provided command and
+// returns the InputFile that is populated by the flags.
+func AddInputFileFlags(cmd *cobra.Command) *InputFile {
+	i := &InputFile{}
+	cmd.Flags().StringVarP(&i.Path, "filename", "f", "", `file that contains the input ("-" for stdin)`)
+	format := (*string)(&i.Format)
+	flagtypes.EnumVar(cmd.Flags(), format, "input-format", string(InputFormatAuto), "format of the input",
+		string(InputFormatAuto), string(InputFormatJSON), string(InputFormatYAML))
+	return i
+}
+
+// Decode decodes the input into the provided value using DecodeInput. Returns an error if Path is empty.
+func (i *InputFile) Decode(v interface{}) error {
+	if i.Path == "" {
+		return fmt.Errorf("input file must be specified")
+	}
+	return DecodeInput(i.Path, i.Format, v)
+}
+
+// DecodeInput reads the input specified by the provided argument and decodes it into the provided value. The argument
+// is the path of a file, which may be prefixed with "@" (as in "@config.yml"), or StdioPath to read from standard
+// input. YAML input is converted to JSON before it is decoded, so the "json" tags of structs are used for both formats.
+// An empty format is treated as InputFormatAuto.
+func DecodeInput(arg string, format InputFormat, v interface{}) error {
+	return decodeInput(arg, format, v, os.Stdin)
+}
+
+func decodeInput(arg string, format InputFormat, v interface{}, stdin io.Reader) error {
+	path := strings.TrimPrefix(arg, "@")
+	name := path
+	if path == StdioPath {
+		name = "standard input"
+	}
+	b, err := readInput(path, maxInputSize, stdin)
+	if err != nil {
+		return err
+	}
+
+	if format == "" || format == InputFormatAuto {
+		format = detectInputFormat(path, b)
+	}
+	switch format {
+	case InputFormatJSON:
+	case InputFormatYAML:
+		if b, err = safeyaml.YAMLtoJSONBytes(b); err != nil {
+			return fmt.Errorf("failed to parse YAML from %s: %v", name, err)
+		}
+	default:
+		return fmt.Errorf("invalid input format %q", format)
+	}
+	if err := safejson.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %v", name, err)
+	}
+	return nil
+}
+
+func detectInputFormat(path string, content []byte) InputFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return InputFormatJSON
+	case ".yml", ".yaml":
+		return InputFormatYAML
+	}
+	if trimmed := bytes.TrimLeft(content, " \t\r\n"); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return InputFormatJSON
+	}
+	return InputFormatYAML
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testInput struct {
+	Name     string   `json:"name"`
+	Replicas int      `json:"replicas"`
+	Tags     []string `json:"tags"`
+}
+
+func TestDecodeInput(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	const (
+		jsonContent = `{"name": "svc", "replicas": 2, "tags": ["a"]}`
+		yamlContent = "name: svc\nreplicas: 2\ntags:\n- a\n"
+	)
+	files := map[string]string{
+		"input.json":  jsonContent,
+		"input.yml":   yamlContent,
+		"input.txt":   yamlContent,
+		"invalid.yml": "name: [",
+	}
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644))
+	}
+	want := testInput{Name: "svc", Replicas: 2, Tags: []string{"a"}}
+
+	for i, tc := range []struct {
+		arg     string
+		format  InputFormat
+		stdin   string
+		wantErr string
+	}{
+		{filepath.Join(tmpDir, "input.json"), InputFormatAuto, "", ""},
+		{"@" + filepath.Join(tmpDir, "input.yml"), InputFormatAuto, "", ""},
+		{filepath.Join(tmpDir, "input.txt"), "", "", ""},
+		// JSON is valid YAML
+		{filepath.Join(tmpDir, "input.json"), InputFormatYAML, "", ""},
+		{StdioPath, InputFormatAuto, jsonContent, ""},
+		{StdioPath, InputFormatAuto, yamlContent, ""},
+		{StdioPath, InputFormatJSON, yamlContent, "failed to decode standard input: "},
+		{filepath.Join(tmpDir, "invalid.yml"), InputFormatAuto, "", "failed to parse YAML from " + filepath.Join(tmpDir, "invalid.yml") + ": "},
+		{StdioPath, "xml", jsonContent, `invalid input format "xml"`},
+	} {
+		var got testInput
+		err := decodeInput(tc.arg, tc.format, &got, strings.NewReader(tc.stdin))
+		if tc.wantErr != "" {
+			require.Error(t, err, "Case %d", i)
+			assert.True(t, strings.HasPrefix(err.Error(), tc.wantErr), "Case %d: unexpected error: %v", i, err)
+			continue
+		}
+		require.NoError(t, err, "Case %d", i)
+		assert.Equal(t, want, got, "Case %d", i)
+	}
+}
+
+func TestInputFileFlags(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	path := filepath.Join(tmpDir, "input")
+	require.NoError(t, ioutil.WriteFile(path, []byte("name: svc\n"), 0644))
+
+	for i, tc := range []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"-f", path}, ""},
+		{[]string{"--filename", path, "--input-format", "yaml"}, ""},
+		{nil, "input file must be specified"},
+		{[]string{"-f", path, "--input-format", "json"}, "failed to decode " + path + ": "},
+	} {
+		var got testInput
+		var gotErr error
+		cmd := &cobra.Command{
+			Use: "apply",
+		}
+		input := AddInputFileFlags(cmd)
+		cmd.Run = func(cmd *cobra.Command, args []string) {
+			gotErr = input.Decode(&got)
+		}
+		cmd.SetArgs(tc.args)
+		require.NoError(t, cmd.Execute(), "Case %d", i)
+		if tc.wantErr != "" {
+			require.Error(t, gotErr, "Case %d", i)
+			assert.True(t, strings.HasPrefix(gotErr.Error(), tc.wantErr), "Case %d: unexpected error: %v", i, gotErr)
+			continue
+		}
+		require.NoError(t, gotErr, "Case %d", i)
+		assert.Equal(t, testInput{Name: "svc"}, got, "Case %d", i)
+	}
+}
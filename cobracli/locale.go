@@ -0,0 +1,45 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/locale"
+)
+
+// LocaleParam returns a Param that adds the "--locale" persistent flag and that populates the provided locale before
+// a command is run. If the flag is specified, its value is used as the name of the locale (for example, "de-DE" or
+// "de_DE.UTF-8"; "C" selects the stable C locale for machine-readable output). Otherwise, the locale is detected from
+// the environment using locale.Detect. Running a command returns an error if the flag specifies an unknown locale. If
+// deterministic output is enabled (see DeterministicParam), locale.C is always used.
+func LocaleParam(l *locale.Locale) Param {
+	var localeFlag string
+	return multiParam(
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			cmd.PersistentFlags().StringVar(&localeFlag, "locale", "", `locale used to format numbers and dates (default from LC_ALL, LC_NUMERIC or LANG; "C" for machine-readable output)`)
+		}),
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				if IsDeterministic(cmd) {
+					*l = locale.C
+					return next(cmd, args)
+				}
+				if localeFlag == "" {
+					*l = locale.Detect()
+					return next(cmd, args)
+				}
+				flagLocale, ok := locale.Lookup(localeFlag)
+				if !ok {
+					return fmt.Errorf("unknown locale %q", localeFlag)
+				}
+				*l = flagLocale
+				return next(cmd, args)
+			}
+		}),
+	)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+	"github.com/palantir/pkg/locale"
+)
+
+func TestLocaleParam(t *testing.T) {
+	for _, name := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		if val, ok := os.LookupEnv(name); ok {
+			require.NoError(t, os.Unsetenv(name))
+			defer func(name, val string) {
+				_ = os.Setenv(name, val)
+			}(name, val)
+		}
+	}
+	require.NoError(t, os.Setenv("LANG", "fr_FR.UTF-8"))
+	defer func() {
+		_ = os.Unsetenv("LANG")
+	}()
+
+	for i, tc := range []struct {
+		args    []string
+		wantRV  int
+		wantTag string
+		wantOut string
+	}{
+		{nil, 0, "fr-FR", ""},
+		{[]string{"--locale", "C"}, 0, "C", ""},
+		{[]string{"--locale", "de_DE.UTF-8"}, 0, "de-DE", ""},
+		{[]string{"--locale", "xx"}, 1, "", "Error: unknown locale \"xx\"\n"},
+	} {
+		var got locale.Locale
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			Run: func(cmd *cobra.Command, args []string) {},
+		}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+		rv := cobracli.Execute(rootCmd, cobracli.LocaleParam(&got))
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantTag, got.Tag, "Case %d", i)
+		assert.True(t, strings.HasPrefix(buf.String(), tc.wantOut), "Case %d: %s", i, buf.String())
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/flagtypes"
+)
+
+type logLevel int
+
+const (
+	logLevelDebug logLevel = iota
+	logLevelInfo
+	logLevelWarn
+	logLevelError
+)
+
+var (
+	logLevels = map[string]logLevel{
+		"debug":   logLevelDebug,
+		"info":    logLevelInfo,
+		"warn":    logLevelWarn,
+		"warning": logLevelWarn,
+		"error":   logLevelError,
+		"fatal":   logLevelError,
+	}
+	logLevelColors = map[logLevel]string{
+		logLevelDebug: "\x1b[2m",
+		logLevelWarn:  "\x1b[33m",
+		logLevelError: "\x1b[31m",
+	}
+	logLevelRegexp = regexp.MustCompile(`(?i)^\[?(debug|info|warn|warning|error|fatal)\b`)
+)
+
+// logsPollInterval is the interval at which log files are checked for new content when following them.
+var logsPollInterval = 250 * time.Millisecond
+
+// LogsCmdParam returns a Param that adds the command returned by LogsCmd to the root command.
+func LogsCmdParam(logFiles ...string) Param {
+	return ConfigureCmdParam(func(cmd *cobra.Command) {
+		cmd.AddCommand(LogsCmd(logFiles...))
+	})
+}
+
+// LogsCmd returns a "logs" command that prints the content of the provided log files of the application, such as the
+// files written using the "--log-file" flag of LogFileParam or by a daemon. Lines are expected to start with an RFC
+// 3339 timestamp (as written by LogFileParam): the lines of all of the files are printed in the order of their
+// timestamps, and lines without a timestamp (such as the continuation lines of stack traces) are kept with the line
+// that precedes them. Files that do not exist are ignored.
+//
+// The level of a line is determined from the first word of the message (for example, "ERROR", "[warn]" or "Error:");
+// lines without a level are treated as "info". The command has the following flags:
+//
+//   - "--follow" (-f): after printing the existing lines, wait for new lines to be written and print them.
+//   - "--since": only print lines written after the specified time, which is either a duration relative to the current
+//     time (for example, "1h") or an RFC 3339 timestamp.
+//   - "--level": only print lines with the specified level or a more severe level.
+//
+// Lines are colorized by level if the output is a terminal that supports ANSI escape sequences.
+func LogsCmd(logFiles ...string) *cobra.Command {
+	var (
+		follow bool
+		since  string
+		level  string
+	)
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Print the log files of the application",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter := logFilter{
+				minLevel: logLevels[level],
+			}
+			if since != "" {
+				sinceTime, err := parseSince(since, time.Now())
+				if err != nil {
+					return err
+				}
+				filter.since = sinceTime
+			}
+			out := cmd.OutOrStdout()
+			printer := &logPrinter{
+				w:        out,
+				colorize: ColorEnabled(cmd, out),
+			}
+
+			offsets := make([]int64, len(logFiles))
+			var lines []logLine
+			for i, logFile := range logFiles {
+				content, err := ioutil.ReadFile(logFile)
+				if err != nil && !os.IsNotExist(err) {
+					return fmt.Errorf("failed to read log file: %v", err)
+				}
+				if follow {
+					// when following, a partial last line is printed once it is complete
+					content = content[:bytes.LastIndexByte(content, '\n')+1]
+				}
+				offsets[i] = int64(len(content))
+				lines = append(lines, filter.apply(parseLogLines(content))...)
+			}
+			sort.SliceStable(lines, func(i, j int) bool {
+				return lines[i].time.Before(lines[j].time)
+			})
+			if err := printer.print(lines); err != nil {
+				return err
+			}
+			if !follow {
+				return nil
+			}
+			return followLogs(cmd, logFiles, offsets, filter, printer)
+		},
+	}
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "wait for new log lines and print them as they are written")
+	cmd.Flags().StringVar(&since, "since", "", `only print lines written after this time (a duration such as "1h" or an RFC 3339 timestamp)`)
+	flagtypes.EnumVar(cmd.Flags(), &level, "level", "debug", "only print lines with this level or a more severe level", "debug", "info", "warn", "error")
+	return cmd
+}
+
+// followLogs prints lines that are appended to the provided log files after the provided offsets until the execution
+// context of the command is done. A file whose size is smaller than its offset is assumed to have been truncated or
+// rotated and is read from the beginning.
+func followLogs(cmd *cobra.Command, logFiles []string, offsets []int64, filter logFilter, printer *logPrinter) error {
+	ctx := Context(cmd)
+	partial := make([][]byte, len(logFiles))
+	ticker := time.NewTicker(logsPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		for i, logFile := range logFiles {
+			content, err := readFrom(logFile, &offsets[i])
+			if err != nil {
+				return err
+			}
+			content = append(partial[i], content...)
+			idx := bytes.LastIndexByte(content, '\n')
+			partial[i] = append([]byte(nil), content[idx+1:]...)
+			if idx == -1 {
+				continue
+			}
+			if err := printer.print(filter.apply(parseLogLines(content[:idx+1]))); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func readFrom(path string, offset *int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log file: %v", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log file: %v", err)
+	}
+	if fi.Size() < *offset {
+		*offset = 0
+	}
+	if _, err := f.Seek(*offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to read log file: %v", err)
+	}
+	content, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log file: %v", err)
+	}
+	*offset += int64(len(content))
+	return content, nil
+}
+
+func parseSince(since string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(since); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid value %q for --since: must be a duration or an RFC 3339 timestamp", since)
+	}
+	return t, nil
+}
+
+type logLine struct {
+	time  time.Time
+	level logLevel
+	text  string
+}
+
+// parseLogLines parses the provided content into lines. Lines without a timestamp have the time and level of the line
+// that precedes them.
+func parseLogLines(content []byte) []logLine {
+	if len(content) == 0 {
+		return nil
+	}
+	var lines []logLine
+	prev := logLine{level: logLevelInfo}
+	for _, text := range strings.Split(strings.TrimSuffix(string(content), "\n"), "\n") {
+		line := logLine{time: prev.time, level: prev.level, text: text}
+		if idx := strings.IndexByte(text, ' '); idx != -1 {
+			if t, err := time.Parse(time.RFC3339, text[:idx]); err == nil {
+				line.time = t
+				line.level = logLevelInfo
+				if m := logLevelRegexp.FindStringSubmatch(text[idx+1:]); m != nil {
+					line.level = logLevels[strings.ToLower(m[1])]
+				}
+			}
+		}
+		lines = append(lines, line)
+		prev = line
+	}
+	return lines
+}
+
+type logFilter struct {
+	since    time.Time
+	minLevel logLevel
+}
+
+func (f logFilter) apply(lines []logLine) []logLine {
+	var filtered []logLine
+	for _, line := range lines {
+		if line.level < f.minLevel || (!f.since.IsZero() && line.time.Before(f.since)) {
+			continue
+		}
+		filtered = append(filtered, line)
+	}
+	return filtered
+}
+
+type logPrinter struct {
+	w        io.Writer
+	colorize bool
+}
+
+func (p *logPrinter) print(lines []logLine) error {
+	for _, line := range lines {
+		text := line.text
+		if color, ok := logLevelColors[line.level]; ok && p.colorize {
+			text = color + text + "\x1b[0m"
+		}
+		if _, err := fmt.Fprintln(p.w, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
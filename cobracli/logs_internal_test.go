@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/fsutil"
+)
+
+func TestLogsCmd(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	appLog := filepath.Join(tmpDir, "app.log")
+	daemonLog := filepath.Join(tmpDir, "daemon.log")
+	require.NoError(t, fsutil.WriteFile(appLog, []byte(`2026-01-01T10:00:00Z starting
+2026-01-01T10:02:00Z Error: failed to connect
+	at main.go:10
+2026-01-01T10:04:00Z DEBUG retrying
+`), 0644))
+	require.NoError(t, fsutil.WriteFile(daemonLog, []byte(`2026-01-01T10:01:00Z [warn] disk almost full
+2026-01-01T10:03:00Z daemon ready
+`), 0644))
+
+	for i, tc := range []struct {
+		args       []string
+		wantOutput string
+	}{
+		{nil, `2026-01-01T10:00:00Z starting
+2026-01-01T10:01:00Z [warn] disk almost full
+2026-01-01T10:02:00Z Error: failed to connect
+	at main.go:10
+2026-01-01T10:03:00Z daemon ready
+2026-01-01T10:04:00Z DEBUG retrying
+`},
+		{[]string{"--level", "warn"}, `2026-01-01T10:01:00Z [warn] disk almost full
+2026-01-01T10:02:00Z Error: failed to connect
+	at main.go:10
+`},
+		{[]string{"--since", "2026-01-01T10:02:30Z"}, `2026-01-01T10:03:00Z daemon ready
+2026-01-01T10:04:00Z DEBUG retrying
+`},
+		{[]string{"--since", "yesterday"}, "Error: invalid value \"yesterday\" for --since: must be a duration or an RFC 3339 timestamp\n"},
+	} {
+		rootCmd := &cobra.Command{Use: "my-app"}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(append([]string{"logs"}, tc.args...))
+		Execute(rootCmd,
+			ConfigureCmdParam(SilenceErrorsConfigurer),
+			ErrorHandlerParam(ErrorPrinterWithDebugHandler(nil, nil)),
+			LogsCmdParam(appLog, daemonLog, filepath.Join(tmpDir, "missing.log")),
+		)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+	}
+}
+
+func TestLogsCmdFollow(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	origInterval := logsPollInterval
+	logsPollInterval = 10 * time.Millisecond
+	defer func() {
+		logsPollInterval = origInterval
+	}()
+
+	appLog := filepath.Join(tmpDir, "app.log")
+	require.NoError(t, fsutil.WriteFile(appLog, []byte("2026-01-01T10:00:00Z starting\n"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rootCmd := &cobra.Command{Use: "my-app"}
+	buf := &syncBuffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"logs", "--follow", "--level", "info"})
+
+	done := make(chan int)
+	go func() {
+		done <- Execute(rootCmd, ContextParam(ctx), LogsCmdParam(appLog))
+	}()
+
+	f, err := os.OpenFile(appLog, os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString("2026-01-01T10:01:00Z DEBUG hidden\n2026-01-01T10:01:00Z part")
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	_, err = f.WriteString("ial line\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	want := "2026-01-01T10:00:00Z starting\n2026-01-01T10:01:00Z partial line\n"
+	for start := time.Now(); buf.String() != want && time.Since(start) < 5*time.Second; {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	assert.Equal(t, 0, <-done)
+	assert.Equal(t, want, buf.String())
+}
+
+// syncBuffer is a bytes.Buffer that can be written and read concurrently.
+type syncBuffer struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const (
+	// OwnerAnnotation is the key of the command annotation that specifies the team that owns a command.
+	OwnerAnnotation = "cobracli_owner"
+	// StabilityAnnotation is the key of the command annotation that specifies the stability level of a command.
+	StabilityAnnotation = "cobracli_stability"
+	// DocsURLAnnotation is the key of the command annotation that specifies the URL of the documentation of a command.
+	DocsURLAnnotation = "cobracli_docs_url"
+	// RequiredScopesAnnotation is the key of the command annotation that declares the authorization scopes that are
+	// required to run a command. The value is a comma-separated list of scopes.
+	RequiredScopesAnnotation = "cobracli_required_scopes"
+)
+
+// Stability is the stability level of a command.
+type Stability string
+
+const (
+	StabilityStable       Stability = "stable"
+	StabilityBeta         Stability = "beta"
+	StabilityExperimental Stability = "experimental"
+	StabilityDeprecated   Stability = "deprecated"
+)
+
+// CommandMeta is the metadata of a command. The metadata is stored in the annotations of the command, so it is
+// available to any code that has access to the command.
+type CommandMeta struct {
+	// Owner is the team that owns the command.
+	Owner string
+	// Stability is the stability level of the command.
+	Stability Stability
+	// DocsURL is the URL of the documentation of the command.
+	DocsURL string
+	// RequiredScopes are the authorization scopes that are required to run the command.
+	RequiredScopes []string
+}
+
+// SetCommandMeta stores the non-empty fields of the provided metadata in the annotations of the provided command.
+// Fields that are empty do not modify the existing annotations of the command and required scopes are added to any
+// scopes that were previously declared.
+func SetCommandMeta(cmd *cobra.Command, meta CommandMeta) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	for key, val := range map[string]string{
+		OwnerAnnotation:     meta.Owner,
+		StabilityAnnotation: string(meta.Stability),
+		DocsURLAnnotation:   meta.DocsURL,
+	} {
+		if val != "" {
+			cmd.Annotations[key] = val
+		}
+	}
+	if len(meta.RequiredScopes) > 0 {
+		addAnnotationValues(cmd, RequiredScopesAnnotation, meta.RequiredScopes)
+	}
+}
+
+// GetCommandMeta returns the metadata of the provided command. Metadata is inherited: the owner, stability and
+// documentation URL are those of the command or of its nearest ancestor that specifies them, and the required scopes
+// are the scopes declared by the command and all of its ancestors.
+func GetCommandMeta(cmd *cobra.Command) CommandMeta {
+	return CommandMeta{
+		Owner:          inheritedAnnotation(cmd, OwnerAnnotation),
+		Stability:      Stability(inheritedAnnotation(cmd, StabilityAnnotation)),
+		DocsURL:        inheritedAnnotation(cmd, DocsURLAnnotation),
+		RequiredScopes: annotationValues(cmd, RequiredScopesAnnotation),
+	}
+}
+
+// ScopesVisibilityHook returns a hook for VisibilityHookParam that makes a command available only if all of the scopes
+// that it requires (as specified by its CommandMeta) are in the provided granted scopes.
+func ScopesVisibilityHook(granted []string) func(cmd *cobra.Command) bool {
+	grantedSet := make(map[string]struct{}, len(granted))
+	for _, scope := range granted {
+		grantedSet[scope] = struct{}{}
+	}
+	return func(cmd *cobra.Command) bool {
+		for _, scope := range GetCommandMeta(cmd).RequiredScopes {
+			if _, ok := grantedSet[scope]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// inheritedAnnotation returns the value of the annotation with the provided key on the provided command or on its
+// nearest ancestor that has a non-empty value for it.
+func inheritedAnnotation(cmd *cobra.Command, key string) string {
+	for curr := cmd; curr != nil; curr = curr.Parent() {
+		if val := curr.Annotations[key]; val != "" {
+			return val
+		}
+	}
+	return ""
+}
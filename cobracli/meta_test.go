@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestCommandMeta(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "my-app"}
+	cobracli.SetCommandMeta(rootCmd, cobracli.CommandMeta{
+		Owner:          "platform",
+		Stability:      cobracli.StabilityStable,
+		RequiredScopes: []string{"read"},
+	})
+	adminCmd := &cobra.Command{Use: "admin"}
+	cobracli.SetCommandMeta(adminCmd, cobracli.CommandMeta{
+		Stability:      cobracli.StabilityBeta,
+		DocsURL:        "https://example.com/docs/admin",
+		RequiredScopes: []string{"admin"},
+	})
+	cobracli.SetCommandMeta(adminCmd, cobracli.CommandMeta{
+		RequiredScopes: []string{"audit"},
+	})
+	resetCmd := &cobra.Command{Use: "reset"}
+	adminCmd.AddCommand(resetCmd)
+	rootCmd.AddCommand(adminCmd)
+
+	assert.Equal(t, cobracli.CommandMeta{
+		Owner:          "platform",
+		Stability:      cobracli.StabilityStable,
+		RequiredScopes: []string{"read"},
+	}, cobracli.GetCommandMeta(rootCmd))
+	assert.Equal(t, cobracli.CommandMeta{
+		Owner:          "platform",
+		Stability:      cobracli.StabilityBeta,
+		DocsURL:        "https://example.com/docs/admin",
+		RequiredScopes: []string{"read", "admin", "audit"},
+	}, cobracli.GetCommandMeta(resetCmd))
+}
+
+func TestScopesVisibilityHook(t *testing.T) {
+	for i, tc := range []struct {
+		granted    []string
+		wantRV     int
+		wantOutput string
+	}{
+		{[]string{"admin"}, 0, "reset\n"},
+		{[]string{"read"}, 1, "Error: command \"admin reset\" is disabled by policy\n"},
+	} {
+		rootCmd := &cobra.Command{Use: "my-app"}
+		adminCmd := &cobra.Command{Use: "admin"}
+		cobracli.SetCommandMeta(adminCmd, cobracli.CommandMeta{RequiredScopes: []string{"admin"}})
+		adminCmd.AddCommand(&cobra.Command{
+			Use: "reset",
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Println(cmd.Name())
+			},
+		})
+		rootCmd.AddCommand(adminCmd)
+
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs([]string{"admin", "reset"})
+
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.VisibilityHookParam(cobracli.ScopesVisibilityHook(tc.granted)))...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Mount grafts the command tree rooted at the provided command under the provided parent command with the provided
+// namespace as its name. This allows independently developed CLIs to be composed into a single umbrella binary: for
+// example, after Mount(rootCmd, "infra", infraRootCmd), the "deploy" subcommand of infraRootCmd is invoked as
+// "<root> infra deploy" and its help output shows that path.
+//
+// The name in the Use field of the mounted command is replaced with the namespace. The persistent flags of the mounted
+// command continue to apply to its subtree, and the persistent flags of the parent command and its ancestors are
+// inherited by the mounted commands. A flag of a mounted command that has the same name as an inherited flag takes
+// precedence over the inherited flag within the mounted subtree.
+//
+// Returns an error without modifying either command if the parent already has a subcommand with the name or an alias
+// equal to the namespace or if a flag of a mounted command uses the same shorthand as an inherited flag with a
+// different name (Cobra cannot resolve such conflicts). Because conflicts are only detected for flags that exist when
+// Mount is called, persistent flags should be added to the parent before commands are mounted.
+func Mount(parent *cobra.Command, namespace string, cmd *cobra.Command) error {
+	if namespace == "" || strings.ContainsAny(namespace, " \t\n") {
+		return fmt.Errorf("invalid namespace %q: must be a non-empty command name", namespace)
+	}
+	for _, child := range parent.Commands() {
+		if child.Name() == namespace || child.HasAlias(namespace) {
+			return fmt.Errorf("cannot mount command at %q: command %q already exists", namespace, child.CommandPath())
+		}
+	}
+
+	inherited := make(map[string]string)
+	for _, flags := range []*pflag.FlagSet{parent.PersistentFlags(), parent.InheritedFlags()} {
+		flags.VisitAll(func(flag *pflag.Flag) {
+			if flag.Shorthand != "" {
+				inherited[flag.Shorthand] = flag.Name
+			}
+		})
+	}
+	var conflicts []string
+	visitCommands(cmd, func(curr *cobra.Command) {
+		for _, flags := range []*pflag.FlagSet{curr.Flags(), curr.PersistentFlags()} {
+			flags.VisitAll(func(flag *pflag.Flag) {
+				if name, ok := inherited[flag.Shorthand]; ok && flag.Shorthand != "" && name != flag.Name {
+					conflicts = append(conflicts, fmt.Sprintf("-%s is used by --%s and by --%s of %q", flag.Shorthand, name, flag.Name, relativeCommandPath(curr)))
+				}
+			})
+		}
+	})
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return fmt.Errorf("cannot mount command at %q: conflicting flag shorthands: %s", namespace, strings.Join(conflicts, "; "))
+	}
+
+	if idx := strings.IndexAny(cmd.Use, " \t\n"); idx != -1 {
+		cmd.Use = namespace + cmd.Use[idx:]
+	} else {
+		cmd.Use = namespace
+	}
+	parent.AddCommand(cmd)
+	return nil
+}
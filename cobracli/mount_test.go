@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func newInfraRootCmd(region *string) *cobra.Command {
+	infraCmd := &cobra.Command{
+		Use:   "infra-cli [command]",
+		Short: "Manage infrastructure",
+	}
+	infraCmd.PersistentFlags().StringVar(region, "region", "us-east-1", "region to operate on")
+	infraCmd.AddCommand(&cobra.Command{
+		Use:   "deploy",
+		Short: "Deploy the infrastructure",
+		Run: func(cmd *cobra.Command, args []string) {
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			cmd.Printf("deploy %s verbose=%t\n", *region, verbose)
+		},
+	})
+	return infraCmd
+}
+
+func TestMount(t *testing.T) {
+	var region string
+	rootCmd := &cobra.Command{Use: "umbrella"}
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
+	require.NoError(t, cobracli.Mount(rootCmd, "infra", newInfraRootCmd(&region)))
+
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"infra", "deploy", "--region", "eu-west-1", "-v"})
+	require.Equal(t, 0, cobracli.Execute(rootCmd))
+	assert.Equal(t, "deploy eu-west-1 verbose=true\n", buf.String())
+
+	buf.Reset()
+	rootCmd.SetArgs([]string{"infra", "deploy", "--help"})
+	require.Equal(t, 0, cobracli.Execute(rootCmd))
+	assert.True(t, strings.HasPrefix(buf.String(), "Deploy the infrastructure\n\nUsage:\n  umbrella infra deploy [flags]\n"), buf.String())
+
+	buf.Reset()
+	rootCmd.SetArgs([]string{"infra", "--help"})
+	require.Equal(t, 0, cobracli.Execute(rootCmd))
+	assert.Contains(t, buf.String(), "  umbrella infra [command]\n")
+	assert.Contains(t, buf.String(), `Use "umbrella infra [command] --help" for more information about a command.`)
+}
+
+func TestMountErrors(t *testing.T) {
+	var region string
+	rootCmd := &cobra.Command{Use: "umbrella"}
+	rootCmd.PersistentFlags().StringP("profile", "r", "", "profile")
+	rootCmd.AddCommand(&cobra.Command{Use: "status", Aliases: []string{"st"}})
+
+	err := cobracli.Mount(rootCmd, "st", newInfraRootCmd(&region))
+	assert.EqualError(t, err, `cannot mount command at "st": command "umbrella status" already exists`)
+
+	infraCmd := newInfraRootCmd(&region)
+	infraCmd.Commands()[0].Flags().StringP("ref", "r", "", "ref to deploy")
+	err = cobracli.Mount(rootCmd, "infra", infraCmd)
+	assert.EqualError(t, err, `cannot mount command at "infra": conflicting flag shorthands: -r is used by --profile and by --ref of "deploy"`)
+	assert.Equal(t, "infra-cli [command]", infraCmd.Use)
+	assert.Len(t, rootCmd.Commands(), 1)
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/httpclient"
+)
+
+// NoCacheParam returns a Param that adds the "--no-cache" persistent flag. If the flag is specified, the execution
+// context of the command (see Context) is derived using httpclient.WithoutCache, so HTTP requests made with the
+// context by clients that use httpclient.NewCachingTransport bypass the response cache.
+func NoCacheParam() Param {
+	var noCache bool
+	return multiParam(
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			cmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "do not use cached responses for network requests")
+		}),
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				if !noCache {
+					return next(cmd, args)
+				}
+				restoreCtx := setContext(cmd, httpclient.WithoutCache(Context(cmd)))
+				defer restoreCtx()
+				return next(cmd, args)
+			}
+		}),
+	)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/clicache"
+	"github.com/palantir/pkg/cobracli"
+	"github.com/palantir/pkg/httpclient"
+)
+
+func TestNoCacheParam(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	cache, err := clicache.New(tmpDir)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=3600")
+	}))
+	defer server.Close()
+	client := &http.Client{
+		Transport: httpclient.NewCachingTransport(cache, nil),
+	}
+
+	for i, tc := range []struct {
+		args       []string
+		wantStatus string
+	}{
+		{nil, "miss"},
+		{nil, "hit"},
+		{[]string{"--no-cache"}, "miss"},
+	} {
+		var gotStatus string
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+				if err != nil {
+					return err
+				}
+				resp, err := client.Do(req.WithContext(cobracli.Context(cmd)))
+				if err != nil {
+					return err
+				}
+				_ = resp.Body.Close()
+				gotStatus = resp.Header.Get(httpclient.CacheStatusHeader)
+				return nil
+			},
+		}
+		rootCmd.SetArgs(append([]string{}, tc.args...))
+		require.Equal(t, 0, cobracli.Execute(rootCmd, cobracli.NoCacheParam()), "Case %d", i)
+		assert.Equal(t, tc.wantStatus, gotStatus, "Case %d", i)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// RequiresNetworkAnnotation is the key of the command annotation that declares that a command requires network
+// access. A command requires network access if it or any of its ancestors has this annotation with the value "true".
+const RequiresNetworkAnnotation = "cobracli_requires_network"
+
+// RequireNetwork sets the RequiresNetworkAnnotation on the provided command.
+func RequireNetwork(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[RequiresNetworkAnnotation] = "true"
+}
+
+// OfflineParam returns a Param that adds "--offline" as a boolean persistent flag that sets the value of the provided
+// *bool and that checks connectivity before running commands that require network access (as declared using
+// RequiresNetworkAnnotation). If such a command is run with "--offline", it fails immediately with an error that
+// states that it requires network access. Otherwise, if probe is non-nil, it is called before the command is run and,
+// if it returns an error, the command fails immediately rather than waiting for network operations to time out.
+// ReachabilityProbe can be used to create a probe. Commands that do not require network access are not affected.
+func OfflineParam(offline *bool, probe func() error) Param {
+	return multiParam(
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			cmd.PersistentFlags().BoolVar(offline, "offline", false, "run in offline mode: commands that require network access fail immediately")
+		}),
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				if !requiresNetwork(cmd) {
+					return next(cmd, args)
+				}
+				if *offline {
+					return fmt.Errorf("%q requires network access and cannot be run with --offline", cmd.CommandPath())
+				}
+				if probe != nil {
+					if err := probe(); err != nil {
+						return fmt.Errorf("%q requires network access, but the network is not reachable: %v", cmd.CommandPath(), err)
+					}
+				}
+				return next(cmd, args)
+			}
+		}),
+	)
+}
+
+// ReachabilityProbe returns a probe for OfflineParam that attempts to establish a TCP connection to the provided
+// address (of the form "host:port") and returns an error if a connection cannot be established within the provided
+// timeout.
+func ReachabilityProbe(addr string, timeout time.Duration) func() error {
+	return func() error {
+		conn, err := net.DialTimeout("tcp", addr, timeout)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+func requiresNetwork(cmd *cobra.Command) bool {
+	for curr := cmd; curr != nil; curr = curr.Parent() {
+		if curr.Annotations[RequiresNetworkAnnotation] == "true" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestOfflineParam(t *testing.T) {
+	for i, tc := range []struct {
+		args       []string
+		probeErr   error
+		wantRV     int
+		wantOutput string
+	}{
+		{[]string{"fetch"}, nil, 0, "fetch\n"},
+		{[]string{"local", "--offline"}, fmt.Errorf("unreachable"), 0, "local\n"},
+		{[]string{"fetch", "--offline"}, nil, 1, "Error: \"my-app fetch\" requires network access and cannot be run with --offline\n"},
+		{[]string{"fetch"}, fmt.Errorf("unreachable"), 1, "Error: \"my-app fetch\" requires network access, but the network is not reachable: unreachable\n"},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+		}
+		for _, name := range []string{"fetch", "local"} {
+			rootCmd.AddCommand(&cobra.Command{
+				Use: name,
+				Run: func(cmd *cobra.Command, args []string) {
+					cmd.Println(cmd.Name())
+				},
+			})
+		}
+		cobracli.RequireNetwork(rootCmd.Commands()[0])
+
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		var offline bool
+		probeErr := tc.probeErr
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.OfflineParam(&offline, func() error {
+			return probeErr
+		}))...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+	}
+}
+
+func TestReachabilityProbe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+
+	assert.NoError(t, cobracli.ReachabilityProbe(addr, time.Second)())
+	require.NoError(t, listener.Close())
+	assert.Error(t, cobracli.ReachabilityProbe(addr, time.Second)())
+}
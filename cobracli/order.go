@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// CommandGroupAnnotation is the key of the command annotation that specifies the group of a command. The group is
+// used to order commands when GroupThenAlphabeticalOrder is used and as the heading under which the command is listed
+// in help output when GroupedUsageTemplateConfigurer is used.
+const CommandGroupAnnotation = "cobracli_command_group"
+
+const (
+	// UngroupedCommandsHeading is the heading under which GroupedUsageTemplateConfigurer lists the commands that do not
+	// have a group if any sibling command has a group.
+	UngroupedCommandsHeading = "Other Commands"
+
+	commandGroupsTemplateFunc   = "cobracliCommandGroups"
+	defaultAvailableCmdsSection = `{{if .HasAvailableSubCommands}}
+
+Available Commands:{{range .Commands}}{{if (or .IsAvailableCommand (eq .Name "help"))}}
+  {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}{{end}}`
+	groupedCmdsSection = `{{if .HasAvailableSubCommands}}{{range ` + commandGroupsTemplateFunc + ` .}}
+
+{{.Heading}}:{{range .Commands}}
+  {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}{{end}}`
+)
+
+// CommandOrder specifies the order in which the subcommands of a command are listed in help output.
+type CommandOrder int
+
+const (
+	// AlphabeticalOrder lists subcommands in alphabetical order of their names.
+	AlphabeticalOrder CommandOrder = iota
+	// DeclarationOrder lists subcommands in the order in which they were added to their parent.
+	DeclarationOrder
+	// GroupThenAlphabeticalOrder lists subcommands by group (as specified by CommandGroupAnnotation) and then in
+	// alphabetical order within each group. Groups are listed in the order in which the first command of the group was
+	// added to the parent, followed by the commands that do not have a group.
+	GroupThenAlphabeticalOrder
+)
+
+// SetCommandGroup sets the CommandGroupAnnotation of the provided command to the provided group.
+func SetCommandGroup(cmd *cobra.Command, group string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[CommandGroupAnnotation] = group
+}
+
+// GroupedUsageTemplateConfigurer configures the usage template of the provided command so that its subcommands (and
+// those of its descendants that do not have their own usage template) are listed in help output under a heading for
+// each group, as specified by CommandGroupAnnotation (see SetCommandGroup):
+//
+//	Management Commands:
+//	  config      Manage configuration
+//	  plugin      Manage plugins
+//
+//	Other Commands:
+//	  help        Help about any command
+//	  version     Print the version
+//
+// Groups are listed in the order in which their first command is listed by the parent and commands are listed in the
+// order of the parent within each group, so CommandOrderParam with GroupThenAlphabeticalOrder lists commands
+// alphabetically within each group. Commands that do not have a group are listed last under UngroupedCommandsHeading,
+// or under "Available Commands" if none of the commands have a group. Has no effect if the command uses a custom usage
+// template.
+func GroupedUsageTemplateConfigurer(command *cobra.Command) {
+	cobra.AddTemplateFunc(commandGroupsTemplateFunc, commandGroups)
+	command.SetUsageTemplate(strings.Replace(command.UsageTemplate(), defaultAvailableCmdsSection, groupedCmdsSection, 1))
+}
+
+// commandGroup is a group of commands listed under a heading in help output.
+type commandGroup struct {
+	Heading  string
+	Commands []*cobra.Command
+}
+
+// commandGroups returns the available subcommands of the provided command grouped by their CommandGroupAnnotation.
+func commandGroups(cmd *cobra.Command) []commandGroup {
+	var groups []commandGroup
+	groupIdx := make(map[string]int)
+	var ungrouped []*cobra.Command
+	for _, sub := range cmd.Commands() {
+		if !sub.IsAvailableCommand() && sub.Name() != "help" {
+			continue
+		}
+		group := sub.Annotations[CommandGroupAnnotation]
+		if group == "" {
+			ungrouped = append(ungrouped, sub)
+			continue
+		}
+		idx, ok := groupIdx[group]
+		if !ok {
+			idx = len(groups)
+			groupIdx[group] = idx
+			groups = append(groups, commandGroup{Heading: group})
+		}
+		groups[idx].Commands = append(groups[idx].Commands, sub)
+	}
+	if len(ungrouped) > 0 {
+		heading := UngroupedCommandsHeading
+		if len(groups) == 0 {
+			heading = "Available Commands"
+		}
+		groups = append(groups, commandGroup{Heading: heading, Commands: ungrouped})
+	}
+	return groups
+}
+
+// CommandOrderParam returns a Param that orders the subcommands of every command in the tree using the provided order.
+//
+// Cobra sorts subcommands alphabetically the first time they are listed unless cobra.EnableCommandSorting is false.
+// Because this setting is global, this Param sets it to false while Execute runs and orders the commands itself. The
+// setting is restored when Execute returns, after which Cobra may sort the subcommands again. The declaration order of
+// the subcommands of a command is only known if the subcommands of that command have not been listed (for example, by
+// calling Commands) before Execute is called.
+func CommandOrderParam(order CommandOrder) Param {
+	return paramFunc(func(executor *executor) {
+		origSorting := cobra.EnableCommandSorting
+		cobra.EnableCommandSorting = false
+		executor.restoreFuncs = append(executor.restoreFuncs, func() {
+			cobra.EnableCommandSorting = origSorting
+		})
+		ConfigureCmdParam(func(rootCmd *cobra.Command) {
+			visitCommands(rootCmd, func(cmd *cobra.Command) {
+				orderCommands(cmd.Commands(), order)
+			})
+		}).apply(executor)
+	})
+}
+
+// orderCommands sorts the provided commands in place. The slice returned by cobra.Command.Commands is the slice used by
+// the command, so sorting it changes the order of the subcommands of the command.
+func orderCommands(cmds []*cobra.Command, order CommandOrder) {
+	switch order {
+	case AlphabeticalOrder:
+		sort.SliceStable(cmds, func(i, j int) bool {
+			return cmds[i].Name() < cmds[j].Name()
+		})
+	case GroupThenAlphabeticalOrder:
+		groupRank := make(map[string]int)
+		for _, cmd := range cmds {
+			group := cmd.Annotations[CommandGroupAnnotation]
+			if _, ok := groupRank[group]; !ok && group != "" {
+				groupRank[group] = len(groupRank)
+			}
+		}
+		rank := func(cmd *cobra.Command) int {
+			if r, ok := groupRank[cmd.Annotations[CommandGroupAnnotation]]; ok {
+				return r
+			}
+			// commands without a group are listed last
+			return len(groupRank)
+		}
+		sort.SliceStable(cmds, func(i, j int) bool {
+			if ri, rj := rank(cmds[i]), rank(cmds[j]); ri != rj {
+				return ri < rj
+			}
+			return cmds[i].Name() < cmds[j].Name()
+		})
+	}
+}
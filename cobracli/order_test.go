@@ -0,0 +1,148 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestCommandOrderParam(t *testing.T) {
+	defer func(orig bool) {
+		cobra.EnableCommandSorting = orig
+	}(cobra.EnableCommandSorting)
+
+	for i, tc := range []struct {
+		order     cobracli.CommandOrder
+		wantOrder []string
+	}{
+		{cobracli.AlphabeticalOrder, []string{"build", "clean", "deploy", "publish", "test", "version"}},
+		{cobracli.DeclarationOrder, []string{"version", "test", "build", "publish", "deploy", "clean"}},
+		{cobracli.GroupThenAlphabeticalOrder, []string{"build", "test", "deploy", "publish", "clean", "version"}},
+	} {
+		cobra.EnableCommandSorting = true
+
+		var gotOrder []string
+		rootCmd := &cobra.Command{Use: "my-app"}
+		for _, curr := range []struct {
+			name  string
+			group string
+		}{
+			{"version", ""},
+			{"test", "develop"},
+			{"build", "develop"},
+			{"publish", "release"},
+			{"deploy", "release"},
+			{"clean", ""},
+		} {
+			cmd := &cobra.Command{
+				Use: curr.name,
+				Run: func(cmd *cobra.Command, args []string) {
+					for _, sub := range cmd.Root().Commands() {
+						if sub.Name() != "help" {
+							gotOrder = append(gotOrder, sub.Name())
+						}
+					}
+				},
+			}
+			if curr.group != "" {
+				cobracli.SetCommandGroup(cmd, curr.group)
+			}
+			rootCmd.AddCommand(cmd)
+		}
+		rootCmd.SetOutput(&bytes.Buffer{})
+		rootCmd.SetArgs([]string{"version"})
+
+		rv := cobracli.Execute(rootCmd, cobracli.CommandOrderParam(tc.order))
+		assert.Equal(t, 0, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOrder, gotOrder, "Case %d", i)
+		// the global setting is restored when Execute returns
+		assert.True(t, cobra.EnableCommandSorting, "Case %d", i)
+	}
+}
+
+func TestGroupedUsageTemplateConfigurer(t *testing.T) {
+	for i, tc := range []struct {
+		name       string
+		groups     map[string]string
+		wantOutput string
+	}{
+		{
+			"grouped and ungrouped commands",
+			map[string]string{"config": "Management Commands", "plugin": "Management Commands", "build": "Build Commands"},
+			`Usage:
+  my-app [command]
+
+Build Commands:
+  build       Build the project
+
+Management Commands:
+  config      Manage configuration
+  plugin      Manage plugins
+
+Other Commands:
+  help        Help about any command
+  version     Print the version
+
+Flags:
+  -h, --help   help for my-app
+
+Use "my-app [command] --help" for more information about a command.
+`,
+		},
+		{
+			"no groups",
+			nil,
+			`Usage:
+  my-app [command]
+
+Available Commands:
+  build       Build the project
+  config      Manage configuration
+  help        Help about any command
+  plugin      Manage plugins
+  version     Print the version
+
+Flags:
+  -h, --help   help for my-app
+
+Use "my-app [command] --help" for more information about a command.
+`,
+		},
+	} {
+		rootCmd := &cobra.Command{Use: "my-app"}
+		for _, curr := range []struct {
+			name  string
+			short string
+		}{
+			{"version", "Print the version"},
+			{"plugin", "Manage plugins"},
+			{"config", "Manage configuration"},
+			{"build", "Build the project"},
+		} {
+			cmd := &cobra.Command{
+				Use:   curr.name,
+				Short: curr.short,
+				Run:   func(cmd *cobra.Command, args []string) {},
+			}
+			if group := tc.groups[curr.name]; group != "" {
+				cobracli.SetCommandGroup(cmd, group)
+			}
+			rootCmd.AddCommand(cmd)
+		}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs([]string{"--help"})
+
+		rv := cobracli.Execute(rootCmd, cobracli.ConfigureCmdParam(cobracli.GroupedUsageTemplateConfigurer))
+		assert.Equal(t, 0, rv, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d: %s", i, tc.name)
+	}
+}
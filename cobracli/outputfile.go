@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/atomicfile"
+)
+
+// OutputFile is the destination of the output of a command as specified by the flags added by AddOutputFileFlags. It
+// implements the common "--output-file" pattern consistently across commands.
+type OutputFile struct {
+	// Path is the path of the output file. If "-", output is written to the output of the command (stdout).
+	Path string
+	// Force specifies whether an existing file at Path may be overwritten.
+	Force bool
+	// CreateDirs specifies whether the parent directories of Path should be created if they do not exist.
+	CreateDirs bool
+}
+
+// AddOutputFileFlags adds the "--output-file", "--force" and "--create-dirs" flags to the provided command and returns
+// the OutputFile that is populated by the flags. The default value of "--output-file" is the provided value: use "-"
+// to write to stdout by default.
+func AddOutputFileFlags(cmd *cobra.Command, defaultPath string) *OutputFile {
+	o := &OutputFile{}
+	cmd.Flags().StringVar(&o.Path, "output-file", defaultPath, `file to which output is written ("-" for stdout)`)
+	cmd.Flags().BoolVar(&o.Force, "force", false, "overwrite the output file if it already exists")
+	cmd.Flags().BoolVar(&o.CreateDirs, "create-dirs", false, "create the parent directories of the output file if they do not exist")
+	return o
+}
+
+// Write calls the provided function to write output to the destination. If Path is "-", the function writes directly
+// to the output of the provided command. Otherwise, the output is written to the file atomically: the file is only
+// created or replaced if the function returns nil. Returns an error without calling the function if the file exists
+// and Force is false.
+func (o *OutputFile) Write(cmd *cobra.Command, write func(w io.Writer) error) error {
+	if o.Path == "-" {
+		return write(cmd.OutOrStdout())
+	}
+	if o.Path == "" {
+		return fmt.Errorf("output file must be specified")
+	}
+
+	if !o.Force {
+		if _, err := os.Stat(o.Path); err == nil {
+			return fmt.Errorf("%s already exists: use --force to overwrite it", o.Path)
+		}
+	}
+	if o.CreateDirs {
+		if err := os.MkdirAll(filepath.Dir(o.Path), 0755); err != nil {
+			return fmt.Errorf("failed to create parent directories of %s: %v", o.Path, err)
+		}
+	}
+
+	f, err := atomicfile.Create(o.Path, 0644)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		_ = f.Abort()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteBytes writes the provided data to the destination using Write.
+func (o *OutputFile) WriteBytes(cmd *cobra.Command, data []byte) error {
+	return o.Write(cmd, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestOutputFile(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	existing := filepath.Join(tmpDir, "existing.txt")
+	require.NoError(t, ioutil.WriteFile(existing, []byte("old"), 0644))
+
+	for i, tc := range []struct {
+		args       []string
+		wantOutput string
+		wantFile   string
+		wantErr    string
+	}{
+		{[]string{"--output-file", "-"}, "report", "", ""},
+		{[]string{"--output-file", filepath.Join(tmpDir, "new.txt")}, "", filepath.Join(tmpDir, "new.txt"), ""},
+		{[]string{"--output-file", existing}, "", "", fmt.Sprintf("%s already exists: use --force to overwrite it", existing)},
+		{[]string{"--output-file", existing, "--force"}, "", existing, ""},
+		{[]string{"--output-file", filepath.Join(tmpDir, "a", "b", "c.txt"), "--create-dirs"}, "", filepath.Join(tmpDir, "a", "b", "c.txt"), ""},
+	} {
+		var outputFile *cobracli.OutputFile
+		cmd := &cobra.Command{
+			Use:           "report",
+			SilenceErrors: true,
+			SilenceUsage:  true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return outputFile.WriteBytes(cmd, []byte("report"))
+			},
+		}
+		outputFile = cobracli.AddOutputFileFlags(cmd, "-")
+		buf := &bytes.Buffer{}
+		cmd.SetOutput(buf)
+		cmd.SetArgs(tc.args)
+
+		err := cmd.Execute()
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d", i)
+			continue
+		}
+		require.NoError(t, err, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+		if tc.wantFile != "" {
+			content, err := ioutil.ReadFile(tc.wantFile)
+			require.NoError(t, err, "Case %d", i)
+			assert.Equal(t, "report", string(content), "Case %d", i)
+		}
+	}
+}
+
+func TestOutputFileNotWrittenOnError(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	outputFile := &cobracli.OutputFile{
+		Path: filepath.Join(tmpDir, "out.txt"),
+	}
+	err = outputFile.Write(&cobra.Command{}, func(w io.Writer) error {
+		_, _ = w.Write([]byte("partial"))
+		return fmt.Errorf("failed")
+	})
+	assert.EqualError(t, err, "failed")
+	infos, err := ioutil.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Empty(t, infos)
+}
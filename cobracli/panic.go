@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/fsutil"
+)
+
+// PanicExitCode is the exit code of a *PanicError. It matches the exit code used by the Go runtime when a program
+// terminates because of an unrecovered panic.
+const PanicExitCode = 2
+
+// PanicError is the error returned for a command that panicked when RecoverPanicsParam is used.
+type PanicError struct {
+	// Value is the value provided to panic.
+	Value interface{}
+	// Stack is the stack trace of the goroutine that panicked.
+	Stack []byte
+	// ReportPath is the path of the crash report that was written for the panic. Empty if no crash report was written.
+	ReportPath string
+}
+
+// Error returns a message that includes the panic value. If a crash report was written, the message includes its path;
+// otherwise, it includes the stack trace.
+func (e *PanicError) Error() string {
+	if e.ReportPath != "" {
+		return fmt.Sprintf("panic: %v (crash report written to %s)", e.Value, e.ReportPath)
+	}
+	return fmt.Sprintf("panic: %v\n\n%s", e.Value, strings.TrimRight(string(e.Stack), "\n"))
+}
+
+// ExitCode returns PanicExitCode.
+func (e *PanicError) ExitCode() int {
+	return PanicExitCode
+}
+
+// RecoverPanicsParam returns a Param that recovers panics that occur while a command runs and returns them as a
+// *PanicError, so that they are processed by the error handlers and exit code extractors in the same manner as other
+// errors. If crashReportDir is non-empty, a crash report that contains the command, arguments, runtime information and
+// stack trace is written to a new file in that directory. Failure to write the report does not prevent the error from
+// being returned. If collectors are registered using EnvironmentParam, the information that they collect is included
+// in the report. Only panics in the Run and RunE functions of commands are recovered. This Param should be provided
+// before other Params that decorate the execution of commands so that panics in those decorators are recovered as well.
+func RecoverPanicsParam(crashReportDir string) Param {
+	return paramFunc(func(executor *executor) {
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) (rErr error) {
+				defer func() {
+					r := recover()
+					if r == nil {
+						return
+					}
+					panicErr := &PanicError{
+						Value: r,
+						Stack: debug.Stack(),
+					}
+					if crashReportDir != "" {
+						if path, err := writeCrashReport(crashReportDir, cmd, args, panicErr, executor.environmentCollectors, time.Now()); err == nil {
+							panicErr.ReportPath = path
+						}
+					}
+					rErr = panicErr
+				}()
+				return next(cmd, args)
+			}
+		}).apply(executor)
+	})
+}
+
+func writeCrashReport(dir string, cmd *cobra.Command, args []string, panicErr *PanicError, environmentCollectors []EnvironmentCollector, now time.Time) (string, error) {
+	if err := fsutil.MkdirAll(dir, fsutil.SecretDirMode); err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	_, _ = fmt.Fprintf(buf, "Time: %s\n", now.UTC().Format(time.RFC3339))
+	_, _ = fmt.Fprintf(buf, "Command: %s\n", cmd.CommandPath())
+	_, _ = fmt.Fprintf(buf, "Arguments: %q\n", args)
+	if version := cmd.Root().Version; version != "" {
+		_, _ = fmt.Fprintf(buf, "Version: %s\n", version)
+	}
+	_, _ = fmt.Fprintf(buf, "Go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	if len(environmentCollectors) > 0 {
+		_, _ = fmt.Fprint(buf, "\nEnvironment:\n")
+		_ = WriteEnvironment(buf, cmd, environmentCollectors...)
+	}
+	_, _ = fmt.Fprintf(buf, "\npanic: %v\n\n%s", panicErr.Value, panicErr.Stack)
+
+	path := filepath.Join(dir, fmt.Sprintf("crash-%s-%s-%d.txt", cmd.Root().Name(), now.UTC().Format("20060102T150405Z"), os.Getpid()))
+	if err := fsutil.WriteFile(path, buf.Bytes(), fsutil.SecretFileMode); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestRecoverPanicsParam(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	for i, tc := range []struct {
+		crashReportDir string
+	}{
+		{""},
+		{filepath.Join(tmpDir, "crashes")},
+	} {
+		var gotErr error
+		rootCmd := &cobra.Command{
+			Use:     "my-app",
+			Version: "1.0.0",
+		}
+		rootCmd.AddCommand(&cobra.Command{
+			Use: "boom",
+			Run: func(cmd *cobra.Command, args []string) {
+				panic("something went wrong")
+			},
+		})
+		rootCmd.SetArgs([]string{"boom", "arg"})
+		rootCmd.SilenceErrors = true
+		rootCmd.SilenceUsage = true
+
+		rv := cobracli.Execute(rootCmd, cobracli.RecoverPanicsParam(tc.crashReportDir), cobracli.ErrorHandlerParam(func(cmd *cobra.Command, err error) {
+			gotErr = err
+		}))
+		assert.Equal(t, cobracli.PanicExitCode, rv, "Case %d", i)
+
+		panicErr, ok := gotErr.(*cobracli.PanicError)
+		require.True(t, ok, "Case %d: expected *PanicError, was %T", i, gotErr)
+		assert.Equal(t, "something went wrong", panicErr.Value, "Case %d", i)
+		assert.Contains(t, string(panicErr.Stack), "TestRecoverPanicsParam", "Case %d", i)
+
+		if tc.crashReportDir == "" {
+			assert.Empty(t, panicErr.ReportPath, "Case %d", i)
+			assert.Regexp(t, `(?s)^panic: something went wrong\n\ngoroutine .+TestRecoverPanicsParam`, gotErr.Error(), "Case %d", i)
+			continue
+		}
+		assert.Equal(t, tc.crashReportDir, filepath.Dir(panicErr.ReportPath), "Case %d", i)
+		assert.Equal(t, "panic: something went wrong (crash report written to "+panicErr.ReportPath+")", gotErr.Error(), "Case %d", i)
+		report, err := ioutil.ReadFile(panicErr.ReportPath)
+		require.NoError(t, err, "Case %d", i)
+		assert.Regexp(t, `(?s)^Time: .+\nCommand: my-app boom\nArguments: \["arg"\]\nVersion: 1.0.0\nGo: .+\n\npanic: something went wrong\n\ngoroutine .+TestRecoverPanicsParam`, string(report), "Case %d", i)
+	}
+}
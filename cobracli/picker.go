@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/console"
+)
+
+// maxPickerMatches is the maximum number of matches shown by the command picker.
+const maxPickerMatches = 20
+
+// InteractivePickerParam returns a Param that makes it such that running the root command without any arguments when
+// standard input and standard output are terminals opens an interactive picker for the commands in the command tree
+// rather than printing help. The picker prompts for a search query, lists the runnable commands (with their short
+// descriptions) that match the query using fuzzy matching and runs the command that is selected. Entering an empty
+// query lists all commands, and closing standard input (Ctrl-D) exits without running a command.
+//
+// The command is selected before the root command is executed and the selected command is then run as if its path had
+// been provided as the arguments, so the persistent pre-runs and decorators of the command run once as usual. The
+// arguments are provided using ArgsParam or are those of the process (see ArgsParam).
+//
+// Has no effect if the root command is runnable, since running it without arguments already has a meaning, or if
+// standard input or standard output is not a terminal, in which case help is printed as usual.
+func InteractivePickerParam() Param {
+	return pickerParam(os.Stdin, func() bool {
+		return console.IsTerminal(os.Stdin) && console.IsTerminal(os.Stdout)
+	})
+}
+
+func pickerParam(in io.Reader, interactive func() bool) Param {
+	return paramFunc(func(executor *executor) {
+		executor.argsRewriters = append(executor.argsRewriters, func(rootCmd *cobra.Command, args []string) ([]string, bool, error) {
+			if rootCmd.Runnable() || len(args) > 0 || !interactive() {
+				return args, true, nil
+			}
+			selected, err := pickCommand(in, rootCmd.OutOrStdout(), rootCmd)
+			if err != nil || selected == nil {
+				return args, false, err
+			}
+			return strings.Fields(relativeCommandPath(selected)), true, nil
+		})
+	})
+}
+
+type pickerCandidate struct {
+	cmd  *cobra.Command
+	path string
+}
+
+// pickCommand prompts for queries read from in and writes the matching commands to out until a command is selected.
+// Returns nil if in is exhausted before a command is selected.
+func pickCommand(in io.Reader, out io.Writer, rootCmd *cobra.Command) (*cobra.Command, error) {
+	var candidates []pickerCandidate
+	visitCommands(rootCmd, func(cmd *cobra.Command) {
+		if cmd == rootCmd || !cmd.Runnable() || !cmd.IsAvailableCommand() {
+			return
+		}
+		for curr := cmd; curr != rootCmd; curr = curr.Parent() {
+			if curr.Hidden || curr.Deprecated != "" {
+				return
+			}
+		}
+		candidates = append(candidates, pickerCandidate{cmd: cmd, path: relativeCommandPath(cmd)})
+	})
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no commands are available")
+	}
+
+	scanner := bufio.NewScanner(in)
+	var matches []pickerCandidate
+	_, _ = fmt.Fprint(out, "Search commands (empty to list all): ")
+	for scanner.Scan() {
+		input := strings.TrimSpace(scanner.Text())
+		if idx, err := strconv.Atoi(input); err == nil && len(matches) > 0 {
+			if idx >= 1 && idx <= len(matches) {
+				return matches[idx-1].cmd, nil
+			}
+			_, _ = fmt.Fprintf(out, "%d is not a valid selection\n", idx)
+		} else {
+			matches = matchCandidates(candidates, input)
+			if len(matches) == 0 {
+				_, _ = fmt.Fprintf(out, "No commands match %q\n", input)
+			}
+			printMatches(out, matches)
+		}
+		if len(matches) == 0 {
+			_, _ = fmt.Fprint(out, "Search commands (empty to list all): ")
+			continue
+		}
+		_, _ = fmt.Fprintf(out, "Select a command [1-%d] or enter a new search: ", len(matches))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	_, _ = fmt.Fprintln(out)
+	return nil, nil
+}
+
+func printMatches(out io.Writer, matches []pickerCandidate) {
+	width := 0
+	for _, match := range matches {
+		if len(match.path) > width {
+			width = len(match.path)
+		}
+	}
+	for i, match := range matches {
+		_, _ = fmt.Fprintf(out, "%3d) %-*s  %s\n", i+1, width, match.path, match.cmd.Short)
+	}
+}
+
+// matchCandidates returns the candidates that match the provided query ordered from the best to the worst match.
+// Matches on the command path are preferred over matches on the description. At most maxPickerMatches candidates are
+// returned.
+func matchCandidates(candidates []pickerCandidate, query string) []pickerCandidate {
+	type scored struct {
+		pickerCandidate
+		score int
+	}
+	var matches []scored
+	for _, candidate := range candidates {
+		score, ok := fuzzyScore(query, candidate.path)
+		if ok {
+			score *= 2
+		} else if score, ok = fuzzyScore(query, candidate.path+" "+candidate.cmd.Short); !ok {
+			continue
+		}
+		matches = append(matches, scored{pickerCandidate: candidate, score: score})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].path < matches[j].path
+	})
+	if len(matches) > maxPickerMatches {
+		matches = matches[:maxPickerMatches]
+	}
+	result := make([]pickerCandidate, len(matches))
+	for i, match := range matches {
+		result[i] = match.pickerCandidate
+	}
+	return result
+}
+
+// fuzzyScore returns whether all of the characters of the query (ignoring whitespace) appear in the target in order
+// (ignoring case) and a score for the match. Characters that match consecutively or at the start of a word score
+// higher, so "ss" scores higher for "server start" than for "status".
+func fuzzyScore(query, target string) (int, bool) {
+	queryRunes := []rune(strings.ToLower(strings.Join(strings.Fields(query), "")))
+	targetRunes := []rune(strings.ToLower(target))
+	score := 0
+	prevMatch := -2
+	qi := 0
+	for ti := 0; ti < len(targetRunes) && qi < len(queryRunes); ti++ {
+		if targetRunes[ti] != queryRunes[qi] {
+			continue
+		}
+		score++
+		if ti == prevMatch+1 {
+			score += 5
+		}
+		if ti == 0 || !unicode.IsLetter(targetRunes[ti-1]) && !unicode.IsDigit(targetRunes[ti-1]) {
+			score += 10
+		}
+		prevMatch = ti
+		qi++
+	}
+	return score, qi == len(queryRunes)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	for i, tc := range []struct {
+		query     string
+		target    string
+		wantMatch bool
+	}{
+		{"", "server start", true},
+		{"ss", "server start", true},
+		{"srvstrt", "server start", true},
+		{"Server S", "server start", true},
+		{"sst", "server start", true},
+		{"xyz", "server start", false},
+		{"startserver", "server start", false},
+	} {
+		_, ok := fuzzyScore(tc.query, tc.target)
+		assert.Equal(t, tc.wantMatch, ok, "Case %d", i)
+	}
+
+	startScore, _ := fuzzyScore("ss", "server start")
+	statusScore, _ := fuzzyScore("ss", "status")
+	assert.True(t, startScore > statusScore, "expected word-start matches to score higher: %d <= %d", startScore, statusScore)
+}
+
+func TestPickerParam(t *testing.T) {
+	for i, tc := range []struct {
+		input       string
+		interactive bool
+		wantOutput  string
+		wantPreRuns int
+	}{
+		{"stp\n1\n", true, `Search commands (empty to list all):   1) server stop  Stop the server
+Select a command [1-1] or enter a new search: ran server stop
+`, 1},
+		{"\n5\n3\n", true, `Search commands (empty to list all):   1) client        Run the client
+  2) server start  Start the server
+  3) server stop   Stop the server
+Select a command [1-3] or enter a new search: 5 is not a valid selection
+Select a command [1-3] or enter a new search: ran server stop
+`, 1},
+		{"xyz\n", true, `Search commands (empty to list all): No commands match "xyz"
+Search commands (empty to list all): 
+`, 0},
+		{"", false, `Usage:
+  my-app [command]
+
+Available Commands:
+  client      Run the client
+  help        Help about any command
+  server      
+
+Flags:
+  -h, --help   help for my-app
+
+Use "my-app [command] --help" for more information about a command.
+`, 0},
+	} {
+		preRuns := 0
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			PersistentPreRun: func(cmd *cobra.Command, args []string) {
+				preRuns++
+			},
+		}
+		serverCmd := &cobra.Command{Use: "server"}
+		for _, name := range []string{"Start", "Stop"} {
+			serverCmd.AddCommand(&cobra.Command{
+				Use:   strings.ToLower(name),
+				Short: name + " the server",
+				Run: func(cmd *cobra.Command, args []string) {
+					cmd.Println("ran", cmd.Parent().Name(), cmd.Name())
+				},
+			})
+		}
+		rootCmd.AddCommand(serverCmd, &cobra.Command{
+			Use:   "client",
+			Short: "Run the client",
+			Run:   func(cmd *cobra.Command, args []string) {},
+		}, &cobra.Command{
+			Use:    "hidden",
+			Hidden: true,
+			Run:    func(cmd *cobra.Command, args []string) {},
+		})
+
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+
+		interactive := tc.interactive
+		rv := Execute(rootCmd, ArgsParam([]string{}), pickerParam(strings.NewReader(tc.input), func() bool { return interactive }))
+		assert.Equal(t, 0, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+		assert.Equal(t, tc.wantPreRuns, preRuns, "Case %d", i)
+		assert.False(t, rootCmd.Runnable(), "Case %d", i)
+	}
+}
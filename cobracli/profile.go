@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+
+	"github.com/spf13/cobra"
+)
+
+// ProfileParam returns a Param that adds the hidden "--cpuprofile", "--memprofile" and "--trace" persistent flags. If
+// they are specified, a CPU profile (see pprof.StartCPUProfile) and an execution trace (see trace.Start) of the command
+// are written to the specified paths while the command runs and a heap profile (see pprof.WriteHeapProfile) is written
+// when it returns. The profiles are written even if the command returns an error: in that case, errors that occur
+// while writing them are written to the status writer (see Status) rather than returned. The profiles can be analyzed
+// using "go tool pprof" and "go tool trace".
+func ProfileParam() Param {
+	var cpuProfilePath, memProfilePath, tracePath string
+	return multiParam(
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			cmd.PersistentFlags().StringVar(&cpuProfilePath, "cpuprofile", "", "write a CPU profile to the specified file")
+			cmd.PersistentFlags().StringVar(&memProfilePath, "memprofile", "", "write a heap profile to the specified file")
+			cmd.PersistentFlags().StringVar(&tracePath, "trace", "", "write an execution trace to the specified file")
+			for _, name := range []string{"cpuprofile", "memprofile", "trace"} {
+				_ = cmd.PersistentFlags().MarkHidden(name)
+			}
+		}),
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				var stops []func() error
+				stopAll := func() error {
+					var firstErr error
+					for i := len(stops) - 1; i >= 0; i-- {
+						if err := stops[i](); err != nil && firstErr == nil {
+							firstErr = err
+						}
+					}
+					return firstErr
+				}
+				if cpuProfilePath != "" {
+					stop, err := startCPUProfile(cpuProfilePath)
+					if err != nil {
+						_ = stopAll()
+						return err
+					}
+					stops = append(stops, stop)
+				}
+				if tracePath != "" {
+					stop, err := startTrace(tracePath)
+					if err != nil {
+						_ = stopAll()
+						return err
+					}
+					stops = append(stops, stop)
+				}
+
+				runErr := next(cmd, args)
+
+				profileErr := stopAll()
+				if memProfilePath != "" {
+					if err := writeHeapProfile(memProfilePath); err != nil && profileErr == nil {
+						profileErr = err
+					}
+				}
+				if runErr != nil {
+					if profileErr != nil {
+						_, _ = fmt.Fprintf(Status(cmd), "Failed to write profile: %v\n", profileErr)
+					}
+					return runErr
+				}
+				return profileErr
+			}
+		}),
+	)
+}
+
+func startCPUProfile(path string) (stop func() error, rErr error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CPU profile: %v", err)
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to start CPU profile: %v", err)
+	}
+	return func() error {
+		pprof.StopCPUProfile()
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write CPU profile: %v", err)
+		}
+		return nil
+	}, nil
+}
+
+func startTrace(path string) (stop func() error, rErr error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create execution trace: %v", err)
+	}
+	if err := trace.Start(f); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to start execution trace: %v", err)
+	}
+	return func() error {
+		trace.Stop()
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write execution trace: %v", err)
+		}
+		return nil
+	}, nil
+}
+
+func writeHeapProfile(path string) (rErr error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create heap profile: %v", err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil && rErr == nil {
+			rErr = fmt.Errorf("failed to write heap profile: %v", err)
+		}
+	}()
+	// run a garbage collection so that the profile reflects the live heap
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		return fmt.Errorf("failed to write heap profile: %v", err)
+	}
+	return nil
+}
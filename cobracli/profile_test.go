@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestProfileParam(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	for i, tc := range []struct {
+		name   string
+		fail   bool
+		wantRV int
+	}{
+		{"success", false, 0},
+		{"error", true, 1},
+	} {
+		caseDir := filepath.Join(tmpDir, fmt.Sprint(i))
+		require.NoError(t, os.Mkdir(caseDir, 0755), "Case %d: %s", i, tc.name)
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if tc.fail {
+					return fmt.Errorf("failed")
+				}
+				return nil
+			},
+		}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs([]string{
+			"--cpuprofile", filepath.Join(caseDir, "cpu.pprof"),
+			"--memprofile", filepath.Join(caseDir, "mem.pprof"),
+			"--trace", filepath.Join(caseDir, "trace.out"),
+		})
+
+		rv := cobracli.Execute(rootCmd, cobracli.ProfileParam())
+		assert.Equal(t, tc.wantRV, rv, "Case %d: %s", i, tc.name)
+		for _, name := range []string{"cpu.pprof", "mem.pprof", "trace.out"} {
+			fi, err := os.Stat(filepath.Join(caseDir, name))
+			require.NoError(t, err, "Case %d: %s", i, tc.name)
+			assert.NotZero(t, fi.Size(), "Case %d: %s: %s is empty", i, tc.name, name)
+		}
+	}
+}
+
+func TestProfileParamFlagsHidden(t *testing.T) {
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		Run: func(cmd *cobra.Command, args []string) {},
+	}
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"--help"})
+
+	rv := cobracli.Execute(rootCmd, cobracli.ProfileParam())
+	assert.Equal(t, 0, rv)
+	assert.NotContains(t, buf.String(), "profile")
+	assert.NotContains(t, buf.String(), "trace")
+}
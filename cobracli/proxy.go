@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/httpclient"
+)
+
+// ProxyParam returns a Param that adds the "--proxy" and "--no-proxy" persistent flags and that populates the provided
+// configuration before a command is run. The configuration is based on the HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+// environment variables (see httpclient.ProxyConfigFromEnvironment):
+//
+//   - If "--proxy" is specified, its value is used as the proxy URL instead of the value from the environment.
+//   - If "--no-proxy" is specified, its value is a comma-separated list of hosts that is added to the hosts specified
+//     by NO_PROXY. The value "*" disables the proxy for all hosts.
+//
+// The Credentials field of the provided configuration is preserved, so it can be set before Execute is called to
+// provide credentials for authenticated proxies. Commands should configure their HTTP clients using the populated
+// configuration (for example, using httpclient.ConfigureProxy).
+func ProxyParam(cfg *httpclient.ProxyConfig) Param {
+	var proxyFlag, noProxyFlag string
+	return multiParam(
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			cmd.PersistentFlags().StringVar(&proxyFlag, "proxy", "", "URL of the proxy to use for network requests (overrides HTTPS_PROXY)")
+			cmd.PersistentFlags().StringVar(&noProxyFlag, "no-proxy", "", `comma-separated hosts for which the proxy is not used (added to NO_PROXY), or "*" for all hosts`)
+		}),
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				envCfg := httpclient.ProxyConfigFromEnvironment()
+				cfg.URL = envCfg.URL
+				if proxyFlag != "" {
+					cfg.URL = proxyFlag
+				}
+				var noProxy []string
+				for _, v := range []string{envCfg.NoProxy, noProxyFlag} {
+					if v != "" {
+						noProxy = append(noProxy, v)
+					}
+				}
+				cfg.NoProxy = strings.Join(noProxy, ",")
+				return next(cmd, args)
+			}
+		}),
+	)
+}
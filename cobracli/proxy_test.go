@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+	"github.com/palantir/pkg/httpclient"
+)
+
+func TestProxyParam(t *testing.T) {
+	for _, name := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "NO_PROXY", "no_proxy"} {
+		if val, ok := os.LookupEnv(name); ok {
+			require.NoError(t, os.Unsetenv(name))
+			defer func(name, val string) {
+				_ = os.Setenv(name, val)
+			}(name, val)
+		}
+	}
+	require.NoError(t, os.Setenv("HTTPS_PROXY", "env-proxy:3128"))
+	require.NoError(t, os.Setenv("NO_PROXY", "localhost"))
+	defer func() {
+		_ = os.Unsetenv("HTTPS_PROXY")
+		_ = os.Unsetenv("NO_PROXY")
+	}()
+
+	for i, tc := range []struct {
+		args []string
+		want httpclient.ProxyConfig
+	}{
+		{nil, httpclient.ProxyConfig{URL: "env-proxy:3128", NoProxy: "localhost"}},
+		{[]string{"--proxy", "flag-proxy:8080", "--no-proxy", "example.com"}, httpclient.ProxyConfig{URL: "flag-proxy:8080", NoProxy: "localhost,example.com"}},
+	} {
+		var got httpclient.ProxyConfig
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			Run: func(cmd *cobra.Command, args []string) {},
+		}
+		rootCmd.SetArgs(tc.args)
+		rv := cobracli.Execute(rootCmd, cobracli.ProxyParam(&got))
+		assert.Equal(t, 0, rv, "Case %d", i)
+		assert.Equal(t, tc.want, got, "Case %d", i)
+	}
+}
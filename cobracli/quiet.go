@@ -0,0 +1,66 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+)
+
+const quietValueName = "quiet"
+
+// QuietFlagParam returns a Param that adds the "--quiet" ("-q") persistent flag. If the flag is specified, the status
+// writer (see Status) discards everything that is written to it while the command runs, IsQuiet returns true and
+// writers returned by QuietWriter discard their output. Errors are still printed by the error handlers, so a quiet
+// command only produces output when it fails (and its primary output, which commands write to cmd.OutOrStdout).
+// Status output is still written to the log file written by LogFileParam.
+func QuietFlagParam() Param {
+	var quiet bool
+	return multiParam(
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			cmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "suppress informational output")
+		}),
+		// applied outside of LogFileParam so that it only discards the status output written to the terminal
+		stagedRunEDecoratorParam(stageFirst, func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				if !quiet {
+					return next(cmd, args)
+				}
+				restoreQuiet := setInvocationValue(cmd, quietValueName, true)
+				defer restoreQuiet()
+				restoreStatus := setStatus(cmd, ioutil.Discard)
+				defer restoreStatus()
+				return next(cmd, args)
+			}
+		}),
+	)
+}
+
+// IsQuiet returns true if the running command was invoked with the "--quiet" flag (see QuietFlagParam).
+func IsQuiet(cmd *cobra.Command) bool {
+	_, ok := invocationValue(cmd, quietValueName)
+	return ok
+}
+
+// QuietWriter returns a writer for informational output that writes to the provided writer unless the provided command
+// is running quietly (see IsQuiet), in which case the output is discarded. Whether the command is running quietly is
+// determined for every write, so the writer can be created before the command runs.
+func QuietWriter(cmd *cobra.Command, w io.Writer) io.Writer {
+	return &quietWriter{cmd: cmd, w: w}
+}
+
+type quietWriter struct {
+	cmd *cobra.Command
+	w   io.Writer
+}
+
+func (q *quietWriter) Write(p []byte) (int, error) {
+	if IsQuiet(q.cmd) {
+		return len(p), nil
+	}
+	return q.w.Write(p)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestQuietFlagParam(t *testing.T) {
+	for i, tc := range []struct {
+		name       string
+		args       []string
+		wantRV     int
+		wantOutput string
+	}{
+		{"not quiet", nil, 0, "status\ninfo\noutput\n"},
+		{"quiet", []string{"--quiet"}, 0, "quiet: true\noutput\n"},
+		{"quiet shorthand", []string{"-q"}, 0, "quiet: true\noutput\n"},
+		{"quiet with error", []string{"-q", "--fail"}, 1, "quiet: true\noutput\nError: failed\n"},
+	} {
+		var fail bool
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if cobracli.IsQuiet(cmd) {
+					_, _ = fmt.Fprintln(cmd.OutOrStdout(), "quiet: true")
+				}
+				_, _ = fmt.Fprintln(cobracli.Status(cmd), "status")
+				_, _ = fmt.Fprintln(cobracli.QuietWriter(cmd, cmd.OutOrStdout()), "info")
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "output")
+				if fail {
+					return fmt.Errorf("failed")
+				}
+				return nil
+			},
+		}
+		rootCmd.Flags().BoolVar(&fail, "fail", false, "")
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.QuietFlagParam())...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d: %s", i, tc.name)
+	}
+}
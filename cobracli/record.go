@@ -0,0 +1,398 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/palantir/pkg/safejson"
+)
+
+const recordFlagName = "record"
+
+var (
+	// recordNow returns the current time. It is a variable so that it can be replaced in tests.
+	recordNow = time.Now
+	// recordStdinIsPiped returns true if standard input should be recorded. It is a variable so that it can be
+	// replaced in tests.
+	recordStdinIsPiped = StdinIsPiped
+	// replayExecutable returns the path of the executable that is run by the replay command. It is a variable so that
+	// it can be replaced in tests.
+	replayExecutable = os.Executable
+)
+
+// replayEnvVars are the environment variables that are passed to invocations replayed by ReplayCmd in addition to the
+// recorded variables: the variables that are needed to find and run executables.
+var replayEnvVars = []string{"PATH", "SYSTEMROOT", "TMPDIR"}
+
+// Recording is a recorded invocation of a command created using the "--record" flag added by RecordParam.
+type Recording struct {
+	// Args are the arguments of the invocation, excluding the "--record" flag.
+	Args []string `json:"args"`
+	// Env are the values of the recorded environment variables that were set.
+	Env map[string]string `json:"env,omitempty"`
+	// Stdin is the content of standard input if it was piped to the command.
+	Stdin []byte `json:"stdin,omitempty"`
+	// Stdout is the output of the command.
+	Stdout string `json:"stdout"`
+	// Status is the status output of the command (see Status).
+	Status string `json:"status"`
+	// Error is the message of the error returned by the command, if any.
+	Error string `json:"error,omitempty"`
+	// ExitCode is the exit code of the invocation.
+	ExitCode int `json:"exitCode"`
+	// RecordedAt is the time at which the invocation completed.
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// ReadRecording reads the recording in the file at the provided path.
+func ReadRecording(path string) (Recording, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Recording{}, fmt.Errorf("failed to read recording: %v", err)
+	}
+	var recording Recording
+	if err := safejson.Unmarshal(b, &recording); err != nil {
+		return Recording{}, fmt.Errorf("failed to parse recording %s: %v", path, err)
+	}
+	return recording, nil
+}
+
+// RecordParam returns a Param that makes invocations reproducible so that bug reports can include everything that is
+// needed to reproduce them. It adds a "--record" persistent flag that specifies the path of a file to which the
+// invocation is recorded (see Recording): its arguments (see ArgsParam), the values of the provided environment
+// variables, standard input (if it is piped), the output and status output of the command and its exit code. It also
+// adds the hidden developer command returned by ReplayCmd, which re-runs a recording.
+//
+// Sensitive values are redacted before the recording is written. The values of the flags and environment variables
+// whose names match DefaultRedactedEnvVars or any of the provided sensitive patterns (which use the syntax of
+// path.Match and are matched case-insensitively, as for EnvVarsCollector) are recorded as RedactedValue. Flags are
+// matched by their long names (for example, "api-key" for "--api-key"). If RedactOutputParam is provided, its redactor
+// is also applied to the arguments, the values of the environment variables and standard input. Invocations whose
+// recorded values are redacted may not be reproduced when they are replayed.
+//
+// If standard input is piped, it is read in full (up to the maximum size supported by ReadInput) before the command
+// is run and is then provided to the command from a temporary file. While the root command is executed, its output is
+// replaced with a recording writer using SetOutput, so commands should write status output using Status rather than
+// cmd.OutOrStderr. The whole execution is recorded, including failures to parse flags and errors returned by pre-run
+// functions, and the recorded exit code is the one returned by Execute once the error handlers have run.
+func RecordParam(envVars, sensitive []string) Param {
+	sensitive = append(append([]string{}, DefaultRedactedEnvVars...), sensitive...)
+	var recordPath string
+	var recording *Recording
+	var stdout, status *bytes.Buffer
+	return paramFunc(func(executor *executor) {
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			// the flag is parsed by Cobra so that it is accepted by every command, but its value is determined from the
+			// arguments before the root command is executed so that failures to parse flags are also recorded
+			cmd.PersistentFlags().String(recordFlagName, "", "record the invocation to the specified file so that it can be replayed")
+			cmd.AddCommand(ReplayCmd())
+		}).apply(executor)
+		executor.invocationHooks = append(executor.invocationHooks, func(rootCmd *cobra.Command) func() {
+			args := executor.invocationArgs()
+			var ok bool
+			if recordPath, ok = recordFlagValue(args); !ok || recordPath == "" {
+				recording = nil
+				return func() {}
+			}
+			recording = &Recording{
+				Args: redactSensitiveFlags(rootCmd, withoutRecordFlag(args), sensitive),
+			}
+			for _, name := range envVars {
+				if val, ok := os.LookupEnv(name); ok {
+					if recording.Env == nil {
+						recording.Env = make(map[string]string)
+					}
+					if matchesEnvVarPattern(name, sensitive) {
+						val = RedactedValue
+					}
+					recording.Env[name] = val
+				}
+			}
+			var restores []func()
+			if recordStdinIsPiped() {
+				stdin, err := readInput(StdioPath, maxInputSize, os.Stdin)
+				if err == nil {
+					var restoreStdin func()
+					if restoreStdin, err = replaceStdin(stdin); err == nil {
+						restores = append(restores, restoreStdin)
+						recording.Stdin = stdin
+					}
+				}
+				if err != nil {
+					_, _ = fmt.Fprintf(Status(rootCmd), "Warning: failed to record standard input: %v\n", err)
+				}
+			}
+
+			// the output of every command that has its own output is recorded (see setTreeOutput)
+			stdout, status = &bytes.Buffer{}, &bytes.Buffer{}
+			restores = append(restores, setStatus(rootCmd, io.MultiWriter(Status(rootCmd), status)))
+			visitCommands(rootCmd, func(cmd *cobra.Command) {
+				if _, ok := cmdOutput(cmd); ok || cmd == rootCmd {
+					restores = append(restores, setOutput(cmd, io.MultiWriter(cmd.OutOrStdout(), stdout)))
+				}
+			})
+			return func() {
+				for i := len(restores) - 1; i >= 0; i-- {
+					restores[i]()
+				}
+			}
+		})
+		// the output is recorded before the error handlers print the error, but the exit code is only known once they
+		// have run
+		executor.completionHandlers = append(executor.completionHandlers, func(executedCmd *cobra.Command, err error) {
+			if recording == nil {
+				return
+			}
+			recording.Stdout = stdout.String()
+			recording.Status = status.String()
+			if err != nil {
+				recording.Error = err.Error()
+			}
+		})
+		executor.exitHandlers = append(executor.exitHandlers, func(executedCmd *cobra.Command, err error, exitCode int) {
+			if recording == nil {
+				return
+			}
+			recording.ExitCode = exitCode
+			recording.RecordedAt = recordNow().UTC()
+			if executor.redactor != nil {
+				redactRecording(recording, executor.redactor)
+			}
+			if writeErr := writeRecording(recordPath, *recording); writeErr != nil {
+				_, _ = fmt.Fprintf(executedCmd.OutOrStderr(), "Warning: %v\n", writeErr)
+				return
+			}
+			_, _ = fmt.Fprintf(executedCmd.OutOrStderr(), "Recorded invocation to %s\n", recordPath)
+		})
+	})
+}
+
+// ReplayCmd returns a hidden "replay" developer command that re-runs the invocation recorded in the provided file (see
+// RecordParam) in a sandbox: the current executable is run with the recorded arguments, environment variables and
+// standard input in a temporary working directory that is also used as the home directory. Apart from the recorded
+// environment variables, the environment of the replayed invocation only contains the variables that are needed to run
+// executables (see replayEnvVars), so that it does not depend on the environment of the user. The output of the
+// replayed invocation is written to the output of the command and its status output is written to the status writer.
+// Returns an error if the output or exit code of the replayed invocation differ from the recording.
+func ReplayCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:    "replay <recording>",
+		Short:  "Replay an invocation recorded using --record",
+		Args:   cobra.ExactArgs(1),
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			recording, err := ReadRecording(args[0])
+			if err != nil {
+				return err
+			}
+			executable, err := replayExecutable()
+			if err != nil {
+				return fmt.Errorf("failed to determine executable: %v", err)
+			}
+			sandboxDir, err := ioutil.TempDir("", cmd.Root().Name()+"-replay-")
+			if err != nil {
+				return fmt.Errorf("failed to create sandbox directory: %v", err)
+			}
+			defer func() {
+				_ = os.RemoveAll(sandboxDir)
+			}()
+
+			_, _ = fmt.Fprintf(Status(cmd), "Replaying %q recorded at %s\n", strings.Join(append([]string{cmd.Root().Name()}, recording.Args...), " "), recording.RecordedAt.Format(time.RFC3339))
+			stdout := &bytes.Buffer{}
+			replayCmd := exec.Command(executable, recording.Args...)
+			replayCmd.Dir = sandboxDir
+			replayCmd.Env = []string{"HOME=" + sandboxDir}
+			for _, name := range replayEnvVars {
+				if val, ok := os.LookupEnv(name); ok {
+					replayCmd.Env = append(replayCmd.Env, name+"="+val)
+				}
+			}
+			for name, val := range recording.Env {
+				replayCmd.Env = append(replayCmd.Env, name+"="+val)
+			}
+			replayCmd.Stdin = bytes.NewReader(recording.Stdin)
+			// the output and status output of the replayed invocation are copied concurrently and may share a writer
+			mutex := &sync.Mutex{}
+			replayCmd.Stdout = &lockedWriter{mutex: mutex, w: io.MultiWriter(cmd.OutOrStdout(), stdout)}
+			replayCmd.Stderr = &lockedWriter{mutex: mutex, w: Status(cmd)}
+			exitCode := 0
+			if err := replayCmd.Run(); err != nil {
+				exitErr, ok := err.(*exec.ExitError)
+				if !ok {
+					return fmt.Errorf("failed to replay recording: %v", err)
+				}
+				exitCode = exitErr.ExitCode()
+			}
+
+			var diffs []string
+			if exitCode != recording.ExitCode {
+				diffs = append(diffs, fmt.Sprintf("exit code is %d, but the recorded exit code is %d", exitCode, recording.ExitCode))
+			}
+			if stdout.String() != recording.Stdout {
+				diffs = append(diffs, "output differs from the recorded output")
+			}
+			if len(diffs) > 0 {
+				return fmt.Errorf("replay did not reproduce the recording:\n  %s", strings.Join(diffs, "\n  "))
+			}
+			_, _ = fmt.Fprintln(Status(cmd), "Replay reproduced the recorded output and exit code")
+			return nil
+		},
+	}
+}
+
+// lockedWriter is a writer that holds a mutex while writing to the underlying writer.
+type lockedWriter struct {
+	mutex *sync.Mutex
+	w     io.Writer
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.w.Write(p)
+}
+
+func writeRecording(path string, recording Recording) error {
+	b, err := safejson.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal recording: %v", err)
+	}
+	if err := ioutil.WriteFile(path, append(b, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write recording: %v", err)
+	}
+	return nil
+}
+
+// recordFlagValue returns the value of the "--record" flag in the provided arguments and true, or false if the flag is
+// not specified. If the flag is specified more than once, the last value is used.
+func recordFlagValue(args []string) (string, bool) {
+	var val string
+	var ok bool
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--":
+			return val, ok
+		case args[i] == "--"+recordFlagName && i+1 < len(args):
+			i++
+			val, ok = args[i], true
+		case strings.HasPrefix(args[i], "--"+recordFlagName+"="):
+			val, ok = strings.TrimPrefix(args[i], "--"+recordFlagName+"="), true
+		}
+	}
+	return val, ok
+}
+
+// withoutRecordFlag returns the provided arguments with the "--record" flag and its value removed.
+func withoutRecordFlag(args []string) []string {
+	var out []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--":
+			return append(out, args[i:]...)
+		case args[i] == "--"+recordFlagName:
+			i++
+		case strings.HasPrefix(args[i], "--"+recordFlagName+"="):
+		default:
+			out = append(out, args[i])
+		}
+	}
+	return out
+}
+
+// redactSensitiveFlags returns the provided arguments with the values of the flags whose names match any of the
+// provided patterns replaced with RedactedValue. The value of a flag is either part of its argument ("--name=value") or
+// the argument that follows it ("--name value") unless a flag with the name in the tree rooted at the provided command
+// does not require a value (such as a boolean flag).
+func redactSensitiveFlags(rootCmd *cobra.Command, args, patterns []string) []string {
+	out := append([]string{}, args...)
+	for i := 0; i < len(out); i++ {
+		if out[i] == "--" {
+			break
+		}
+		if !strings.HasPrefix(out[i], "--") {
+			continue
+		}
+		name := strings.TrimPrefix(out[i], "--")
+		if idx := strings.IndexByte(name, '='); idx != -1 {
+			if matchesEnvVarPattern(name[:idx], patterns) {
+				out[i] = "--" + name[:idx] + "=" + RedactedValue
+			}
+			continue
+		}
+		if matchesEnvVarPattern(name, patterns) && flagRequiresValue(rootCmd, name) && i+1 < len(out) {
+			i++
+			out[i] = RedactedValue
+		}
+	}
+	return out
+}
+
+// flagRequiresValue returns false if a flag with the provided name in the tree rooted at the provided command can be
+// specified without a value, and true otherwise.
+func flagRequiresValue(rootCmd *cobra.Command, name string) bool {
+	requiresValue := true
+	visitCommands(rootCmd, func(cmd *cobra.Command) {
+		for _, flags := range []*pflag.FlagSet{cmd.PersistentFlags(), cmd.LocalNonPersistentFlags()} {
+			if f := flags.Lookup(name); f != nil && f.NoOptDefVal != "" {
+				requiresValue = false
+			}
+		}
+	})
+	return requiresValue
+}
+
+// redactRecording redacts the arguments, the values of the environment variables and the standard input of the
+// provided recording using the provided redactor.
+func redactRecording(recording *Recording, redactor *Redactor) {
+	for i, arg := range recording.Args {
+		recording.Args[i] = string(redactor.Redact([]byte(arg)))
+	}
+	for name, val := range recording.Env {
+		recording.Env[name] = string(redactor.Redact([]byte(val)))
+	}
+	if recording.Stdin != nil {
+		recording.Stdin = redactor.Redact(recording.Stdin)
+	}
+}
+
+// replaceStdin replaces os.Stdin with a temporary file that contains the provided content and returns a function that
+// restores it.
+func replaceStdin(content []byte) (restore func(), rErr error) {
+	f, err := ioutil.TempFile("", "stdin-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rErr != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+	if _, err := f.Write(content); err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	origStdin := os.Stdin
+	os.Stdin = f
+	return func() {
+		os.Stdin = origStdin
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}, nil
+}
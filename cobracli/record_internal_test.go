@@ -0,0 +1,203 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordParam(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a shell script as an executable")
+	}
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	origRecordNow, origStdinIsPiped, origReplayExecutable, origStdin := recordNow, recordStdinIsPiped, replayExecutable, os.Stdin
+	defer func() {
+		recordNow, recordStdinIsPiped, replayExecutable, os.Stdin = origRecordNow, origStdinIsPiped, origReplayExecutable, origStdin
+	}()
+	recordNow = func() time.Time {
+		return time.Date(2026, 1, 2, 3, 4, 5, 0, time.Local)
+	}
+	recordStdinIsPiped = func() bool {
+		return true
+	}
+	stdinFile := filepath.Join(tmpDir, "stdin.txt")
+	require.NoError(t, ioutil.WriteFile(stdinFile, []byte("hello"), 0644))
+	os.Stdin, err = os.Open(stdinFile)
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Stdin.Close()
+	}()
+	require.NoError(t, os.Setenv("COBRACLI_TEST_RECORD", "env-value"))
+	defer func() {
+		_ = os.Unsetenv("COBRACLI_TEST_RECORD")
+	}()
+
+	newRootCmd := func() *cobra.Command {
+		rootCmd := &cobra.Command{Use: "my-app"}
+		rootCmd.AddCommand(&cobra.Command{
+			Use: "greet",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				input, err := ReadInput(StdioPath, 0)
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintln(Status(cmd), "Greeting...")
+				cmd.Printf("%s %s %s\n", input, args[0], os.Getenv("COBRACLI_TEST_RECORD"))
+				return NewExitCodeError(3, fmt.Errorf("greeting failed"))
+			},
+		})
+		return rootCmd
+	}
+
+	recordingPath := filepath.Join(tmpDir, "recording.json")
+	rootCmd := newRootCmd()
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rv := Execute(rootCmd, append(DefaultParams(nil), ArgsParam([]string{"greet", "--record", recordingPath, "world"}), RecordParam([]string{"COBRACLI_TEST_RECORD", "COBRACLI_TEST_UNSET"}, nil))...)
+	assert.Equal(t, 3, rv)
+	assert.Equal(t, "Greeting...\nhello world env-value\nError: greeting failed\nRecorded invocation to "+recordingPath+"\n", buf.String())
+
+	recording, err := ReadRecording(recordingPath)
+	require.NoError(t, err)
+	assert.Equal(t, Recording{
+		Args:       []string{"greet", "world"},
+		Env:        map[string]string{"COBRACLI_TEST_RECORD": "env-value"},
+		Stdin:      []byte("hello"),
+		Stdout:     "hello world env-value\n",
+		Status:     "Greeting...\n",
+		Error:      "greeting failed",
+		ExitCode:   3,
+		RecordedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.Local).UTC(),
+	}, recording)
+
+	// failures to parse flags are recorded
+	parseErrPath := filepath.Join(tmpDir, "parse-error.json")
+	rootCmd = newRootCmd()
+	rootCmd.SetOutput(&bytes.Buffer{})
+	rv = Execute(rootCmd, append(DefaultParams(nil), ArgsParam([]string{"greet", "--record=" + parseErrPath, "--unknown"}), RecordParam(nil, nil))...)
+	assert.Equal(t, 1, rv)
+	recording, err = ReadRecording(parseErrPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"greet", "--unknown"}, recording.Args)
+	assert.Equal(t, "unknown flag: --unknown", recording.Error)
+	assert.Equal(t, 1, recording.ExitCode)
+
+	for i, tc := range []struct {
+		script     string
+		wantRV     int
+		wantOutput string
+	}{
+		{
+			"#!/bin/sh\necho Greeting... >&2\necho \"$(cat) $2 $COBRACLI_TEST_RECORD\"\nexit 3\n",
+			0,
+			"Replaying \"my-app greet world\" recorded at 2026-01-02T03:04:05Z\n" +
+				"Greeting...\n" +
+				"hello world env-value\n" +
+				"Replay reproduced the recorded output and exit code\n",
+		},
+		{
+			"#!/bin/sh\necho \"$(cat) $2\"\n",
+			1,
+			"Replaying \"my-app greet world\" recorded at 2026-01-02T03:04:05Z\n" +
+				"hello world\n" +
+				"Error: replay did not reproduce the recording:\n" +
+				"  exit code is 0, but the recorded exit code is 3\n" +
+				"  output differs from the recorded output\n",
+		},
+	} {
+		executable := filepath.Join(tmpDir, fmt.Sprintf("my-app-%d", i))
+		require.NoError(t, ioutil.WriteFile(executable, []byte(tc.script), 0755))
+		replayExecutable = func() (string, error) {
+			return executable, nil
+		}
+
+		rootCmd := newRootCmd()
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rv := Execute(rootCmd, append(DefaultParams(nil), ArgsParam([]string{"replay", recordingPath}), RecordParam(nil, nil))...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		// the output and status output of the replayed invocation are copied from separate pipes, so the order in which
+		// their lines are written is not deterministic
+		assert.ElementsMatch(t, strings.Split(tc.wantOutput, "\n"), strings.Split(buf.String(), "\n"), "Case %d", i)
+	}
+}
+
+func TestRecordParamRedactsSensitiveValues(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	origStdinIsPiped, origStdin := recordStdinIsPiped, os.Stdin
+	defer func() {
+		recordStdinIsPiped, os.Stdin = origStdinIsPiped, origStdin
+	}()
+	recordStdinIsPiped = func() bool {
+		return true
+	}
+	stdinFile := filepath.Join(tmpDir, "stdin.txt")
+	require.NoError(t, ioutil.WriteFile(stdinFile, []byte("Authorization: Bearer stdin-token"), 0644))
+	os.Stdin, err = os.Open(stdinFile)
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Stdin.Close()
+	}()
+	for k, v := range map[string]string{
+		"COBRACLI_TEST_TOKEN":  "env-token",
+		"COBRACLI_TEST_REGION": "us-east-1",
+		"COBRACLI_TEST_HEADER": "Bearer header-token",
+	} {
+		require.NoError(t, os.Setenv(k, v))
+		defer func(k string) {
+			_ = os.Unsetenv(k)
+		}(k)
+	}
+
+	rootCmd := &cobra.Command{Use: "my-app"}
+	greetCmd := &cobra.Command{
+		Use: "greet",
+		Run: func(cmd *cobra.Command, args []string) {},
+	}
+	greetCmd.Flags().String("api-key", "", "API key")
+	greetCmd.Flags().Bool("secret-mode", false, "secret mode")
+	greetCmd.Flags().String("name", "", "name")
+	greetCmd.Flags().String("session", "", "session")
+	rootCmd.AddCommand(greetCmd)
+	rootCmd.SetOutput(&bytes.Buffer{})
+
+	recordingPath := filepath.Join(tmpDir, "recording.json")
+	rv := Execute(rootCmd, append(DefaultParams(nil),
+		ArgsParam([]string{"greet", "--record", recordingPath, "--api-key", "key", "--secret-mode", "world", "--name=Bearer name-token", "--session=session-id"}),
+		RecordParam([]string{"COBRACLI_TEST_TOKEN", "COBRACLI_TEST_REGION", "COBRACLI_TEST_HEADER"}, []string{"session", "*_REGION"}),
+		RedactOutputParam(),
+	)...)
+	assert.Equal(t, 0, rv)
+
+	recording, err := ReadRecording(recordingPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"greet", "--api-key", RedactedValue, "--secret-mode", "world", "--name=Bearer [REDACTED]", "--session=" + RedactedValue}, recording.Args)
+	assert.Equal(t, map[string]string{
+		"COBRACLI_TEST_TOKEN":  RedactedValue,
+		"COBRACLI_TEST_REGION": RedactedValue,
+		"COBRACLI_TEST_HEADER": "Bearer [REDACTED]",
+	}, recording.Env)
+	assert.Equal(t, "Authorization: Bearer [REDACTED]", string(recording.Stdin))
+}
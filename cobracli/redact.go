@@ -0,0 +1,174 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"io"
+	"reflect"
+	"regexp"
+
+	"github.com/spf13/cobra"
+)
+
+// RedactionPlaceholder is the text that replaces the sensitive values removed by a Redactor.
+const RedactionPlaceholder = "[REDACTED]"
+
+// DefaultRedactionPatterns are the patterns used by RedactOutputParam if no patterns are provided. They recognize
+// common credential formats: AWS access key IDs and secret access keys, bearer tokens, JSON Web Tokens, GitHub tokens,
+// PEM-encoded private keys and passwords embedded in URLs.
+var DefaultRedactionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	regexp.MustCompile(`(?i)aws_secret_access_key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})`),
+	regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]+=*)`),
+	regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`),
+	regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`),
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+	regexp.MustCompile(`://[^/\s:@]+:([^/\s@]+)@`),
+}
+
+// Redactor replaces the matches of a set of patterns with RedactionPlaceholder. If a pattern has capturing groups,
+// only the text matched by its first group is replaced, which allows patterns to match the context of a value (such
+// as "Bearer ") without removing it. Otherwise, the entire match is replaced.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor returns a Redactor that redacts the matches of the provided patterns.
+func NewRedactor(patterns ...*regexp.Regexp) *Redactor {
+	return &Redactor{
+		patterns: patterns,
+	}
+}
+
+// Redact returns a copy of the provided content in which the matches of the patterns of the redactor are replaced.
+func (r *Redactor) Redact(content []byte) []byte {
+	for _, pattern := range r.patterns {
+		content = redactMatches(pattern, content)
+	}
+	return content
+}
+
+func redactMatches(pattern *regexp.Regexp, content []byte) []byte {
+	matches := pattern.FindAllSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return content
+	}
+	var out []byte
+	prevEnd := 0
+	for _, match := range matches {
+		start, end := match[0], match[1]
+		if len(match) >= 4 && match[2] != -1 {
+			start, end = match[2], match[3]
+		}
+		out = append(out, content[prevEnd:start]...)
+		out = append(out, RedactionPlaceholder...)
+		prevEnd = end
+	}
+	return append(out, content[prevEnd:]...)
+}
+
+// Writer returns a writer that redacts the content written to it before writing it to the provided writer. Content is
+// redacted one write at a time, so values that are split across multiple writes are not recognized.
+func (r *Redactor) Writer(w io.Writer) io.Writer {
+	return &redactingWriter{
+		w:        w,
+		redactor: r,
+	}
+}
+
+type redactingWriter struct {
+	w        io.Writer
+	redactor *Redactor
+}
+
+func (w *redactingWriter) Write(p []byte) (int, error) {
+	if _, err := w.w.Write(w.redactor.Redact(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// RedactOutputParam returns a Param that redacts sensitive values that match the provided patterns (or
+// DefaultRedactionPatterns if none are provided) from human-readable output to prevent secrets from leaking into
+// shared terminal sessions, logs and support bundles. The filter applies to:
+//
+//   - the status writer (see Status), including the log file written by LogFileParam;
+//   - the output of a command if its output format is OutputFormatText (see GetOutputFormat). Structured output is not
+//     modified so that it remains parseable;
+//   - the artifacts of the command added by SupportBundleCmdParam.
+//
+// While a command runs, its output is replaced with a redacting writer using SetOutput, so commands should write
+// status output using Status rather than cmd.OutOrStderr.
+func RedactOutputParam(patterns ...*regexp.Regexp) Param {
+	if len(patterns) == 0 {
+		patterns = DefaultRedactionPatterns
+	}
+	redactor := NewRedactor(patterns...)
+	return paramFunc(func(executor *executor) {
+		executor.redactor = redactor
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				restoreStatus := setStatus(cmd, redactor.Writer(Status(cmd)))
+				defer restoreStatus()
+				if GetOutputFormat(cmd) == OutputFormatText {
+					restoreOutput := setOutput(cmd, redactor.Writer(cmd.OutOrStdout()))
+					defer restoreOutput()
+				}
+				return next(cmd, args)
+			}
+		}).apply(executor)
+	})
+}
+
+// RedactArtifact returns a copy of the provided artifact whose content is redacted by the provided redactor.
+func RedactArtifact(artifact SupportBundleArtifact, redactor *Redactor) SupportBundleArtifact {
+	collect := artifact.Collect
+	artifact.Collect = func(cmd *cobra.Command) ([]byte, error) {
+		content, err := collect(cmd)
+		if err != nil || content == nil {
+			return content, err
+		}
+		return redactor.Redact(content), nil
+	}
+	return artifact
+}
+
+// setOutput sets the output of the provided command and returns a function that restores the previous output.
+func setOutput(cmd *cobra.Command, w io.Writer) (restore func()) {
+	prev, hasPrev := cmdOutput(cmd)
+	cmd.SetOutput(w)
+	return func() {
+		if hasPrev {
+			cmd.SetOutput(prev)
+			return
+		}
+		cmd.SetOutput(nil)
+	}
+}
+
+// cmdOutput returns the output that was set on the provided command itself using SetOutput and true, or false if its
+// output is inherited from its parent or is the default output. cobra does not expose this directly, so it is inferred
+// by comparing the output of the command with the output of its parent or, for a root command, with its error output
+// (which is only the same writer if the output was set).
+func cmdOutput(cmd *cobra.Command) (io.Writer, bool) {
+	out := cmd.OutOrStdout()
+	if cmd.HasParent() {
+		if sameWriter(out, cmd.Parent().OutOrStdout()) {
+			return nil, false
+		}
+		return out, true
+	}
+	if sameWriter(out, cmd.OutOrStderr()) {
+		return out, true
+	}
+	return nil, false
+}
+
+func sameWriter(a, b io.Writer) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
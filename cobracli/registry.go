@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// Registry is a registry of commands that are shared by multiple binaries. Commands register themselves with tags
+// (typically in an init function of the package that implements them) and the main function of each binary adds the
+// commands with the tags that it selects to its root command:
+//
+//	// package commands
+//	var Registry = cobracli.NewRegistry()
+//
+//	func init() {
+//		Registry.Register("", newUsersCmd, "admin", "user")
+//		Registry.Register("users", newUsersDeleteCmd, "admin")
+//	}
+//
+//	// package main of the "admin" binary
+//	if err := commands.Registry.AddCommands(rootCmd, "admin"); err != nil { ... }
+//
+// This allows a suite of related binaries to share command implementations without duplicating command trees.
+type Registry struct {
+	mutex   sync.Mutex
+	entries []registryEntry
+}
+
+type registryEntry struct {
+	parentPath string
+	newCmd     func() *cobra.Command
+	tags       map[string]struct{}
+}
+
+// NewRegistry returns a new empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register registers a command with the provided tags. The command is added as a subcommand of the command with the
+// provided path relative to the root command (for example, "server"; the empty string specifies the root command). The
+// provided function is called to create a new instance of the command every time the command is added to a root
+// command. A command that is registered without tags is added to every root command.
+func (r *Registry) Register(parentPath string, newCmd func() *cobra.Command, tags ...string) {
+	tagSet := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tagSet[tag] = struct{}{}
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.entries = append(r.entries, registryEntry{
+		parentPath: strings.Join(strings.Fields(parentPath), " "),
+		newCmd:     newCmd,
+		tags:       tagSet,
+	})
+}
+
+// AddCommands adds the registered commands that are selected by the provided tags to the provided root command.
+// Commands are added in the order in which they were registered. A command is selected if it has at least one of the
+// provided tags or if it was registered without tags. Commands whose parent command is not selected are not added.
+// Returns an error if the parent of a selected command is neither a registered command nor a command that is already
+// part of the command tree of the root command.
+func (r *Registry) AddCommands(rootCmd *cobra.Command, tags ...string) error {
+	r.mutex.Lock()
+	entries := append([]registryEntry(nil), r.entries...)
+	r.mutex.Unlock()
+
+	selected := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		selected[tag] = struct{}{}
+	}
+
+	// paths of registered commands that were not added because they or their parents were not selected
+	excluded := make(map[string]struct{})
+	for _, entry := range entries {
+		cmd := entry.newCmd()
+		path := strings.TrimSpace(entry.parentPath + " " + cmd.Name())
+		if _, ok := excluded[entry.parentPath]; ok || !entry.selectedBy(selected) {
+			excluded[path] = struct{}{}
+			continue
+		}
+		parent, ok := findCommand(rootCmd, entry.parentPath)
+		if !ok {
+			return fmt.Errorf("parent command %q of command %q does not exist", entry.parentPath, path)
+		}
+		parent.AddCommand(cmd)
+	}
+	return nil
+}
+
+func (e registryEntry) selectedBy(tags map[string]struct{}) bool {
+	if len(e.tags) == 0 {
+		return true
+	}
+	for tag := range e.tags {
+		if _, ok := tags[tag]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// findCommand returns the command with the provided path relative to the provided root command.
+func findCommand(rootCmd *cobra.Command, path string) (*cobra.Command, bool) {
+	curr := rootCmd
+	for _, name := range strings.Fields(path) {
+		var next *cobra.Command
+		for _, child := range curr.Commands() {
+			if child.Name() == name {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return nil, false
+		}
+		curr = next
+	}
+	return curr, true
+}
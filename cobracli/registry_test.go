@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestRegistry(t *testing.T) {
+	newCmd := func(name string) func() *cobra.Command {
+		return func() *cobra.Command {
+			return &cobra.Command{
+				Use: name,
+				Run: func(cmd *cobra.Command, args []string) {},
+			}
+		}
+	}
+	registry := cobracli.NewRegistry()
+	registry.Register("", newCmd("users"), "admin", "user")
+	registry.Register("users", newCmd("list"), "admin", "user")
+	registry.Register("users", newCmd("delete"), "admin")
+	registry.Register("", newCmd("agent"), "agent")
+	registry.Register("agent", newCmd("start"))
+	registry.Register("", newCmd("doctor"))
+
+	for i, tc := range []struct {
+		tags []string
+		want []string
+	}{
+		{[]string{"admin"}, []string{"doctor", "users", "users delete", "users list"}},
+		{[]string{"user"}, []string{"doctor", "users", "users list"}},
+		{[]string{"agent"}, []string{"agent", "agent start", "doctor"}},
+		{nil, []string{"doctor"}},
+	} {
+		rootCmd := &cobra.Command{Use: "my-app"}
+		require.NoError(t, registry.AddCommands(rootCmd, tc.tags...), "Case %d", i)
+
+		var got []string
+		var visit func(cmd *cobra.Command, prefix string)
+		visit = func(cmd *cobra.Command, prefix string) {
+			for _, child := range cmd.Commands() {
+				got = append(got, prefix+child.Name())
+				visit(child, prefix+child.Name()+" ")
+			}
+		}
+		visit(rootCmd, "")
+		assert.Equal(t, tc.want, got, "Case %d", i)
+	}
+
+	registry.Register("missing", newCmd("orphan"), "admin")
+	err := registry.AddCommands(&cobra.Command{Use: "my-app"}, "admin")
+	assert.EqualError(t, err, `parent command "missing" of command "missing orphan" does not exist`)
+}
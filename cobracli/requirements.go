@@ -0,0 +1,205 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// RequiredEnvAnnotation is the key of the command annotation that declares the environment variables that must be
+	// set to run the command. The value is a comma-separated list of variable names.
+	RequiredEnvAnnotation = "cobracli_required_env"
+	// RequiredExecutablesAnnotation is the key of the command annotation that declares the external executables that
+	// must be available on the PATH to run the command. The value is a comma-separated list of entries of the form
+	// "name" or "name>=version". If a minimum version is specified, the version of the executable is determined by
+	// running it with the "--version" flag and using the first dot-separated version number in the output.
+	RequiredExecutablesAnnotation = "cobracli_required_executables"
+)
+
+// executableVersionTimeout is the maximum amount of time for which an executable is run to determine its version. It
+// is a variable so that it can be replaced in tests.
+var executableVersionTimeout = 5 * time.Second
+
+// RequireEnv adds the provided environment variables to the RequiredEnvAnnotation of the provided command.
+func RequireEnv(cmd *cobra.Command, names ...string) {
+	addAnnotationValues(cmd, RequiredEnvAnnotation, names)
+}
+
+// RequireExecutables adds the provided executable specifications (of the form "name" or "name>=version") to the
+// RequiredExecutablesAnnotation of the provided command.
+func RequireExecutables(cmd *cobra.Command, specs ...string) {
+	addAnnotationValues(cmd, RequiredExecutablesAnnotation, specs)
+}
+
+// RequirementsParam returns a Param that enforces the requirements declared using RequiredEnvAnnotation and
+// RequiredExecutablesAnnotation. Requirements declared on a command apply to the command and all of its descendants.
+// Before a command is run (and before its pre-run functions are called), all of its requirements are verified and, if
+// any are not met, the command is not run and an error that lists every unmet requirement is returned. While Execute
+// runs, the requirements of a command are also appended to its long description so that they are included in help
+// output and generated documentation.
+func RequirementsParam() Param {
+	return multiParam(
+		restorableConfigureCmdParam(func(rootCmd *cobra.Command) func() {
+			origLongs := make(map[*cobra.Command]string)
+			visitCommands(rootCmd, func(cmd *cobra.Command) {
+				section := requirementsSection(cmd)
+				if section == "" {
+					return
+				}
+				long := cmd.Long
+				if long == "" {
+					long = cmd.Short
+				}
+				if !strings.HasSuffix(long, section) {
+					origLongs[cmd] = cmd.Long
+					cmd.Long = strings.TrimRight(long, "\n") + "\n\n" + section
+				}
+			})
+			return func() {
+				for cmd, long := range origLongs {
+					cmd.Long = long
+				}
+			}
+		}),
+		preRunHookParam(func(cmd *cobra.Command, args []string) (func(), error) {
+			return nil, VerifyRequirements(cmd)
+		}),
+	)
+}
+
+// VerifyRequirements verifies the requirements declared on the provided command and its ancestors. Returns an error
+// that lists all of the requirements that are not met, or nil if all of the requirements are met. Executables are run
+// to determine their versions with the execution context of the command (see Context) and a timeout of 5 seconds.
+func VerifyRequirements(cmd *cobra.Command) error {
+	var problems []string
+	for _, name := range annotationValues(cmd, RequiredEnvAnnotation) {
+		if _, ok := os.LookupEnv(name); !ok {
+			problems = append(problems, fmt.Sprintf("environment variable %s is not set", name))
+		}
+	}
+	for _, spec := range annotationValues(cmd, RequiredExecutablesAnnotation) {
+		if problem := verifyExecutable(Context(cmd), spec); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("requirements for %q are not met:\n  %s", cmd.CommandPath(), strings.Join(problems, "\n  "))
+}
+
+var versionRegexp = regexp.MustCompile(`\d+(\.\d+)+`)
+
+func verifyExecutable(ctx context.Context, spec string) string {
+	name, minVersion := spec, ""
+	if idx := strings.Index(spec, ">="); idx != -1 {
+		name, minVersion = strings.TrimSpace(spec[:idx]), strings.TrimSpace(spec[idx+2:])
+	}
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return fmt.Sprintf("executable %s was not found on the PATH", name)
+	}
+	if minVersion == "" {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(ctx, executableVersionTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, path, "--version").CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Sprintf("failed to determine version of executable %s: %s --version did not complete within %v", name, name, executableVersionTimeout)
+	}
+	if err != nil {
+		return fmt.Sprintf("failed to determine version of executable %s: %v", name, err)
+	}
+	version := versionRegexp.FindString(string(output))
+	if version == "" {
+		return fmt.Sprintf("failed to determine version of executable %s from output %q", name, strings.TrimSpace(string(output)))
+	}
+	if compareVersions(version, minVersion) < 0 {
+		return fmt.Sprintf("executable %s has version %s, but version %s or later is required", name, version, minVersion)
+	}
+	return ""
+}
+
+// compareVersions compares two dot-separated numeric versions. Missing components are treated as 0 and components
+// that are not numeric are treated as 0.
+func compareVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aVal, bVal int
+		if i < len(aParts) {
+			aVal, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bVal, _ = strconv.Atoi(bParts[i])
+		}
+		if aVal != bVal {
+			if aVal < bVal {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// requirementsSection returns the description of the requirements of the provided command, or an empty string if it
+// does not have any requirements.
+func requirementsSection(cmd *cobra.Command) string {
+	var lines []string
+	if env := annotationValues(cmd, RequiredEnvAnnotation); len(env) > 0 {
+		lines = append(lines, "  Environment variables: "+strings.Join(env, ", "))
+	}
+	if executables := annotationValues(cmd, RequiredExecutablesAnnotation); len(executables) > 0 {
+		lines = append(lines, "  Executables: "+strings.Join(executables, ", "))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "Requirements:\n" + strings.Join(lines, "\n")
+}
+
+// annotationValues returns the comma-separated values of the annotation with the provided key on the provided command
+// and all of its ancestors, starting with the root command. Duplicate values are omitted.
+func annotationValues(cmd *cobra.Command, key string) []string {
+	var cmds []*cobra.Command
+	for curr := cmd; curr != nil; curr = curr.Parent() {
+		cmds = append([]*cobra.Command{curr}, cmds...)
+	}
+	var values []string
+	seen := make(map[string]struct{})
+	for _, curr := range cmds {
+		for _, v := range strings.Split(curr.Annotations[key], ",") {
+			v = strings.TrimSpace(v)
+			if _, ok := seen[v]; ok || v == "" {
+				continue
+			}
+			seen[v] = struct{}{}
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func addAnnotationValues(cmd *cobra.Command, key string, values []string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	all := values
+	if existing := cmd.Annotations[key]; existing != "" {
+		all = append([]string{existing}, values...)
+	}
+	cmd.Annotations[key] = strings.Join(all, ",")
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyExecutableTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a shell script as an executable")
+	}
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	toolPath := filepath.Join(tmpDir, "slow-tool")
+	require.NoError(t, ioutil.WriteFile(toolPath, []byte("#!/bin/sh\nexec sleep 10\n"), 0755))
+
+	origTimeout := executableVersionTimeout
+	defer func() {
+		executableVersionTimeout = origTimeout
+	}()
+	executableVersionTimeout = 50 * time.Millisecond
+
+	start := time.Now()
+	problem := verifyExecutable(context.Background(), toolPath+">=1.0")
+	assert.Equal(t, "failed to determine version of executable "+toolPath+": "+toolPath+" --version did not complete within 50ms", problem)
+	assert.True(t, time.Since(start) < 5*time.Second, "verifying the executable took %v", time.Since(start))
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestRequirementsParam(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a shell script as an executable")
+	}
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(tmpDir, "fake-tool"), []byte("#!/bin/sh\necho fake-tool version 1.4.2\n"), 0755))
+
+	origPath := os.Getenv("PATH")
+	require.NoError(t, os.Setenv("PATH", tmpDir+string(os.PathListSeparator)+origPath))
+	defer func() {
+		_ = os.Setenv("PATH", origPath)
+	}()
+	require.NoError(t, os.Setenv("COBRACLI_TEST_SET", "value"))
+	defer func() {
+		_ = os.Unsetenv("COBRACLI_TEST_SET")
+	}()
+
+	for i, tc := range []struct {
+		env         []string
+		executables []string
+		wantRV      int
+		wantOutput  string
+	}{
+		{[]string{"COBRACLI_TEST_SET"}, []string{"fake-tool>=1.4"}, 0, "pre-run\nran\n"},
+		{
+			[]string{"COBRACLI_TEST_SET", "COBRACLI_TEST_UNSET"},
+			[]string{"fake-tool>=1.10", "cobracli-missing-tool"},
+			1,
+			`Error: requirements for "my-app sub" are not met:
+  environment variable COBRACLI_TEST_UNSET is not set
+  executable fake-tool has version 1.4.2, but version 1.10 or later is required
+  executable cobracli-missing-tool was not found on the PATH
+`,
+		},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			// requirements are verified before pre-run functions are called
+			PersistentPreRun: func(cmd *cobra.Command, args []string) {
+				cmd.Println("pre-run")
+			},
+		}
+		subCmd := &cobra.Command{
+			Use:   "sub",
+			Short: "Runs the subcommand",
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Println("ran")
+			},
+		}
+		rootCmd.AddCommand(subCmd)
+		cobracli.RequireEnv(rootCmd, tc.env[0])
+		cobracli.RequireEnv(subCmd, tc.env[1:]...)
+		cobracli.RequireExecutables(subCmd, tc.executables...)
+
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs([]string{"sub"})
+
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.RequirementsParam())...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+	}
+}
+
+func TestRequirementsParamAddsHelp(t *testing.T) {
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+	}
+	subCmd := &cobra.Command{
+		Use:   "sub",
+		Short: "Runs the subcommand",
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+	rootCmd.AddCommand(subCmd)
+	cobracli.RequireEnv(subCmd, "API_TOKEN")
+	cobracli.RequireExecutables(subCmd, "git>=2.20", "docker")
+
+	for i := 0; i < 2; i++ {
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs([]string{"sub", "--help"})
+		cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.RequirementsParam())...)
+		assert.Contains(t, buf.String(), `Runs the subcommand
+
+Requirements:
+  Environment variables: API_TOKEN
+  Executables: git>=2.20, docker
+
+Usage:`, "Case %d", i)
+		// the long description is restored when Execute returns
+		assert.Equal(t, "", subCmd.Long, "Case %d", i)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// CommandDisabledError is the error returned when a command that is disabled by policy is invoked.
+type CommandDisabledError struct {
+	// Path is the path of the command relative to the root command (for example, "server start").
+	Path string
+}
+
+func (e *CommandDisabledError) Error() string {
+	return fmt.Sprintf("command %q is disabled by policy", e.Path)
+}
+
+// RestrictCommandsParam returns a Param that restricts the commands that can be run. Commands are identified by their
+// path relative to the root command with the elements separated by spaces (for example, "server start"). An entry in
+// a list matches the command with that path and all of its descendants.
+//
+// If the allow list is non-empty, only commands that match an entry in the allow list are permitted. Commands that
+// match an entry in the deny list are never permitted, even if they are also matched by the allow list. The root
+// command itself is always permitted. Commands that are not permitted are hidden from help output while Execute runs,
+// and invoking one returns a *CommandDisabledError before any of its pre-run functions are called. Commands that are
+// not permitted but that have a descendant that is permitted remain visible so that the permitted descendant can be
+// reached.
+//
+// CommandListFromEnv can be used to read the lists from environment variables.
+func RestrictCommandsParam(allow, deny []string) Param {
+	allow, deny = normalizeCommandPaths(allow), normalizeCommandPaths(deny)
+	if len(allow) == 0 && len(deny) == 0 {
+		return nil
+	}
+	policy := &commandPolicy{
+		allow: allow,
+		deny:  deny,
+	}
+	return policyParam(policy.permitted, policy.visible)
+}
+
+// CommandListFromEnv returns the command paths specified by the environment variable with the provided name. The
+// value of the variable is a comma-separated list of command paths. Returns nil if the variable is not set or empty.
+func CommandListFromEnv(name string) []string {
+	val := os.Getenv(name)
+	if strings.TrimSpace(val) == "" {
+		return nil
+	}
+	return strings.Split(val, ",")
+}
+
+// policyParam returns a Param that hides the commands for which visible returns false (until Execute returns) and that
+// returns a *CommandDisabledError when a command for which permitted returns false is invoked.
+func policyParam(permitted, visible func(cmd *cobra.Command) bool) Param {
+	return multiParam(
+		restorableConfigureCmdParam(func(rootCmd *cobra.Command) func() {
+			var hidden []*cobra.Command
+			visitCommands(rootCmd, func(cmd *cobra.Command) {
+				if cmd.HasParent() && !cmd.Hidden && !visible(cmd) {
+					cmd.Hidden = true
+					hidden = append(hidden, cmd)
+				}
+			})
+			return func() {
+				for _, cmd := range hidden {
+					cmd.Hidden = false
+				}
+			}
+		}),
+		preRunHookParam(func(cmd *cobra.Command, args []string) (func(), error) {
+			if cmd.HasParent() && !permitted(cmd) {
+				return nil, &CommandDisabledError{
+					Path: relativeCommandPath(cmd),
+				}
+			}
+			return nil, nil
+		}),
+	)
+}
+
+// multiParam returns a Param that applies all of the provided params.
+func multiParam(params ...Param) Param {
+	return paramFunc(func(executor *executor) {
+		for _, p := range params {
+			p.apply(executor)
+		}
+	})
+}
+
+type commandPolicy struct {
+	allow []string
+	deny  []string
+}
+
+func (p *commandPolicy) permitted(cmd *cobra.Command) bool {
+	path := relativeCommandPath(cmd)
+	for _, denied := range p.deny {
+		if isCommandPathPrefix(denied, path) {
+			return false
+		}
+	}
+	if len(p.allow) == 0 {
+		return true
+	}
+	for _, allowed := range p.allow {
+		if isCommandPathPrefix(allowed, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *commandPolicy) visible(cmd *cobra.Command) bool {
+	if p.permitted(cmd) {
+		return true
+	}
+	path := relativeCommandPath(cmd)
+	for _, allowed := range p.allow {
+		if !isCommandPathPrefix(path, allowed) {
+			continue
+		}
+		denied := false
+		for _, d := range p.deny {
+			if isCommandPathPrefix(d, allowed) {
+				denied = true
+				break
+			}
+		}
+		if !denied {
+			return true
+		}
+	}
+	return false
+}
+
+// relativeCommandPath returns the path of the provided command relative to its root command.
+func relativeCommandPath(cmd *cobra.Command) string {
+	var parts []string
+	for curr := cmd; curr.HasParent(); curr = curr.Parent() {
+		parts = append([]string{curr.Name()}, parts...)
+	}
+	return strings.Join(parts, " ")
+}
+
+// isCommandPathPrefix returns true if the command path prefix is equal to path or is the path of an ancestor of path.
+func isCommandPathPrefix(prefix, path string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+" ")
+}
+
+func normalizeCommandPaths(paths []string) []string {
+	var normalized []string
+	for _, p := range paths {
+		if p = strings.Join(strings.Fields(p), " "); p != "" {
+			normalized = append(normalized, p)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestRestrictCommandsParam(t *testing.T) {
+	for i, tc := range []struct {
+		allow      []string
+		deny       []string
+		args       []string
+		wantRV     int
+		wantOutput string
+		wantHidden []string
+	}{
+		{nil, nil, []string{"server", "start"}, 0, "pre-run\nserver start\n", nil},
+		{[]string{"server start"}, nil, []string{"server", "start"}, 0, "pre-run\nserver start\n", []string{"server stop", "client"}},
+		{[]string{"server start"}, nil, []string{"client"}, 1, "Error: command \"client\" is disabled by policy\n", []string{"server stop", "client"}},
+		{[]string{"server"}, []string{"server stop"}, []string{"server", "stop"}, 1, "Error: command \"server stop\" is disabled by policy\n", []string{"server stop", "client"}},
+		{nil, []string{"server"}, []string{"server", "start"}, 1, "Error: command \"server start\" is disabled by policy\n", []string{"server", "server start", "server stop"}},
+		{nil, []string{" client "}, []string{"server", "stop"}, 0, "pre-run\nserver stop\n", []string{"client"}},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			// commands are rejected before pre-run functions are called
+			PersistentPreRun: func(cmd *cobra.Command, args []string) {
+				cmd.Println("pre-run")
+			},
+		}
+		var commands []*cobra.Command
+		serverCmd := &cobra.Command{Use: "server"}
+		for _, name := range []string{"start", "stop"} {
+			serverCmd.AddCommand(&cobra.Command{
+				Use: name,
+				Run: func(cmd *cobra.Command, args []string) {
+					cmd.Println(cmd.Parent().Name(), cmd.Name())
+				},
+			})
+		}
+		clientCmd := &cobra.Command{
+			Use: "client",
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Println(cmd.Name())
+			},
+		}
+		rootCmd.AddCommand(serverCmd, clientCmd)
+		commands = append(commands, serverCmd, serverCmd.Commands()[0], serverCmd.Commands()[1], clientCmd)
+
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		hiddenCommands := func() []string {
+			var hidden []string
+			for _, cmd := range commands {
+				if cmd.Hidden {
+					hidden = append(hidden, cmd.CommandPath()[len("my-app "):])
+				}
+			}
+			return hidden
+		}
+		var hidden []string
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil),
+			cobracli.RestrictCommandsParam(tc.allow, tc.deny),
+			cobracli.ConfigureCmdParam(func(*cobra.Command) {
+				hidden = hiddenCommands()
+			}),
+		)...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+		assert.Equal(t, tc.wantHidden, hidden, "Case %d", i)
+		// commands are no longer hidden when Execute returns
+		assert.Nil(t, hiddenCommands(), "Case %d", i)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/flagtypes"
+	"github.com/palantir/pkg/safejson"
+	"github.com/palantir/pkg/safeyaml"
+)
+
+// OutputFormat is the format in which the results of commands are written.
+type OutputFormat string
+
+const (
+	// OutputFormatText writes results in a human-readable form. Results that implement TextRenderer are rendered using
+	// RenderText, results that implement fmt.Stringer are written using String and other results are written using
+	// the "%v" verb of the fmt package.
+	OutputFormatText OutputFormat = "text"
+	// OutputFormatJSON writes results as indented JSON.
+	OutputFormatJSON OutputFormat = "json"
+	// OutputFormatYAML writes results as YAML. Results are converted to JSON first, so the "json" tags of structs are
+	// used to determine the names of keys.
+	OutputFormatYAML OutputFormat = "yaml"
+)
+
+const outputFormatValueName = "output-format"
+
+// TextRenderer is implemented by results that control how they are written in the text output format.
+type TextRenderer interface {
+	RenderText(w io.Writer) error
+}
+
+// RunRFunc is a function that runs a command and returns its result rather than writing it.
+type RunRFunc func(cmd *cobra.Command, args []string) (result interface{}, err error)
+
+// RunR adapts the provided function so that it can be used as the RunE function of a command. If the function returns
+// a non-nil result and no error, the result is written to the output of the command using WriteResult. This allows
+// command code to return values without handling formatting.
+func RunR(fn RunRFunc) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		result, err := fn(cmd, args)
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			return nil
+		}
+		return WriteResult(cmd, result)
+	}
+}
+
+// OutputFormatParam returns a Param that adds the "--output-format" persistent flag, which specifies the format used
+// by WriteResult. The flag accepts the values "text", "json" and "yaml" and its default value is the provided format.
+func OutputFormatParam(defaultFormat OutputFormat) Param {
+	format := string(defaultFormat)
+	return multiParam(
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			flagtypes.EnumVar(cmd.PersistentFlags(), &format, "output-format", string(defaultFormat), "format of the output",
+				string(OutputFormatText), string(OutputFormatJSON), string(OutputFormatYAML))
+		}),
+		// applied outside of other Params (such as RedactOutputParam) so that they observe the output format
+		stagedRunEDecoratorParam(stageEarly, func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				restore := setInvocationValue(cmd, outputFormatValueName, OutputFormat(format))
+				defer restore()
+				return next(cmd, args)
+			}
+		}),
+	)
+}
+
+// GetOutputFormat returns the output format for the invocation that is running the provided command as specified by
+// the flag added by OutputFormatParam. Returns OutputFormatText if OutputFormatParam is not used.
+func GetOutputFormat(cmd *cobra.Command) OutputFormat {
+	if format, ok := invocationValue(cmd, outputFormatValueName); ok {
+		return format.(OutputFormat)
+	}
+	return OutputFormatText
+}
+
+// WriteResult writes the provided result to the output of the provided command in the format returned by
+// GetOutputFormat.
+func WriteResult(cmd *cobra.Command, result interface{}) error {
+	defer TracePhase(cmd, TracePhaseRender)()
+	w := cmd.OutOrStdout()
+	switch format := GetOutputFormat(cmd); format {
+	case OutputFormatJSON:
+		b, err := safejson.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal result as JSON: %v", err)
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	case OutputFormatYAML:
+		jsonBytes, err := safejson.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal result as YAML: %v", err)
+		}
+		b, err := safeyaml.JSONtoYAMLBytes(jsonBytes)
+		if err != nil {
+			return fmt.Errorf("failed to marshal result as YAML: %v", err)
+		}
+		_, err = w.Write(b)
+		return err
+	case OutputFormatText:
+		if renderer, ok := result.(TextRenderer); ok {
+			return renderer.RenderText(w)
+		}
+		_, err := fmt.Fprintln(w, result)
+		return err
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+type serverStatus struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+}
+
+func (s serverStatus) RenderText(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s: healthy=%t\n", s.Name, s.Healthy)
+	return err
+}
+
+func TestRunR(t *testing.T) {
+	for i, tc := range []struct {
+		args       []string
+		result     interface{}
+		wantRV     int
+		wantOutput string
+	}{
+		{nil, serverStatus{Name: "web", Healthy: true}, 0, "web: healthy=true\n"},
+		{[]string{"--output-format", "json"}, serverStatus{Name: "web", Healthy: true}, 0, "{\n  \"name\": \"web\",\n  \"healthy\": true\n}\n"},
+		{[]string{"--output-format", "yaml"}, serverStatus{Name: "web", Healthy: true}, 0, "name: web\nhealthy: true\n"},
+		{nil, 42, 0, "42\n"},
+		{nil, nil, 0, ""},
+		{[]string{"--output-format", "xml"}, nil, 1, "Error: invalid argument \"xml\" for \"--output-format\" flag: \"xml\" is not one of the allowed values: text, json, yaml\n"},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			RunE: cobracli.RunR(func(cmd *cobra.Command, args []string) (interface{}, error) {
+				return tc.result, nil
+			}),
+		}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, cobracli.ConfigureCmdParam(cobracli.SilenceErrorsConfigurer), cobracli.ErrorHandlerParam(cobracli.ErrorPrinterWithDebugHandler(nil, nil)), cobracli.OutputFormatParam(cobracli.OutputFormatText))
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+	}
+}
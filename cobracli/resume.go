@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/palantir/pkg/checkpoint"
+	"github.com/palantir/pkg/xdgdir"
+)
+
+// ResumableAnnotation is the key of the command annotation that marks a command as resumable (see SetResumable). The
+// value must be "true".
+const ResumableAnnotation = "cobracli_resumable"
+
+// checkpointsDirName is the name of the directory in the state directory of an application that contains checkpoints.
+const checkpointsDirName = "checkpoints"
+
+// resumeFlagName is the name of the flag added to resumable commands by ResumeParam.
+const resumeFlagName = "resume"
+
+type checkpointContextKey struct{}
+
+// SetResumable marks the provided command as resumable, which makes ResumeParam provide a checkpoint to it.
+func SetResumable(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[ResumableAnnotation] = "true"
+}
+
+// ResumeParam returns a Param that provides a checkpoint (see the checkpoint package) to the commands that are marked
+// as resumable using SetResumable and adds the "--resume" flag to them. Commands that perform long-running bulk
+// operations can retrieve the checkpoint using CheckpointFromContext and use it to record the items that they complete
+// and to skip the items that were completed by a previous invocation.
+//
+// The checkpoint is stored in the state directory of the application with the provided name (see xdgdir.AppDirs) and
+// is specific to the command, its positional arguments and the values of the flags that were set. It is created when it
+// is first retrieved: if "--resume" is not specified, any existing checkpoint is discarded at that point. If the
+// command succeeds, its checkpoint is removed. If it fails, the checkpoint is flushed and a message that describes how
+// to resume is written to the status writer (see Status).
+func ResumeParam(appName string) Param {
+	var resume bool
+	return multiParam(
+		ConfigureCmdParam(func(rootCmd *cobra.Command) {
+			visitCommands(rootCmd, func(cmd *cobra.Command) {
+				if isResumable(cmd) && cmd.Flags().Lookup(resumeFlagName) == nil {
+					cmd.Flags().BoolVar(&resume, resumeFlagName, false, "resume a previously interrupted operation by skipping the items it completed")
+				}
+			})
+		}),
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				if !isResumable(cmd) {
+					return next(cmd, args)
+				}
+				lazy := &lazyCheckpoint{
+					open: func() (*checkpoint.Checkpoint, error) {
+						dirs, err := xdgdir.AppDirs(appName)
+						if err != nil {
+							return nil, err
+						}
+						path := filepath.Join(dirs.State, checkpointsDirName, checkpointFileName(cmd, args))
+						if !resume {
+							return checkpoint.New(path)
+						}
+						cp, err := checkpoint.Open(path)
+						if err != nil {
+							return nil, err
+						}
+						if cp.Len() > 0 {
+							_, _ = fmt.Fprintf(Status(cmd), "Resuming: skipping %d completed item(s)\n", cp.Len())
+						}
+						return cp, nil
+					},
+				}
+
+				restoreCtx := setContext(cmd, context.WithValue(Context(cmd), checkpointContextKey{}, lazy))
+				defer restoreCtx()
+
+				runErr := next(cmd, args)
+				cp := lazy.created()
+				if cp == nil {
+					return runErr
+				}
+				if runErr != nil {
+					if err := cp.Close(); err != nil {
+						return fmt.Errorf("%v (additionally, failed to save progress: %v)", runErr, err)
+					}
+					if cp.Len() > 0 {
+						_, _ = fmt.Fprintf(Status(cmd), "Progress saved: run the command again with --resume to skip the %d completed item(s)\n", cp.Len())
+					}
+					return runErr
+				}
+				return cp.Remove()
+			}
+		}),
+	)
+}
+
+// CheckpointFromContext returns the checkpoint provided by ResumeParam, creating it if this is the first time that it
+// is retrieved. Returns an error if the provided context does not carry a checkpoint (for example, because the
+// command is not marked as resumable) or if the checkpoint cannot be created.
+func CheckpointFromContext(ctx context.Context) (*checkpoint.Checkpoint, error) {
+	lazy, ok := ctx.Value(checkpointContextKey{}).(*lazyCheckpoint)
+	if !ok {
+		return nil, fmt.Errorf("no checkpoint is available: the command must be marked as resumable using SetResumable and ResumeParam must be provided")
+	}
+	return lazy.get()
+}
+
+// lazyCheckpoint is a checkpoint that is opened when it is first retrieved.
+type lazyCheckpoint struct {
+	once sync.Once
+	open func() (*checkpoint.Checkpoint, error)
+	cp   *checkpoint.Checkpoint
+	err  error
+}
+
+func (l *lazyCheckpoint) get() (*checkpoint.Checkpoint, error) {
+	l.once.Do(func() {
+		l.cp, l.err = l.open()
+	})
+	return l.cp, l.err
+}
+
+// created returns the checkpoint if it was opened successfully, or nil otherwise. The checkpoint is no longer opened
+// by get once this function has been called.
+func (l *lazyCheckpoint) created() *checkpoint.Checkpoint {
+	l.once.Do(func() {})
+	return l.cp
+}
+
+func isResumable(cmd *cobra.Command) bool {
+	return cmd.Annotations[ResumableAnnotation] == "true"
+}
+
+// checkpointFileName returns the name of the checkpoint file for the provided command invocation: the path of the
+// command joined with dashes followed by a hash of the arguments and of the flags that were set (other than
+// "--resume").
+func checkpointFileName(cmd *cobra.Command, args []string) string {
+	h := sha256.New()
+	for _, arg := range args {
+		_, _ = fmt.Fprintf(h, "%d:%s", len(arg), arg)
+	}
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if !f.Changed || f.Name == resumeFlagName {
+			return
+		}
+		flag := "--" + f.Name + "=" + f.Value.String()
+		_, _ = fmt.Fprintf(h, "%d:%s", len(flag), flag)
+	})
+	return fmt.Sprintf("%s-%s.json", strings.Replace(cmd.CommandPath(), " ", "-", -1), hex.EncodeToString(h.Sum(nil))[:12])
+}
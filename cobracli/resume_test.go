@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestResumeParam(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	orig, ok := os.LookupEnv("XDG_STATE_HOME")
+	defer func() {
+		if ok {
+			_ = os.Setenv("XDG_STATE_HOME", orig)
+		} else {
+			_ = os.Unsetenv("XDG_STATE_HOME")
+		}
+	}()
+	require.NoError(t, os.Setenv("XDG_STATE_HOME", tmpDir))
+	checkpointsDir := filepath.Join(tmpDir, "my-app", "checkpoints")
+
+	for i, tc := range []struct {
+		args          []string
+		failOn        string
+		wantProcessed []string
+		wantRV        int
+		wantOutput    string
+		wantFiles     int
+	}{
+		{[]string{"upload", "src"}, "c", []string{"a", "b"}, 1, "Progress saved: run the command again with --resume to skip the 2 completed item(s)\n", 1},
+		// checkpoints are specific to the arguments of the command
+		{[]string{"upload", "other", "--resume"}, "", []string{"a", "b", "c", "d"}, 0, "", 1},
+		{[]string{"upload", "src", "--resume"}, "d", []string{"c"}, 1, "Resuming: skipping 2 completed item(s)\nProgress saved: run the command again with --resume to skip the 3 completed item(s)\n", 1},
+		{[]string{"upload", "src", "--resume"}, "", []string{"d"}, 0, "Resuming: skipping 3 completed item(s)\n", 0},
+		// checkpoint is removed when the command succeeds
+		{[]string{"upload", "src", "--resume"}, "", []string{"a", "b", "c", "d"}, 0, "", 0},
+		{[]string{"upload", "src"}, "b", []string{"a"}, 1, "Progress saved: run the command again with --resume to skip the 1 completed item(s)\n", 1},
+		// checkpoint is discarded if --resume is not specified
+		{[]string{"upload", "src"}, "", []string{"a", "b", "c", "d"}, 0, "", 0},
+		{[]string{"upload", "src"}, "c", []string{"a", "b"}, 1, "Progress saved: run the command again with --resume to skip the 2 completed item(s)\n", 1},
+		// checkpoints are specific to the flags of the command
+		{[]string{"upload", "src", "--resume", "--parallel", "2"}, "", []string{"a", "b", "c", "d"}, 0, "", 1},
+		// commands that are not resumable do not create or discard checkpoints
+		{[]string{"list"}, "", nil, 0, "", 1},
+		{[]string{"upload", "src", "--resume"}, "", []string{"c", "d"}, 0, "Resuming: skipping 2 completed item(s)\n", 0},
+	} {
+		var processed []string
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+		}
+		uploadCmd := &cobra.Command{
+			Use: "upload",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				cp, err := cobracli.CheckpointFromContext(cobracli.Context(cmd))
+				if err != nil {
+					return err
+				}
+				for _, item := range []string{"a", "b", "c", "d"} {
+					if cp.IsDone(item) {
+						continue
+					}
+					if item == tc.failOn {
+						return fmt.Errorf("failed on %s", item)
+					}
+					processed = append(processed, item)
+					if err := cp.MarkDone(item); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}
+		uploadCmd.Flags().Int("parallel", 1, "number of parallel uploads")
+		cobracli.SetResumable(uploadCmd)
+		rootCmd.AddCommand(uploadCmd, &cobra.Command{
+			Use: "list",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if _, err := cobracli.CheckpointFromContext(cobracli.Context(cmd)); err == nil {
+					return fmt.Errorf("unexpected checkpoint")
+				}
+				return nil
+			},
+		})
+		outBuf := &bytes.Buffer{}
+		rootCmd.SetOutput(outBuf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, cobracli.ResumeParam("my-app"), cobracli.ConfigureCmdParam(cobracli.SilenceErrorsConfigurer))
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantProcessed, processed, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, outBuf.String(), "Case %d", i)
+
+		files, _ := ioutil.ReadDir(checkpointsDir)
+		assert.Equal(t, tc.wantFiles, len(files), "Case %d", i)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/runtimeenv"
+	"github.com/palantir/pkg/signals"
+)
+
+// ForceExitCode is the exit code used when SignalHandlerParam terminates the process because a second signal was
+// received before the command returned. It matches the exit code that shells use for processes terminated by SIGINT.
+const ForceExitCode = 130
+
+// osExit is the function used to terminate the process. It is a variable so that it can be replaced in tests.
+var osExit = os.Exit
+
+// isPID1 and startReaper are variables so that they can be replaced in tests.
+var (
+	isPID1      = runtimeenv.IsPID1
+	startReaper = signals.StartReaper
+)
+
+// SignalHandlerParam returns a Param that handles the provided signals while the root command is executed, which
+// includes parsing flags and calling pre-run functions. If no signals are provided, SIGINT and SIGTERM are handled.
+// When the first signal is received, a message is written to the status writer (see Status) and the execution context
+// of the invocation (see Context) is cancelled, so that the command can stop and clean up. If another signal is
+// received before Execute returns, the process exits immediately with ForceExitCode. The handlers are removed when
+// Execute returns, so the default behavior for the signals is restored.
+func SignalHandlerParam(signals ...os.Signal) Param {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	return invocationHookParam(func(rootCmd *cobra.Command) func() {
+		ctx, cancel := context.WithCancel(Context(rootCmd))
+		restoreCtx := setContext(rootCmd, ctx)
+
+		sigCh := make(chan os.Signal, 2)
+		signal.Notify(sigCh, signals...)
+		done := make(chan struct{})
+		statusWriter := Status(rootCmd)
+		go func() {
+			select {
+			case sig := <-sigCh:
+				_, _ = fmt.Fprintf(statusWriter, "Received %v, stopping (send the signal again to exit immediately)\n", sig)
+				cancel()
+			case <-done:
+				return
+			}
+			select {
+			case <-sigCh:
+				osExit(ForceExitCode)
+			case <-done:
+			}
+		}()
+		return func() {
+			signal.Stop(sigCh)
+			close(done)
+			restoreCtx()
+			cancel()
+		}
+	})
+}
+
+// ReapZombiesParam returns a Param that reaps orphaned child processes that have exited (see signals.StartReaper) while
+// a command runs if the process is the init process (PID 1) of its PID namespace. This is the case when a CLI is used
+// as the entrypoint of a container without an init process such as tini, where orphaned processes would otherwise
+// accumulate as zombies. Child processes started by the command should be excluded from reaping using
+// signals.ExcludeFromReaping; otherwise, they must be waited for within the provided grace period after they exit
+// (signals.DefaultReapGracePeriod if it is not positive). Does nothing if the process is not PID 1.
+func ReapZombiesParam(gracePeriod time.Duration) Param {
+	return runEDecoratorParam(func(next RunEFunc) RunEFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			if !isPID1() {
+				return next(cmd, args)
+			}
+			stop := startReaper(gracePeriod)
+			defer stop()
+			return next(cmd, args)
+		}
+	})
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignalHandlerParam(t *testing.T) {
+	exitCodes := make(chan int, 1)
+	origOSExit := osExit
+	osExit = func(code int) {
+		exitCodes <- code
+	}
+	defer func() {
+		osExit = origOSExit
+	}()
+
+	proc, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+
+	for i, tc := range []struct {
+		numSignals   int
+		wantExitCode int
+	}{
+		{1, -1},
+		{2, ForceExitCode},
+	} {
+		var gotErr error
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				ctx := Context(cmd)
+				if err := proc.Signal(os.Interrupt); err != nil {
+					return err
+				}
+				select {
+				case <-ctx.Done():
+				case <-time.After(5 * time.Second):
+					return fmt.Errorf("context was not cancelled")
+				}
+				if tc.numSignals > 1 {
+					if err := proc.Signal(os.Interrupt); err != nil {
+						return err
+					}
+					select {
+					case code := <-exitCodes:
+						return &exitCodeError{code: code}
+					case <-time.After(5 * time.Second):
+						return fmt.Errorf("process was not exited")
+					}
+				}
+				return ctx.Err()
+			},
+			SilenceErrors: true,
+			SilenceUsage:  true,
+		}
+		outBuf := &bytes.Buffer{}
+		rootCmd.SetOutput(outBuf)
+		rv := Execute(rootCmd, SignalHandlerParam(), ErrorHandlerParam(func(cmd *cobra.Command, err error) {
+			gotErr = err
+		}))
+		if tc.wantExitCode == -1 {
+			assert.Equal(t, 1, rv, "Case %d", i)
+			assert.Equal(t, context.Canceled, gotErr, "Case %d", i)
+		} else {
+			assert.Equal(t, tc.wantExitCode, rv, "Case %d", i)
+		}
+		assert.Equal(t, "Received interrupt, stopping (send the signal again to exit immediately)\n", outBuf.String(), "Case %d", i)
+	}
+}
+
+func TestSignalHandlerParamDuringPreRun(t *testing.T) {
+	proc, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+
+	ran := false
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := proc.Signal(os.Interrupt); err != nil {
+				return err
+			}
+			select {
+			case <-Context(cmd).Done():
+				return Context(cmd).Err()
+			case <-time.After(5 * time.Second):
+				return fmt.Errorf("context was not cancelled")
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			ran = true
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	outBuf := &bytes.Buffer{}
+	rootCmd.SetOutput(outBuf)
+	var gotErr error
+	rv := Execute(rootCmd, SignalHandlerParam(), ErrorHandlerParam(func(cmd *cobra.Command, err error) {
+		gotErr = err
+	}))
+	assert.Equal(t, 1, rv)
+	assert.Equal(t, context.Canceled, gotErr)
+	assert.False(t, ran)
+	assert.Equal(t, "Received interrupt, stopping (send the signal again to exit immediately)\n", outBuf.String())
+}
+
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit %d", e.code)
+}
+
+func (e *exitCodeError) ExitCode() int {
+	return e.code
+}
+
+func TestReapZombiesParam(t *testing.T) {
+	origIsPID1, origStartReaper := isPID1, startReaper
+	defer func() {
+		isPID1, startReaper = origIsPID1, origStartReaper
+	}()
+
+	for i, tc := range []struct {
+		pid1        bool
+		wantStarted bool
+	}{
+		{false, false},
+		{true, true},
+	} {
+		var started, stopped bool
+		var gotGracePeriod time.Duration
+		isPID1 = func() bool {
+			return tc.pid1
+		}
+		startReaper = func(gracePeriod time.Duration) func() {
+			started, gotGracePeriod = true, gracePeriod
+			return func() {
+				stopped = true
+			}
+		}
+		var runningWithReaper bool
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			Run: func(cmd *cobra.Command, args []string) {
+				runningWithReaper = started && !stopped
+			},
+		}
+		rootCmd.SetArgs(nil)
+
+		rv := Execute(rootCmd, ReapZombiesParam(time.Minute))
+		assert.Equal(t, 0, rv, "Case %d", i)
+		assert.Equal(t, tc.wantStarted, runningWithReaper, "Case %d", i)
+		assert.Equal(t, tc.wantStarted, stopped, "Case %d", i)
+		if tc.wantStarted {
+			assert.Equal(t, time.Minute, gotGracePeriod, "Case %d", i)
+		}
+	}
+}
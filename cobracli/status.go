@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/flagtypes"
+)
+
+// Status returns the writer that commands should use for status output such as logs, warnings and progress
+// information. Output that is the result of the command should be written to the output of the command instead. By
+// default, this is the writer returned by cmd.OutOrStderr(), but params such as LogFileParam may change it for the
+// duration of an invocation.
+func Status(cmd *cobra.Command) io.Writer {
+	if w, ok := invocationValue(cmd, statusValueName); ok {
+		return w.(io.Writer)
+	}
+	return cmd.OutOrStderr()
+}
+
+// setStatus sets the status writer for the command tree that contains the provided command and returns a function
+// that restores the previous writer.
+func setStatus(cmd *cobra.Command, w io.Writer) (restore func()) {
+	return setInvocationValue(cmd, statusValueName, w)
+}
+
+// LogFileParam returns a Param that adds "--log-file" as a persistent flag. If the flag is specified, everything that
+// is written to the status writer (see Status) is also appended to the file at the specified path with every line
+// prefixed by a timestamp. Output to the terminal is unchanged. If the command returns an error, the error is also
+// written to the file. This is useful for collecting diagnostic information in support bundles.
+func LogFileParam() Param {
+	return paramFunc(func(executor *executor) {
+		var logFile flagtypes.Path
+		multiParam(
+			ConfigureCmdParam(func(cmd *cobra.Command) {
+				flagtypes.PathVar(cmd.PersistentFlags(), &logFile, "log-file", "", "file to which status output is appended with timestamps")
+			}),
+			// applied outside of other Params (such as VerbosityParam and RedactOutputParam) so that they use the status
+			// writer that writes to the log file
+			stagedRunEDecoratorParam(stageEarly, func(next RunEFunc) RunEFunc {
+				return func(cmd *cobra.Command, args []string) (rErr error) {
+					if logFile == "" {
+						return next(cmd, args)
+					}
+					f, err := os.OpenFile(string(logFile), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+					if err != nil {
+						return fmt.Errorf("failed to open log file: %v", err)
+					}
+					fileWriter := newTimestampWriter(f, func() time.Time {
+						return Now(cmd)
+					})
+					defer func() {
+						if rErr != nil {
+							_, _ = fmt.Fprintf(fileWriter, "Error: %v\n", rErr)
+						}
+						if err := f.Close(); err != nil && rErr == nil {
+							rErr = fmt.Errorf("failed to close log file: %v", err)
+						}
+					}()
+
+					restoreStatus := setStatus(cmd, io.MultiWriter(Status(cmd), fileWriter))
+					defer restoreStatus()
+					return next(cmd, args)
+				}
+			}),
+		).apply(executor)
+	})
+}
+
+// timestampWriter is a writer that prefixes every line written to it with a timestamp.
+type timestampWriter struct {
+	mutex   sync.Mutex
+	w       io.Writer
+	now     func() time.Time
+	midLine bool
+}
+
+func newTimestampWriter(w io.Writer, now func() time.Time) *timestampWriter {
+	return &timestampWriter{
+		w:   w,
+		now: now,
+	}
+}
+
+func (w *timestampWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	var buf []byte
+	for _, b := range p {
+		if !w.midLine {
+			buf = append(buf, w.now().Format(time.RFC3339)...)
+			buf = append(buf, ' ')
+			w.midLine = true
+		}
+		buf = append(buf, b)
+		if b == '\n' {
+			w.midLine = false
+		}
+	}
+	if _, err := w.w.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
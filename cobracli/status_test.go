@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestLogFileParam(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	logFile := filepath.Join(tmpDir, "cli.log")
+
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Println("result")
+			_, _ = fmt.Fprint(cobracli.Status(cmd), "status ")
+			_, _ = fmt.Fprint(cobracli.Status(cmd), "line\nwarning\n")
+			return fmt.Errorf("failed")
+		},
+	}
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"--log-file", logFile})
+
+	rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.LogFileParam())...)
+	assert.Equal(t, 1, rv)
+	assert.Equal(t, "result\nstatus line\nwarning\nError: failed\n", buf.String())
+
+	content, err := ioutil.ReadFile(logFile)
+	require.NoError(t, err)
+	const ts = `\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\S* `
+	assert.Regexp(t, regexp.MustCompile("^"+ts+"status line\n"+ts+"warning\n"+ts+"Error: failed\n$"), string(content))
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/console"
+)
+
+// StdioPath is the path that specifies standard input when used as an input path and standard output when used as an
+// output path.
+const StdioPath = "-"
+
+// OpenInput opens the input with the provided path for reading. If the path is StdioPath, standard input is returned
+// (closing it is a no-op). Otherwise, the file at the path is opened.
+func OpenInput(path string) (io.ReadCloser, error) {
+	return openInput(path, os.Stdin)
+}
+
+// ReadInput reads all of the input with the provided path (see OpenInput). If maxSize is positive, an error is
+// returned if the input is larger than maxSize bytes. This guards against unexpectedly large input (for example, if
+// the wrong file is piped to a command) exhausting memory.
+func ReadInput(path string, maxSize int64) ([]byte, error) {
+	return readInput(path, maxSize, os.Stdin)
+}
+
+// OpenOutput opens the output with the provided path for writing. If the path is StdioPath, the output of the provided
+// command is returned (closing it is a no-op). Otherwise, the file at the path is created or truncated. Use
+// OutputFile to write files atomically.
+func OpenOutput(cmd *cobra.Command, path string) (io.WriteCloser, error) {
+	if path == StdioPath {
+		return nopWriteCloser{Writer: cmd.OutOrStdout()}, nil
+	}
+	return os.Create(path)
+}
+
+// OutputWritersParam returns a Param that redirects the output of the command tree to the provided writers, so that
+// programs and tests can capture or redirect all of the output of a CLI without configuring each command. A nil writer
+// leaves the corresponding output unchanged.
+//
+// Cobra uses a single writer for the output of a command, so stdout is set as the output of the root command (and of
+// any descendant whose output was set explicitly) while the command runs: cmd.OutOrStdout, cmd.OutOrStderr and the
+// print functions of commands (such as cmd.Println) all write to it. stderr is used as the status writer (see Status)
+// and as the output of the command tree while the error handlers run, so errors and usage are printed to it. The
+// previous outputs are restored when Execute returns.
+func OutputWritersParam(stdout, stderr io.Writer) Param {
+	return paramFunc(func(executor *executor) {
+		executor.stdout = stdout
+		executor.stderr = stderr
+	})
+}
+
+// setTreeOutput sets the output of the provided root command and of every descendant whose output was set explicitly
+// to the provided writer. Returns a function that restores the previous outputs.
+func setTreeOutput(rootCmd *cobra.Command, w io.Writer) (restore func()) {
+	var restores []func()
+	visitCommands(rootCmd, func(cmd *cobra.Command) {
+		if cmd == rootCmd {
+			restores = append(restores, setOutput(cmd, w))
+			return
+		}
+		if _, ok := cmdOutput(cmd); ok {
+			restores = append(restores, setOutput(cmd, w))
+		}
+	})
+	return func() {
+		for i := len(restores) - 1; i >= 0; i-- {
+			restores[i]()
+		}
+	}
+}
+
+// StdinIsPiped returns true if standard input is not a terminal: that is, if input is piped or redirected to the
+// process.
+func StdinIsPiped() bool {
+	return !console.IsTerminal(os.Stdin)
+}
+
+// RequirePipedStdin returns an error that describes how to provide input to the provided command if standard input is
+// a terminal. Commands that read their input from standard input should call this function before reading so that
+// they fail clearly rather than appearing to hang when run interactively.
+func RequirePipedStdin(cmd *cobra.Command) error {
+	return requirePipedStdin(cmd, StdinIsPiped())
+}
+
+func openInput(path string, stdin io.Reader) (io.ReadCloser, error) {
+	if path == StdioPath {
+		return ioutil.NopCloser(stdin), nil
+	}
+	return os.Open(path)
+}
+
+func readInput(path string, maxSize int64, stdin io.Reader) ([]byte, error) {
+	r, err := openInput(path, stdin)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	if maxSize <= 0 {
+		return ioutil.ReadAll(r)
+	}
+	b, err := ioutil.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > maxSize {
+		name := path
+		if path == StdioPath {
+			name = "standard input"
+		}
+		return nil, fmt.Errorf("input from %s exceeds the maximum size of %d bytes", name, maxSize)
+	}
+	return b, nil
+}
+
+func requirePipedStdin(cmd *cobra.Command, piped bool) error {
+	if piped {
+		return nil
+	}
+	return fmt.Errorf("%s reads its input from standard input, but standard input is a terminal: pipe or redirect input to it (for example, \"%s < input.txt\")", cmd.CommandPath(), cmd.CommandPath())
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
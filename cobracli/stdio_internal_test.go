@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadInput(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	filePath := filepath.Join(tmpDir, "input.txt")
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("file content"), 0644))
+
+	for i, tc := range []struct {
+		path    string
+		maxSize int64
+		want    string
+		wantErr string
+	}{
+		{StdioPath, 0, "stdin content", ""},
+		{StdioPath, 13, "stdin content", ""},
+		{StdioPath, 12, "", "input from standard input exceeds the maximum size of 12 bytes"},
+		{filePath, 0, "file content", ""},
+		{filePath, 4, "", "input from " + filePath + " exceeds the maximum size of 4 bytes"},
+	} {
+		got, err := readInput(tc.path, tc.maxSize, strings.NewReader("stdin content"))
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d", i)
+			continue
+		}
+		require.NoError(t, err, "Case %d", i)
+		assert.Equal(t, tc.want, string(got), "Case %d", i)
+	}
+}
+
+func TestOpenOutput(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	cmd := &cobra.Command{
+		Use: "my-app",
+	}
+	outBuf := &bytes.Buffer{}
+	cmd.SetOutput(outBuf)
+
+	w, err := OpenOutput(cmd, StdioPath)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("to stdout"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Equal(t, "to stdout", outBuf.String())
+
+	filePath := filepath.Join(tmpDir, "output.txt")
+	w, err = OpenOutput(cmd, filePath)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("to file"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	content, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "to file", string(content))
+}
+
+func TestRequirePipedStdin(t *testing.T) {
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+	}
+	importCmd := &cobra.Command{
+		Use: "import",
+	}
+	rootCmd.AddCommand(importCmd)
+
+	assert.NoError(t, requirePipedStdin(importCmd, true))
+	assert.EqualError(t, requirePipedStdin(importCmd, false), `my-app import reads its input from standard input, but standard input is a terminal: pipe or redirect input to it (for example, "my-app import < input.txt")`)
+}
+
+func TestOutputWritersParam(t *testing.T) {
+	for i, tc := range []struct {
+		name       string
+		args       []string
+		wantStdout string
+		wantStderr string
+	}{
+		{"success", []string{"sub"}, "output\n", "status\n"},
+		{"child with explicit output", []string{"explicit"}, "output\n", "status\n"},
+		{"error", []string{"sub", "--fail"}, "output\n", "status\nError: failed\n"},
+	} {
+		var fail bool
+		run := func(cmd *cobra.Command, args []string) error {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "output")
+			_, _ = fmt.Fprintln(Status(cmd), "status")
+			if fail {
+				return fmt.Errorf("failed")
+			}
+			return nil
+		}
+		rootCmd := &cobra.Command{Use: "my-app"}
+		rootCmd.PersistentFlags().BoolVar(&fail, "fail", false, "")
+		rootCmd.AddCommand(&cobra.Command{Use: "sub", RunE: run})
+		explicitOutput := &bytes.Buffer{}
+		explicitCmd := &cobra.Command{Use: "explicit", RunE: run}
+		explicitCmd.SetOutput(explicitOutput)
+		rootCmd.AddCommand(explicitCmd)
+		rootCmd.SetArgs(tc.args)
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		rv := Execute(rootCmd, append(DefaultParams(nil), OutputWritersParam(stdout, stderr))...)
+		assert.Equal(t, tc.wantStderr != "status\n", rv != 0, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantStdout, stdout.String(), "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantStderr, stderr.String(), "Case %d: %s", i, tc.name)
+		assert.Empty(t, explicitOutput.String(), "Case %d: %s", i, tc.name)
+
+		out, ok := cmdOutput(explicitCmd)
+		assert.True(t, ok, "Case %d: %s", i, tc.name)
+		assert.Equal(t, explicitOutput, out, "Case %d: %s: output was not restored", i, tc.name)
+		_, ok = cmdOutput(rootCmd)
+		assert.False(t, ok, "Case %d: %s: output was not restored", i, tc.name)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// SupportBundleArtifact is an artifact that is included in the archive created by the command returned by
+// SupportBundleCmd.
+type SupportBundleArtifact struct {
+	// Name is the path of the artifact within the archive (for example, "config.yml"). Must use "/" as the separator.
+	Name string
+	// Collect returns the content of the artifact. Artifacts that contain sensitive information (such as resolved
+	// configuration) must redact it before returning the content.
+	Collect func(cmd *cobra.Command) ([]byte, error)
+}
+
+// FileArtifact returns an artifact with the provided name whose content is the content of the file at the provided
+// path. The artifact is omitted (without an error) if the file does not exist.
+func FileArtifact(name, filePath string) SupportBundleArtifact {
+	return SupportBundleArtifact{
+		Name: name,
+		Collect: func(cmd *cobra.Command) ([]byte, error) {
+			content, err := ioutil.ReadFile(filePath)
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return content, err
+		},
+	}
+}
+
+// VersionArtifact returns an artifact named "version.txt" that contains the provided application name and version and
+// information about the runtime.
+func VersionArtifact(appName, version string) SupportBundleArtifact {
+	return SupportBundleArtifact{
+		Name: "version.txt",
+		Collect: func(cmd *cobra.Command) ([]byte, error) {
+			return []byte(fmt.Sprintf("%s version %s\ngo version %s\nplatform %s-%s\n", appName, version, runtime.Version(), runtime.GOOS, runtime.GOARCH)), nil
+		},
+	}
+}
+
+// SupportBundleCmdParam returns a Param that adds the command returned by SupportBundleCmd to the root command. If
+// collectors are registered using EnvironmentParam, the artifact returned by EnvironmentArtifact for those collectors
+// is included in the bundle as well. If RedactOutputParam is provided, the content of every artifact is redacted (see
+// RedactArtifact).
+func SupportBundleCmdParam(artifacts ...SupportBundleArtifact) Param {
+	return paramFunc(func(executor *executor) {
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			if len(executor.environmentCollectors) > 0 {
+				artifacts = append(artifacts[:len(artifacts):len(artifacts)], EnvironmentArtifact(executor.environmentCollectors...))
+			}
+			if executor.redactor != nil {
+				redacted := make([]SupportBundleArtifact, len(artifacts))
+				for i, artifact := range artifacts {
+					redacted[i] = RedactArtifact(artifact, executor.redactor)
+				}
+				artifacts = redacted
+			}
+			cmd.AddCommand(SupportBundleCmd(artifacts...))
+		}).apply(executor)
+	})
+}
+
+// SupportBundleCmd returns a "support-bundle" command that collects the provided artifacts into a single .tar.gz
+// archive that can be attached to support tickets. An artifact that cannot be collected does not cause the command to
+// fail: instead, the errors are recorded in an "errors.txt" file in the archive. Artifacts whose Collect function
+// returns nil content are omitted.
+func SupportBundleCmd(artifacts ...SupportBundleArtifact) *cobra.Command {
+	var outputPath string
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Create an archive of diagnostic information to attach to support tickets",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := outputPath
+			if path == "" {
+				path = fmt.Sprintf("%s-support-bundle-%s.tar.gz", cmd.Root().Name(), time.Now().Format("20060102-150405"))
+			}
+			content, err := createSupportBundle(cmd, artifacts)
+			if err != nil {
+				return err
+			}
+			if err := ioutil.WriteFile(path, content, 0600); err != nil {
+				return fmt.Errorf("failed to write support bundle: %v", err)
+			}
+			cmd.Printf("Wrote support bundle to %s\n", path)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "path of the archive to create (default <app>-support-bundle-<timestamp>.tar.gz)")
+	return cmd
+}
+
+func createSupportBundle(cmd *cobra.Command, artifacts []SupportBundleArtifact) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	modTime := time.Now()
+	writeEntry := func(name string, content []byte) error {
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0600,
+			Size:    int64(len(content)),
+			ModTime: modTime,
+		}); err != nil {
+			return fmt.Errorf("failed to write header for %s: %v", name, err)
+		}
+		if _, err := tarWriter.Write(content); err != nil {
+			return fmt.Errorf("failed to write %s: %v", name, err)
+		}
+		return nil
+	}
+
+	errs := &bytes.Buffer{}
+	for _, artifact := range artifacts {
+		content, err := artifact.Collect(cmd)
+		if err != nil {
+			_, _ = fmt.Fprintf(errs, "%s: %v\n", artifact.Name, err)
+			continue
+		}
+		if content == nil {
+			continue
+		}
+		if err := writeEntry(path.Clean(artifact.Name), content); err != nil {
+			return nil, err
+		}
+	}
+	if errs.Len() > 0 {
+		if err := writeEntry("errors.txt", errs.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close tar writer: %v", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close gzip writer: %v", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestSupportBundleCmd(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	auditLog := filepath.Join(tmpDir, "audit.log")
+	require.NoError(t, ioutil.WriteFile(auditLog, []byte("audit entry\n"), 0644))
+	outputPath := filepath.Join(tmpDir, "bundle.tar.gz")
+
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+	}
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"support-bundle", "--output", outputPath})
+
+	rv := cobracli.Execute(rootCmd, cobracli.SupportBundleCmdParam(
+		cobracli.VersionArtifact("my-app", "1.0.0"),
+		cobracli.FileArtifact("logs/audit.log", auditLog),
+		cobracli.FileArtifact("crash.txt", filepath.Join(tmpDir, "does-not-exist")),
+		cobracli.SupportBundleArtifact{
+			Name: "doctor.txt",
+			Collect: func(cmd *cobra.Command) ([]byte, error) {
+				return nil, fmt.Errorf("doctor failed")
+			},
+		},
+	))
+	require.Equal(t, 0, rv, buf.String())
+	assert.Equal(t, fmt.Sprintf("Wrote support bundle to %s\n", outputPath), buf.String())
+
+	f, err := os.Open(outputPath)
+	require.NoError(t, err)
+	defer func() {
+		_ = f.Close()
+	}()
+	gzipReader, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+
+	got := make(map[string]string)
+	for {
+		hdr, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := ioutil.ReadAll(tarReader)
+		require.NoError(t, err)
+		got[hdr.Name] = string(content)
+	}
+	assert.Contains(t, got["version.txt"], "my-app version 1.0.0\n")
+	delete(got, "version.txt")
+	assert.Equal(t, map[string]string{
+		"logs/audit.log": "audit entry\n",
+		"errors.txt":     "doctor.txt: doctor failed\n",
+	}, got)
+}
+
+func TestSupportBundleCmdDefaultOutput(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() {
+		require.NoError(t, os.Chdir(wd))
+	}()
+
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+	}
+	supportBundleCmd := cobracli.SupportBundleCmd()
+	rootCmd.AddCommand(supportBundleCmd)
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+
+	rv := cobracli.Execute(rootCmd, cobracli.ArgsParam([]string{"support-bundle"}))
+	require.Equal(t, 0, rv, buf.String())
+	assert.Regexp(t, `^Wrote support bundle to my-app-support-bundle-\d{8}-\d{6}\.tar\.gz\n$`, buf.String())
+
+	// the default path is not stored in the flag, so it is computed again by later invocations
+	assert.Equal(t, "", supportBundleCmd.Flags().Lookup("output").Value.String())
+}
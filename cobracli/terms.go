@@ -0,0 +1,148 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/atomicfile"
+	"github.com/palantir/pkg/console"
+	"github.com/palantir/pkg/fsutil"
+	"github.com/palantir/pkg/xdgdir"
+)
+
+// acceptedTermsFileName is the name of the file in the state directory of an application that records the versions of
+// the terms that have been accepted.
+const acceptedTermsFileName = "accepted-terms.json"
+
+// Terms are terms (such as a license or an end-user license agreement) that the user must accept before commands can
+// be run.
+type Terms struct {
+	// Name identifies the terms (for example, "eula"). Acceptance is recorded separately for every name.
+	Name string
+	// Version is the version of the terms. Acceptance of one version does not imply acceptance of other versions, so
+	// the user is asked to accept the terms again when the version changes.
+	Version string
+	// Text is the text shown to the user before asking for acceptance. Typically contains a summary of the terms and a
+	// URL at which the full terms can be read.
+	Text string
+	// AcceptEnvVar is the name of an environment variable that can be set to the version of the terms to accept them
+	// non-interactively (for example, in CI environments). Ignored if empty.
+	AcceptEnvVar string
+}
+
+// TermsNotAcceptedError is the error returned when a command is run before the user has accepted the required terms.
+type TermsNotAcceptedError struct {
+	Terms Terms
+	// Declined is true if the user was asked to accept the terms and declined.
+	Declined bool
+}
+
+func (e *TermsNotAcceptedError) Error() string {
+	if e.Declined {
+		return fmt.Sprintf("the terms of %s (version %s) must be accepted to continue", e.Terms.Name, e.Terms.Version)
+	}
+	msg := fmt.Sprintf("the terms of %s (version %s) have not been accepted: run this command in an interactive terminal to review and accept them", e.Terms.Name, e.Terms.Version)
+	if e.Terms.AcceptEnvVar != "" {
+		msg += fmt.Sprintf(" or set %s=%s to accept them non-interactively", e.Terms.AcceptEnvVar, e.Terms.Version)
+	}
+	return msg
+}
+
+// TermsAcceptanceParam returns a Param that requires the user to accept the provided terms before a command is run.
+// Acceptance is recorded with the version of the terms in the state directory of the application with the provided
+// name (see xdgdir.AppDirs). If the current version of the terms has not been accepted, then:
+//
+//   - If the environment variable named by AcceptEnvVar is set to the version of the terms, acceptance is recorded.
+//   - Otherwise, if standard input and standard error are terminals, the text of the terms is printed and the user is
+//     asked whether they accept them. Acceptance is recorded if they do.
+//   - Otherwise, the command is not run and a *TermsNotAcceptedError that describes how to accept the terms is
+//     returned.
+func TermsAcceptanceParam(appName string, terms Terms) Param {
+	return termsAcceptanceParam(appName, terms, os.Stdin, func() bool {
+		return console.IsTerminal(os.Stdin) && console.IsTerminal(os.Stderr)
+	})
+}
+
+func termsAcceptanceParam(appName string, terms Terms, in io.Reader, interactive func() bool) Param {
+	return runEDecoratorParam(func(next RunEFunc) RunEFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			dirs, err := xdgdir.AppDirs(appName)
+			if err != nil {
+				return err
+			}
+			path := filepath.Join(dirs.State, acceptedTermsFileName)
+			accepted, err := readAcceptedTerms(path)
+			if err != nil {
+				return err
+			}
+			if accepted[terms.Name] == terms.Version {
+				return next(cmd, args)
+			}
+
+			if terms.AcceptEnvVar == "" || os.Getenv(terms.AcceptEnvVar) != terms.Version {
+				if !interactive() {
+					return &TermsNotAcceptedError{Terms: terms}
+				}
+				out := cmd.OutOrStderr()
+				if terms.Text != "" {
+					_, _ = fmt.Fprintln(out, strings.TrimSuffix(terms.Text, "\n"))
+				}
+				_, _ = fmt.Fprintf(out, "Do you accept the terms of %s (version %s)? [y/N] ", terms.Name, terms.Version)
+				answer, readErr := bufio.NewReader(in).ReadString('\n')
+				if readErr != nil && answer == "" {
+					_, _ = fmt.Fprintln(out)
+				}
+				if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+					return &TermsNotAcceptedError{Terms: terms, Declined: true}
+				}
+			}
+
+			accepted[terms.Name] = terms.Version
+			if err := writeAcceptedTerms(dirs.State, path, accepted); err != nil {
+				return err
+			}
+			return next(cmd, args)
+		}
+	})
+}
+
+func readAcceptedTerms(path string) (map[string]string, error) {
+	accepted := make(map[string]string)
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return accepted, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read accepted terms: %v", err)
+	}
+	if err := json.Unmarshal(b, &accepted); err != nil {
+		return nil, fmt.Errorf("failed to read accepted terms from %s: %v", path, err)
+	}
+	return accepted, nil
+}
+
+func writeAcceptedTerms(stateDir, path string, accepted map[string]string) error {
+	if err := fsutil.MkdirAll(stateDir, fsutil.SecretDirMode); err != nil {
+		return fmt.Errorf("failed to create state directory: %v", err)
+	}
+	b, err := json.MarshalIndent(accepted, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to record accepted terms: %v", err)
+	}
+	if err := atomicfile.WriteFile(path, append(b, '\n'), fsutil.SecretFileMode); err != nil {
+		return fmt.Errorf("failed to record accepted terms: %v", err)
+	}
+	return nil
+}
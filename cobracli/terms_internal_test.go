@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTermsAcceptanceParam(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	for name, val := range map[string]string{
+		"XDG_STATE_HOME":      tmpDir,
+		"MY_APP_ACCEPT_TERMS": "",
+	} {
+		orig, ok := os.LookupEnv(name)
+		defer func(name, orig string, ok bool) {
+			if ok {
+				_ = os.Setenv(name, orig)
+			} else {
+				_ = os.Unsetenv(name)
+			}
+		}(name, orig, ok)
+		require.NoError(t, os.Setenv(name, val))
+	}
+
+	statePath := filepath.Join(tmpDir, "my-app", "accepted-terms.json")
+	for i, tc := range []struct {
+		name        string
+		version     string
+		envVal      string
+		input       string
+		interactive bool
+		wantRV      int
+		wantOutput  string
+		wantState   string
+	}{
+		{
+			"non-interactive invocation fails with instructions",
+			"1.0", "", "", false, 1,
+			"Error: the terms of eula (version 1.0) have not been accepted: run this command in an interactive terminal to review and accept them or set MY_APP_ACCEPT_TERMS=1.0 to accept them non-interactively\n",
+			"",
+		},
+		{
+			"declining fails",
+			"1.0", "", "n\n", true, 1,
+			"Terms text\nDo you accept the terms of eula (version 1.0)? [y/N] Error: the terms of eula (version 1.0) must be accepted to continue\n",
+			"",
+		},
+		{
+			"accepting records acceptance",
+			"1.0", "", "y\n", true, 0,
+			"Terms text\nDo you accept the terms of eula (version 1.0)? [y/N] ran\n",
+			"{\n  \"eula\": \"1.0\"\n}\n",
+		},
+		{
+			"accepted version does not prompt",
+			"1.0", "", "", false, 0,
+			"ran\n",
+			"{\n  \"eula\": \"1.0\"\n}\n",
+		},
+		{
+			"environment variable accepts new version",
+			"2.0", "2.0", "", false, 0,
+			"ran\n",
+			"{\n  \"eula\": \"2.0\"\n}\n",
+		},
+		{
+			"environment variable with other version does not accept",
+			"3.0", "2.0", "", false, 1,
+			"Error: the terms of eula (version 3.0) have not been accepted: run this command in an interactive terminal to review and accept them or set MY_APP_ACCEPT_TERMS=3.0 to accept them non-interactively\n",
+			"{\n  \"eula\": \"2.0\"\n}\n",
+		},
+	} {
+		require.NoError(t, os.Setenv("MY_APP_ACCEPT_TERMS", tc.envVal))
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Println("ran")
+			},
+		}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs([]string{})
+
+		terms := Terms{
+			Name:         "eula",
+			Version:      tc.version,
+			Text:         "Terms text",
+			AcceptEnvVar: "MY_APP_ACCEPT_TERMS",
+		}
+		rv := Execute(rootCmd,
+			ConfigureCmdParam(SilenceErrorsConfigurer),
+			ErrorHandlerParam(ErrorPrinterWithDebugHandler(nil, nil)),
+			termsAcceptanceParam("my-app", terms, strings.NewReader(tc.input), func() bool {
+				return tc.interactive
+			}),
+		)
+		assert.Equal(t, tc.wantRV, rv, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d: %s", i, tc.name)
+
+		state, err := ioutil.ReadFile(statePath)
+		if tc.wantState == "" {
+			assert.True(t, os.IsNotExist(err), "Case %d: %s", i, tc.name)
+			continue
+		}
+		require.NoError(t, err, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantState, string(state), "Case %d: %s", i, tc.name)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// TimeoutAnnotation is the key of the command annotation that specifies the maximum amount of time that a command
+	// may run. The value is a duration string as accepted by time.ParseDuration. The annotation of the nearest
+	// ancestor applies to commands that do not specify it. It is enforced by GlobalTimeoutParam.
+	TimeoutAnnotation = "cobracli_timeout"
+
+	// TimeoutExitCode is the exit code used when a command fails because its timeout elapsed. It matches the exit code
+	// used by the "timeout" utility.
+	TimeoutExitCode = 124
+
+	// DefaultDeadlineWarningFraction is the fraction of the time available to a command after which
+	// DeadlineWarningParam warns that the deadline is approaching if no valid fraction is provided.
+	DefaultDeadlineWarningFraction = 0.8
+)
+
+// GlobalTimeoutParam returns a Param that adds "--timeout" as a duration persistent flag and that sets a deadline on
+// the execution context (see Context) of every command that is run. If a command has a TimeoutAnnotation and the flag
+// is also specified, the shorter of the two timeouts is used. If neither is specified, no deadline is set. If a
+// command returns an error after its deadline has elapsed, the error is reported as a timeout and TimeoutExitCode is
+// used as the exit code (unless an exit code extractor is configured). Equivalent to TimeoutParam(0).
+func GlobalTimeoutParam() Param {
+	return TimeoutParam(0)
+}
+
+// TimeoutParam returns a Param that behaves like GlobalTimeoutParam, except that the provided timeout is used as the
+// default value of the "--timeout" flag, so that every command has a deadline unless the flag is set to 0 (in which
+// case only TimeoutAnnotation applies). This is useful for CLIs that are run in environments such as CI where commands
+// that hang must be stopped.
+func TimeoutParam(defaultTimeout time.Duration) Param {
+	return paramFunc(func(executor *executor) {
+		var timeoutFlag time.Duration
+		multiParam(
+			ConfigureCmdParam(func(cmd *cobra.Command) {
+				cmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", defaultTimeout, "maximum amount of time the command may run (for example, 30s or 5m)")
+			}),
+			// applied outside of other Params (such as DeadlineWarningParam) so that they observe the deadline
+			stagedRunEDecoratorParam(stageEarly, func(next RunEFunc) RunEFunc {
+				return func(cmd *cobra.Command, args []string) error {
+					timeout, err := commandTimeout(cmd, timeoutFlag)
+					if err != nil {
+						return err
+					}
+					if timeout <= 0 {
+						return next(cmd, args)
+					}
+
+					ctx, cancel := context.WithTimeout(Context(cmd), timeout)
+					defer cancel()
+					restoreCtx := setContext(cmd, ctx)
+					defer restoreCtx()
+
+					err = next(cmd, args)
+					if err != nil && ctx.Err() == context.DeadlineExceeded {
+						return &timeoutError{
+							err:     err,
+							timeout: timeout,
+						}
+					}
+					return err
+				}
+			}),
+		).apply(executor)
+	})
+}
+
+// DeadlineWarningParam returns a Param that writes a warning to the status writer (see Status) once the provided
+// fraction of the time available to a command has elapsed, so that operators can react before the command is stopped.
+// The time available is determined by the deadline of the execution context of the command (see Context), such as the
+// deadline set by GlobalTimeoutParam. Commands whose context has no deadline are not affected. If the fraction is not
+// greater than 0 and less than 1, DefaultDeadlineWarningFraction is used.
+func DeadlineWarningParam(fraction float64) Param {
+	if fraction <= 0 || fraction >= 1 {
+		fraction = DefaultDeadlineWarningFraction
+	}
+	return runEDecoratorParam(func(next RunEFunc) RunEFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			ctx := Context(cmd)
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				return next(cmd, args)
+			}
+			available := time.Until(deadline)
+			if available <= 0 {
+				return next(cmd, args)
+			}
+
+			timer := time.NewTimer(time.Duration(float64(available) * fraction))
+			done := make(chan struct{})
+			var wg sync.WaitGroup
+			wg.Add(1)
+			statusWriter := Status(cmd)
+			go func() {
+				defer wg.Done()
+				select {
+				case <-timer.C:
+					remaining := time.Until(deadline).Round(time.Millisecond)
+					if remaining < 0 {
+						remaining = 0
+					}
+					_, _ = fmt.Fprintf(statusWriter, "Warning: %.0f%% of the %v timeout has elapsed (%v remaining)\n", fraction*100, available.Round(time.Millisecond), remaining)
+				case <-ctx.Done():
+				case <-done:
+				}
+			}()
+			defer func() {
+				timer.Stop()
+				close(done)
+				wg.Wait()
+			}()
+			return next(cmd, args)
+		}
+	})
+}
+
+// commandTimeout returns the timeout that applies to the provided command: the shorter of the provided flag timeout and
+// the timeout specified by the TimeoutAnnotation of the command or its nearest ancestor that has one. Values that are
+// not positive are ignored.
+func commandTimeout(cmd *cobra.Command, flagTimeout time.Duration) (time.Duration, error) {
+	timeout := flagTimeout
+	for curr := cmd; curr != nil; curr = curr.Parent() {
+		val, ok := curr.Annotations[TimeoutAnnotation]
+		if !ok {
+			continue
+		}
+		annotationTimeout, err := time.ParseDuration(val)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value %q for annotation %s of %q: %v", val, TimeoutAnnotation, curr.CommandPath(), err)
+		}
+		if annotationTimeout > 0 && (timeout <= 0 || annotationTimeout < timeout) {
+			timeout = annotationTimeout
+		}
+		break
+	}
+	return timeout, nil
+}
+
+type timeoutError struct {
+	err     error
+	timeout time.Duration
+}
+
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf("timed out after %v: %v", e.timeout, e.err)
+}
+
+func (e *timeoutError) ExitCode() int {
+	return TimeoutExitCode
+}
+
+func (e *timeoutError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestGlobalTimeoutParam(t *testing.T) {
+	for i, tc := range []struct {
+		annotation string
+		args       []string
+		wantRV     int
+		wantOutput string
+	}{
+		{"", nil, 0, "no deadline\n"},
+		{"", []string{"--timeout", "10ms"}, cobracli.TimeoutExitCode, "Error: timed out after 10ms: context deadline exceeded\n"},
+		{"20ms", nil, cobracli.TimeoutExitCode, "Error: timed out after 20ms: context deadline exceeded\n"},
+		{"20ms", []string{"--timeout", "10ms"}, cobracli.TimeoutExitCode, "Error: timed out after 10ms: context deadline exceeded\n"},
+		{"10ms", []string{"--timeout", "1h"}, cobracli.TimeoutExitCode, "Error: timed out after 10ms: context deadline exceeded\n"},
+		{"invalid", nil, 1, "Error: invalid value \"invalid\" for annotation cobracli_timeout of \"my-app\": time: invalid duration \"invalid\"\n"},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				ctx := cobracli.Context(cmd)
+				if _, ok := ctx.Deadline(); !ok {
+					cmd.Println("no deadline")
+					return nil
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(5 * time.Second):
+					return nil
+				}
+			},
+		}
+		if tc.annotation != "" {
+			rootCmd.Annotations = map[string]string{
+				cobracli.TimeoutAnnotation: tc.annotation,
+			}
+		}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.GlobalTimeoutParam())...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+	}
+}
+
+func TestDeadlineWarningParam(t *testing.T) {
+	for i, tc := range []struct {
+		args        []string
+		sleep       time.Duration
+		wantRV      int
+		wantWarning bool
+	}{
+		{nil, 0, 0, false},
+		{[]string{"--timeout", "1h"}, 0, 0, false},
+		{[]string{"--timeout", "100ms"}, time.Second, cobracli.TimeoutExitCode, true},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if tc.sleep == 0 {
+					return nil
+				}
+				ctx := cobracli.Context(cmd)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(tc.sleep):
+					return nil
+				}
+			},
+		}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.GlobalTimeoutParam(), cobracli.DeadlineWarningParam(0.5))...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		if tc.wantWarning {
+			assert.Regexp(t, `^Warning: 50% of the 100ms timeout has elapsed \(\d+ms remaining\)\nError: timed out after 100ms: context deadline exceeded\n$`, buf.String(), "Case %d", i)
+		} else {
+			assert.NotContains(t, buf.String(), "Warning", "Case %d", i)
+		}
+	}
+}
+
+func TestTimeoutParam(t *testing.T) {
+	for i, tc := range []struct {
+		args       []string
+		wantRV     int
+		wantOutput string
+	}{
+		{nil, cobracli.TimeoutExitCode, "Error: timed out after 10ms: context deadline exceeded\n"},
+		{[]string{"--timeout", "20ms"}, cobracli.TimeoutExitCode, "Error: timed out after 20ms: context deadline exceeded\n"},
+		{[]string{"--timeout", "0"}, 0, "no deadline\n"},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				ctx := cobracli.Context(cmd)
+				if _, ok := ctx.Deadline(); !ok {
+					cmd.Println("no deadline")
+					return nil
+				}
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil), cobracli.TimeoutParam(10*time.Millisecond))...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+	}
+}
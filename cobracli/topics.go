@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// TopicAnnotation is the key of the annotation that is set on commands created using AddTopic.
+const TopicAnnotation = "cobracli_topic"
+
+const (
+	defaultTopicsSection = `{{if .HasHelpSubCommands}}
+
+Additional help topics:{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
+  {{rpad .CommandPath .CommandPathPadding}} {{.Short}}{{end}}{{end}}{{end}}`
+	topicsSection = `{{if .HasHelpSubCommands}}
+
+Topics:{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
+  {{rpad .Name .NamePadding}} {{.Short}}{{end}}{{end}}
+
+Use "{{.Root.Name}} help [topic]" to read about a topic.{{end}}`
+)
+
+// AddTopic adds a documentation topic with the provided name to the provided parent command and returns it. A topic
+// is a command that is not runnable and has no subcommands: invoking it (directly or using "help <topic>") prints the
+// provided long description. Topics are not listed as available commands: instead, they are listed as help topics in
+// the help output of their parent.
+func AddTopic(parent *cobra.Command, name, short, long string) *cobra.Command {
+	topic := &cobra.Command{
+		Use:   name,
+		Short: short,
+		Long:  long,
+		Annotations: map[string]string{
+			TopicAnnotation: "true",
+		},
+	}
+	parent.AddCommand(topic)
+	return topic
+}
+
+// IsTopic returns true if the provided command was created using AddTopic.
+func IsTopic(cmd *cobra.Command) bool {
+	_, ok := cmd.Annotations[TopicAnnotation]
+	return ok
+}
+
+// TopicsParam returns a Param that lists help topics under a "Topics" section in help output that shows the name of
+// each topic and how to read it. Has no effect if the root command uses a custom usage template.
+func TopicsParam() Param {
+	return ConfigureCmdParam(func(rootCmd *cobra.Command) {
+		rootCmd.SetUsageTemplate(strings.Replace(rootCmd.UsageTemplate(), defaultTopicsSection, topicsSection, 1))
+	})
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestTopics(t *testing.T) {
+	for i, tc := range []struct {
+		args       []string
+		wantOutput string
+	}{
+		{[]string{"help", "environment"}, "The following environment variables are used.\n\n"},
+		{[]string{"exit-codes"}, "Exit codes:\n  0 success\n  1 failure\n\n"},
+		{[]string{"--help"}, `Usage:
+  my-app [command]
+
+Available Commands:
+  build       Build the project
+  help        Help about any command
+
+Flags:
+  -h, --help   help for my-app
+
+Topics:
+  environment Environment variables
+  exit-codes  Exit codes
+
+Use "my-app help [topic]" to read about a topic.
+
+Use "my-app [command] --help" for more information about a command.
+`},
+	} {
+		rootCmd := &cobra.Command{Use: "my-app"}
+		rootCmd.AddCommand(&cobra.Command{
+			Use:   "build",
+			Short: "Build the project",
+			Run:   func(cmd *cobra.Command, args []string) {},
+		})
+		environment := cobracli.AddTopic(rootCmd, "environment", "Environment variables", "The following environment variables are used.")
+		cobracli.AddTopic(rootCmd, "exit-codes", "Exit codes", "Exit codes:\n  0 success\n  1 failure")
+		assert.True(t, cobracli.IsTopic(environment), "Case %d", i)
+		assert.False(t, cobracli.IsTopic(rootCmd), "Case %d", i)
+
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, cobracli.TopicsParam())
+		assert.Equal(t, 0, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+	}
+}
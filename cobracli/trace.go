@@ -0,0 +1,224 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Names of the phases recorded by TraceExecutionParam and WriteResult.
+const (
+	// TracePhaseStartup is the phase from the start of Execute until the Run/RunE function of the command is called.
+	// It includes parsing flags and arguments, validating arguments and running the pre-run functions.
+	TracePhaseStartup = "startup"
+	// TracePhaseRun is the phase in which the Run/RunE function of the command runs.
+	TracePhaseRun = "run"
+	// TracePhaseShutdown is the phase from the return of the Run/RunE function of the command until the command tree
+	// returns. It includes running the post-run functions.
+	TracePhaseShutdown = "shutdown"
+	// TracePhaseRender is the phase in which WriteResult renders the result of a command.
+	TracePhaseRender = "render"
+)
+
+const traceValueName = "trace"
+
+// traceNow returns the current time. It is a variable so that it can be replaced in tests.
+var traceNow = time.Now
+
+// PhaseTiming is the timing of a single phase of the execution of a command.
+type PhaseTiming struct {
+	// Name is the name of the phase.
+	Name string
+	// Depth is the nesting depth of the phase. Phases recorded using TracePhase while another phase is in progress
+	// are nested in that phase.
+	Depth int
+	// Start is the offset of the start of the phase from the start of the execution.
+	Start time.Duration
+	// Duration is the duration of the phase.
+	Duration time.Duration
+}
+
+// ExecutionTrace is the timing breakdown of the execution of a command.
+type ExecutionTrace struct {
+	// Command is the path of the command that was executed.
+	Command string
+	// Start is the time at which the execution started.
+	Start time.Time
+	// Duration is the total duration of the execution.
+	Duration time.Duration
+	// Err is the error returned by the command, if any.
+	Err error
+	// Phases are the phases of the execution in the order in which they started.
+	Phases []PhaseTiming
+}
+
+// Write writes a human-readable timing breakdown of the trace to the provided writer. Nested phases are indented
+// under the phase in which they ran.
+func (t ExecutionTrace) Write(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "Timing for %s (total %v):\n", t.Command, t.Duration); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, phase := range t.Phases {
+		if _, err := fmt.Fprintf(tw, "  %s%s\t%v\t%s\n", strings.Repeat("  ", phase.Depth), phase.Name, phase.Duration, tracePercent(phase.Duration, t.Duration)); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+func tracePercent(d, total time.Duration) string {
+	if total <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.1f%%", float64(d)*100/float64(total))
+}
+
+// TraceReporter is a function that reports the trace of the execution of a command. It is called after the command
+// tree returns and before the error handlers are run.
+type TraceReporter func(cmd *cobra.Command, trace ExecutionTrace)
+
+// TraceExecutionParam returns a Param that records the timing of the phases of the execution of a command and reports
+// the resulting trace using the provided reporter once the command returns. The startup, run and shutdown phases are
+// recorded automatically, and commands and other Params can record additional phases (for example, loading
+// configuration) using TracePhase. WriteResult records the time spent rendering results as the render phase. Time
+// spent in Params that wrap the Run/RunE function of the command and are provided before this Param (or that are always
+// applied outside of other Params, see CommandMiddlewareParam) is counted as part of the run phase, while time spent
+// in those provided after it is counted as part of the startup phase.
+//
+// DebugTraceReporter returns a reporter that writes the timing breakdown when a debug flag is set. A reporter that
+// exports traces to a telemetry backend can be provided instead, or combined with it.
+func TraceExecutionParam(reporter TraceReporter) Param {
+	var tracer *executionTracer
+	return paramFunc(func(executor *executor) {
+		executor.rootCmdConfigurers = append(executor.rootCmdConfigurers, func(*cobra.Command) {
+			tracer = &executionTracer{
+				start: traceNow(),
+			}
+		})
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				tracer.endStartup()
+				restoreTracer := setInvocationValue(cmd, traceValueName, tracer)
+				defer restoreTracer()
+
+				endRun := tracer.begin(TracePhaseRun)
+				defer func() {
+					endRun()
+					tracer.mutex.Lock()
+					tracer.runEnd = traceNow()
+					tracer.mutex.Unlock()
+				}()
+				return next(cmd, args)
+			}
+		}).apply(executor)
+		executor.completionHandlers = append(executor.completionHandlers, func(executedCmd *cobra.Command, err error) {
+			if reporter == nil {
+				return
+			}
+			reporter(executedCmd, tracer.finish(executedCmd, err))
+		})
+	})
+}
+
+// DebugTraceReporter returns a TraceReporter that writes the timing breakdown of the trace to the status writer of the
+// command (see Status) if the value of the provided debug variable is true when the command returns.
+func DebugTraceReporter(debugVar *bool) TraceReporter {
+	return func(cmd *cobra.Command, trace ExecutionTrace) {
+		if debugVar == nil || !*debugVar {
+			return
+		}
+		_ = trace.Write(Status(cmd))
+	}
+}
+
+// TracePhase records the start of a phase with the provided name in the trace of the command that is running and
+// returns a function that records its end. Phases that start while another phase is in progress are nested in that
+// phase. If the execution of the command is not being traced (see TraceExecutionParam), the returned function does
+// nothing.
+//
+// Typical usage is "defer cobracli.TracePhase(cmd, "config load")()".
+func TracePhase(cmd *cobra.Command, name string) (end func()) {
+	v, ok := invocationValue(cmd, traceValueName)
+	if !ok {
+		return func() {}
+	}
+	return v.(*executionTracer).begin(name)
+}
+
+type executionTracer struct {
+	mutex        sync.Mutex
+	start        time.Time
+	startupEnded bool
+	runEnd       time.Time
+	depth        int
+	phases       []PhaseTiming
+}
+
+func (t *executionTracer) endStartup() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.startupEnded {
+		return
+	}
+	t.startupEnded = true
+	t.phases = append(t.phases, PhaseTiming{
+		Name:     TracePhaseStartup,
+		Duration: traceNow().Sub(t.start),
+	})
+}
+
+func (t *executionTracer) begin(name string) func() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	start := traceNow()
+	idx := len(t.phases)
+	t.phases = append(t.phases, PhaseTiming{
+		Name:  name,
+		Depth: t.depth,
+		Start: start.Sub(t.start),
+	})
+	t.depth++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.mutex.Lock()
+			defer t.mutex.Unlock()
+			t.phases[idx].Duration = traceNow().Sub(start)
+			t.depth--
+		})
+	}
+}
+
+func (t *executionTracer) finish(cmd *cobra.Command, err error) ExecutionTrace {
+	// the command did not run if the startup phase did not end (for example, if parsing the flags failed)
+	t.endStartup()
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	end := traceNow()
+	if !t.runEnd.IsZero() {
+		t.phases = append(t.phases, PhaseTiming{
+			Name:     TracePhaseShutdown,
+			Start:    t.runEnd.Sub(t.start),
+			Duration: end.Sub(t.runEnd),
+		})
+	}
+	return ExecutionTrace{
+		Command:  cmd.CommandPath(),
+		Start:    t.start,
+		Duration: end.Sub(t.start),
+		Err:      err,
+		Phases:   append([]PhaseTiming(nil), t.phases...),
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceExecutionParam(t *testing.T) {
+	now := time.Unix(0, 0)
+	origTraceNow := traceNow
+	traceNow = func() time.Time {
+		return now
+	}
+	defer func() {
+		traceNow = origTraceNow
+	}()
+
+	for i, tc := range []struct {
+		name       string
+		args       []string
+		debug      bool
+		wantCode   int
+		wantPhases []PhaseTiming
+		wantOutput string
+	}{
+		{
+			name:     "successful command",
+			args:     []string{"sub"},
+			wantCode: 0,
+			wantPhases: []PhaseTiming{
+				{Name: TracePhaseStartup, Duration: 10 * time.Millisecond},
+				{Name: TracePhaseRun, Start: 10 * time.Millisecond, Duration: 25 * time.Millisecond},
+				{Name: "load", Depth: 1, Start: 15 * time.Millisecond, Duration: 20 * time.Millisecond},
+				{Name: TracePhaseRender, Depth: 1, Start: 35 * time.Millisecond},
+				{Name: TracePhaseShutdown, Start: 35 * time.Millisecond, Duration: 3 * time.Millisecond},
+			},
+		},
+		{
+			name:     "debug output",
+			args:     []string{"sub"},
+			debug:    true,
+			wantCode: 0,
+			wantPhases: []PhaseTiming{
+				{Name: TracePhaseStartup, Duration: 10 * time.Millisecond},
+				{Name: TracePhaseRun, Start: 10 * time.Millisecond, Duration: 25 * time.Millisecond},
+				{Name: "load", Depth: 1, Start: 15 * time.Millisecond, Duration: 20 * time.Millisecond},
+				{Name: TracePhaseRender, Depth: 1, Start: 35 * time.Millisecond},
+				{Name: TracePhaseShutdown, Start: 35 * time.Millisecond, Duration: 3 * time.Millisecond},
+			},
+			wantOutput: `Timing for my-app sub (total 38ms):
+  startup   10ms  26.3%
+  run       25ms  65.8%
+    load    20ms  52.6%
+    render  0s    0.0%
+  shutdown  3ms   7.9%
+`,
+		},
+		{
+			name:     "command does not run",
+			args:     []string{"sub", "--unknown"},
+			wantCode: 1,
+			wantPhases: []PhaseTiming{
+				{Name: TracePhaseStartup},
+			},
+		},
+	} {
+		now = time.Unix(0, 0)
+		debug := tc.debug
+		var gotTrace ExecutionTrace
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+		}
+		subCmd := &cobra.Command{
+			Use: "sub",
+			PersistentPreRun: func(cmd *cobra.Command, args []string) {
+				now = now.Add(10 * time.Millisecond)
+			},
+			RunE: func(cmd *cobra.Command, args []string) error {
+				now = now.Add(5 * time.Millisecond)
+				endLoad := TracePhase(cmd, "load")
+				now = now.Add(20 * time.Millisecond)
+				endLoad()
+				return WriteResult(cmd, "result")
+			},
+			PostRun: func(cmd *cobra.Command, args []string) {
+				now = now.Add(3 * time.Millisecond)
+			},
+		}
+		rootCmd.AddCommand(subCmd)
+		rootCmd.SetArgs(tc.args)
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		statusBuf := &bytes.Buffer{}
+
+		code := Execute(rootCmd,
+			TraceExecutionParam(func(cmd *cobra.Command, trace ExecutionTrace) {
+				gotTrace = trace
+				restore := setStatus(cmd, statusBuf)
+				defer restore()
+				DebugTraceReporter(&debug)(cmd, trace)
+			}),
+		)
+		require.Equal(t, tc.wantCode, code, "Case %d: %s\nOutput:\n%s", i, tc.name, buf.String())
+		assert.Equal(t, "my-app sub", gotTrace.Command, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantPhases, gotTrace.Phases, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantOutput, statusBuf.String(), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestTracePhaseNotTraced(t *testing.T) {
+	cmd := &cobra.Command{
+		Use: "my-app",
+	}
+	end := TracePhase(cmd, "load")
+	end()
+}
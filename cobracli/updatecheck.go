@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/palantir/pkg/fsutil"
+	"github.com/palantir/pkg/safejson"
+	"github.com/palantir/pkg/xdgdir"
+)
+
+const (
+	// UpdateCheckInterval is the minimum interval between the update checks performed by UpdateCheckParam.
+	UpdateCheckInterval = 24 * time.Hour
+
+	// updateCheckFileName is the name of the file in the state directory of an application that records the result of
+	// the last update check.
+	updateCheckFileName = "update-check.json"
+)
+
+// updateCheckWait is the maximum amount of time that UpdateCheckParam waits for an update check that is still running
+// when the command returns. It is a variable so that it can be replaced in tests.
+var updateCheckWait = 500 * time.Millisecond
+
+// updateCheckState is the content of the file that records the result of the last update check.
+type updateCheckState struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Latest    string    `json:"latest,omitempty"`
+}
+
+// UpdateCheckParam returns a Param that checks whether a newer version of the application is available while a
+// command runs and, if one is, writes a one-line notice that describes it to the status writer (see Status) after the
+// command returns. The current version is the Version of the root command (see VersionFlagParam): if it is empty, no
+// check is performed. The provided checker is called with the execution context of the command and the current version
+// and returns the latest version.
+//
+// The checker is called in a separate goroutine at most once every UpdateCheckInterval: the time at which the check is
+// started and the latest version are recorded in the state directory of the application (see xdgdir.AppDirs), whose
+// name is the name of the root command, and the recorded latest version is used for the notice until the next check.
+// If the check has not completed shortly after the command returns, its result is discarded. Errors returned by the
+// checker are ignored. No notice is written if the command is running quietly (see QuietFlagParam).
+func UpdateCheckParam(checker func(ctx context.Context, current string) (latest string, err error)) Param {
+	return runEDecoratorParam(func(next RunEFunc) RunEFunc {
+		return func(cmd *cobra.Command, args []string) error {
+			current := cmd.Root().Version
+			if current == "" {
+				return next(cmd, args)
+			}
+			statePath := ""
+			if dirs, err := xdgdir.AppDirs(cmd.Root().Name()); err == nil {
+				statePath = filepath.Join(dirs.State, updateCheckFileName)
+			}
+			state := readUpdateCheckState(statePath)
+
+			var results chan string
+			if time.Since(state.CheckedAt) >= UpdateCheckInterval {
+				// the attempt is recorded before the check starts so that checks that fail or do not complete before
+				// the command returns are not retried by every invocation
+				state.CheckedAt = time.Now()
+				writeUpdateCheckState(statePath, state)
+				results = make(chan string, 1)
+				ctx := Context(cmd)
+				go func() {
+					latest, err := checker(ctx, current)
+					if err != nil {
+						latest = ""
+					}
+					results <- latest
+				}()
+			}
+
+			runErr := next(cmd, args)
+
+			if results != nil {
+				select {
+				case latest := <-results:
+					if latest != "" {
+						state.Latest = latest
+						writeUpdateCheckState(statePath, state)
+					}
+				case <-time.After(updateCheckWait):
+				}
+			}
+			if state.Latest != "" && isNewerVersion(state.Latest, current) && !IsQuiet(cmd) {
+				_, _ = fmt.Fprintf(Status(cmd), "A new version of %s is available: %s (current version: %s)\n", cmd.Root().Name(), state.Latest, current)
+			}
+			return runErr
+		}
+	})
+}
+
+func readUpdateCheckState(path string) updateCheckState {
+	var state updateCheckState
+	if path == "" {
+		return state
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return state
+	}
+	_ = safejson.Unmarshal(b, &state)
+	return state
+}
+
+// writeUpdateCheckState records the provided state at the provided path. Errors are ignored: if the state cannot be
+// recorded, the check is performed again on the next invocation.
+func writeUpdateCheckState(path string, state updateCheckState) {
+	if path == "" {
+		return
+	}
+	b, err := safejson.Marshal(state)
+	if err != nil {
+		return
+	}
+	if err := fsutil.MkdirAll(filepath.Dir(path), fsutil.SecretDirMode); err != nil {
+		return
+	}
+	_ = fsutil.WriteFile(path, b, fsutil.SecretFileMode)
+}
+
+// isNewerVersion returns true if the latest version is newer than the current version. Versions are compared by their
+// dot-separated numeric components (ignoring a leading "v" and any pre-release or build suffix). If either version
+// does not have this form, the latest version is considered newer if it differs from the current version.
+func isNewerVersion(latest, current string) bool {
+	latestParts, ok := versionParts(latest)
+	if !ok {
+		return latest != current
+	}
+	currentParts, ok := versionParts(current)
+	if !ok {
+		return latest != current
+	}
+	for i := 0; i < len(latestParts) || i < len(currentParts); i++ {
+		var l, c int
+		if i < len(latestParts) {
+			l = latestParts[i]
+		}
+		if i < len(currentParts) {
+			c = currentParts[i]
+		}
+		if l != c {
+			return l > c
+		}
+	}
+	return false
+}
+
+func versionParts(version string) ([]int, bool) {
+	version = strings.TrimPrefix(version, "v")
+	if idx := strings.IndexAny(version, "-+"); idx >= 0 {
+		version = version[:idx]
+	}
+	var parts []int
+	for _, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateCheckParam(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	orig, ok := os.LookupEnv("XDG_STATE_HOME")
+	defer func() {
+		if ok {
+			_ = os.Setenv("XDG_STATE_HOME", orig)
+		} else {
+			_ = os.Unsetenv("XDG_STATE_HOME")
+		}
+	}()
+	require.NoError(t, os.Setenv("XDG_STATE_HOME", tmpDir))
+	origWait := updateCheckWait
+	defer func() {
+		updateCheckWait = origWait
+	}()
+	updateCheckWait = 50 * time.Millisecond
+	statePath := filepath.Join(tmpDir, "my-app", updateCheckFileName)
+
+	for i, tc := range []struct {
+		name        string
+		version     string
+		state       *updateCheckState
+		latest      string
+		slow        bool
+		wantChecked bool
+		wantOutput  string
+	}{
+		{"first check", "1.1.0", nil, "1.2.0", false, true, "ran\nA new version of my-app is available: 1.2.0 (current version: 1.1.0)\n"},
+		{"recorded check", "1.1.0", nil, "1.3.0", false, false, "ran\nA new version of my-app is available: 1.2.0 (current version: 1.1.0)\n"},
+		{"no version", "", nil, "1.3.0", false, false, "ran\n"},
+		{"expired check", "1.1.0", &updateCheckState{CheckedAt: time.Now().Add(-2 * UpdateCheckInterval), Latest: "1.2.0"}, "1.1.0", false, true, "ran\n"},
+		{"slow check", "1.1.0", &updateCheckState{CheckedAt: time.Now().Add(-2 * UpdateCheckInterval)}, "1.2.0", true, true, "ran\n"},
+		// the attempt is recorded even though the slow check did not complete
+		{"after slow check", "1.1.0", nil, "1.2.0", false, false, "ran\n"},
+	} {
+		if tc.state != nil {
+			writeUpdateCheckState(statePath, *tc.state)
+		}
+		var checked int32
+		checker := func(ctx context.Context, current string) (string, error) {
+			atomic.StoreInt32(&checked, 1)
+			assert.Equal(t, tc.version, current, "Case %d: %s", i, tc.name)
+			if tc.slow {
+				<-ctx.Done()
+				return "", ctx.Err()
+			}
+			return tc.latest, nil
+		}
+		rootCmd := &cobra.Command{
+			Use:     "my-app",
+			Version: tc.version,
+			Run: func(cmd *cobra.Command, args []string) {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "ran")
+			},
+		}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(nil)
+
+		rv := Execute(rootCmd, UpdateCheckParam(checker))
+		assert.Equal(t, 0, rv, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantChecked, atomic.LoadInt32(&checked) == 1, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestIsNewerVersion(t *testing.T) {
+	for i, tc := range []struct {
+		latest  string
+		current string
+		want    bool
+	}{
+		{"1.2.0", "1.1.0", true},
+		{"1.10.0", "1.9.0", true},
+		{"v2.0.0", "1.9.9", true},
+		{"1.1.0", "1.1.0", false},
+		{"1.1", "1.1.0", false},
+		{"1.1.0", "1.2.0-rc1", false},
+		{"1.2.0", "1.2.0-5-gabcdef", false},
+		{"nightly-2", "nightly-1", true},
+		{"nightly-1", "nightly-1", false},
+	} {
+		assert.Equal(t, tc.want, isNewerVersion(tc.latest, tc.current), "Case %d", i)
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// UsageError is the error returned when a command is invoked incorrectly: for example, when an unknown flag is
+// specified, when a flag value is invalid or when the positional arguments are not valid for the command. The fields of
+// the error describe the failure in a structured manner and are included when the error is marshaled as JSON.
+type UsageError struct {
+	// Command is the path of the command that was invoked (for example, "my-app server start").
+	Command string `json:"command"`
+	// Flag is the name of the flag that caused the error (without leading dashes), if any.
+	Flag string `json:"flag,omitempty"`
+	// Arg is the positional argument or flag value that caused the error, if any.
+	Arg string `json:"arg,omitempty"`
+	// Suggestion is the flag name or argument that the user may have meant, if any.
+	Suggestion string `json:"suggestion,omitempty"`
+	// Err is the underlying error.
+	Err error `json:"-"`
+}
+
+func (e *UsageError) Error() string {
+	if e.Suggestion == "" {
+		return e.Err.Error()
+	}
+	suggestion := e.Suggestion
+	if e.Flag != "" {
+		suggestion = "--" + suggestion
+	}
+	return fmt.Sprintf("%v (did you mean %q?)", e.Err, suggestion)
+}
+
+// Cause returns the underlying error.
+func (e *UsageError) Cause() error {
+	return e.Err
+}
+
+// Unwrap returns the underlying error.
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON marshals the error as a JSON object that contains its fields and its message.
+func (e *UsageError) MarshalJSON() ([]byte, error) {
+	type usageError UsageError
+	return json.Marshal(struct {
+		*usageError
+		Message string `json:"message"`
+	}{
+		usageError: (*usageError)(e),
+		Message:    e.Error(),
+	})
+}
+
+// ErrorChain returns the provided error followed by the errors that it wraps, outermost first. The wrapped errors are
+// determined using "Cause() error" (as implemented by github.com/pkg/errors) and "Unwrap() error" (as implemented by
+// errors created using fmt.Errorf with the "%w" verb). Returns nil if err is nil.
+func ErrorChain(err error) []error {
+	var chain []error
+	for err != nil {
+		chain = append(chain, err)
+		switch e := err.(type) {
+		case interface{ Cause() error }:
+			err = e.Cause()
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			err = nil
+		}
+	}
+	return chain
+}
+
+// AsUsageError returns the *UsageError in the chain of the provided error (see ErrorChain). Returns false if the chain
+// does not contain a *UsageError.
+func AsUsageError(err error) (*UsageError, bool) {
+	for _, curr := range ErrorChain(err) {
+		if usageErr, ok := curr.(*UsageError); ok {
+			return usageErr, true
+		}
+	}
+	return nil, false
+}
+
+// UsageErrorsConfigurer configures the provided command and all of its subcommands so that errors that occur while
+// parsing flags or validating positional arguments are returned as a *UsageError. Commands that do not specify Args
+// use Cobra's legacy argument validation, which is not affected.
+func UsageErrorsConfigurer(command *cobra.Command) {
+	command.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return newFlagUsageError(c, err)
+	})
+	visitCommands(command, func(cmd *cobra.Command) {
+		if cmd.Args == nil {
+			return
+		}
+		validate := cmd.Args
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			err := validate(cmd, args)
+			if err == nil {
+				return nil
+			}
+			if _, ok := err.(*UsageError); ok {
+				return err
+			}
+			return newArgUsageError(cmd, err)
+		}
+	})
+}
+
+// SuggestionsConfigurer configures the provided command and all of its subcommands to suggest the closest matching
+// subcommand when an unknown subcommand is specified. The suggestion is based on the Levenshtein distance between the
+// specified name and the names of the available subcommands, which must be at most the SuggestionsMinimumDistance of
+// the command (2 if it is not set), and on the SuggestFor field of the subcommands. For example:
+//
+//	unknown command "stat" for "my-app server" (did you mean "start"?)
+//
+// Cobra only reports unknown subcommands for the root command: by default, specifying an unknown subcommand of a
+// command that is not runnable prints its help and succeeds. This configurer makes such commands return a *UsageError
+// for unknown subcommands and print their help only if no arguments are specified. Commands that specify Args and
+// runnable subcommands that have subcommands of their own (which accept arbitrary arguments) are not affected.
+//
+// This configurer should be provided after any Params that add subcommands so that they are included. The suggestions
+// for unknown flags are provided by UsageErrorsConfigurer and FlagErrorsUsageErrorConfigurer.
+func SuggestionsConfigurer(command *cobra.Command) {
+	visitCommands(command, func(cmd *cobra.Command) {
+		cmd.DisableSuggestions = false
+		if cmd.SuggestionsMinimumDistance <= 0 {
+			cmd.SuggestionsMinimumDistance = 2
+		}
+		if cmd.Args != nil || !cmd.HasSubCommands() || (cmd.HasParent() && cmd.Runnable()) {
+			return
+		}
+		cmd.Args = unknownSubcommandArgs
+		if !cmd.Runnable() {
+			cmd.RunE = func(cmd *cobra.Command, args []string) error {
+				return cmd.Help()
+			}
+		}
+	})
+}
+
+// unknownSubcommandArgs returns a *UsageError that suggests the closest matching subcommand if any arguments are
+// provided. It is only used for commands that have subcommands, so any argument is an unknown subcommand.
+func unknownSubcommandArgs(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	usageErr := &UsageError{
+		Command: cmd.CommandPath(),
+		Arg:     args[0],
+		Err:     fmt.Errorf("unknown command %q for %q", args[0], cmd.CommandPath()),
+	}
+	if suggestions := cmd.SuggestionsFor(args[0]); len(suggestions) > 0 {
+		// prefer the closest suggestion by distance; suggestions due to SuggestFor may not be close by distance
+		usageErr.Suggestion = closestMatch(cmd, args[0], suggestions)
+		if usageErr.Suggestion == "" {
+			usageErr.Suggestion = suggestions[0]
+		}
+	}
+	return usageErr
+}
+
+// PrintUsageOnUsageErrorHandlerDecorator decorates the provided error handler to add functionality that prints the
+// command usage if the error that occurred is a *UsageError (see AsUsageError). This handler first processes the error
+// using the provided handler.
+func PrintUsageOnUsageErrorHandlerDecorator(fn func(*cobra.Command, error)) func(*cobra.Command, error) {
+	return func(command *cobra.Command, err error) {
+		fn(command, err)
+
+		if _, ok := AsUsageError(err); !ok {
+			return
+		}
+		command.Println(strings.TrimSuffix(command.UsageString(), "\n"))
+	}
+}
+
+var (
+	unknownFlagRegexp       = regexp.MustCompile(`^unknown flag: --(\S+)`)
+	unknownShorthandRegexp  = regexp.MustCompile(`^(?:unknown shorthand flag|flag needs an argument): '(.)' in -`)
+	flagNeedsArgumentRegexp = regexp.MustCompile(`^flag needs an argument: -{1,2}(\S+)`)
+	invalidFlagValueRegexp  = regexp.MustCompile(`^invalid argument ("(?:[^"\\]|\\.)*") for "(?:-., )?--(\S+)" flag`)
+	invalidArgRegexp        = regexp.MustCompile(`^(?:invalid argument|unknown command) ("(?:[^"\\]|\\.)*") for `)
+)
+
+func newFlagUsageError(cmd *cobra.Command, err error) *UsageError {
+	usageErr := &UsageError{
+		Command: cmd.CommandPath(),
+		Err:     err,
+	}
+	msg := err.Error()
+	if m := unknownFlagRegexp.FindStringSubmatch(msg); m != nil {
+		usageErr.Flag = m[1]
+		var names []string
+		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			if !flag.Hidden {
+				names = append(names, flag.Name)
+			}
+		})
+		usageErr.Suggestion = closestMatch(cmd, m[1], names)
+	} else if m := unknownShorthandRegexp.FindStringSubmatch(msg); m != nil {
+		usageErr.Flag = m[1]
+	} else if m := flagNeedsArgumentRegexp.FindStringSubmatch(msg); m != nil {
+		usageErr.Flag = m[1]
+	} else if m := invalidFlagValueRegexp.FindStringSubmatch(msg); m != nil {
+		usageErr.Flag = m[2]
+		usageErr.Arg = unquote(m[1])
+	}
+	return usageErr
+}
+
+func newArgUsageError(cmd *cobra.Command, err error) *UsageError {
+	usageErr := &UsageError{
+		Command: cmd.CommandPath(),
+		Err:     err,
+	}
+	if m := invalidArgRegexp.FindStringSubmatch(err.Error()); m != nil {
+		usageErr.Arg = unquote(m[1])
+		if !cmd.HasAvailableSubCommands() {
+			usageErr.Suggestion = closestMatch(cmd, usageErr.Arg, cmd.ValidArgs)
+		}
+	}
+	return usageErr
+}
+
+// closestMatch returns the candidate that is closest to the provided value by Levenshtein distance if the distance is
+// at most the suggestion distance of the command (or if the candidate has the value as a prefix). Returns the empty
+// string if there is no such candidate or if suggestions are disabled for the command.
+func closestMatch(cmd *cobra.Command, val string, candidates []string) string {
+	if cmd.DisableSuggestions || val == "" {
+		return ""
+	}
+	maxDistance := cmd.SuggestionsMinimumDistance
+	if maxDistance <= 0 {
+		maxDistance = 2
+	}
+	best, bestDistance := "", maxDistance+1
+	for _, candidate := range candidates {
+		d := levenshteinDistance(strings.ToLower(val), strings.ToLower(candidate))
+		if strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(val)) && d > maxDistance {
+			d = maxDistance
+		}
+		if d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+func levenshteinDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(t)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// unquote returns the value of the provided Go string literal, or the literal itself if it is not valid.
+func unquote(s string) string {
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		return unquoted
+	}
+	return s
+}
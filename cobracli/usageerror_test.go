@@ -0,0 +1,265 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestUsageErrors(t *testing.T) {
+	for i, tc := range []struct {
+		args []string
+		want cobracli.UsageError
+	}{
+		{
+			[]string{"deploy", "--verbos"},
+			cobracli.UsageError{Command: "my-app deploy", Flag: "verbos", Suggestion: "verbose"},
+		},
+		{
+			[]string{"deploy", "--replicas", "many", "prod"},
+			cobracli.UsageError{Command: "my-app deploy", Flag: "replicas", Arg: "many"},
+		},
+		{
+			[]string{"deploy", "-x"},
+			cobracli.UsageError{Command: "my-app deploy", Flag: "x"},
+		},
+		{
+			[]string{"deploy", "--replicas"},
+			cobracli.UsageError{Command: "my-app deploy", Flag: "replicas"},
+		},
+		{
+			[]string{"deploy", "prdo"},
+			cobracli.UsageError{Command: "my-app deploy", Arg: "prdo", Suggestion: "prod"},
+		},
+		{
+			[]string{"deploy"},
+			cobracli.UsageError{Command: "my-app deploy"},
+		},
+	} {
+		var gotErr error
+		rootCmd := &cobra.Command{Use: "my-app"}
+		deployCmd := &cobra.Command{
+			Use: "deploy",
+			Args: func(cmd *cobra.Command, args []string) error {
+				if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+					return err
+				}
+				return cobra.OnlyValidArgs(cmd, args)
+			},
+			ValidArgs: []string{"dev", "prod"},
+			Run:       func(cmd *cobra.Command, args []string) {},
+		}
+		deployCmd.Flags().Bool("verbose", false, "")
+		deployCmd.Flags().Int("replicas", 1, "")
+		rootCmd.AddCommand(deployCmd)
+		rootCmd.SetOutput(&bytes.Buffer{})
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd,
+			cobracli.ConfigureCmdParam(cobracli.SilenceErrorsConfigurer),
+			cobracli.ConfigureCmdParam(cobracli.UsageErrorsConfigurer),
+			cobracli.ErrorHandlerParam(func(cmd *cobra.Command, err error) {
+				gotErr = err
+			}),
+		)
+		assert.Equal(t, 1, rv, "Case %d", i)
+		usageErr, ok := cobracli.AsUsageError(gotErr)
+		require.True(t, ok, "Case %d: %v", i, gotErr)
+		got := *usageErr
+		got.Err = nil
+		assert.Equal(t, tc.want, got, "Case %d", i)
+	}
+}
+
+func TestUsageErrorPrintsUsage(t *testing.T) {
+	rootCmd := &cobra.Command{
+		Use:  "my-app",
+		Args: cobra.NoArgs,
+		Run:  func(cmd *cobra.Command, args []string) {},
+	}
+	rootCmd.Flags().Bool("verbose", false, "print verbose output")
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"--verbos"})
+
+	rv := cobracli.Execute(rootCmd, cobracli.DefaultParams(nil)...)
+	assert.Equal(t, 1, rv)
+	assert.Equal(t, `Error: unknown flag: --verbos (did you mean "--verbose"?)
+Usage:
+  my-app [flags]
+
+Flags:
+  -h, --help      help for my-app
+      --verbose   print verbose output
+`, buf.String())
+}
+
+func TestUsageErrorJSON(t *testing.T) {
+	err := errors.Wrap(&cobracli.UsageError{
+		Command:    "my-app deploy",
+		Flag:       "verbos",
+		Suggestion: "verbose",
+		Err:        errors.New("unknown flag: --verbos"),
+	}, "failed")
+
+	usageErr, ok := cobracli.AsUsageError(err)
+	require.True(t, ok)
+	bytes, marshalErr := json.Marshal(usageErr)
+	require.NoError(t, marshalErr)
+	assert.JSONEq(t, `{"command":"my-app deploy","flag":"verbos","suggestion":"verbose","message":"unknown flag: --verbos (did you mean \"--verbose\"?)"}`, string(bytes))
+
+	_, ok = cobracli.AsUsageError(errors.New("failed"))
+	assert.False(t, ok)
+}
+
+func TestSuggestionsConfigurer(t *testing.T) {
+	for i, tc := range []struct {
+		name       string
+		args       []string
+		wantRV     int
+		wantErr    *cobracli.UsageError
+		wantOutput string
+	}{
+		{
+			"unknown subcommand of root command",
+			[]string{"servr"},
+			1,
+			&cobracli.UsageError{Command: "my-app", Arg: "servr", Suggestion: "server"},
+			"",
+		},
+		{
+			"unknown subcommand of nested command",
+			[]string{"server", "stat"},
+			1,
+			&cobracli.UsageError{Command: "my-app server", Arg: "stat", Suggestion: "start"},
+			"",
+		},
+		{
+			"unknown subcommand suggested using SuggestFor",
+			[]string{"server", "launch"},
+			1,
+			&cobracli.UsageError{Command: "my-app server", Arg: "launch", Suggestion: "start"},
+			"",
+		},
+		{
+			"unknown subcommand without suggestion",
+			[]string{"server", "stop"},
+			1,
+			&cobracli.UsageError{Command: "my-app server", Arg: "stop"},
+			"",
+		},
+		{
+			"command without arguments prints help",
+			[]string{"server"},
+			0,
+			nil,
+			"Usage:\n  my-app server [flags]\n  my-app server [command]\n",
+		},
+		{
+			"known subcommand",
+			[]string{"server", "start"},
+			0,
+			nil,
+			"started\n",
+		},
+	} {
+		var gotErr error
+		rootCmd := &cobra.Command{Use: "my-app"}
+		serverCmd := &cobra.Command{Use: "server"}
+		serverCmd.AddCommand(&cobra.Command{
+			Use:        "start",
+			SuggestFor: []string{"launch"},
+			Run: func(cmd *cobra.Command, args []string) {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "started")
+			},
+		})
+		rootCmd.AddCommand(serverCmd)
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd,
+			cobracli.ConfigureCmdParam(cobracli.SilenceErrorsConfigurer),
+			cobracli.ConfigureCmdParam(cobracli.SuggestionsConfigurer),
+			cobracli.ErrorHandlerParam(func(cmd *cobra.Command, err error) {
+				gotErr = err
+			}),
+		)
+		assert.Equal(t, tc.wantRV, rv, "Case %d: %s", i, tc.name)
+		if tc.wantErr == nil {
+			assert.NoError(t, gotErr, "Case %d: %s", i, tc.name)
+			assert.Contains(t, buf.String(), tc.wantOutput, "Case %d: %s", i, tc.name)
+			continue
+		}
+		usageErr, ok := cobracli.AsUsageError(gotErr)
+		require.True(t, ok, "Case %d: %s: %v", i, tc.name, gotErr)
+		got := *usageErr
+		got.Err = nil
+		assert.Equal(t, *tc.wantErr, got, "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestSuggestionsConfigurerErrorMessage(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "my-app"}
+	serverCmd := &cobra.Command{Use: "server"}
+	serverCmd.AddCommand(&cobra.Command{
+		Use: "start",
+		Run: func(cmd *cobra.Command, args []string) {},
+	})
+	rootCmd.AddCommand(serverCmd)
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"server", "stat"})
+
+	rv := cobracli.Execute(rootCmd, cobracli.ConfigureCmdParam(cobracli.SilenceErrorsConfigurer), cobracli.ConfigureCmdParam(cobracli.SuggestionsConfigurer), cobracli.ErrorHandlerParam(cobracli.ErrorPrinterWithDebugHandler(nil, nil)))
+	assert.Equal(t, 1, rv)
+	assert.Equal(t, "Error: unknown command \"stat\" for \"my-app server\" (did you mean \"start\"?)\n", buf.String())
+}
+
+func TestFlagErrorsUsageErrorConfigurerSuggestsFlag(t *testing.T) {
+	rootCmd := &cobra.Command{
+		Use: "my-app",
+		Run: func(cmd *cobra.Command, args []string) {},
+	}
+	rootCmd.Flags().Bool("verbose", false, "print verbose output")
+	buf := &bytes.Buffer{}
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"--verbos"})
+
+	rv := cobracli.Execute(rootCmd, cobracli.ConfigureCmdParam(cobracli.SilenceErrorsConfigurer), cobracli.ConfigureCmdParam(cobracli.FlagErrorsUsageErrorConfigurer), cobracli.ErrorHandlerParam(cobracli.ErrorPrinterWithDebugHandler(nil, nil)))
+	assert.Equal(t, 1, rv)
+	assert.Equal(t, `Error: unknown flag: --verbos (did you mean "--verbose"?)
+Usage:
+  my-app [flags]
+
+Flags:
+  -h, --help      help for my-app
+      --verbose   print verbose output
+`, buf.String())
+}
+
+func TestErrorChain(t *testing.T) {
+	base := fmt.Errorf("base")
+	withCause := errors.Wrap(base, "wrapped")
+	withUnwrap := fmt.Errorf("outer: %w", withCause)
+
+	assert.Nil(t, cobracli.ErrorChain(nil))
+	assert.Equal(t, []error{base}, cobracli.ErrorChain(base))
+	chain := cobracli.ErrorChain(withUnwrap)
+	require.True(t, len(chain) > 2)
+	assert.Equal(t, withUnwrap, chain[0])
+	assert.Equal(t, withCause, chain[1])
+	assert.Equal(t, base, chain[len(chain)-1])
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+)
+
+type (
+	verbosityContextKey struct{}
+	loggerContextKey    struct{}
+)
+
+// VerbosityParam returns a Param that adds the "--verbose" ("-v") persistent flag, which counts the number of times it
+// is specified: "-v" is verbosity 1, "-vv" (or "-v -v") is verbosity 2 and so on. The verbosity is stored in the
+// execution context of the command (see Context) and can be retrieved using VerbosityFromContext.
+//
+// If configureLogger is true, a *slog.Logger that writes text records to the status writer (see Status) is also
+// stored in the execution context and can be retrieved using LoggerFromContext. Its level is determined by the
+// verbosity (see VerbosityLevel). The logger uses the status writer as configured by other Params (such as
+// LogFileParam), regardless of the order in which they are provided.
+func VerbosityParam(configureLogger bool) Param {
+	var verbosity int
+	return multiParam(
+		ConfigureCmdParam(func(cmd *cobra.Command) {
+			cmd.PersistentFlags().CountVarP(&verbosity, "verbose", "v", "increase the verbosity of output (can be repeated, such as -vv)")
+		}),
+		runEDecoratorParam(func(next RunEFunc) RunEFunc {
+			return func(cmd *cobra.Command, args []string) error {
+				ctx := context.WithValue(Context(cmd), verbosityContextKey{}, verbosity)
+				if configureLogger {
+					logger := slog.New(slog.NewTextHandler(Status(cmd), &slog.HandlerOptions{
+						Level: VerbosityLevel(verbosity),
+					}))
+					ctx = context.WithValue(ctx, loggerContextKey{}, logger)
+				}
+				restoreCtx := setContext(cmd, ctx)
+				defer restoreCtx()
+				return next(cmd, args)
+			}
+		}),
+	)
+}
+
+// VerbosityFromContext returns the verbosity stored in the provided context by VerbosityParam, or 0 if the context
+// does not carry a verbosity.
+func VerbosityFromContext(ctx context.Context) int {
+	verbosity, _ := ctx.Value(verbosityContextKey{}).(int)
+	return verbosity
+}
+
+// LoggerFromContext returns the logger stored in the provided context by VerbosityParam, or false if the context does
+// not carry a logger.
+func LoggerFromContext(ctx context.Context) (*slog.Logger, bool) {
+	logger, ok := ctx.Value(loggerContextKey{}).(*slog.Logger)
+	return logger, ok
+}
+
+// VerbosityLevel returns the minimum level of the records logged at the provided verbosity: slog.LevelWarn for 0,
+// slog.LevelInfo for 1, slog.LevelDebug for 2 and a level that is 4 lower for every additional level of verbosity, so
+// that commands can log very detailed records (such as the contents of requests) at levels below slog.LevelDebug.
+func VerbosityLevel(verbosity int) slog.Level {
+	if verbosity <= 0 {
+		return slog.LevelWarn
+	}
+	return slog.LevelWarn - slog.Level(4*verbosity)
+}
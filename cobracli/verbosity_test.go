@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+var timeAttrRegexp = regexp.MustCompile(`(?m)^time=\S+ `)
+
+func TestVerbosityParam(t *testing.T) {
+	for i, tc := range []struct {
+		args       []string
+		wantOutput string
+	}{
+		{nil, "verbosity: 0\nlevel=WARN msg=warn\n"},
+		{[]string{"-v"}, "verbosity: 1\nlevel=INFO msg=info\nlevel=WARN msg=warn\n"},
+		{[]string{"-vv"}, "verbosity: 2\nlevel=DEBUG msg=debug\nlevel=INFO msg=info\nlevel=WARN msg=warn\n"},
+		{[]string{"-v", "--verbose", "-v"}, "verbosity: 3\nlevel=DEBUG-4 msg=trace\nlevel=DEBUG msg=debug\nlevel=INFO msg=info\nlevel=WARN msg=warn\n"},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+			Run: func(cmd *cobra.Command, args []string) {
+				ctx := cobracli.Context(cmd)
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "verbosity: %d\n", cobracli.VerbosityFromContext(ctx))
+				logger, ok := cobracli.LoggerFromContext(ctx)
+				require.True(t, ok, "Case %d", i)
+				logger.Log(ctx, slog.LevelDebug-4, "trace")
+				logger.Debug("debug")
+				logger.Info("info")
+				logger.Warn("warn")
+			},
+		}
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		rv := cobracli.Execute(rootCmd, cobracli.VerbosityParam(true))
+		assert.Equal(t, 0, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, timeAttrRegexp.ReplaceAllString(buf.String(), ""), "Case %d", i)
+	}
+}
@@ -6,6 +6,8 @@ package cobracli
 
 import (
 	"fmt"
+	"runtime/debug"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -13,11 +15,70 @@ import (
 // VersionCmd returns a command that prints the version of the application with the given name and given version to the
 // Stdout of the command.
 func VersionCmd(appName, version string) *cobra.Command {
-	return &cobra.Command{
+	versionCmd := &cobra.Command{
 		Use:   "version",
 		Short: fmt.Sprintf("Print %s version", appName),
 		Run: func(cmd *cobra.Command, args []string) {
 			cmd.Printf("%s version %s\n", appName, version)
 		},
 	}
+	SkipFirstRun(versionCmd)
+	return versionCmd
+}
+
+// VersionParam configures a command so that it has both a "version" subcommand and a "--version" flag that print the
+// provided version (see VersionCmdParam and VersionFlagParam). If the provided version is empty, the version of the
+// main module of the binary is used (see BuildInfoVersion). Is a noop if no version can be determined.
+func VersionParam(version string) Param {
+	if version == "" {
+		version = BuildInfoVersion("")
+	}
+	return multiParam(
+		VersionFlagParam(version),
+		VersionCmdParam(version),
+	)
+}
+
+// readBuildInfo is the function used to read the build information of the binary. It is a variable so that it can be
+// replaced in tests.
+var readBuildInfo = debug.ReadBuildInfo
+
+// BuildInfoVersion returns the provided version annotated with the build information embedded in the binary (see
+// runtime/debug.ReadBuildInfo): the VCS commit, the VCS commit time and the Go version used to build the binary. If
+// the provided version is empty, the version of the main module is used instead (unless it is "(devel)"). The returned
+// value has the form "1.0.0 (commit 0123abc, built 2026-01-02T03:04:05Z, go1.22.0)". Information that is not available
+// is omitted. Returns the provided version unmodified if the binary does not have build information.
+func BuildInfoVersion(version string) string {
+	info, ok := readBuildInfo()
+	if !ok {
+		return version
+	}
+	if version == "" && info.Main.Version != "(devel)" {
+		version = info.Main.Version
+	}
+
+	var details []string
+	settings := make(map[string]string)
+	for _, setting := range info.Settings {
+		settings[setting.Key] = setting.Value
+	}
+	if commit := settings["vcs.revision"]; commit != "" {
+		if len(commit) > 7 {
+			commit = commit[:7]
+		}
+		if settings["vcs.modified"] == "true" {
+			commit += "-dirty"
+		}
+		details = append(details, "commit "+commit)
+	}
+	if date := settings["vcs.time"]; date != "" {
+		details = append(details, "built "+date)
+	}
+	if info.GoVersion != "" {
+		details = append(details, info.GoVersion)
+	}
+	if version == "" || len(details) == 0 {
+		return version
+	}
+	return fmt.Sprintf("%s (%s)", version, strings.Join(details, ", "))
 }
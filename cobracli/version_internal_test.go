@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildInfoVersion(t *testing.T) {
+	origReadBuildInfo := readBuildInfo
+	defer func() {
+		readBuildInfo = origReadBuildInfo
+	}()
+
+	for i, tc := range []struct {
+		version string
+		info    *debug.BuildInfo
+		want    string
+	}{
+		{"1.0.0", nil, "1.0.0"},
+		{"", nil, ""},
+		{
+			"1.0.0",
+			&debug.BuildInfo{
+				GoVersion: "go1.22.0",
+				Main:      debug.Module{Version: "v0.9.0"},
+				Settings: []debug.BuildSetting{
+					{Key: "vcs.revision", Value: "0123abcdef0123abcdef"},
+					{Key: "vcs.time", Value: "2026-01-02T03:04:05Z"},
+					{Key: "vcs.modified", Value: "true"},
+				},
+			},
+			"1.0.0 (commit 0123abc-dirty, built 2026-01-02T03:04:05Z, go1.22.0)",
+		},
+		{
+			"",
+			&debug.BuildInfo{
+				GoVersion: "go1.22.0",
+				Main:      debug.Module{Version: "v0.9.0"},
+			},
+			"v0.9.0 (go1.22.0)",
+		},
+		{
+			"",
+			&debug.BuildInfo{
+				GoVersion: "go1.22.0",
+				Main:      debug.Module{Version: "(devel)"},
+			},
+			"",
+		},
+	} {
+		info := tc.info
+		readBuildInfo = func() (*debug.BuildInfo, bool) {
+			return info, info != nil
+		}
+		assert.Equal(t, tc.want, BuildInfoVersion(tc.version), "Case %d", i)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// VisibilityHookParam returns a Param that uses the provided hook to determine whether commands are available to the
+// caller. This can be used to hide or block commands based on information that is only known at runtime, such as the
+// role or entitlements of the current user. The hook is called when the root command is executed and is not called
+// for the root command itself. Commands for which the hook returns false are hidden from help output while Execute
+// runs, and invoking such a command (or any of its descendants) returns a *CommandDisabledError.
+//
+// The result of the hook is cached for each command for the duration of one invocation of Execute, so the hook is
+// called at most once per command per invocation.
+func VisibilityHookParam(hook func(cmd *cobra.Command) bool) Param {
+	return paramFunc(func(executor *executor) {
+		cache := make(map[*cobra.Command]bool)
+		visible := func(cmd *cobra.Command) bool {
+			if v, ok := cache[cmd]; ok {
+				return v
+			}
+			v := hook(cmd)
+			cache[cmd] = v
+			return v
+		}
+		permitted := func(cmd *cobra.Command) bool {
+			for curr := cmd; curr.HasParent(); curr = curr.Parent() {
+				if !visible(curr) {
+					return false
+				}
+			}
+			return true
+		}
+		policyParam(permitted, visible).apply(executor)
+	})
+}
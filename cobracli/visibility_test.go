@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cobracli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/cobracli"
+)
+
+func TestVisibilityHookParam(t *testing.T) {
+	for i, tc := range []struct {
+		args       []string
+		wantRV     int
+		wantOutput string
+	}{
+		{[]string{"user", "list"}, 0, "user list\n"},
+		{[]string{"admin", "reset"}, 1, "Error: command \"admin reset\" is disabled by policy\n"},
+	} {
+		rootCmd := &cobra.Command{
+			Use: "my-app",
+		}
+		for _, name := range []string{"user", "admin"} {
+			groupCmd := &cobra.Command{Use: name}
+			for _, sub := range []string{"list", "reset"} {
+				groupCmd.AddCommand(&cobra.Command{
+					Use: sub,
+					Run: func(cmd *cobra.Command, args []string) {
+						cmd.Println(cmd.Parent().Name(), cmd.Name())
+					},
+				})
+			}
+			rootCmd.AddCommand(groupCmd)
+		}
+
+		calls := make(map[string]int)
+		hook := func(cmd *cobra.Command) bool {
+			calls[cmd.CommandPath()]++
+			return cmd.Name() != "admin"
+		}
+
+		buf := &bytes.Buffer{}
+		rootCmd.SetOutput(buf)
+		rootCmd.SetArgs(tc.args)
+
+		hidden := make(map[string]bool)
+		rv := cobracli.Execute(rootCmd, append(cobracli.DefaultParams(nil),
+			cobracli.VisibilityHookParam(hook),
+			cobracli.ConfigureCmdParam(func(rootCmd *cobra.Command) {
+				for _, cmd := range rootCmd.Commands() {
+					hidden[cmd.Name()] = cmd.Hidden
+				}
+			}),
+		)...)
+		assert.Equal(t, tc.wantRV, rv, "Case %d", i)
+		assert.Equal(t, tc.wantOutput, buf.String(), "Case %d", i)
+
+		for path, n := range calls {
+			assert.Equal(t, 1, n, "Case %d: hook called more than once for %s", i, path)
+		}
+		_, rootCalled := calls["my-app"]
+		assert.False(t, rootCalled, "Case %d", i)
+		for _, cmd := range rootCmd.Commands() {
+			assert.Equal(t, cmd.Name() == "admin", hidden[cmd.Name()], "Case %d: %s", i, cmd.Name())
+			// commands are no longer hidden when Execute returns
+			assert.False(t, cmd.Hidden, "Case %d: %s", i, cmd.Name())
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package console provides functionality for writing to terminals consistently across platforms. It detects whether
+// output is a terminal, determines the width of the terminal and determines whether ANSI escape sequences (used for
+// color and cursor movement) are supported.
+//
+// On Windows, modern consoles (Windows 10 and later and Windows Terminal) support ANSI escape sequences once virtual
+// terminal processing has been enabled for the console. Legacy consoles do not support them at all, so escape
+// sequences must be removed from the output to prevent them from being rendered as garbage characters. NewWriter
+// handles both cases.
+package console
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const (
+	// DefaultWidth is the width returned by Width when the width of the output cannot be determined.
+	DefaultWidth = 80
+	// NoColorEnvVar is the environment variable that disables color output when it is set to a non-empty value. See
+	// https://no-color.org.
+	NoColorEnvVar = "NO_COLOR"
+)
+
+// IsTerminal returns true if the provided writer is an *os.File that is connected to a terminal.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return terminal.IsTerminal(int(f.Fd()))
+}
+
+// Width returns the width (in columns) of the terminal that the provided writer is connected to. If the width cannot
+// be determined from the terminal, the value of the COLUMNS environment variable is used if it is a positive integer.
+// Otherwise, DefaultWidth is returned.
+func Width(w io.Writer) int {
+	if f, ok := w.(*os.File); ok {
+		if width, ok := terminalWidth(f); ok && width > 0 {
+			return width
+		}
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return DefaultWidth
+}
+
+// SupportsANSI returns true if the provided writer is a terminal that renders ANSI escape sequences. On Windows, this
+// enables virtual terminal processing for the console if it is not already enabled.
+func SupportsANSI(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || !IsTerminal(f) {
+		return false
+	}
+	return enableVirtualTerminal(f)
+}
+
+// SupportsColor returns true if output written to the provided writer should contain ANSI escape sequences for color:
+// that is, if the writer supports them (see SupportsANSI) and the NoColorEnvVar environment variable is not set to a
+// non-empty value.
+func SupportsColor(w io.Writer) bool {
+	return os.Getenv(NoColorEnvVar) == "" && SupportsANSI(w)
+}
+
+// NewWriter returns a writer that writes to the provided writer. If the provided writer is a terminal that cannot
+// render ANSI escape sequences (such as a legacy Windows console), the returned writer removes all ANSI escape
+// sequences from the output. Otherwise, the provided writer is returned unmodified.
+func NewWriter(w io.Writer) io.Writer {
+	if IsTerminal(w) && !SupportsANSI(w) {
+		return NewStripANSIWriter(w)
+	}
+	return w
+}
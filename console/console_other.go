@@ -0,0 +1,28 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package console
+
+import (
+	"os"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// enableVirtualTerminal is a no-op on platforms other than Windows: all terminals are assumed to support ANSI escape
+// sequences.
+func enableVirtualTerminal(f *os.File) bool {
+	return true
+}
+
+func terminalWidth(f *os.File) (int, bool) {
+	width, _, err := terminal.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0, false
+	}
+	return width, true
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package console
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const enableVirtualTerminalProcessing = 0x0004
+
+var (
+	kernel32                       = syscall.NewLazyDLL("kernel32.dll")
+	procSetConsoleMode             = kernel32.NewProc("SetConsoleMode")
+	procGetConsoleScreenBufferInfo = kernel32.NewProc("GetConsoleScreenBufferInfo")
+)
+
+type (
+	coord struct {
+		x int16
+		y int16
+	}
+	smallRect struct {
+		left   int16
+		top    int16
+		right  int16
+		bottom int16
+	}
+	consoleScreenBufferInfo struct {
+		size              coord
+		cursorPosition    coord
+		attributes        uint16
+		window            smallRect
+		maximumWindowSize coord
+	}
+)
+
+// enableVirtualTerminal enables virtual terminal processing for the console if it is not already enabled. Returns false
+// if the console does not support virtual terminal processing (legacy consoles).
+func enableVirtualTerminal(f *os.File) bool {
+	handle := syscall.Handle(f.Fd())
+	var mode uint32
+	if err := syscall.GetConsoleMode(handle, &mode); err != nil {
+		return false
+	}
+	if mode&enableVirtualTerminalProcessing != 0 {
+		return true
+	}
+	r, _, _ := procSetConsoleMode.Call(uintptr(handle), uintptr(mode|enableVirtualTerminalProcessing))
+	return r != 0
+}
+
+// terminalWidth returns the width of the visible window of the console. The size of the screen buffer is not used
+// because, in ConHost, the buffer is frequently wider than the visible window.
+func terminalWidth(f *os.File) (int, bool) {
+	var info consoleScreenBufferInfo
+	r, _, _ := procGetConsoleScreenBufferInfo.Call(f.Fd(), uintptr(unsafe.Pointer(&info)))
+	if r == 0 {
+		return 0, false
+	}
+	return int(info.window.right-info.window.left) + 1, true
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package console
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+)
+
+// labelColors are the ANSI escape sequences of the colors assigned to the labels of a Multiplexer in turn.
+var labelColors = []string{
+	"\x1b[36m", // cyan
+	"\x1b[33m", // yellow
+	"\x1b[32m", // green
+	"\x1b[35m", // magenta
+	"\x1b[34m", // blue
+	"\x1b[96m", // bright cyan
+	"\x1b[93m", // bright yellow
+	"\x1b[92m", // bright green
+	"\x1b[95m", // bright magenta
+	"\x1b[94m", // bright blue
+}
+
+// MultiplexerOption configures a Multiplexer.
+type MultiplexerOption func(m *Multiplexer)
+
+// WithColor sets whether the labels of a Multiplexer are colored using ANSI escape sequences. The default is the value
+// of SupportsColor for the output of the Multiplexer.
+func WithColor(enabled bool) MultiplexerOption {
+	return func(m *Multiplexer) {
+		m.color = enabled
+	}
+}
+
+// WithLineSerialization configures a Multiplexer to buffer the output of each source until a line is complete and to
+// write complete lines only. Lines from different sources are then never interleaved, at the cost of delaying partial
+// lines (such as prompts and progress indicators) until they are complete or the source is closed.
+func WithLineSerialization() MultiplexerOption {
+	return func(m *Multiplexer) {
+		m.serializeLines = true
+	}
+}
+
+// Multiplexer streams the output of multiple concurrent sources (such as child processes) to a single writer,
+// prefixing each line with the label of its source, in the manner of docker-compose:
+//
+//	web    | Listening on :8080
+//	worker | Processing job 1
+//
+// Labels are padded to the length of the longest label, so all of the sources should be created (using Writer) before
+// any output is written. By default, output is written as soon as it is received: if a source writes a partial line and
+// another source then writes, the partial line is terminated and its remainder is written on a new line with the label
+// of its source. Use WithLineSerialization to write complete lines only. A Multiplexer is safe for concurrent use.
+type Multiplexer struct {
+	w              io.Writer
+	color          bool
+	serializeLines bool
+
+	mu         sync.Mutex
+	labelWidth int
+	numSources int
+	// open is the source whose line was last written without a terminating newline, if any.
+	open *multiplexedWriter
+}
+
+// NewMultiplexer returns a new Multiplexer that writes to the provided writer.
+func NewMultiplexer(w io.Writer, opts ...MultiplexerOption) *Multiplexer {
+	m := &Multiplexer{
+		w:     w,
+		color: SupportsColor(w),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Writer returns a writer for the source with the provided label. For example, it can be used as the Stdout and Stderr
+// of an exec.Cmd. The writer must be closed once the source is done writing so that any incomplete last line is
+// written.
+func (m *Multiplexer) Writer(label string) io.WriteCloser {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(label) > m.labelWidth {
+		m.labelWidth = len(label)
+	}
+	w := &multiplexedWriter{
+		m:     m,
+		label: label,
+		color: labelColors[m.numSources%len(labelColors)],
+	}
+	m.numSources++
+	return w
+}
+
+// write writes the provided output of the provided source. Must be called with m.mu held.
+func (m *Multiplexer) write(src *multiplexedWriter, p []byte) error {
+	if len(p) == 0 {
+		return nil
+	}
+	var out []byte
+	if m.open != nil && m.open != src {
+		// terminate the partial line of another source
+		out = append(out, '\n')
+		m.open = nil
+	}
+	for len(p) > 0 {
+		if m.open == nil {
+			out = append(out, m.prefix(src)...)
+			m.open = src
+		}
+		idx := bytes.IndexByte(p, '\n')
+		if idx < 0 {
+			out = append(out, p...)
+			break
+		}
+		out = append(out, p[:idx+1]...)
+		p = p[idx+1:]
+		m.open = nil
+	}
+	_, err := m.w.Write(out)
+	return err
+}
+
+func (m *Multiplexer) prefix(src *multiplexedWriter) string {
+	label := src.label + strings.Repeat(" ", m.labelWidth-len(src.label))
+	if m.color {
+		return src.color + label + " |" + ansiReset + " "
+	}
+	return label + " | "
+}
+
+const ansiReset = "\x1b[0m"
+
+type multiplexedWriter struct {
+	m     *Multiplexer
+	label string
+	color string
+	// buf is the incomplete last line written by the source when lines are serialized.
+	buf []byte
+}
+
+func (w *multiplexedWriter) Write(p []byte) (int, error) {
+	w.m.mu.Lock()
+	defer w.m.mu.Unlock()
+	if !w.m.serializeLines {
+		return len(p), w.m.write(w, p)
+	}
+	w.buf = append(w.buf, p...)
+	idx := bytes.LastIndexByte(w.buf, '\n')
+	if idx < 0 {
+		return len(p), nil
+	}
+	err := w.m.write(w, w.buf[:idx+1])
+	w.buf = append([]byte(nil), w.buf[idx+1:]...)
+	return len(p), err
+}
+
+// Close writes the incomplete last line of the source, if any, terminated by a newline.
+func (w *multiplexedWriter) Close() error {
+	w.m.mu.Lock()
+	defer w.m.mu.Unlock()
+	if len(w.buf) > 0 {
+		err := w.m.write(w, append(w.buf, '\n'))
+		w.buf = nil
+		return err
+	}
+	if w.m.open == w {
+		w.m.open = nil
+		_, err := w.m.w.Write([]byte{'\n'})
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package console_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/console"
+)
+
+func TestMultiplexer(t *testing.T) {
+	type write struct {
+		source int
+		data   string
+	}
+	for i, tc := range []struct {
+		name   string
+		opts   []console.MultiplexerOption
+		writes []write
+		want   string
+	}{
+		{
+			"complete lines",
+			nil,
+			[]write{{0, "starting\n"}, {1, "ready\nlistening\n"}, {0, "done\n"}},
+			"web    | starting\nworker | ready\nworker | listening\nweb    | done\n",
+		},
+		{
+			"interleaved partial lines are split",
+			nil,
+			[]write{{0, "downloading..."}, {1, "ready\n"}, {0, " done\n"}},
+			"web    | downloading...\nworker | ready\nweb    |  done\n",
+		},
+		{
+			"partial lines are serialized",
+			[]console.MultiplexerOption{console.WithLineSerialization()},
+			[]write{{0, "downloading..."}, {1, "ready\n"}, {0, " done\nexit"}},
+			"worker | ready\nweb    | downloading... done\nweb    | exit\n",
+		},
+		{
+			"color",
+			[]console.MultiplexerOption{console.WithColor(true)},
+			[]write{{0, "a\n"}, {1, "b\n"}},
+			"\x1b[36mweb    |\x1b[0m a\n\x1b[33mworker |\x1b[0m b\n",
+		},
+	} {
+		buf := &bytes.Buffer{}
+		m := console.NewMultiplexer(buf, tc.opts...)
+		sources := []io.WriteCloser{m.Writer("web"), m.Writer("worker")}
+		for _, w := range tc.writes {
+			_, err := sources[w.source].Write([]byte(w.data))
+			require.NoError(t, err, "Case %d: %s", i, tc.name)
+		}
+		for _, source := range sources {
+			require.NoError(t, source.Close(), "Case %d: %s", i, tc.name)
+		}
+		assert.Equal(t, tc.want, buf.String(), "Case %d: %s", i, tc.name)
+	}
+}
+
+func TestMultiplexerConcurrentWrites(t *testing.T) {
+	buf := &bytes.Buffer{}
+	m := console.NewMultiplexer(buf, console.WithLineSerialization())
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		w := m.Writer(fmt.Sprint(i))
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				// write each line in two chunks so that chunks of different sources interleave
+				_, _ = fmt.Fprintf(w, "line %d ", j)
+				_, _ = fmt.Fprintf(w, "of %d\n", i)
+			}
+			_ = w.Close()
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 400)
+	for _, line := range lines {
+		var source, num, of int
+		_, err := fmt.Sscanf(line, "%d | line %d of %d", &source, &num, &of)
+		require.NoError(t, err, line)
+		assert.Equal(t, source, of, line)
+	}
+}
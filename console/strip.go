@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package console
+
+import (
+	"io"
+)
+
+// StripANSI returns the provided string with all ANSI escape sequences removed.
+func StripANSI(s string) string {
+	var p ansiParser
+	return string(p.strip([]byte(s)))
+}
+
+// NewStripANSIWriter returns a writer that removes all ANSI escape sequences from the content written to it before
+// writing it to the provided writer. Escape sequences that span multiple calls to Write are handled correctly.
+func NewStripANSIWriter(w io.Writer) io.Writer {
+	return &stripANSIWriter{w: w}
+}
+
+type stripANSIWriter struct {
+	w      io.Writer
+	parser ansiParser
+}
+
+func (s *stripANSIWriter) Write(p []byte) (int, error) {
+	if _, err := s.w.Write(s.parser.strip(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+type ansiState int
+
+const (
+	ansiText ansiState = iota
+	ansiEscape
+	ansiCSI
+	ansiOSC
+	ansiOSCEscape
+)
+
+// ansiParser removes escape sequences from its input. It is stateful so that escape sequences that are split across
+// multiple inputs are removed.
+type ansiParser struct {
+	state ansiState
+}
+
+func (p *ansiParser) strip(in []byte) []byte {
+	out := make([]byte, 0, len(in))
+	for _, b := range in {
+		switch p.state {
+		case ansiText:
+			if b == 0x1b {
+				p.state = ansiEscape
+				continue
+			}
+			out = append(out, b)
+		case ansiEscape:
+			switch b {
+			case '[':
+				p.state = ansiCSI
+			case ']':
+				p.state = ansiOSC
+			default:
+				// two-character escape sequence
+				p.state = ansiText
+			}
+		case ansiCSI:
+			// control sequence is terminated by a byte in the range 0x40-0x7e
+			if b >= 0x40 && b <= 0x7e {
+				p.state = ansiText
+			}
+		case ansiOSC:
+			// operating system command is terminated by BEL or ST (ESC \)
+			switch b {
+			case 0x07:
+				p.state = ansiText
+			case 0x1b:
+				p.state = ansiOSCEscape
+			}
+		case ansiOSCEscape:
+			if b == '\\' {
+				p.state = ansiText
+			} else {
+				p.state = ansiOSC
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package console_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/console"
+)
+
+func TestStripANSI(t *testing.T) {
+	for i, tc := range []struct {
+		in   string
+		want string
+	}{
+		{"plain text", "plain text"},
+		{"\x1b[31mError:\x1b[0m failed", "Error: failed"},
+		{"\x1b[1;32mbold green\x1b[m", "bold green"},
+		{"progress\x1b[2K\rdone", "progress\rdone"},
+		{"\x1b]0;window title\x07text", "text"},
+		{"\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", "link"},
+		{"\x1b7saved\x1b8", "saved"},
+	} {
+		assert.Equal(t, tc.want, console.StripANSI(tc.in), "Case %d", i)
+	}
+}
+
+func TestStripANSIWriterSplitSequences(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := console.NewStripANSIWriter(buf)
+	for _, chunk := range []string{"\x1b", "[3", "1mred", "\x1b[", "0m plain"} {
+		n, err := w.Write([]byte(chunk))
+		require.NoError(t, err)
+		assert.Equal(t, len(chunk), n)
+	}
+	assert.Equal(t, "red plain", buf.String())
+}
+
+func TestNewWriterNonTerminal(t *testing.T) {
+	buf := &bytes.Buffer{}
+	assert.False(t, console.IsTerminal(buf))
+	assert.False(t, console.SupportsANSI(buf))
+	assert.False(t, console.SupportsColor(buf))
+	assert.Equal(t, buf, console.NewWriter(buf))
+}
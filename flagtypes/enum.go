@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flagtypes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// Enum is a string flag value that must be one of a fixed set of allowed values.
+type Enum struct {
+	value   *string
+	allowed []string
+}
+
+var _ pflag.Value = (*Enum)(nil)
+
+// EnumVar defines a flag with the specified name, default value and usage string on the provided flag set whose value
+// must be one of the provided allowed values. The value of the flag is stored in p. The allowed values are recorded
+// using the AllowedValuesAnnotation of the flag.
+func EnumVar(flags *pflag.FlagSet, p *string, name, value, usage string, allowed ...string) {
+	EnumVarP(flags, p, name, "", value, usage, allowed...)
+}
+
+// EnumVarP is like EnumVar, but accepts a shorthand letter that can be used after a single dash.
+func EnumVarP(flags *pflag.FlagSet, p *string, name, shorthand, value, usage string, allowed ...string) {
+	*p = value
+	flags.VarP(&Enum{value: p, allowed: allowed}, name, shorthand, usage)
+	_ = SetAllowedValues(flags, name, allowed...)
+}
+
+// Set sets the value if it is one of the allowed values and returns an error otherwise.
+func (e *Enum) Set(val string) error {
+	for _, allowed := range e.allowed {
+		if val == allowed {
+			*e.value = val
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not one of the allowed values: %s", val, strings.Join(e.allowed, ", "))
+}
+
+// String returns the current value.
+func (e *Enum) String() string {
+	return *e.value
+}
+
+// Type returns the type name shown in flag usage.
+func (e *Enum) Type() string {
+	return "string"
+}
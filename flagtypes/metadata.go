@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flagtypes
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// Keys of the flag annotations used to record metadata about flags. The metadata is descriptive: it is used to
+// generate documentation such as verbose help output.
+const (
+	// EnvVarAnnotation records the environment variable that can be used to provide the value of a flag.
+	EnvVarAnnotation = "flagtypes_env_var"
+	// ConfigKeyAnnotation records the key in a configuration file that can be used to provide the value of a flag.
+	ConfigKeyAnnotation = "flagtypes_config_key"
+	// DefaultSourceAnnotation records a description of where the default value of a flag comes from when it is not
+	// a constant (for example, "current git branch").
+	DefaultSourceAnnotation = "flagtypes_default_source"
+	// AllowedValuesAnnotation records the values that are permitted for a flag.
+	AllowedValuesAnnotation = "flagtypes_allowed_values"
+)
+
+// Metadata is the metadata recorded for a flag using the annotations of this package.
+type Metadata struct {
+	EnvVar        string
+	ConfigKey     string
+	DefaultSource string
+	AllowedValues []string
+}
+
+// IsEmpty returns true if no metadata is recorded.
+func (m Metadata) IsEmpty() bool {
+	return m.EnvVar == "" && m.ConfigKey == "" && m.DefaultSource == "" && len(m.AllowedValues) == 0
+}
+
+// FlagMetadata returns the metadata recorded for the provided flag.
+func FlagMetadata(f *pflag.Flag) Metadata {
+	first := func(key string) string {
+		if vals := f.Annotations[key]; len(vals) > 0 {
+			return vals[0]
+		}
+		return ""
+	}
+	return Metadata{
+		EnvVar:        first(EnvVarAnnotation),
+		ConfigKey:     first(ConfigKeyAnnotation),
+		DefaultSource: first(DefaultSourceAnnotation),
+		AllowedValues: f.Annotations[AllowedValuesAnnotation],
+	}
+}
+
+// SetEnvVar records the environment variable that can be used to provide the value of the flag with the provided name.
+// Returns an error if the flag set does not contain a flag with the provided name.
+func SetEnvVar(flags *pflag.FlagSet, name, envVar string) error {
+	return flags.SetAnnotation(name, EnvVarAnnotation, []string{envVar})
+}
+
+// SetConfigKey records the configuration key that can be used to provide the value of the flag with the provided name.
+// Returns an error if the flag set does not contain a flag with the provided name.
+func SetConfigKey(flags *pflag.FlagSet, name, key string) error {
+	return flags.SetAnnotation(name, ConfigKeyAnnotation, []string{key})
+}
+
+// SetDefaultSource records a description of where the default value of the flag with the provided name comes from.
+// Returns an error if the flag set does not contain a flag with the provided name.
+func SetDefaultSource(flags *pflag.FlagSet, name, source string) error {
+	return flags.SetAnnotation(name, DefaultSourceAnnotation, []string{source})
+}
+
+// SetAllowedValues records the values that are permitted for the flag with the provided name. Returns an error if the
+// flag set does not contain a flag with the provided name.
+func SetAllowedValues(flags *pflag.FlagSet, name string, values ...string) error {
+	return flags.SetAnnotation(name, AllowedValuesAnnotation, values)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flagtypes_test
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/flagtypes"
+)
+
+func TestFlagMetadata(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	var port int
+	flags.IntVar(&port, "port", 8080, "port to listen on")
+	var format string
+	flagtypes.EnumVar(flags, &format, "format", "json", "output format", "json", "yaml")
+
+	assert.True(t, flagtypes.FlagMetadata(flags.Lookup("port")).IsEmpty())
+
+	require.NoError(t, flagtypes.SetEnvVar(flags, "port", "APP_PORT"))
+	require.NoError(t, flagtypes.SetConfigKey(flags, "port", "server.port"))
+	require.NoError(t, flagtypes.SetDefaultSource(flags, "port", "server.yml"))
+	assert.Equal(t, flagtypes.Metadata{
+		EnvVar:        "APP_PORT",
+		ConfigKey:     "server.port",
+		DefaultSource: "server.yml",
+	}, flagtypes.FlagMetadata(flags.Lookup("port")))
+	assert.Equal(t, flagtypes.Metadata{
+		AllowedValues: []string{"json", "yaml"},
+	}, flagtypes.FlagMetadata(flags.Lookup("format")))
+
+	assert.Error(t, flagtypes.SetEnvVar(flags, "unknown", "UNKNOWN"))
+}
+
+func TestEnumFlag(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	var format string
+	flagtypes.EnumVarP(flags, &format, "format", "f", "json", "output format", "json", "yaml")
+	assert.Equal(t, "json", format)
+
+	require.NoError(t, flags.Parse([]string{"-f", "yaml"}))
+	assert.Equal(t, "yaml", format)
+
+	err := flags.Parse([]string{"--format", "xml"})
+	assert.EqualError(t, err, `invalid argument "xml" for "-f, --format" flag: "xml" is not one of the allowed values: json, yaml`)
+	assert.Equal(t, "yaml", format)
+}
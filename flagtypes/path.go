@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package flagtypes provides flag value types that can be registered on a pflag.FlagSet and that can also be decoded
+// from configuration files, so that the same normalization is applied to a value regardless of where it is specified.
+// It also defines flag annotations that record metadata about flags (such as the environment variable or configuration
+// key that can provide their value) that is used to generate documentation.
+package flagtypes
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// Path is a string that represents a filesystem path. When set as a flag value or decoded from JSON or YAML, the
+// value is expanded using ExpandPath: a leading "~" or "~user" is replaced with the home directory of the current or
+// named user and environment variable references of the form $VAR or ${VAR} are replaced with their values.
+// Referencing an environment variable that is not defined is an error.
+//
+// Path implements pflag.Value, so it can be registered on a flag set directly:
+//
+//	var cfgPath flagtypes.Path
+//	cmd.Flags().Var(&cfgPath, "config", "path to the configuration file")
+type Path string
+
+var _ pflag.Value = (*Path)(nil)
+
+// PathVar defines a Path flag with the specified name, default value and usage string on the provided flag set. The
+// default value is expanded using ExpandPath: if expansion fails, the default value is used as-is.
+func PathVar(flags *pflag.FlagSet, p *Path, name, value, usage string) {
+	PathVarP(flags, p, name, "", value, usage)
+}
+
+// PathVarP is like PathVar, but accepts a shorthand letter that can be used after a single dash.
+func PathVarP(flags *pflag.FlagSet, p *Path, name, shorthand, value, usage string) {
+	*p = Path(value)
+	if expanded, err := ExpandPath(value); err == nil {
+		*p = Path(expanded)
+	}
+	flags.VarP(p, name, shorthand, usage)
+}
+
+// Set expands the provided value using ExpandPath and stores the result.
+func (p *Path) Set(val string) error {
+	expanded, err := ExpandPath(val)
+	if err != nil {
+		return err
+	}
+	*p = Path(expanded)
+	return nil
+}
+
+// String returns the expanded path.
+func (p *Path) String() string {
+	return string(*p)
+}
+
+// Type returns the type name shown in flag usage.
+func (p *Path) Type() string {
+	return "path"
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. This is used when a Path is decoded from JSON or YAML.
+func (p *Path) UnmarshalText(text []byte) error {
+	return p.Set(string(text))
+}
+
+// ExpandPath expands the provided path. A leading "~" is replaced with the home directory of the current user and a
+// leading "~user" is replaced with the home directory of the named user. Environment variable references of the form
+// $VAR and ${VAR} are replaced with the value of the variable. Returns an error that lists all of the referenced
+// variables that are not defined (a variable that is defined with an empty value is not an error).
+func ExpandPath(path string) (string, error) {
+	var undefined []string
+	expanded := os.Expand(path, func(name string) string {
+		val, ok := os.LookupEnv(name)
+		if !ok {
+			undefined = append(undefined, name)
+		}
+		return val
+	})
+	if len(undefined) > 0 {
+		sort.Strings(undefined)
+		return "", fmt.Errorf("failed to expand path %q: undefined environment variable(s): %s", path, strings.Join(undefined, ", "))
+	}
+	return expandTilde(expanded)
+}
+
+func expandTilde(path string) (string, error) {
+	if !strings.HasPrefix(path, "~") {
+		return path, nil
+	}
+	name, rest := path[1:], ""
+	if idx := strings.IndexAny(name, `/\`); idx != -1 {
+		name, rest = name[:idx], name[idx:]
+	}
+
+	var homeDir string
+	if name == "" {
+		dir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to expand path %q: %v", path, err)
+		}
+		homeDir = dir
+	} else {
+		u, err := user.Lookup(name)
+		if err != nil {
+			return "", fmt.Errorf("failed to expand path %q: %v", path, err)
+		}
+		homeDir = u.HomeDir
+	}
+	if rest == "" {
+		return homeDir, nil
+	}
+	return filepath.Join(homeDir, rest), nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flagtypes_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/user"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/palantir/pkg/flagtypes"
+)
+
+func TestExpandPath(t *testing.T) {
+	homeDir, err := os.UserHomeDir()
+	require.NoError(t, err)
+	currUser, err := user.Current()
+	require.NoError(t, err)
+
+	require.NoError(t, os.Setenv("FLAGTYPES_TEST_DIR", "/opt/dir"))
+	defer func() {
+		_ = os.Unsetenv("FLAGTYPES_TEST_DIR")
+	}()
+
+	for i, tc := range []struct {
+		in      string
+		want    string
+		wantErr string
+	}{
+		{"foo/bar.yml", "foo/bar.yml", ""},
+		{"~", homeDir, ""},
+		{"~/foo.yml", filepath.Join(homeDir, "foo.yml"), ""},
+		{"~" + currUser.Username + "/foo.yml", filepath.Join(currUser.HomeDir, "foo.yml"), ""},
+		{"$FLAGTYPES_TEST_DIR/foo.yml", "/opt/dir/foo.yml", ""},
+		{"${FLAGTYPES_TEST_DIR}/foo.yml", "/opt/dir/foo.yml", ""},
+		{"foo/~/bar", "foo/~/bar", ""},
+		{"$FLAGTYPES_UNDEFINED_B/${FLAGTYPES_UNDEFINED_A}", "", `failed to expand path "$FLAGTYPES_UNDEFINED_B/${FLAGTYPES_UNDEFINED_A}": undefined environment variable(s): FLAGTYPES_UNDEFINED_A, FLAGTYPES_UNDEFINED_B`},
+	} {
+		got, err := flagtypes.ExpandPath(tc.in)
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr, "Case %d", i)
+			continue
+		}
+		require.NoError(t, err, "Case %d", i)
+		assert.Equal(t, tc.want, got, "Case %d", i)
+	}
+}
+
+func TestPathFlag(t *testing.T) {
+	homeDir, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	var p flagtypes.Path
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flagtypes.PathVar(flags, &p, "config", "~/default.yml", "configuration file")
+	assert.Equal(t, flagtypes.Path(filepath.Join(homeDir, "default.yml")), p)
+
+	require.NoError(t, flags.Parse([]string{"--config", "~/foo.yml"}))
+	assert.Equal(t, flagtypes.Path(filepath.Join(homeDir, "foo.yml")), p)
+
+	err = flags.Parse([]string{"--config", "$FLAGTYPES_UNDEFINED/foo.yml"})
+	assert.EqualError(t, err, `invalid argument "$FLAGTYPES_UNDEFINED/foo.yml" for "--config" flag: failed to expand path "$FLAGTYPES_UNDEFINED/foo.yml": undefined environment variable(s): FLAGTYPES_UNDEFINED`)
+}
+
+func TestPathConfig(t *testing.T) {
+	homeDir, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	type config struct {
+		Path flagtypes.Path `json:"path" yaml:"path"`
+	}
+
+	var jsonCfg config
+	require.NoError(t, json.Unmarshal([]byte(`{"path":"~/foo.yml"}`), &jsonCfg))
+	assert.Equal(t, flagtypes.Path(filepath.Join(homeDir, "foo.yml")), jsonCfg.Path)
+
+	var yamlCfg config
+	require.NoError(t, yaml.Unmarshal([]byte(`path: ~/foo.yml`), &yamlCfg))
+	assert.Equal(t, flagtypes.Path(filepath.Join(homeDir, "foo.yml")), yamlCfg.Path)
+}
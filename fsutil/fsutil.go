@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fsutil provides helpers for creating files and directories with explicit permissions. The modes provided
+// to the functions in this package are applied exactly: they are not masked by the umask of the current process.
+//
+// When a program is run using "sudo", files created by the program are owned by root, which typically prevents the
+// invoking user from modifying or removing them later. FixOwnership can be used to transfer ownership of created
+// artifacts back to the user that invoked sudo.
+package fsutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+)
+
+const (
+	// SecretFileMode is the mode that files containing secrets (credentials, tokens, etc.) are expected to have.
+	SecretFileMode os.FileMode = 0600
+	// SecretDirMode is the mode that directories containing secrets are expected to have.
+	SecretDirMode os.FileMode = 0700
+)
+
+// WriteFile writes data to the file at the provided path, creating it if necessary. The mode of the file is set to
+// exactly the provided mode regardless of the umask. If the file already exists, its mode is updated to match the
+// provided mode. The mode is set before any data is written, so data (such as secrets) is never readable with a more
+// permissive mode than the provided one.
+func WriteFile(path string, data []byte, perm os.FileMode) (rErr error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, perm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil && rErr == nil {
+			rErr = err
+		}
+	}()
+	if err := f.Chmod(perm); err != nil {
+		return err
+	}
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// Mkdir creates the directory at the provided path. The mode of the directory is set to exactly the provided mode
+// regardless of the umask.
+func Mkdir(path string, perm os.FileMode) error {
+	if err := os.Mkdir(path, perm); err != nil {
+		return err
+	}
+	return os.Chmod(path, perm)
+}
+
+// MkdirAll creates the directory at the provided path along with any necessary parents. The mode of every directory
+// created by this function is set to exactly the provided mode regardless of the umask. The modes of directories that
+// already exist are not modified.
+func MkdirAll(path string, perm os.FileMode) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s to absolute path: %v", path, err)
+	}
+
+	// determine the directories that do not yet exist, from the deepest to the shallowest. Any stat error is treated
+	// as the path not existing: if the path cannot be created, the error is surfaced when creating it.
+	var missing []string
+	for curr := absPath; ; curr = filepath.Dir(curr) {
+		fi, err := os.Stat(curr)
+		if err == nil {
+			if !fi.IsDir() {
+				return fmt.Errorf("%s exists and is not a directory", curr)
+			}
+			break
+		}
+		missing = append(missing, curr)
+		if filepath.Dir(curr) == curr {
+			break
+		}
+	}
+
+	for i := len(missing) - 1; i >= 0; i-- {
+		if err := Mkdir(missing[i], perm); err != nil && !os.IsExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// SudoOwner returns the user and group IDs of the user that invoked the current process using sudo. Returns false if
+// the current process is not running as root or if the SUDO_UID and SUDO_GID environment variables are not set to
+// valid values.
+func SudoOwner() (uid, gid int, ok bool) {
+	if os.Geteuid() != 0 {
+		return 0, 0, false
+	}
+	uid, err := strconv.Atoi(os.Getenv("SUDO_UID"))
+	if err != nil {
+		return 0, 0, false
+	}
+	gid, err = strconv.Atoi(os.Getenv("SUDO_GID"))
+	if err != nil {
+		return 0, 0, false
+	}
+	return uid, gid, true
+}
+
+// FixOwnership changes the owner of the provided paths to the user that invoked the current process using sudo. If
+// the process is not running under sudo, this function is a no-op. Paths are not traversed recursively: every path
+// whose ownership should be updated must be provided.
+func FixOwnership(paths ...string) error {
+	uid, gid, ok := SudoOwner()
+	if !ok {
+		return nil
+	}
+	for _, p := range paths {
+		if err := os.Lchown(p, uid, gid); err != nil {
+			return fmt.Errorf("failed to change owner of %s to %d:%d: %v", p, uid, gid, err)
+		}
+	}
+	return nil
+}
+
+// VerifySecretFile returns an error if the file at the provided path can be read or written by any user other than
+// its owner. The returned error describes the command that can be run to remediate the problem. Always returns nil on
+// Windows, where Unix permission bits are not meaningful.
+func VerifySecretFile(path string) error {
+	return verifySecure(path, SecretFileMode)
+}
+
+// VerifySecretDir returns an error if the directory at the provided path can be accessed by any user other than its
+// owner. The returned error describes the command that can be run to remediate the problem. Always returns nil on
+// Windows, where Unix permission bits are not meaningful.
+func VerifySecretDir(path string) error {
+	return verifySecure(path, SecretDirMode)
+}
+
+func verifySecure(path string, want os.FileMode) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if got := fi.Mode().Perm(); got&^want != 0 {
+		return fmt.Errorf("permissions %#o for %s are too open: it must not be accessible by other users. Run \"chmod %o %s\" to fix", got, path, want, path)
+	}
+	return nil
+}
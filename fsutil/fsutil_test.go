@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package fsutil_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/fsutil"
+)
+
+func TestWriteFileIgnoresUmask(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	oldMask := syscall.Umask(0077)
+	defer syscall.Umask(oldMask)
+
+	path := filepath.Join(tmpDir, "file.txt")
+	require.NoError(t, fsutil.WriteFile(path, []byte("content"), 0644))
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), fi.Mode().Perm())
+}
+
+func TestWriteFileRestrictsExistingFile(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	path := filepath.Join(tmpDir, "secret.txt")
+	require.NoError(t, ioutil.WriteFile(path, []byte("previous content that is longer"), 0644))
+	require.NoError(t, os.Chmod(path, 0644))
+	require.NoError(t, fsutil.WriteFile(path, []byte("secret"), fsutil.SecretFileMode))
+
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, fsutil.SecretFileMode, fi.Mode().Perm())
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(content))
+}
+
+func TestMkdirAll(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	oldMask := syscall.Umask(0077)
+	defer syscall.Umask(oldMask)
+
+	require.NoError(t, os.Chmod(tmpDir, 0700))
+	require.NoError(t, fsutil.MkdirAll(filepath.Join(tmpDir, "a", "b", "c"), 0755))
+
+	for _, p := range []string{"a", "a/b", "a/b/c"} {
+		fi, err := os.Stat(filepath.Join(tmpDir, p))
+		require.NoError(t, err)
+		assert.True(t, fi.IsDir())
+		assert.Equal(t, os.FileMode(0755), fi.Mode().Perm(), p)
+	}
+	// existing directory is not modified
+	fi, err := os.Stat(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), fi.Mode().Perm())
+
+	// file in path results in error
+	filePath := filepath.Join(tmpDir, "file")
+	require.NoError(t, fsutil.WriteFile(filePath, nil, 0644))
+	err = fsutil.MkdirAll(filepath.Join(filePath, "sub"), 0755)
+	assert.EqualError(t, err, fmt.Sprintf("%s exists and is not a directory", filePath))
+}
+
+func TestVerifySecretFile(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	for i, tc := range []struct {
+		perm    os.FileMode
+		wantErr bool
+	}{
+		{0600, false},
+		{0400, false},
+		{0640, true},
+		{0644, true},
+		{0666, true},
+	} {
+		path := filepath.Join(tmpDir, fmt.Sprintf("secret-%d", i))
+		require.NoError(t, fsutil.WriteFile(path, []byte("secret"), tc.perm))
+
+		err := fsutil.VerifySecretFile(path)
+		if !tc.wantErr {
+			assert.NoError(t, err, "Case %d", i)
+			continue
+		}
+		assert.EqualError(t, err, fmt.Sprintf(`permissions %#o for %s are too open: it must not be accessible by other users. Run "chmod 600 %s" to fix`, tc.perm, path, path), "Case %d", i)
+	}
+}
+
+func TestFixOwnershipNoopWithoutSudo(t *testing.T) {
+	if _, _, ok := fsutil.SudoOwner(); ok {
+		t.Skip("test must not be run using sudo")
+	}
+	assert.NoError(t, fsutil.FixOwnership("/path/that/does/not/exist"))
+}
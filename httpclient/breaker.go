@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpclient
+
+import (
+	"net/http"
+
+	"github.com/palantir/pkg/retry"
+)
+
+// NewCircuitBreakerTransport returns a RoundTripper that makes requests using the provided delegate through the
+// provided circuit breaker. A request fails if the delegate returns an error or if the response status is 429 (Too
+// Many Requests) or a 5xx status. When the breaker is open, requests are not made and retry.ErrCircuitOpen is
+// returned. If delegate is nil, http.DefaultTransport is used.
+func NewCircuitBreakerTransport(breaker *retry.CircuitBreaker, delegate http.RoundTripper) http.RoundTripper {
+	if delegate == nil {
+		delegate = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		done, err := breaker.Allow()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := delegate.RoundTrip(req)
+		done(err == nil && !isFailureStatus(resp.StatusCode))
+		return resp, err
+	})
+}
+
+func isFailureStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
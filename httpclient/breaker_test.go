@@ -0,0 +1,46 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/httpclient"
+	"github.com/palantir/pkg/retry"
+)
+
+func TestCircuitBreakerTransport(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	breaker := retry.NewCircuitBreaker(retry.WithWindowSize(2), retry.WithCoolDown(time.Hour))
+	client := &http.Client{
+		Transport: httpclient.NewCircuitBreakerTransport(breaker, nil),
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	}
+	assert.Equal(t, retry.StateOpen, breaker.State())
+
+	_, err := client.Get(server.URL)
+	require.Error(t, err)
+	assert.Equal(t, retry.ErrCircuitOpen, err.(*url.Error).Err)
+	assert.Equal(t, 2, requests)
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/palantir/pkg/clicache"
+)
+
+// CacheStatusHeader is the header that is set on responses returned by the transport returned by NewCachingTransport
+// to describe how the cache was used: "hit" if the response was served from the cache without a request,
+// "revalidated" if the server confirmed that the cached response is still valid and "miss" otherwise.
+const CacheStatusHeader = "X-Cache-Status"
+
+// MaxCachedResponseSize is the maximum size in bytes of a response body that is stored by the transport returned by
+// NewCachingTransport. Larger responses are passed through without being read into memory or cached.
+const MaxCachedResponseSize = 10 << 20
+
+type noCacheContextKey struct{}
+
+// WithoutCache returns a context that causes transports returned by NewCachingTransport to bypass the cache for
+// requests made with the context: responses are not served from the cache, but cacheable responses are still stored.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheContextKey{}, true)
+}
+
+// NewCachingTransport returns a RoundTripper that caches the responses to GET requests made using the provided
+// delegate in the provided cache. Caching honors the Cache-Control, Expires, ETag and Last-Modified response headers:
+//
+//   - Successful responses are stored unless they specify "Cache-Control: no-store". Responses are fresh for the
+//     duration specified by the "max-age" directive or the Expires header. Responses that are not fresh are only
+//     stored if they have an ETag or Last-Modified header.
+//   - A fresh cached response is returned without making a request unless the request specifies "Cache-Control:
+//     no-cache" or its context was returned by WithoutCache.
+//   - When a cached response is no longer fresh, a conditional request (using If-None-Match or If-Modified-Since) is
+//     made and the cached response is returned if the server responds with 304 (Not Modified).
+//
+// Because the cache is private to the user, responses are keyed by the URL, the Authorization header and the Accept
+// header of the request, and "Cache-Control: private" responses are cached. Responses with a Vary header that names
+// any other request header are not cached, and neither are responses whose body is larger than
+// MaxCachedResponseSize. Failures to read from or write to the
+// cache do not cause requests to fail. If delegate is nil, http.DefaultTransport is used.
+func NewCachingTransport(cache *clicache.Cache, delegate http.RoundTripper) http.RoundTripper {
+	if delegate == nil {
+		delegate = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		reqDirectives := parseCacheControl(req.Header.Get("Cache-Control"))
+		if req.Method != http.MethodGet || reqDirectives.has("no-store") || req.Header.Get("Range") != "" {
+			return delegate.RoundTrip(req)
+		}
+
+		key := responseCacheKey(req)
+		now := time.Now()
+		entry, cached := loadCachedResponse(cache, key)
+		bypass, _ := req.Context().Value(noCacheContextKey{}).(bool)
+		if cached && !bypass && !reqDirectives.has("no-cache") && now.Before(entry.Expires) {
+			if resp, err := entry.response(req); err == nil {
+				resp.Header.Set(CacheStatusHeader, "hit")
+				return resp, nil
+			}
+		}
+
+		outReq := req
+		if cached && !bypass && req.Header.Get("If-None-Match") == "" && req.Header.Get("If-Modified-Since") == "" {
+			cachedResp, err := entry.response(req)
+			if err == nil {
+				if etag := cachedResp.Header.Get("ETag"); etag != "" {
+					outReq = cloneWithHeader(outReq, "If-None-Match", etag)
+				}
+				if lastModified := cachedResp.Header.Get("Last-Modified"); lastModified != "" {
+					outReq = cloneWithHeader(outReq, "If-Modified-Since", lastModified)
+				}
+			}
+		}
+
+		resp, err := delegate.RoundTrip(outReq)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusNotModified && outReq != req {
+			cachedResp, err := entry.response(req)
+			if err == nil {
+				drain(resp.Body)
+				// refresh the freshness of the stored response using the headers of the new response
+				for _, name := range []string{"Cache-Control", "Expires", "Date", "ETag", "Last-Modified"} {
+					if val := resp.Header.Get(name); val != "" {
+						cachedResp.Header.Set(name, val)
+					}
+				}
+				storeCachedResponse(cache, key, cachedResp, now)
+				cachedResp.Header.Set(CacheStatusHeader, "revalidated")
+				return cachedResp, nil
+			}
+			return resp, nil
+		}
+		if resp.StatusCode == http.StatusOK && !parseCacheControl(resp.Header.Get("Cache-Control")).has("no-store") {
+			storeCachedResponse(cache, key, resp, now)
+		}
+		resp.Header.Set(CacheStatusHeader, "miss")
+		return resp, nil
+	})
+}
+
+// cachedResponse is the value stored in the cache for a response.
+type cachedResponse struct {
+	// Expires is the time until which the response is fresh.
+	Expires time.Time `json:"expires"`
+	// Response is the response serialized in HTTP/1.1 wire format.
+	Response []byte `json:"response"`
+}
+
+func (c cachedResponse) response(req *http.Request) (*http.Response, error) {
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(c.Response)), req)
+}
+
+func loadCachedResponse(cache *clicache.Cache, key string) (cachedResponse, bool) {
+	data, ok, err := cache.Get(key)
+	if err != nil || !ok {
+		return cachedResponse{}, false
+	}
+	var entry cachedResponse
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return cachedResponse{}, false
+	}
+	return entry, true
+}
+
+// storeCachedResponse stores the provided response in the cache if it is fresh or can be revalidated. The body of the
+// response is read and replaced so that it can still be read by the caller.
+func storeCachedResponse(cache *clicache.Cache, key string, resp *http.Response, now time.Time) {
+	freshness := freshnessLifetime(resp.Header, now)
+	if freshness <= 0 && resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "" {
+		return
+	}
+	if !varyIsKeyed(resp.Header) || resp.ContentLength > MaxCachedResponseSize {
+		return
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, MaxCachedResponseSize+1))
+	if err == nil && len(body) > MaxCachedResponseSize {
+		// the body is too large to cache: return what was read followed by the rest of the body
+		resp.Body = readCloser{
+			Reader: io.MultiReader(bytes.NewReader(body), resp.Body),
+			Closer: resp.Body,
+		}
+		return
+	}
+	_ = resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	stored := *resp
+	stored.Body = ioutil.NopCloser(bytes.NewReader(body))
+	stored.ContentLength = int64(len(body))
+	stored.TransferEncoding = nil
+	dump, err := httputil.DumpResponse(&stored, true)
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(cachedResponse{
+		Expires:  now.Add(freshness),
+		Response: dump,
+	})
+	if err != nil {
+		return
+	}
+	// responses that can be revalidated are kept until they are evicted
+	var ttl time.Duration
+	if resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "" {
+		ttl = freshness
+	}
+	_ = cache.Set(key, data, ttl)
+}
+
+// varyIsKeyed returns true if every request header named by the Vary header of a response is part of the key returned
+// by responseCacheKey, so that the response can be cached.
+func varyIsKeyed(header http.Header) bool {
+	for _, val := range header.Values("Vary") {
+		for _, name := range strings.Split(val, ",") {
+			switch http.CanonicalHeaderKey(strings.TrimSpace(name)) {
+			case "", "Accept", "Authorization":
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// freshnessLifetime returns the duration for which a response with the provided headers is fresh.
+func freshnessLifetime(header http.Header, now time.Time) time.Duration {
+	directives := parseCacheControl(header.Get("Cache-Control"))
+	if directives.has("no-cache") {
+		return 0
+	}
+	if maxAge, ok := directives["max-age"]; ok {
+		if seconds, err := strconv.Atoi(maxAge); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		return 0
+	}
+	if expires := header.Get("Expires"); expires != "" {
+		expiresTime, err := http.ParseTime(expires)
+		if err != nil {
+			return 0
+		}
+		date := now
+		if dateTime, err := http.ParseTime(header.Get("Date")); err == nil {
+			date = dateTime
+		}
+		return expiresTime.Sub(date)
+	}
+	return 0
+}
+
+type cacheControl map[string]string
+
+func parseCacheControl(val string) cacheControl {
+	directives := make(cacheControl)
+	for _, part := range strings.Split(val, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value := part, ""
+		if idx := strings.IndexByte(part, '='); idx != -1 {
+			name, value = part[:idx], strings.Trim(part[idx+1:], `"`)
+		}
+		directives[strings.ToLower(name)] = value
+	}
+	return directives
+}
+
+func (c cacheControl) has(name string) bool {
+	_, ok := c[name]
+	return ok
+}
+
+func responseCacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return "http:" + req.URL.String() + "\n" + req.Header.Get("Accept") + "\n" + hex.EncodeToString(sum[:])
+}
+
+func cloneWithHeader(req *http.Request, name, value string) *http.Request {
+	clone := req.Clone(req.Context())
+	clone.Header.Set(name, value)
+	return clone
+}
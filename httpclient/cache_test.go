@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpclient_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/clicache"
+	"github.com/palantir/pkg/httpclient"
+)
+
+func TestCachingTransport(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	cache, err := clicache.New(tmpDir)
+	require.NoError(t, err)
+
+	requests := make(map[string]int)
+	version := 1
+	large := strings.Repeat("x", httpclient.MaxCachedResponseSize)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/fresh":
+			w.Header().Set("Cache-Control", "max-age=3600")
+		case "/etag":
+			etag := fmt.Sprintf(`"v%d"`, version)
+			w.Header().Set("ETag", etag)
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		case "/no-store":
+			w.Header().Set("Cache-Control", "no-store, max-age=3600")
+		case "/vary-accept":
+			w.Header().Set("Cache-Control", "max-age=3600")
+			w.Header().Set("Vary", "Accept, Authorization")
+		case "/vary-language":
+			w.Header().Set("Cache-Control", "max-age=3600")
+			w.Header().Set("Vary", "Accept, Accept-Language")
+		case "/large":
+			w.Header().Set("Cache-Control", "max-age=3600")
+			_, _ = fmt.Fprint(w, large)
+		}
+		_, _ = fmt.Fprintf(w, "%s v%d", r.URL.Path, version)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: httpclient.NewCachingTransport(cache, nil),
+	}
+	get := func(ctx context.Context, path string) (string, string) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req.WithContext(ctx))
+		require.NoError(t, err)
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body), resp.Header.Get(httpclient.CacheStatusHeader)
+	}
+
+	for i, tc := range []struct {
+		path         string
+		bypass       bool
+		newVersion   int
+		wantBody     string
+		wantStatus   string
+		wantRequests int
+	}{
+		{"/fresh", false, 1, "/fresh v1", "miss", 1},
+		{"/fresh", false, 1, "/fresh v1", "hit", 1},
+		{"/fresh", true, 2, "/fresh v2", "miss", 2},
+		{"/fresh", false, 2, "/fresh v2", "hit", 2},
+		{"/etag", false, 2, "/etag v2", "miss", 1},
+		{"/etag", false, 2, "/etag v2", "revalidated", 2},
+		{"/etag", false, 3, "/etag v3", "miss", 3},
+		{"/no-store", false, 3, "/no-store v3", "miss", 1},
+		{"/no-store", false, 3, "/no-store v3", "miss", 2},
+		{"/plain", false, 3, "/plain v3", "miss", 1},
+		{"/plain", false, 3, "/plain v3", "miss", 2},
+		{"/vary-accept", false, 3, "/vary-accept v3", "miss", 1},
+		{"/vary-accept", false, 3, "/vary-accept v3", "hit", 1},
+		{"/vary-language", false, 3, "/vary-language v3", "miss", 1},
+		{"/vary-language", false, 3, "/vary-language v3", "miss", 2},
+		{"/large", false, 3, large + "/large v3", "miss", 1},
+		{"/large", false, 3, large + "/large v3", "miss", 2},
+	} {
+		version = tc.newVersion
+		ctx := context.Background()
+		if tc.bypass {
+			ctx = httpclient.WithoutCache(ctx)
+		}
+		body, status := get(ctx, tc.path)
+		assert.Equal(t, tc.wantBody, body, "Case %d", i)
+		assert.Equal(t, tc.wantStatus, status, "Case %d", i)
+		assert.Equal(t, tc.wantRequests, requests[tc.path], "Case %d", i)
+	}
+}
@@ -61,3 +61,16 @@ func NewHTTP2Transporter(timeout time.Duration, tlsConf *tls.Config) (*http.Tran
 
 	return tr, nil
 }
+
+// ConfigureMaxConnections limits the number of connections that the provided transport opens to any single host to the
+// provided number and sizes its idle connection pool accordingly. Values that are not positive are ignored. This
+// allows CLIs to bound the number of concurrent requests to the concurrency that the environment supports rather than
+// using fixed pool sizes that overwhelm small machines.
+func ConfigureMaxConnections(tr *http.Transport, maxConns int) {
+	if maxConns <= 0 {
+		return
+	}
+	tr.MaxConnsPerHost = maxConns
+	tr.MaxIdleConnsPerHost = maxConns
+	tr.MaxIdleConns = maxConns
+}
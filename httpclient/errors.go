@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpclient
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/palantir/pkg/safejson"
+)
+
+// maxErrorBodySize is the maximum number of bytes of a response body that are read by CheckResponse.
+const maxErrorBodySize = 64 * 1024
+
+// ErrorSchema specifies how the fields of a ResponseError are read from the JSON body and the headers of a response.
+// Fields are top-level keys of a JSON object. Empty values are ignored.
+type ErrorSchema struct {
+	// CodeField is the key of the field that contains the error code.
+	CodeField string
+	// MessageField is the key of the field that contains the error message.
+	MessageField string
+	// RequestIDField is the key of the field that contains the request ID.
+	RequestIDField string
+	// RequestIDHeader is the header that contains the request ID. The header is used if the body does not specify a
+	// request ID.
+	RequestIDHeader string
+}
+
+// DefaultErrorSchema returns the schema used when CheckResponse is called without a schema. It reads the "errorCode",
+// "message" and "requestId" fields of the body and the "X-Request-Id" header.
+func DefaultErrorSchema() ErrorSchema {
+	return ErrorSchema{
+		CodeField:       "errorCode",
+		MessageField:    "message",
+		RequestIDField:  "requestId",
+		RequestIDHeader: "X-Request-Id",
+	}
+}
+
+// ResponseError is an error that represents a response with a non-2xx status.
+type ResponseError struct {
+	// StatusCode is the status code of the response.
+	StatusCode int `json:"statusCode"`
+	// Code is the error code specified by the response, if any.
+	Code string `json:"code,omitempty"`
+	// Message is the error message specified by the response. If the body of the response is not a JSON object, it is
+	// the (trimmed) body.
+	Message string `json:"message,omitempty"`
+	// RequestID is the ID of the request specified by the response, if any.
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// Error returns a message of the form "<status> <text>: <code>: <message> (request ID: <ID>)", where the parts that
+// are not specified by the response are omitted.
+func (e *ResponseError) Error() string {
+	parts := []string{fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))}
+	for _, part := range []string{e.Code, e.Message} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	msg := strings.Join(parts, ": ")
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID: %s)", e.RequestID)
+	}
+	return msg
+}
+
+// ExitCode returns the exit code that a CLI should use when it fails because of the error, using the codes defined by
+// the BSD sysexits.h header (see the cobracli/exitcodes package): 77 (permission denied) for 401 (Unauthorized) and
+// 403 (Forbidden), 66 (no input) for 404 (Not Found) and 69 (service unavailable) for 5xx statuses. Returns 1 for other
+// statuses. This allows cobracli.Execute to determine the exit code of commands that return the error.
+func (e *ResponseError) ExitCode() int {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return 77
+	case e.StatusCode == http.StatusNotFound:
+		return 66
+	case e.StatusCode >= 500 && e.StatusCode < 600:
+		return 69
+	default:
+		return 1
+	}
+}
+
+// CheckResponse returns nil if the status of the provided response is 2xx. Otherwise, it reads and closes the body of
+// the response and returns a *ResponseError whose fields are populated using the provided schema. If no schema is
+// provided, DefaultErrorSchema is used.
+func CheckResponse(resp *http.Response, schema ...ErrorSchema) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	s := DefaultErrorSchema()
+	if len(schema) > 0 {
+		s = schema[0]
+	}
+
+	respErr := &ResponseError{
+		StatusCode: resp.StatusCode,
+	}
+	if resp.Body != nil {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		_ = resp.Body.Close()
+
+		var fields map[string]interface{}
+		if err := safejson.Unmarshal(body, &fields); err == nil {
+			respErr.Code = stringField(fields, s.CodeField)
+			respErr.Message = stringField(fields, s.MessageField)
+			respErr.RequestID = stringField(fields, s.RequestIDField)
+		} else {
+			respErr.Message = strings.TrimSpace(string(body))
+		}
+	}
+	if respErr.RequestID == "" && s.RequestIDHeader != "" {
+		respErr.RequestID = resp.Header.Get(s.RequestIDHeader)
+	}
+	return respErr
+}
+
+func stringField(fields map[string]interface{}, key string) string {
+	if key == "" {
+		return ""
+	}
+	switch v := fields[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpclient_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/httpclient"
+)
+
+func TestCheckResponse(t *testing.T) {
+	for i, tc := range []struct {
+		status  int
+		header  http.Header
+		body    string
+		schema  []httpclient.ErrorSchema
+		want    *httpclient.ResponseError
+		wantMsg string
+	}{
+		{
+			status: http.StatusOK,
+			body:   `{"errorCode":"IGNORED"}`,
+		},
+		{
+			status:  http.StatusNotFound,
+			body:    `{"errorCode":"NOT_FOUND","message":"dataset not found","requestId":"abc"}`,
+			want:    &httpclient.ResponseError{StatusCode: 404, Code: "NOT_FOUND", Message: "dataset not found", RequestID: "abc"},
+			wantMsg: "404 Not Found: NOT_FOUND: dataset not found (request ID: abc)",
+		},
+		{
+			status:  http.StatusForbidden,
+			header:  http.Header{"X-Request-Id": []string{"from-header"}},
+			body:    `{"errorCode":"PERMISSION_DENIED"}`,
+			want:    &httpclient.ResponseError{StatusCode: 403, Code: "PERMISSION_DENIED", RequestID: "from-header"},
+			wantMsg: "403 Forbidden: PERMISSION_DENIED (request ID: from-header)",
+		},
+		{
+			status:  http.StatusBadGateway,
+			body:    "upstream unavailable\n",
+			want:    &httpclient.ResponseError{StatusCode: 502, Message: "upstream unavailable"},
+			wantMsg: "502 Bad Gateway: upstream unavailable",
+		},
+		{
+			status: http.StatusBadRequest,
+			header: http.Header{"Trace-Id": []string{"trace"}},
+			body:   `{"error":{"code":1},"code":42,"detail":"invalid name"}`,
+			schema: []httpclient.ErrorSchema{{
+				CodeField:       "code",
+				MessageField:    "detail",
+				RequestIDHeader: "Trace-Id",
+			}},
+			want:    &httpclient.ResponseError{StatusCode: 400, Code: "42", Message: "invalid name", RequestID: "trace"},
+			wantMsg: "400 Bad Request: 42: invalid name (request ID: trace)",
+		},
+	} {
+		resp := &http.Response{
+			StatusCode: tc.status,
+			Header:     tc.header,
+			Body:       ioutil.NopCloser(strings.NewReader(tc.body)),
+		}
+		err := httpclient.CheckResponse(resp, tc.schema...)
+		if tc.want == nil {
+			assert.NoError(t, err, "Case %d", i)
+			continue
+		}
+		assert.Equal(t, tc.want, err, "Case %d", i)
+		assert.EqualError(t, err, tc.wantMsg, "Case %d", i)
+	}
+}
+
+func TestResponseErrorExitCode(t *testing.T) {
+	for i, tc := range []struct {
+		status int
+		want   int
+	}{
+		{http.StatusBadRequest, 1},
+		{http.StatusUnauthorized, 77},
+		{http.StatusForbidden, 77},
+		{http.StatusNotFound, 66},
+		{http.StatusConflict, 1},
+		{http.StatusInternalServerError, 69},
+		{http.StatusServiceUnavailable, 69},
+	} {
+		err := &httpclient.ResponseError{StatusCode: tc.status}
+		assert.Equal(t, tc.want, err.ExitCode(), "Case %d", i)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	gometrics "github.com/palantir/go-metrics"
+
+	"github.com/palantir/pkg/metrics"
+)
+
+// TransferDirection is the direction in which the bytes of a body are transferred.
+type TransferDirection string
+
+const (
+	// Upload is the direction of request bodies.
+	Upload TransferDirection = "upload"
+	// Download is the direction of response bodies.
+	Download TransferDirection = "download"
+)
+
+// TransferredBytesMetricName is the name of the meter that NewProgressTransport marks with the number of bytes of
+// request and response bodies that are transferred. The meter is tagged with "direction" ("upload" or "download"), so
+// it provides the transfer rate in each direction.
+const TransferredBytesMetricName = "httpclient.transferred.bytes"
+
+// ProgressFunc is called as the body of a request or response is transferred. transferred is the number of bytes of
+// the body transferred so far and total is the size of the body, or -1 if the size is not known.
+type ProgressFunc func(req *http.Request, direction TransferDirection, transferred, total int64)
+
+// NewProgressTransport returns a RoundTripper that makes requests using the provided delegate and reports the
+// progress of transferring request and response bodies. The provided function (if non-nil) is called with 0
+// transferred bytes before a body is transferred and after every read of the body thereafter, so it can be used to
+// render progress bars for large payloads. The number of transferred bytes is also marked on the meter named
+// TransferredBytesMetricName in the metrics registry of the context of the request (see metrics.FromContext). Requests
+// and responses without a body are not reported. If delegate is nil, http.DefaultTransport is used.
+func NewProgressTransport(progress ProgressFunc, delegate http.RoundTripper) http.RoundTripper {
+	if delegate == nil {
+		delegate = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		registry := metrics.FromContext(req.Context())
+		outReq := req
+		if req.Body != nil && req.Body != http.NoBody {
+			total := req.ContentLength
+			if total == 0 {
+				// a request with a body and a ContentLength of 0 has a body of unknown size (see http.Request)
+				total = -1
+			}
+			outReq = req.Clone(req.Context())
+			outReq.Body = newProgressReadCloser(req.Body, req, Upload, total, progress, registry)
+		}
+		resp, err := delegate.RoundTrip(outReq)
+		if err != nil || resp.Body == nil || resp.Body == http.NoBody {
+			return resp, err
+		}
+		resp.Body = newProgressReadCloser(resp.Body, req, Download, resp.ContentLength, progress, registry)
+		return resp, nil
+	})
+}
+
+// progressReadCloser is a ReadCloser that reports the number of bytes read from it.
+type progressReadCloser struct {
+	io.ReadCloser
+
+	req       *http.Request
+	direction TransferDirection
+	total     int64
+	progress  ProgressFunc
+	meter     gometrics.Meter
+
+	mu          sync.Mutex
+	started     bool
+	transferred int64
+}
+
+func newProgressReadCloser(rc io.ReadCloser, req *http.Request, direction TransferDirection, total int64, progress ProgressFunc, registry metrics.Registry) *progressReadCloser {
+	if total < 0 {
+		total = -1
+	}
+	return &progressReadCloser{
+		ReadCloser: rc,
+		req:        req,
+		direction:  direction,
+		total:      total,
+		progress:   progress,
+		meter:      registry.Meter(TransferredBytesMetricName, metrics.MustNewTag("direction", string(direction))),
+	}
+}
+
+func (r *progressReadCloser) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	if !r.started {
+		r.started = true
+		r.report(0)
+	}
+	r.mu.Unlock()
+
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.mu.Lock()
+		r.transferred += int64(n)
+		r.meter.Mark(int64(n))
+		r.report(r.transferred)
+		r.mu.Unlock()
+	}
+	return n, err
+}
+
+func (r *progressReadCloser) report(transferred int64) {
+	if r.progress != nil {
+		r.progress(r.req, r.direction, transferred, r.total)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpclient_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/httpclient"
+	"github.com/palantir/pkg/metrics"
+)
+
+func TestProgressTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Length", strconv.Itoa(2*len(body)))
+		_, _ = w.Write(bytes.Repeat(body, 2))
+	}))
+	defer server.Close()
+
+	type progressCall struct {
+		direction          httpclient.TransferDirection
+		transferred, total int64
+	}
+	var mu sync.Mutex
+	var calls []progressCall
+	client := &http.Client{
+		Transport: httpclient.NewProgressTransport(func(req *http.Request, direction httpclient.TransferDirection, transferred, total int64) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, progressCall{direction, transferred, total})
+		}, nil),
+	}
+
+	registry := metrics.NewRootMetricsRegistry()
+	payload := strings.Repeat("a", 64*1024)
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(payload))
+	require.NoError(t, err)
+	resp, err := client.Do(req.WithContext(metrics.WithRegistry(req.Context(), registry)))
+	require.NoError(t, err)
+	n, err := io.Copy(ioutil.Discard, resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, int64(2*len(payload)), n)
+
+	mu.Lock()
+	defer mu.Unlock()
+	lastCall := make(map[httpclient.TransferDirection]progressCall)
+	for _, call := range calls {
+		prev, ok := lastCall[call.direction]
+		if !ok {
+			assert.Equal(t, int64(0), call.transferred, "first call for %s should report 0 bytes", call.direction)
+		} else {
+			assert.True(t, call.transferred >= prev.transferred, "progress for %s should not decrease", call.direction)
+		}
+		lastCall[call.direction] = call
+	}
+	assert.Equal(t, progressCall{httpclient.Upload, int64(len(payload)), int64(len(payload))}, lastCall[httpclient.Upload])
+	assert.Equal(t, progressCall{httpclient.Download, int64(2 * len(payload)), int64(2 * len(payload))}, lastCall[httpclient.Download])
+
+	assert.Equal(t, int64(len(payload)), registry.Meter(httpclient.TransferredBytesMetricName, metrics.MustNewTag("direction", "upload")).Count())
+	assert.Equal(t, int64(2*len(payload)), registry.Meter(httpclient.TransferredBytesMetricName, metrics.MustNewTag("direction", "download")).Count())
+}
+
+func TestProgressTransportUnknownRequestSize(t *testing.T) {
+	var totals []int64
+	transport := httpclient.NewProgressTransport(func(req *http.Request, direction httpclient.TransferDirection, transferred, total int64) {
+		if direction == httpclient.Upload {
+			totals = append(totals, total)
+		}
+	}, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		_, _ = io.Copy(ioutil.Discard, req.Body)
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+
+	// the size of a body that is not a *bytes.Buffer, *bytes.Reader or *strings.Reader is not known
+	req, err := http.NewRequest(http.MethodPost, "http://localhost", ioutil.NopCloser(strings.NewReader("payload")))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), req.ContentLength)
+	_, err = transport.RoundTrip(req)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, totals)
+	for _, total := range totals {
+		assert.Equal(t, int64(-1), total)
+	}
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpclient
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ProxyConfig is the configuration for the proxy used by HTTP clients.
+type ProxyConfig struct {
+	// URL is the URL of the proxy. If the URL does not specify a scheme, "http" is used. If empty, requests are not
+	// made through a proxy.
+	URL string
+	// NoProxy is a comma-separated list of hosts for which the proxy is not used. It has the same semantics as the
+	// NO_PROXY environment variable: each entry is an IP address, a CIDR range or a domain name with an optional port.
+	// A domain name matches that domain and all of its subdomains. The entry "*" disables the proxy for all hosts.
+	NoProxy string
+	// Credentials, if non-nil, is called to determine the credentials used to authenticate to the proxy when URL does
+	// not include user information. It can be used to retrieve the credentials from a secure store rather than
+	// specifying them in the proxy URL. If the function returns an empty username, no credentials are used.
+	Credentials func(proxyURL *url.URL) (username, password string, err error)
+}
+
+// ProxyConfigFromEnvironment returns the proxy configuration specified by the HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+// environment variables (or their lowercase forms). HTTPS_PROXY takes precedence over HTTP_PROXY.
+func ProxyConfigFromEnvironment() ProxyConfig {
+	return ProxyConfig{
+		URL:     getEnvAny("HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"),
+		NoProxy: getEnvAny("NO_PROXY", "no_proxy"),
+	}
+}
+
+// ProxyFunc returns a function that can be used as the Proxy function of an *http.Transport. Returns an error if the
+// proxy URL is not valid.
+func (c ProxyConfig) ProxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	if c.URL == "" {
+		return func(*http.Request) (*url.URL, error) {
+			return nil, nil
+		}, nil
+	}
+	rawURL := c.URL
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %v", c.URL, err)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: host is empty", c.URL)
+	}
+	noProxy := parseNoProxy(c.NoProxy)
+
+	return func(req *http.Request) (*url.URL, error) {
+		if noProxy.matches(req.URL) {
+			return nil, nil
+		}
+		if proxyURL.User != nil || c.Credentials == nil {
+			return proxyURL, nil
+		}
+		username, password, err := c.Credentials(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get credentials for proxy %s: %v", proxyURL.Host, err)
+		}
+		if username == "" {
+			return proxyURL, nil
+		}
+		withCreds := *proxyURL
+		withCreds.User = url.UserPassword(username, password)
+		return &withCreds, nil
+	}, nil
+}
+
+// ConfigureProxy configures the provided transport to use the proxy specified by the provided configuration.
+func ConfigureProxy(tr *http.Transport, cfg ProxyConfig) error {
+	proxyFunc, err := cfg.ProxyFunc()
+	if err != nil {
+		return err
+	}
+	tr.Proxy = proxyFunc
+	return nil
+}
+
+type noProxyMatcher struct {
+	all      bool
+	ipNets   []*net.IPNet
+	ips      []net.IP
+	patterns []hostPattern
+}
+
+type hostPattern struct {
+	domain string
+	port   string
+}
+
+func parseNoProxy(noProxy string) noProxyMatcher {
+	var m noProxyMatcher
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			m.all = true
+			continue
+		}
+		if _, ipNet, err := net.ParseCIDR(entry); err == nil {
+			m.ipNets = append(m.ipNets, ipNet)
+			continue
+		}
+		host, port, err := net.SplitHostPort(entry)
+		if err != nil {
+			host, port = entry, ""
+		}
+		if ip := net.ParseIP(host); ip != nil {
+			m.ips = append(m.ips, ip)
+			continue
+		}
+		m.patterns = append(m.patterns, hostPattern{
+			domain: strings.TrimPrefix(strings.TrimPrefix(host, "*"), "."),
+			port:   port,
+		})
+	}
+	return m
+}
+
+func (m noProxyMatcher) matches(u *url.URL) bool {
+	if m.all {
+		return true
+	}
+	host := strings.ToLower(u.Hostname())
+	if ip := net.ParseIP(host); ip != nil {
+		for _, ipNet := range m.ipNets {
+			if ipNet.Contains(ip) {
+				return true
+			}
+		}
+		for _, noProxyIP := range m.ips {
+			if noProxyIP.Equal(ip) {
+				return true
+			}
+		}
+		return false
+	}
+	port := u.Port()
+	for _, p := range m.patterns {
+		if p.port != "" && p.port != port {
+			continue
+		}
+		if host == p.domain || strings.HasSuffix(host, "."+p.domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func getEnvAny(names ...string) string {
+	for _, name := range names {
+		if val := os.Getenv(name); val != "" {
+			return val
+		}
+	}
+	return ""
+}
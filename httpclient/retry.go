@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/palantir/pkg/retry"
+)
+
+// RetryAfter returns the amount of time that the server that sent the provided response requested that the client
+// wait before making another request. The "Retry-After" header is used if present (either as a number of seconds or as
+// an HTTP date). Otherwise, the "X-RateLimit-Reset" header is used if present: values that are large enough to be a
+// Unix timestamp are treated as the time (in seconds since the epoch) at which the rate limit resets, while smaller
+// values are treated as a number of seconds. Returns false if the response does not specify a delay. Delays in the
+// past are returned as 0.
+func RetryAfter(resp *http.Response) (time.Duration, bool) {
+	return retryAfter(resp, time.Now())
+}
+
+// unixTimestampThreshold is the value above which X-RateLimit-Reset values are interpreted as Unix timestamps rather
+// than as a number of seconds (corresponds to 2001-09-09).
+const unixTimestampThreshold = 1000000000
+
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	if val := strings.TrimSpace(resp.Header.Get("Retry-After")); val != "" {
+		if secs, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return nonNegative(time.Duration(secs) * time.Second), true
+		}
+		if t, err := http.ParseTime(val); err == nil {
+			return nonNegative(t.Sub(now)), true
+		}
+	}
+	if val := strings.TrimSpace(resp.Header.Get("X-RateLimit-Reset")); val != "" {
+		if secs, err := strconv.ParseInt(val, 10, 64); err == nil {
+			if secs >= unixTimestampThreshold {
+				return nonNegative(time.Unix(secs, 0).Sub(now)), true
+			}
+			return nonNegative(time.Duration(secs) * time.Second), true
+		}
+	}
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// DefaultRetryOptions returns the retry options used by NewRetryTransport when no options are provided: at most 4
+// attempts with exponential backoff starting at 250 milliseconds and capped at 10 seconds.
+func DefaultRetryOptions() []retry.Option {
+	return []retry.Option{
+		retry.WithMaxAttempts(4),
+		retry.WithInitialBackoff(250 * time.Millisecond),
+		retry.WithMaxBackoff(10 * time.Second),
+	}
+}
+
+// NewRetryTransport returns a RoundTripper that retries requests made using the provided delegate. Requests are
+// retried when the response status is 429 (Too Many Requests), 502, 503 or 504 and when the delegate returns an error
+// for a request with an idempotent method. When a response specifies a delay using the headers understood by
+// RetryAfter, the next attempt is not made until that delay has elapsed rather than using the exponential backoff
+// (the delay is capped at the maximum backoff and at the deadline of the request context). Requests with a body are
+// only retried if the body can be re-read (http.Request.GetBody is set). The response of the last attempt is returned.
+// If delegate is nil, http.DefaultTransport is used. If no options are provided, DefaultRetryOptions is used.
+func NewRetryTransport(delegate http.RoundTripper, opts ...retry.Option) http.RoundTripper {
+	if delegate == nil {
+		delegate = http.DefaultTransport
+	}
+	if len(opts) == 0 {
+		opts = DefaultRetryOptions()
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			// body cannot be re-read, so the request cannot be retried
+			return delegate.RoundTrip(req)
+		}
+
+		// the retry context is cancelled to stop retrying when an attempt fails with an error that is not retryable
+		retryCtx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+
+		var resp *http.Response
+		attempt := 0
+		err := retry.Do(retryCtx, func() error {
+			if resp != nil {
+				drain(resp.Body)
+				resp = nil
+			}
+			currReq := req
+			if attempt > 0 && req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					cancel()
+					return fmt.Errorf("failed to get request body: %v", err)
+				}
+				currReq = req.Clone(req.Context())
+				currReq.Body = body
+			}
+			attempt++
+
+			var err error
+			resp, err = delegate.RoundTrip(currReq)
+			if err != nil {
+				if !isIdempotent(req.Method) {
+					cancel()
+				}
+				return err
+			}
+			if !isRetryableStatus(resp.StatusCode) {
+				return nil
+			}
+			statusErr := fmt.Errorf("request failed with status %d", resp.StatusCode)
+			if hint, ok := RetryAfter(resp); ok {
+				return retry.WithBackoffHint(statusErr, hint)
+			}
+			return statusErr
+		}, opts...)
+		if resp != nil {
+			// response of the last attempt is returned even if its status is retryable
+			return resp, nil
+		}
+		return nil, err
+	})
+}
+
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func drain(body io.ReadCloser) {
+	_, _ = io.Copy(ioutil.Discard, body)
+	_ = body.Close()
+}
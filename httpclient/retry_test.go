@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpclient_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/httpclient"
+	"github.com/palantir/pkg/retry"
+)
+
+func TestRetryAfter(t *testing.T) {
+	for i, tc := range []struct {
+		header http.Header
+		min    time.Duration
+		max    time.Duration
+		wantOK bool
+	}{
+		{http.Header{}, 0, 0, false},
+		{http.Header{"Retry-After": []string{"5"}}, 5 * time.Second, 5 * time.Second, true},
+		{http.Header{"Retry-After": []string{time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)}}, 58 * time.Second, time.Minute, true},
+		{http.Header{"Retry-After": []string{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)}}, 0, 0, true},
+		{http.Header{"Retry-After": []string{"invalid"}}, 0, 0, false},
+		{http.Header{"X-Ratelimit-Reset": []string{"3"}}, 3 * time.Second, 3 * time.Second, true},
+		{http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)}}, 58 * time.Second, time.Minute, true},
+		{http.Header{"Retry-After": []string{"1"}, "X-Ratelimit-Reset": []string{"3"}}, time.Second, time.Second, true},
+	} {
+		got, ok := httpclient.RetryAfter(&http.Response{Header: tc.header})
+		assert.Equal(t, tc.wantOK, ok, "Case %d", i)
+		assert.True(t, got >= tc.min && got <= tc.max, "Case %d: %v not in [%v, %v]", i, got, tc.min, tc.max)
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	var requests int
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if requests == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: httpclient.NewRetryTransport(nil, retry.WithInitialBackoff(time.Millisecond), retry.WithMaxBackoff(2*time.Second)),
+	}
+	start := time.Now()
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, []string{"payload", "payload"}, bodies)
+	assert.True(t, time.Since(start) >= time.Second, "Retry-After was not honored")
+}
+
+func TestRetryTransportReturnsLastResponse(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: httpclient.NewRetryTransport(nil, retry.WithMaxAttempts(3), retry.WithInitialBackoff(time.Millisecond)),
+	}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 3, requests)
+}
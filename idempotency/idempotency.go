@@ -0,0 +1,152 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package idempotency provides idempotency keys for operations that call create-style APIs. An idempotency key
+// identifies a single logical operation: sending the same key with every attempt of the operation (including retries
+// made after the process restarts) allows the server to detect duplicate requests and perform the operation at most
+// once.
+//
+// Keys are persisted by a Store so that an operation that is interrupted and then run again within the configured
+// window reuses the same key. Keys are propagated to the code that makes the API call using a context.Context.
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/palantir/pkg/atomicfile"
+	"github.com/palantir/pkg/safejson"
+	"github.com/palantir/pkg/uuid"
+)
+
+// Header is the HTTP header used to send idempotency keys.
+const Header = "Idempotency-Key"
+
+// Store persists the idempotency keys of operations in a file. The store is safe for concurrent use within a process,
+// but a store file must not be shared by processes that run concurrently.
+type Store struct {
+	path   string
+	window time.Duration
+	now    func() time.Time
+	mutex  sync.Mutex
+}
+
+type storedKey struct {
+	Key     uuid.UUID `json:"key"`
+	Created time.Time `json:"created"`
+}
+
+// NewStore returns a store that persists keys in the file at the provided path. A persisted key is reused for an
+// operation only if it was created less than the provided window ago.
+func NewStore(path string, window time.Duration) *Store {
+	return &Store{
+		path:   path,
+		window: window,
+		now:    time.Now,
+	}
+}
+
+// Key returns the idempotency key for the operation with the provided identifier. If a key for the operation was
+// persisted within the window of the store, that key is returned. Otherwise, a new key (a version 7 UUID) is created,
+// persisted and returned. The identifier should uniquely describe the logical operation (for example, the name of the
+// API and the name of the resource being created).
+func (s *Store) Key(operation string) (uuid.UUID, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	keys, err := s.load()
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if stored, ok := keys[operation]; ok {
+		return stored.Key, nil
+	}
+	key := uuid.NewUUIDv7()
+	keys[operation] = storedKey{
+		Key:     key,
+		Created: s.now(),
+	}
+	if err := s.save(keys); err != nil {
+		return uuid.UUID{}, err
+	}
+	return key, nil
+}
+
+// Complete removes the persisted key for the operation with the provided identifier. It should be called once the
+// operation has completed (successfully or with an error that should not be retried) so that a subsequent run of the
+// same operation uses a new key.
+func (s *Store) Complete(operation string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	keys, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := keys[operation]; !ok {
+		return nil
+	}
+	delete(keys, operation)
+	return s.save(keys)
+}
+
+// load returns the persisted keys that are within the window of the store.
+func (s *Store) load() (map[string]storedKey, error) {
+	keys := make(map[string]storedKey)
+	content, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return keys, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read idempotency keys from %s: %v", s.path, err)
+	}
+	if err := safejson.Unmarshal(content, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse idempotency keys from %s: %v", s.path, err)
+	}
+	now := s.now()
+	for operation, stored := range keys {
+		if now.Sub(stored.Created) >= s.window {
+			delete(keys, operation)
+		}
+	}
+	return keys, nil
+}
+
+func (s *Store) save(keys map[string]storedKey) error {
+	content, err := safejson.Marshal(keys)
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotency keys: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory for idempotency keys: %v", err)
+	}
+	return atomicfile.WriteFile(s.path, content, 0600)
+}
+
+type contextKey struct{}
+
+// WithKey returns a copy of the provided context that carries the provided idempotency key.
+func WithKey(ctx context.Context, key uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, key)
+}
+
+// KeyFromContext returns the idempotency key carried by the provided context, or false if it does not carry one.
+func KeyFromContext(ctx context.Context) (uuid.UUID, bool) {
+	key, ok := ctx.Value(contextKey{}).(uuid.UUID)
+	return key, ok
+}
+
+// SetHeader sets the idempotency key header of the provided request to the key carried by the context of the request.
+// Is a no-op if the context does not carry a key.
+func SetHeader(req *http.Request) {
+	if key, ok := KeyFromContext(req.Context()); ok {
+		req.Header.Set(Header, key.String())
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	path := filepath.Join(tmpDir, "state", "idempotency.json")
+
+	now := time.Now()
+	store := NewStore(path, time.Hour)
+	store.now = func() time.Time { return now }
+
+	key, err := store.Key("create-dataset:foo")
+	require.NoError(t, err)
+	otherKey, err := store.Key("create-dataset:bar")
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherKey)
+
+	// a new store using the same file (for example, after the process restarts) returns the same key
+	restarted := NewStore(path, time.Hour)
+	restarted.now = func() time.Time { return now.Add(30 * time.Minute) }
+	got, err := restarted.Key("create-dataset:foo")
+	require.NoError(t, err)
+	assert.Equal(t, key, got)
+
+	// keys outside of the window are not reused
+	restarted.now = func() time.Time { return now.Add(2 * time.Hour) }
+	got, err = restarted.Key("create-dataset:foo")
+	require.NoError(t, err)
+	assert.NotEqual(t, key, got)
+
+	// completed operations use a new key
+	require.NoError(t, restarted.Complete("create-dataset:foo"))
+	afterComplete, err := restarted.Key("create-dataset:foo")
+	require.NoError(t, err)
+	assert.NotEqual(t, got, afterComplete)
+}
+
+func TestContext(t *testing.T) {
+	_, ok := KeyFromContext(context.Background())
+	assert.False(t, ok)
+
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	store := NewStore(filepath.Join(tmpDir, "keys.json"), time.Hour)
+	key, err := store.Key("op")
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost", nil)
+	require.NoError(t, err)
+	SetHeader(req)
+	assert.Empty(t, req.Header.Get(Header))
+
+	req = req.WithContext(WithKey(req.Context(), key))
+	SetHeader(req)
+	assert.Equal(t, key.String(), req.Header.Get(Header))
+}
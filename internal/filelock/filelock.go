@@ -0,0 +1,31 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package filelock provides exclusive advisory locks on files that are shared between processes.
+package filelock
+
+import (
+	"fmt"
+	"os"
+)
+
+// WithLock calls the provided function while holding an exclusive lock on the file at the provided path, blocking
+// until the lock can be acquired. The file is created with the provided permissions if it does not exist and is not
+// removed afterwards. Returns the error returned by the function, or an error if the lock cannot be acquired.
+func WithLock(path string, perm os.FileMode, fn func() error) error {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, perm)
+	if err != nil {
+		return fmt.Errorf("failed to open lock file: %v", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	if err := lockFile(f); err != nil {
+		return fmt.Errorf("failed to lock %s: %v", path, err)
+	}
+	defer func() {
+		_ = unlockFile(f)
+	}()
+	return fn()
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filelock_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/internal/filelock"
+)
+
+func TestWithLock(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	path := filepath.Join(tmpDir, "lock")
+
+	// separate file descriptors exclude each other even within a single process
+	var wg sync.WaitGroup
+	holders, maxHolders := 0, 0
+	var mutex sync.Mutex
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, filelock.WithLock(path, 0600, func() error {
+				mutex.Lock()
+				holders++
+				if holders > maxHolders {
+					maxHolders = holders
+				}
+				mutex.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				mutex.Lock()
+				holders--
+				mutex.Unlock()
+				return nil
+			}))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, maxHolders)
+
+	err = filelock.WithLock(path, 0600, func() error {
+		return fmt.Errorf("failed")
+	})
+	assert.EqualError(t, err, "failed")
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filelock
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const lockfileExclusiveLock = 0x00000002
+
+var (
+	kernel32         = syscall.NewLazyDLL("kernel32.dll")
+	procLockFileEx   = kernel32.NewProc("LockFileEx")
+	procUnlockFileEx = kernel32.NewProc("UnlockFileEx")
+)
+
+func lockFile(f *os.File) error {
+	var overlapped syscall.Overlapped
+	r, _, err := procLockFileEx.Call(f.Fd(), lockfileExclusiveLock, 0, 1, 0, uintptr(unsafe.Pointer(&overlapped)))
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	var overlapped syscall.Overlapped
+	r, _, err := procUnlockFileEx.Call(f.Fd(), 0, 1, 0, uintptr(unsafe.Pointer(&overlapped)))
+	if r == 0 {
+		return err
+	}
+	return nil
+}
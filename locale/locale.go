@@ -0,0 +1,210 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package locale formats numbers, currency amounts and dates according to the conventions of a locale so that output
+// intended for humans matches the expectations of users internationally.
+//
+// The locale of the user is determined from the LC_ALL, LC_NUMERIC and LANG environment variables using Detect. The C
+// locale formats values in a stable manner that does not depend on the environment (no digit grouping, "." as the
+// decimal separator and ISO 8601 dates), so it should be used for output that is intended to be read by machines.
+//
+// Only a fixed set of common locales is supported: Lookup reports whether a locale is known.
+package locale
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale describes the conventions used to format values.
+type Locale struct {
+	// Tag is the BCP 47 language tag of the locale (for example, "de-DE"), or "C" for the C locale.
+	Tag string
+	// DecimalSeparator separates the integer and fractional parts of numbers.
+	DecimalSeparator string
+	// GroupSeparator separates groups of 3 digits in the integer part of numbers. Digits are not grouped if empty.
+	GroupSeparator string
+	// DateLayout and TimeLayout are the layouts (as used by time.Time.Format) for dates and times of day.
+	DateLayout string
+	TimeLayout string
+	// CurrencyAfter is true if the currency symbol is written after the amount rather than before it.
+	CurrencyAfter bool
+	// CurrencySpace is true if the currency symbol is separated from the amount by a space.
+	CurrencySpace bool
+}
+
+// C is the locale used for stable, machine-readable output.
+var C = Locale{
+	Tag:              "C",
+	DecimalSeparator: ".",
+	DateLayout:       "2006-01-02",
+	TimeLayout:       "15:04:05",
+	CurrencyAfter:    true,
+	CurrencySpace:    true,
+}
+
+var locales = map[string]Locale{
+	"en-US": {Tag: "en-US", DecimalSeparator: ".", GroupSeparator: ",", DateLayout: "01/02/2006", TimeLayout: "3:04 PM"},
+	"en-GB": {Tag: "en-GB", DecimalSeparator: ".", GroupSeparator: ",", DateLayout: "02/01/2006", TimeLayout: "15:04"},
+	"de-DE": {Tag: "de-DE", DecimalSeparator: ",", GroupSeparator: ".", DateLayout: "02.01.2006", TimeLayout: "15:04", CurrencyAfter: true, CurrencySpace: true},
+	"de-CH": {Tag: "de-CH", DecimalSeparator: ".", GroupSeparator: "’", DateLayout: "02.01.2006", TimeLayout: "15:04", CurrencySpace: true},
+	"fr-FR": {Tag: "fr-FR", DecimalSeparator: ",", GroupSeparator: " ", DateLayout: "02/01/2006", TimeLayout: "15:04", CurrencyAfter: true, CurrencySpace: true},
+	"es-ES": {Tag: "es-ES", DecimalSeparator: ",", GroupSeparator: ".", DateLayout: "02/01/2006", TimeLayout: "15:04", CurrencyAfter: true, CurrencySpace: true},
+	"it-IT": {Tag: "it-IT", DecimalSeparator: ",", GroupSeparator: ".", DateLayout: "02/01/2006", TimeLayout: "15:04", CurrencyAfter: true, CurrencySpace: true},
+	"nl-NL": {Tag: "nl-NL", DecimalSeparator: ",", GroupSeparator: ".", DateLayout: "02-01-2006", TimeLayout: "15:04", CurrencySpace: true},
+	"pt-BR": {Tag: "pt-BR", DecimalSeparator: ",", GroupSeparator: ".", DateLayout: "02/01/2006", TimeLayout: "15:04", CurrencySpace: true},
+	"sv-SE": {Tag: "sv-SE", DecimalSeparator: ",", GroupSeparator: " ", DateLayout: "2006-01-02", TimeLayout: "15:04", CurrencyAfter: true, CurrencySpace: true},
+	"ja-JP": {Tag: "ja-JP", DecimalSeparator: ".", GroupSeparator: ",", DateLayout: "2006/01/02", TimeLayout: "15:04"},
+	"zh-CN": {Tag: "zh-CN", DecimalSeparator: ".", GroupSeparator: ",", DateLayout: "2006/01/02", TimeLayout: "15:04"},
+}
+
+// defaultRegions maps languages to the locale used when only a language is specified.
+var defaultRegions = map[string]string{
+	"en": "en-US",
+	"de": "de-DE",
+	"fr": "fr-FR",
+	"es": "es-ES",
+	"it": "it-IT",
+	"nl": "nl-NL",
+	"pt": "pt-BR",
+	"sv": "sv-SE",
+	"ja": "ja-JP",
+	"zh": "zh-CN",
+}
+
+// Lookup returns the locale for the provided name, which may be a BCP 47 language tag ("de-DE") or a POSIX locale
+// name ("de_DE.UTF-8"). If the region of the name is not known, the default locale for its language is used. The
+// names "C" and "POSIX" return the C locale. Returns false if the locale is not known.
+func Lookup(name string) (Locale, bool) {
+	// remove the codeset and modifier of POSIX locale names
+	if idx := strings.IndexAny(name, ".@"); idx != -1 {
+		name = name[:idx]
+	}
+	if name == "C" || name == "POSIX" {
+		return C, true
+	}
+	parts := strings.SplitN(strings.Replace(name, "_", "-", -1), "-", 2)
+	lang := strings.ToLower(parts[0])
+	if len(parts) == 2 {
+		if l, ok := locales[lang+"-"+strings.ToUpper(parts[1])]; ok {
+			return l, true
+		}
+	}
+	if tag, ok := defaultRegions[lang]; ok {
+		return locales[tag], true
+	}
+	return Locale{}, false
+}
+
+// Detect returns the locale specified by the environment. The first non-empty value of the LC_ALL, LC_NUMERIC and
+// LANG environment variables is used. Returns the C locale if none of the variables are set or if the locale they
+// specify is not known.
+func Detect() Locale {
+	for _, key := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		if val := os.Getenv(key); val != "" {
+			if l, ok := Lookup(val); ok {
+				return l
+			}
+			return C
+		}
+	}
+	return C
+}
+
+// FormatInt formats the provided integer.
+func (l Locale) FormatInt(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	if n < 0 {
+		return "-" + l.group(s[1:])
+	}
+	return l.group(s)
+}
+
+// FormatFloat formats the provided number with the provided number of digits after the decimal separator.
+func (l Locale) FormatFloat(f float64, decimals int) string {
+	s := strconv.FormatFloat(f, 'f', decimals, 64)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, fracPart := s, ""
+	if idx := strings.IndexByte(s, '.'); idx != -1 {
+		intPart, fracPart = s[:idx], s[idx+1:]
+	}
+	if fracPart == "" {
+		return sign + l.group(intPart)
+	}
+	return sign + l.group(intPart) + l.DecimalSeparator + fracPart
+}
+
+// currencies maps ISO 4217 currency codes to their symbols and the number of digits after the decimal separator.
+var currencies = map[string]struct {
+	symbol   string
+	decimals int
+}{
+	"USD": {"$", 2},
+	"EUR": {"€", 2},
+	"GBP": {"£", 2},
+	"JPY": {"¥", 0},
+	"CNY": {"¥", 2},
+	"CHF": {"CHF", 2},
+	"SEK": {"kr", 2},
+	"BRL": {"R$", 2},
+	"INR": {"₹", 2},
+}
+
+// FormatCurrency formats the provided amount of the currency with the provided ISO 4217 code. The symbol of the
+// currency is used if it is known (the C locale always uses the code). Amounts in currencies that are not known are
+// formatted with 2 decimal digits and the code of the currency.
+func (l Locale) FormatCurrency(amount float64, code string) string {
+	symbol, decimals := code, 2
+	if c, ok := currencies[code]; ok {
+		decimals = c.decimals
+		if l.Tag != C.Tag {
+			symbol = c.symbol
+		}
+	}
+	formatted := l.FormatFloat(amount, decimals)
+	space := ""
+	if l.CurrencySpace {
+		space = " "
+	}
+	if l.CurrencyAfter {
+		return formatted + space + symbol
+	}
+	if strings.HasPrefix(formatted, "-") {
+		return "-" + symbol + space + formatted[1:]
+	}
+	return symbol + space + formatted
+}
+
+// FormatDate formats the date of the provided time.
+func (l Locale) FormatDate(t time.Time) string {
+	return t.Format(l.DateLayout)
+}
+
+// FormatDateTime formats the date and time of day of the provided time.
+func (l Locale) FormatDateTime(t time.Time) string {
+	return t.Format(l.DateLayout + " " + l.TimeLayout)
+}
+
+// group inserts the group separator between every group of 3 digits of the provided string of digits.
+func (l Locale) group(digits string) string {
+	if l.GroupSeparator == "" || len(digits) <= 3 {
+		return digits
+	}
+	var sb strings.Builder
+	first := len(digits) % 3
+	if first == 0 {
+		first = 3
+	}
+	sb.WriteString(digits[:first])
+	for i := first; i < len(digits); i += 3 {
+		sb.WriteString(l.GroupSeparator)
+		sb.WriteString(digits[i : i+3])
+	}
+	return sb.String()
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package locale_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/locale"
+)
+
+func TestLookup(t *testing.T) {
+	for i, tc := range []struct {
+		name    string
+		wantTag string
+		wantOK  bool
+	}{
+		{"C", "C", true},
+		{"POSIX", "C", true},
+		{"C.UTF-8", "C", true},
+		{"de-DE", "de-DE", true},
+		{"de_CH.UTF-8", "de-CH", true},
+		{"fr_FR@euro", "fr-FR", true},
+		{"en_AU.UTF-8", "en-US", true},
+		{"pt", "pt-BR", true},
+		{"xx_YY", "", false},
+	} {
+		got, ok := locale.Lookup(tc.name)
+		assert.Equal(t, tc.wantOK, ok, "Case %d", i)
+		assert.Equal(t, tc.wantTag, got.Tag, "Case %d", i)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	for _, key := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		orig, ok := os.LookupEnv(key)
+		defer func(key, orig string, ok bool) {
+			if ok {
+				_ = os.Setenv(key, orig)
+			} else {
+				_ = os.Unsetenv(key)
+			}
+		}(key, orig, ok)
+		require.NoError(t, os.Unsetenv(key))
+	}
+
+	assert.Equal(t, "C", locale.Detect().Tag)
+
+	require.NoError(t, os.Setenv("LANG", "de_DE.UTF-8"))
+	assert.Equal(t, "de-DE", locale.Detect().Tag)
+
+	require.NoError(t, os.Setenv("LC_ALL", "ja_JP.UTF-8"))
+	assert.Equal(t, "ja-JP", locale.Detect().Tag)
+
+	require.NoError(t, os.Setenv("LC_ALL", "xx_YY"))
+	assert.Equal(t, "C", locale.Detect().Tag)
+}
+
+func TestFormat(t *testing.T) {
+	date := time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC)
+	for i, tc := range []struct {
+		locale       string
+		wantInt      string
+		wantFloat    string
+		wantCurrency string
+		wantDate     string
+		wantDateTime string
+	}{
+		{"C", "-1234567", "1234567.89", "-1234.50 EUR", "2026-03-14", "2026-03-14 15:09:26"},
+		{"en-US", "-1,234,567", "1,234,567.89", "-€1,234.50", "03/14/2026", "03/14/2026 3:09 PM"},
+		{"de-DE", "-1.234.567", "1.234.567,89", "-1.234,50 €", "14.03.2026", "14.03.2026 15:09"},
+		{"de-CH", "-1’234’567", "1’234’567.89", "-€ 1’234.50", "14.03.2026", "14.03.2026 15:09"},
+		{"fr-FR", "-1 234 567", "1 234 567,89", "-1 234,50 €", "14/03/2026", "14/03/2026 15:09"},
+		{"ja-JP", "-1,234,567", "1,234,567.89", "-€1,234.50", "2026/03/14", "2026/03/14 15:09"},
+	} {
+		l, ok := locale.Lookup(tc.locale)
+		require.True(t, ok, "Case %d", i)
+		assert.Equal(t, tc.wantInt, l.FormatInt(-1234567), "Case %d", i)
+		assert.Equal(t, tc.wantFloat, l.FormatFloat(1234567.891, 2), "Case %d", i)
+		assert.Equal(t, tc.wantCurrency, l.FormatCurrency(-1234.5, "EUR"), "Case %d", i)
+		assert.Equal(t, tc.wantDate, l.FormatDate(date), "Case %d", i)
+		assert.Equal(t, tc.wantDateTime, l.FormatDateTime(date), "Case %d", i)
+	}
+}
+
+func TestFormatCurrency(t *testing.T) {
+	enUS, _ := locale.Lookup("en-US")
+	assert.Equal(t, "¥1,235", enUS.FormatCurrency(1234.6, "JPY"))
+	assert.Equal(t, "XYZ1,234.50", enUS.FormatCurrency(1234.5, "XYZ"))
+	assert.Equal(t, "1234 JPY", locale.C.FormatCurrency(1234.4, "JPY"))
+	assert.Equal(t, "0", locale.C.FormatInt(0))
+	assert.Equal(t, "123", enUS.FormatFloat(123.4, 0))
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matcher
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// errWalkStopped is returned by walker.call if the walk was stopped by an earlier error. It is never returned by Walk
+// because the earlier error takes precedence.
+var errWalkStopped = errors.New("walk stopped")
+
+// WalkOption configures the behavior of Walk.
+type WalkOption func(o *walkOptions)
+
+type walkOptions struct {
+	workers int
+}
+
+// WithWorkers sets the maximum number of directories that Walk reads concurrently. Values less than 1 are treated as
+// 1. The default is 4 times the number of CPUs.
+func WithWorkers(workers int) WalkOption {
+	return func(o *walkOptions) {
+		if workers < 1 {
+			workers = 1
+		}
+		o.workers = workers
+	}
+}
+
+// WalkFunc is the function called by Walk for every path that matches the include matcher and does not match the
+// exclude matcher. The provided path is relative to the directory being walked. If the function returns
+// filepath.SkipDir for a directory, the contents of the directory are not walked. Any other non-nil error stops the
+// walk and is returned by Walk.
+type WalkFunc func(relPath string, entry os.DirEntry) error
+
+// Walk walks the directory tree rooted at the provided directory and calls fn for every path (other than the root)
+// that matches the include matcher but does not match the exclude matcher. It returns the same paths as ListFiles, but
+// directories are read concurrently by a bounded number of workers and results are streamed to fn rather than
+// collected, which makes it suitable for very large trees.
+//
+// Directories that match the exclude matcher are pruned: their contents are not read. This is consistent with the
+// matchers provided by this package, which match all of the descendants of any path that they match.
+//
+// Paths are provided to fn in no particular order. Calls to fn are serialized, so fn does not need to be safe for
+// concurrent use.
+func Walk(dir string, include, exclude Matcher, fn WalkFunc, opts ...WalkOption) error {
+	dirAbsPath, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to convert path %s to absolute path", dir)
+	}
+	if fileInfo, err := os.Stat(dirAbsPath); err != nil {
+		return fmt.Errorf("failed to stat %s", dirAbsPath)
+	} else if !fileInfo.IsDir() {
+		return fmt.Errorf("%s is not a directory", dirAbsPath)
+	}
+
+	o := walkOptions{
+		workers: 4 * runtime.NumCPU(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	w := &walker{
+		root:    dirAbsPath,
+		include: include,
+		exclude: exclude,
+		fn:      fn,
+		queue:   []string{"."},
+		pending: 1,
+	}
+	w.cond = sync.NewCond(&w.mutex)
+
+	var wg sync.WaitGroup
+	for i := 0; i < o.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.work()
+		}()
+	}
+	wg.Wait()
+	return w.err
+}
+
+type walker struct {
+	root    string
+	include Matcher
+	exclude Matcher
+	fn      WalkFunc
+	fnMutex sync.Mutex
+
+	mutex sync.Mutex
+	cond  *sync.Cond
+	// queue contains the relative paths of the directories that have not yet been read.
+	queue []string
+	// pending is the number of directories that are in the queue or are being read.
+	pending int
+	// err is the first error that was encountered.
+	err error
+}
+
+func (w *walker) work() {
+	for {
+		w.mutex.Lock()
+		for len(w.queue) == 0 && w.pending > 0 && w.err == nil {
+			w.cond.Wait()
+		}
+		if w.err != nil || w.pending == 0 {
+			w.mutex.Unlock()
+			w.cond.Broadcast()
+			return
+		}
+		relDir := w.queue[len(w.queue)-1]
+		w.queue = w.queue[:len(w.queue)-1]
+		w.mutex.Unlock()
+
+		subdirs, err := w.readDir(relDir)
+
+		w.mutex.Lock()
+		if err != nil && w.err == nil {
+			w.err = err
+		}
+		w.queue = append(w.queue, subdirs...)
+		w.pending += len(subdirs) - 1
+		w.mutex.Unlock()
+		w.cond.Broadcast()
+	}
+}
+
+// readDir reads the directory with the provided relative path, calls the walk function for the matching entries and
+// returns the relative paths of the subdirectories that should be walked.
+func (w *walker) readDir(relDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(w.root, relDir))
+	if err != nil {
+		return nil, fmt.Errorf("walk failed at %s: %v", filepath.Join(w.root, relDir), err)
+	}
+	var subdirs []string
+	for _, entry := range entries {
+		relPath := entry.Name()
+		if relDir != "." {
+			relPath = filepath.Join(relDir, relPath)
+		}
+		if w.exclude != nil && w.exclude.Match(relPath) {
+			continue
+		}
+		if w.include != nil && w.include.Match(relPath) {
+			if err := w.call(relPath, entry); err == filepath.SkipDir && entry.IsDir() {
+				continue
+			} else if err != nil && err != filepath.SkipDir {
+				return nil, err
+			}
+		}
+		if entry.IsDir() {
+			subdirs = append(subdirs, relPath)
+		}
+	}
+	return subdirs, nil
+}
+
+func (w *walker) call(relPath string, entry os.DirEntry) error {
+	w.fnMutex.Lock()
+	defer w.fnMutex.Unlock()
+
+	w.mutex.Lock()
+	stopped := w.err != nil
+	w.mutex.Unlock()
+	if stopped {
+		return errWalkStopped
+	}
+	return w.fn(relPath, entry)
+}
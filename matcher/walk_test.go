@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matcher_test
+
+import (
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/matcher"
+)
+
+func TestWalk(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	for i, tc := range []struct {
+		include matcher.Matcher
+		exclude matcher.Matcher
+		workers int
+	}{
+		{matcher.Name(`.+\.go`), nil, 1},
+		{matcher.Name(`.+\.go`), matcher.Hidden(), 4},
+		{matcher.Any(matcher.Name(`.+\.go`), matcher.Path("vendor")), matcher.Path("vendor/b"), 8},
+		{matcher.Path("*"), matcher.Name("inner"), 2},
+	} {
+		currCaseTmpDir := createFiles(t, tmpDir, map[string]string{
+			"notgo.txt":               "",
+			"isgo.go":                 "",
+			".hidden.go":              "",
+			".hidden/inner.go":        "",
+			"indir/isgo.go":           "",
+			"indir/inner/deep/foo.go": "",
+			"vendor/a/a.go":           "",
+			"vendor/b/b.go":           "",
+		})
+
+		want, err := matcher.ListFiles(currCaseTmpDir, tc.include, tc.exclude)
+		require.NoError(t, err, "Case %d", i)
+
+		var got []string
+		err = matcher.Walk(currCaseTmpDir, tc.include, tc.exclude, func(relPath string, entry os.DirEntry) error {
+			got = append(got, relPath)
+			return nil
+		}, matcher.WithWorkers(tc.workers))
+		require.NoError(t, err, "Case %d", i)
+		sort.Strings(got)
+		sort.Strings(want)
+		assert.Equal(t, want, got, "Case %d", i)
+	}
+}
+
+func TestWalkSkipDirAndErrors(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+	currCaseTmpDir := createFiles(t, tmpDir, map[string]string{
+		"a/foo.txt": "",
+		"b/bar.txt": "",
+	})
+
+	var got []string
+	err = matcher.Walk(currCaseTmpDir, matcher.Path("*"), nil, func(relPath string, entry os.DirEntry) error {
+		got = append(got, relPath)
+		if relPath == "a" {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	sort.Strings(got)
+	assert.Equal(t, []string{"a", "b", filepath.Join("b", "bar.txt")}, got)
+
+	err = matcher.Walk(currCaseTmpDir, matcher.Path("*"), nil, func(relPath string, entry os.DirEntry) error {
+		return fmt.Errorf("failed at %s", relPath)
+	})
+	assert.Regexp(t, `^failed at (a|b)$`, err)
+
+	err = matcher.Walk(filepath.Join(currCaseTmpDir, "a", "foo.txt"), matcher.Path("*"), nil, func(relPath string, entry os.DirEntry) error {
+		return nil
+	})
+	assert.EqualError(t, err, fmt.Sprintf("%s is not a directory", filepath.Join(currCaseTmpDir, "a", "foo.txt")))
+}
+
+// BenchmarkWalk compares Walk to filepath.WalkDir. The size of the tree can be increased using the
+// MATCHER_BENCHMARK_FILES environment variable (for example, to benchmark 1,000,000 file trees).
+func BenchmarkWalk(b *testing.B) {
+	numFiles := 10000
+	if val := os.Getenv("MATCHER_BENCHMARK_FILES"); val != "" {
+		_, err := fmt.Sscanf(val, "%d", &numFiles)
+		require.NoError(b, err)
+	}
+
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(b, err)
+	const filesPerDir = 100
+	for i := 0; i < numFiles; i++ {
+		dir := filepath.Join(tmpDir, fmt.Sprintf("d%d", i/(filesPerDir*filesPerDir)), fmt.Sprintf("d%d", i/filesPerDir))
+		if i%filesPerDir == 0 {
+			require.NoError(b, os.MkdirAll(dir, 0755))
+		}
+		require.NoError(b, ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("f%d.go", i)), nil, 0644))
+	}
+	include := matcher.Name(`.+\.go`)
+
+	b.Run("Walk", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			count := 0
+			require.NoError(b, matcher.Walk(tmpDir, include, nil, func(relPath string, entry os.DirEntry) error {
+				count++
+				return nil
+			}))
+			require.Equal(b, numFiles, count)
+		}
+	})
+	b.Run("filepath.WalkDir", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			count := 0
+			require.NoError(b, filepath.WalkDir(tmpDir, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				relPath, err := filepath.Rel(tmpDir, path)
+				if err != nil {
+					return err
+				}
+				if include.Match(relPath) {
+					count++
+				}
+				return nil
+			}))
+			require.Equal(b, numFiles, count)
+		}
+	})
+}
@@ -10,6 +10,8 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/palantir/pkg/typenames"
 )
 
 type Matcher interface {
@@ -46,13 +48,13 @@ func NewEqualsMatcher(want interface{}) Matcher {
 
 func (m *EqualsMatcher) Matches(in interface{}) error {
 	if !reflect.DeepEqual(m.Want, in) {
-		return fmt.Errorf("want: %T(%+v)\ngot:  %T(%+v)", m.Want, m.Want, in, in)
+		return fmt.Errorf("want: %s(%+v)\ngot:  %s(%+v)", typenames.Of(m.Want), m.Want, typenames.Of(in), in)
 	}
 	return nil
 }
 
 func (m *EqualsMatcher) String() string {
-	return fmt.Sprintf("equals(%T(%+v))", m.Want, m.Want)
+	return fmt.Sprintf("equals(%s(%+v))", typenames.Of(m.Want), m.Want)
 }
 
 type RegExpMatcher struct {
@@ -66,7 +68,7 @@ func NewRegExpMatcher(want string) Matcher {
 func (m *RegExpMatcher) Matches(in interface{}) error {
 	str, ok := in.(string)
 	if !ok {
-		return fmt.Errorf("want to match regexp %s, but %T(%+v) is not a string", m.WantRegexp, in, in)
+		return fmt.Errorf("want to match regexp %s, but %s(%+v) is not a string", m.WantRegexp, typenames.Of(in), in)
 	}
 	if !regexp.MustCompile(m.WantRegexp).MatchString(str) {
 		return fmt.Errorf("regexp %s does not match %s", m.WantRegexp, str)
@@ -106,7 +108,7 @@ type MapMatcher map[string]Matcher
 func (m MapMatcher) Matches(in interface{}) error {
 	inMap, ok := in.(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("want: %+v\ngot:  %+v\n%T(%+v)is not a map", m, in, in, in)
+		return fmt.Errorf("want: %+v\ngot:  %+v\n%s(%+v)is not a map", m, in, typenames.Of(in), in)
 	}
 	if len(m) != len(inMap) {
 		genericM := make(map[string]interface{})
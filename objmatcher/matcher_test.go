@@ -64,6 +64,14 @@ func TestEqualsMatcher(t *testing.T) {
 			},
 			wantErr: "want: objmatcher_test.testStruct({label:foo num:13})\ngot:  objmatcher_test.testStruct({label:bar num:13})",
 		},
+		{
+			name: "anonymous structs mismatch",
+			matcherWant: struct {
+				Label string `json:"label"`
+			}{Label: "foo"},
+			given:   "foo",
+			wantErr: "want: struct { Label string }({Label:foo})\ngot:  string(foo)",
+		},
 		{
 			name: "maps match",
 			matcherWant: map[string]interface{}{
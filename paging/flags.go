@@ -0,0 +1,32 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package paging
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// Flags stores the values of the flags that control how many items a list command returns.
+type Flags struct {
+	limit int
+	all   bool
+}
+
+// FlagsVar defines the "--limit" and "--all" flags on the provided flag set. "--limit" specifies the maximum number
+// of items to return and defaults to the provided value. "--all" returns all items and takes precedence over
+// "--limit".
+func FlagsVar(flags *pflag.FlagSet, f *Flags, defaultLimit int) {
+	flags.IntVar(&f.limit, "limit", defaultLimit, "maximum number of items to return")
+	flags.BoolVar(&f.all, "all", false, "return all items (overrides --limit)")
+}
+
+// Limit returns the maximum number of items that should be returned, or 0 if all items should be returned. The value
+// can be provided to WithLimit.
+func (f *Flags) Limit() int {
+	if f.all || f.limit < 0 {
+		return 0
+	}
+	return f.limit
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package paging
+
+import (
+	"context"
+	"io"
+
+	"github.com/palantir/pkg/safejson"
+)
+
+// WriteNDJSON writes the remaining items of the provided iterator to the provided writer as newline-delimited JSON
+// (one JSON value per line). Items are written as they are fetched, so output starts before all pages are fetched.
+func WriteNDJSON[T any](ctx context.Context, w io.Writer, it *Iterator[T]) error {
+	enc := safejson.Encoder(w)
+	for it.Next(ctx) {
+		if err := enc.Encode(it.Item()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// WriteJSONArray writes the remaining items of the provided iterator to the provided writer as a JSON array using a
+// safejson.StreamEncoder. Items are written as they are fetched. If an error occurs while iterating, the array is not
+// terminated.
+func WriteJSONArray[T any](ctx context.Context, w io.Writer, it *Iterator[T]) error {
+	enc := safejson.NewStreamEncoder(w)
+	if err := enc.WriteArrayStart(); err != nil {
+		return err
+	}
+	for it.Next(ctx) {
+		if err := enc.WriteElement(it.Item()); err != nil {
+			return err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	return enc.Close()
+}
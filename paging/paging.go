@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package paging provides an iterator over the items of paginated APIs so that commands that list resources handle
+// pagination uniformly. Both next-token pagination (each page specifies the token of the following page) and offset
+// pagination (pages are requested by the offset of their first item) are supported:
+//
+//	it := paging.NewTokenIterator(func(ctx context.Context, token string, pageSize int) (paging.Page[Dataset], error) {
+//		resp, err := client.ListDatasets(ctx, token, pageSize)
+//		if err != nil {
+//			return paging.Page[Dataset]{}, err
+//		}
+//		return paging.Page[Dataset]{Items: resp.Datasets, NextToken: resp.NextPageToken}, nil
+//	}, paging.WithLimit(flags.Limit()))
+//	for it.Next(ctx) {
+//		fmt.Println(it.Item().Name)
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+package paging
+
+import (
+	"context"
+)
+
+// DefaultPageSize is the page size used when WithPageSize is not specified.
+const DefaultPageSize = 100
+
+// Page is a page of items returned by a token-based paginated API.
+type Page[T any] struct {
+	// Items are the items of the page.
+	Items []T
+	// NextToken is the token of the next page. It is empty if this is the last page.
+	NextToken string
+}
+
+// TokenFetchFunc fetches the page identified by the provided token. The token of the first page is the empty string.
+// pageSize is the maximum number of items that should be returned.
+type TokenFetchFunc[T any] func(ctx context.Context, token string, pageSize int) (Page[T], error)
+
+// OffsetFetchFunc fetches at most pageSize items starting at the provided offset. A page with fewer than pageSize items
+// is treated as the last page.
+type OffsetFetchFunc[T any] func(ctx context.Context, offset, pageSize int) ([]T, error)
+
+// Option configures an Iterator.
+type Option func(o *options)
+
+type options struct {
+	pageSize int
+	limit    int
+}
+
+// WithPageSize sets the maximum number of items that are requested per page. Values that are not positive are ignored.
+func WithPageSize(pageSize int) Option {
+	return func(o *options) {
+		if pageSize > 0 {
+			o.pageSize = pageSize
+		}
+	}
+}
+
+// WithLimit sets the maximum total number of items returned by the iterator. Pages are not requested once the limit is
+// reached and the page size is reduced so that no more items than necessary are requested. A limit of 0 indicates no
+// limit.
+func WithLimit(limit int) Option {
+	return func(o *options) {
+		o.limit = limit
+	}
+}
+
+// Iterator iterates over the items of a paginated API, fetching pages as required. An Iterator is not safe for
+// concurrent use.
+type Iterator[T any] struct {
+	fetch func(ctx context.Context, pageSize int) ([]T, bool, error)
+	opts  options
+
+	page     []T
+	idx      int
+	returned int
+	lastPage bool
+	item     T
+	err      error
+}
+
+// NewTokenIterator returns an Iterator that fetches pages using the provided function. Iteration stops after a page
+// with an empty NextToken is returned.
+func NewTokenIterator[T any](fetch TokenFetchFunc[T], opts ...Option) *Iterator[T] {
+	var token string
+	return newIterator(func(ctx context.Context, pageSize int) ([]T, bool, error) {
+		page, err := fetch(ctx, token, pageSize)
+		if err != nil {
+			return nil, false, err
+		}
+		token = page.NextToken
+		return page.Items, page.NextToken == "", nil
+	}, opts)
+}
+
+// NewOffsetIterator returns an Iterator that fetches pages using the provided function. Iteration stops after a page
+// with fewer items than the requested page size is returned.
+func NewOffsetIterator[T any](fetch OffsetFetchFunc[T], opts ...Option) *Iterator[T] {
+	var offset int
+	return newIterator(func(ctx context.Context, pageSize int) ([]T, bool, error) {
+		items, err := fetch(ctx, offset, pageSize)
+		if err != nil {
+			return nil, false, err
+		}
+		offset += len(items)
+		return items, len(items) < pageSize, nil
+	}, opts)
+}
+
+func newIterator[T any](fetch func(ctx context.Context, pageSize int) ([]T, bool, error), opts []Option) *Iterator[T] {
+	o := options{
+		pageSize: DefaultPageSize,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Iterator[T]{
+		fetch: fetch,
+		opts:  o,
+	}
+}
+
+// Next advances the iterator to the next item, fetching the next page if required. Returns false when there are no
+// more items, when the limit has been reached or when an error occurs (in which case Err returns the error).
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil || it.limitReached() {
+		return false
+	}
+	for it.idx >= len(it.page) {
+		if it.lastPage {
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		pageSize := it.opts.pageSize
+		if it.opts.limit > 0 && it.opts.limit-it.returned < pageSize {
+			pageSize = it.opts.limit - it.returned
+		}
+		page, lastPage, err := it.fetch(ctx, pageSize)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page, it.idx, it.lastPage = page, 0, lastPage
+	}
+	it.item = it.page[it.idx]
+	it.idx++
+	it.returned++
+	return true
+}
+
+// Item returns the current item. It is only valid after a call to Next that returned true.
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+func (it *Iterator[T]) limitReached() bool {
+	return it.opts.limit > 0 && it.returned >= it.opts.limit
+}
+
+// Collect returns all of the remaining items of the provided iterator.
+func Collect[T any](ctx context.Context, it *Iterator[T]) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
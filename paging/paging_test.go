@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package paging_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/paging"
+)
+
+// fakeAPI serves the integers [0, numItems) in pages.
+type fakeAPI struct {
+	numItems  int
+	pageSizes []int
+}
+
+func (a *fakeAPI) fetchToken(ctx context.Context, token string, pageSize int) (paging.Page[int], error) {
+	offset := 0
+	if token != "" {
+		var err error
+		if offset, err = strconv.Atoi(token); err != nil {
+			return paging.Page[int]{}, err
+		}
+	}
+	items, _ := a.fetchOffset(ctx, offset, pageSize)
+	page := paging.Page[int]{Items: items}
+	if next := offset + len(items); next < a.numItems {
+		page.NextToken = strconv.Itoa(next)
+	}
+	return page, nil
+}
+
+func (a *fakeAPI) fetchOffset(ctx context.Context, offset, pageSize int) ([]int, error) {
+	a.pageSizes = append(a.pageSizes, pageSize)
+	var items []int
+	for i := offset; i < a.numItems && len(items) < pageSize; i++ {
+		items = append(items, i)
+	}
+	return items, nil
+}
+
+func TestIterator(t *testing.T) {
+	for i, tc := range []struct {
+		numItems      int
+		opts          []paging.Option
+		want          []int
+		wantPageSizes []int
+	}{
+		{0, nil, nil, []int{100}},
+		{5, []paging.Option{paging.WithPageSize(2)}, []int{0, 1, 2, 3, 4}, []int{2, 2, 2}},
+		{6, []paging.Option{paging.WithPageSize(3)}, []int{0, 1, 2, 3, 4, 5}, []int{3, 3}},
+		{10, []paging.Option{paging.WithPageSize(4), paging.WithLimit(6)}, []int{0, 1, 2, 3, 4, 5}, []int{4, 2}},
+		{3, []paging.Option{paging.WithPageSize(4), paging.WithLimit(6)}, []int{0, 1, 2}, []int{4}},
+	} {
+		tokenAPI := &fakeAPI{numItems: tc.numItems}
+		got, err := paging.Collect(context.Background(), paging.NewTokenIterator(tokenAPI.fetchToken, tc.opts...))
+		require.NoError(t, err, "Case %d", i)
+		assert.Equal(t, tc.want, got, "Case %d: token", i)
+		assert.Equal(t, tc.wantPageSizes, tokenAPI.pageSizes, "Case %d: token", i)
+
+		offsetAPI := &fakeAPI{numItems: tc.numItems}
+		got, err = paging.Collect(context.Background(), paging.NewOffsetIterator(offsetAPI.fetchOffset, tc.opts...))
+		require.NoError(t, err, "Case %d", i)
+		assert.Equal(t, tc.want, got, "Case %d: offset", i)
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	calls := 0
+	it := paging.NewOffsetIterator(func(ctx context.Context, offset, pageSize int) ([]int, error) {
+		calls++
+		if offset > 0 {
+			return nil, fmt.Errorf("page request failed")
+		}
+		return []int{1, 2}, nil
+	}, paging.WithPageSize(2))
+
+	got, err := paging.Collect(context.Background(), it)
+	assert.EqualError(t, err, "page request failed")
+	assert.Equal(t, []int{1, 2}, got)
+	assert.False(t, it.Next(context.Background()))
+	assert.Equal(t, 2, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = paging.Collect(ctx, paging.NewOffsetIterator((&fakeAPI{numItems: 1}).fetchOffset))
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestFlags(t *testing.T) {
+	for i, tc := range []struct {
+		args []string
+		want int
+	}{
+		{nil, 50},
+		{[]string{"--limit", "10"}, 10},
+		{[]string{"--all"}, 0},
+		{[]string{"--limit", "10", "--all"}, 0},
+	} {
+		var f paging.Flags
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		paging.FlagsVar(flags, &f, 50)
+		require.NoError(t, flags.Parse(tc.args), "Case %d", i)
+		assert.Equal(t, tc.want, f.Limit(), "Case %d", i)
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	type item struct {
+		Name string `json:"name"`
+	}
+	newIterator := func() *paging.Iterator[item] {
+		return paging.NewTokenIterator(func(ctx context.Context, token string, pageSize int) (paging.Page[item], error) {
+			if token == "" {
+				return paging.Page[item]{Items: []item{{"a"}, {"<b>"}}, NextToken: "next"}, nil
+			}
+			return paging.Page[item]{Items: []item{{"c"}}}, nil
+		})
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, paging.WriteNDJSON(context.Background(), buf, newIterator()))
+	assert.Equal(t, `{"name":"a"}
+{"name":"<b>"}
+{"name":"c"}
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, paging.WriteJSONArray(context.Background(), buf, newIterator()))
+	assert.Equal(t, `[{"name":"a"},{"name":"<b>"},{"name":"c"}]`+"\n", buf.String())
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package refreshable
+
+import (
+	"sync"
+)
+
+// Map returns a Refreshable whose value is the result of applying mapFn to the value of the provided Refreshable. The
+// returned Refreshable is updated whenever the original is updated. Because subscribers are notified asynchronously,
+// the value of the returned Refreshable reflects an update of the original shortly after the update is made rather
+// than immediately.
+//
+// The returned stop function unsubscribes the returned Refreshable from the original: after it is called, the
+// returned Refreshable retains its last value and is no longer updated.
+func Map[T any, M any](original Refreshable[T], mapFn func(T) M) (mapped Refreshable[M], stop func()) {
+	mapped, stop, _ = MapWithError(original, func(val T) (M, error) {
+		return mapFn(val), nil
+	}, nil)
+	return mapped, stop
+}
+
+// MapWithError is like Map, but mapFn may return an error to reject a value. If the current value of the original
+// Refreshable is rejected, MapWithError returns the error and a nil Refreshable. If a subsequent update of the original
+// is rejected, the returned Refreshable retains the last successfully mapped value and onError (if non-nil) is called
+// with the rejected value and the error.
+func MapWithError[T any, M any](original Refreshable[T], mapFn func(T) (M, error), onError func(T, error)) (mapped Refreshable[M], stop func(), err error) {
+	// mu ensures that no update is applied until the initial value has been computed
+	var mu sync.Mutex
+	var updatable Updatable[M]
+
+	mu.Lock()
+	unsubscribe := original.Subscribe(func(val T) {
+		mu.Lock()
+		defer mu.Unlock()
+		if updatable == nil {
+			return
+		}
+		mappedVal, err := mapFn(val)
+		if err != nil {
+			if onError != nil {
+				onError(val, err)
+			}
+			return
+		}
+		updatable.Update(mappedVal)
+	})
+	initial, err := mapFn(original.Current())
+	if err != nil {
+		mu.Unlock()
+		unsubscribe()
+		return nil, nil, err
+	}
+	updatable = New(initial)
+	mu.Unlock()
+	return updatable, unsubscribe, nil
+}
+
+// Validate returns a Refreshable that has the value of the provided Refreshable as long as that value is valid
+// according to validateFn. If the current value of the original is invalid, Validate returns the error and a nil
+// Refreshable. If a subsequent update of the original is invalid, the update is rejected: the returned Refreshable
+// retains the last valid value and onError (if non-nil) is called with the invalid value and the error.
+func Validate[T any](original Refreshable[T], validateFn func(T) error, onError func(T, error)) (validated Refreshable[T], stop func(), err error) {
+	return MapWithError(original, func(val T) (T, error) {
+		return val, validateFn(val)
+	}, onError)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package refreshable_test
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/refreshable"
+)
+
+func TestMap(t *testing.T) {
+	r := refreshable.New("foo")
+	mapped, stop := refreshable.Map[string, int](r, func(val string) int {
+		return len(val)
+	})
+	assert.Equal(t, 3, mapped.Current())
+
+	updates := make(chan int, 10)
+	mapped.Subscribe(func(val int) {
+		updates <- val
+	})
+	r.Update("foobar")
+	assert.Equal(t, 6, receive(t, updates))
+	assert.Equal(t, 6, mapped.Current())
+
+	stop()
+	r.Update("a")
+	select {
+	case val := <-updates:
+		assert.Fail(t, "unexpected update after stop", val)
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Equal(t, 6, mapped.Current())
+}
+
+func TestMapWithError(t *testing.T) {
+	r := refreshable.New("https://localhost:8443")
+
+	errs := make(chan string, 10)
+	parsed, stop, err := refreshable.MapWithError(r, url.Parse, func(val string, err error) {
+		errs <- val
+	})
+	require.NoError(t, err)
+	defer stop()
+	assert.Equal(t, "localhost:8443", parsed.Current().Host)
+
+	updates := make(chan *url.URL, 10)
+	parsed.Subscribe(func(val *url.URL) {
+		updates <- val
+	})
+
+	// invalid update is rejected and the last good value is retained
+	r.Update("https://[invalid")
+	assert.Equal(t, "https://[invalid", receive(t, errs))
+	assert.Equal(t, "localhost:8443", parsed.Current().Host)
+
+	r.Update("https://localhost:9443")
+	assert.Equal(t, "localhost:9443", receive(t, updates).Host)
+	assert.Equal(t, "localhost:9443", parsed.Current().Host)
+}
+
+func TestValidate(t *testing.T) {
+	positive := func(val int) error {
+		if val <= 0 {
+			return fmt.Errorf("%d is not positive", val)
+		}
+		return nil
+	}
+
+	_, _, err := refreshable.Validate[int](refreshable.New(0), positive, nil)
+	assert.EqualError(t, err, "0 is not positive")
+
+	r := refreshable.New(1)
+	errs := make(chan error, 10)
+	validated, stop, err := refreshable.Validate[int](r, positive, func(_ int, err error) {
+		errs <- err
+	})
+	require.NoError(t, err)
+	defer stop()
+
+	updates := make(chan int, 10)
+	validated.Subscribe(func(val int) {
+		updates <- val
+	})
+	r.Update(-1)
+	assert.EqualError(t, receive(t, errs), "-1 is not positive")
+	assert.Equal(t, 1, validated.Current())
+
+	r.Update(2)
+	assert.Equal(t, 2, receive(t, updates))
+}
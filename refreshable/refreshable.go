@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package refreshable provides values that can be updated at runtime and whose updates can be observed.
+//
+// The current value of a Refreshable is stored as an immutable snapshot that is replaced atomically on every update,
+// so Current is a single atomic load that never blocks and never observes a partially-applied update. Values stored in
+// a Refreshable must be treated as immutable by all readers: to change the value, construct a new value and call
+// Update.
+//
+// Every subscriber is notified on its own goroutine, so a slow or blocked subscriber never delays Update, Current or
+// any other subscriber. Each subscriber observes updates in the order in which they were made, but a subscriber that
+// falls behind is only notified of the latest value rather than of every intermediate value.
+//
+// Map, MapWithError and Validate derive a Refreshable from another one, so that values derived from a setting (for
+// example, a URL parsed from a string) are kept up-to-date and invalid updates are rejected.
+package refreshable
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Refreshable is a value that can change over time.
+type Refreshable[T any] interface {
+	// Current returns the most recent value.
+	Current() T
+	// Subscribe registers the provided function to be called with the new value whenever the value is updated.
+	// Returns a function that unsubscribes the consumer. After unsubscribe returns, the consumer is not called with any
+	// further updates, although a call that is already in progress may still be running.
+	Subscribe(consumer func(T)) (unsubscribe func())
+}
+
+// Updatable is a Refreshable whose value can be updated.
+type Updatable[T any] interface {
+	Refreshable[T]
+	// Update sets the current value and notifies all subscribers of the new value.
+	Update(val T)
+}
+
+// New returns a new Updatable with the provided initial value.
+func New[T any](initial T) Updatable[T] {
+	r := &defaultRefreshable[T]{}
+	r.current.Store(&initial)
+	r.subscribers.Store(&[]*subscriber[T]{})
+	return r
+}
+
+type defaultRefreshable[T any] struct {
+	current atomic.Pointer[T]
+	// subscribers is a copy-on-write slice: it is never modified after being stored.
+	subscribers atomic.Pointer[[]*subscriber[T]]
+	// mu serializes updates and modifications of subscribers.
+	mu sync.Mutex
+}
+
+func (r *defaultRefreshable[T]) Current() T {
+	return *r.current.Load()
+}
+
+func (r *defaultRefreshable[T]) Update(val T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.current.Store(&val)
+	for _, sub := range *r.subscribers.Load() {
+		sub.notify(val)
+	}
+}
+
+func (r *defaultRefreshable[T]) Subscribe(consumer func(T)) (unsubscribe func()) {
+	sub := &subscriber[T]{consumer: consumer}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.storeSubscribers(append(append([]*subscriber[T]{}, *r.subscribers.Load()...), sub))
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+
+			sub.close()
+			var remaining []*subscriber[T]
+			for _, curr := range *r.subscribers.Load() {
+				if curr != sub {
+					remaining = append(remaining, curr)
+				}
+			}
+			r.storeSubscribers(remaining)
+		})
+	}
+}
+
+func (r *defaultRefreshable[T]) storeSubscribers(subs []*subscriber[T]) {
+	r.subscribers.Store(&subs)
+}
+
+// subscriber delivers values to a consumer on a dedicated goroutine. Only the latest undelivered value is retained.
+type subscriber[T any] struct {
+	consumer func(T)
+
+	mu       sync.Mutex
+	pending  *T
+	draining bool
+	closed   bool
+}
+
+// notify records the provided value as the latest value for the subscriber and starts a goroutine to deliver it if
+// one is not already running. Never blocks on the consumer.
+func (s *subscriber[T]) notify(val T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.pending = &val
+	if s.draining {
+		return
+	}
+	s.draining = true
+	go s.drain()
+}
+
+func (s *subscriber[T]) drain() {
+	for {
+		s.mu.Lock()
+		if s.closed || s.pending == nil {
+			s.draining = false
+			s.mu.Unlock()
+			return
+		}
+		val := *s.pending
+		s.pending = nil
+		s.mu.Unlock()
+
+		s.consumer(val)
+	}
+}
+
+func (s *subscriber[T]) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.pending = nil
+}
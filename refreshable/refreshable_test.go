@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package refreshable_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/refreshable"
+)
+
+func TestRefreshable(t *testing.T) {
+	r := refreshable.New("initial")
+	assert.Equal(t, "initial", r.Current())
+
+	updates := make(chan string, 10)
+	unsubscribe := r.Subscribe(func(val string) {
+		updates <- val
+	})
+
+	r.Update("second")
+	assert.Equal(t, "second", r.Current())
+	assert.Equal(t, "second", receive(t, updates))
+
+	unsubscribe()
+	r.Update("third")
+	assert.Equal(t, "third", r.Current())
+	select {
+	case val := <-updates:
+		assert.Fail(t, "unexpected update after unsubscribe", val)
+	case <-time.After(50 * time.Millisecond):
+	}
+	// calling unsubscribe again is a no-op
+	unsubscribe()
+}
+
+func TestSlowSubscriberDoesNotBlockOthers(t *testing.T) {
+	r := refreshable.New(0)
+
+	blocked := make(chan struct{})
+	defer close(blocked)
+	r.Subscribe(func(int) {
+		<-blocked
+	})
+
+	updates := make(chan int, 10)
+	r.Subscribe(func(val int) {
+		updates <- val
+	})
+
+	r.Update(1)
+	assert.Equal(t, 1, receive(t, updates))
+	r.Update(2)
+	assert.Equal(t, 2, receive(t, updates))
+	assert.Equal(t, 2, r.Current())
+}
+
+func TestSubscriberSeesUpdatesInOrder(t *testing.T) {
+	r := refreshable.New(0)
+
+	var mu sync.Mutex
+	var seen []int
+	done := make(chan struct{})
+	const numUpdates = 1000
+	r.Subscribe(func(val int) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, val)
+		if val == numUpdates {
+			close(done)
+		}
+	})
+	for i := 1; i <= numUpdates; i++ {
+		r.Update(i)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for final update")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// intermediate values may be coalesced, but values must be strictly increasing
+	for i := 1; i < len(seen); i++ {
+		assert.True(t, seen[i] > seen[i-1], "values out of order: %v", seen)
+	}
+}
+
+func receive[T any](t *testing.T, c <-chan T) T {
+	select {
+	case val := <-c:
+		return val
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for value")
+	}
+	var zero T
+	return zero
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package refreshable_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/palantir/pkg/refreshable"
+)
+
+// snapshot is a value whose fields must always be consistent with each other. Observing a snapshot whose fields differ
+// indicates a torn read.
+type snapshot struct {
+	a, b    int
+	payload [8]int
+}
+
+func newSnapshot(i int) snapshot {
+	s := snapshot{a: i, b: i}
+	for j := range s.payload {
+		s.payload[j] = i
+	}
+	return s
+}
+
+func (s snapshot) consistent() bool {
+	if s.a != s.b {
+		return false
+	}
+	for _, v := range s.payload {
+		if v != s.a {
+			return false
+		}
+	}
+	return true
+}
+
+// TestStressNoTornReads concurrently updates, reads, subscribes and unsubscribes and verifies that every observed
+// value is internally consistent. Intended to be run with -race.
+func TestStressNoTornReads(t *testing.T) {
+	const (
+		numWriters     = 4
+		numReaders     = 8
+		numSubscribers = 8
+		duration       = 500 * time.Millisecond
+	)
+	if testing.Short() {
+		t.Skip("skipping stress test in short mode")
+	}
+
+	r := refreshable.New(newSnapshot(0))
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	var torn, reads, notifications int64
+
+	var counter int64
+	for i := 0; i < numWriters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					r.Update(newSnapshot(int(atomic.AddInt64(&counter, 1))))
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < numReaders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					if !r.Current().consistent() {
+						atomic.AddInt64(&torn, 1)
+					}
+					atomic.AddInt64(&reads, 1)
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < numSubscribers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					unsubscribe := r.Subscribe(func(s snapshot) {
+						if !s.consistent() {
+							atomic.AddInt64(&torn, 1)
+						}
+						atomic.AddInt64(&notifications, 1)
+					})
+					time.Sleep(time.Millisecond)
+					unsubscribe()
+				}
+			}
+		}()
+	}
+
+	time.Sleep(duration)
+	close(stop)
+	wg.Wait()
+
+	assert.Equal(t, int64(0), atomic.LoadInt64(&torn))
+	assert.True(t, atomic.LoadInt64(&reads) > 0)
+	assert.True(t, atomic.LoadInt64(&notifications) > 0)
+	assert.True(t, r.Current().consistent())
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package retry
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by CircuitBreaker.Do (and by Do when configured with WithCircuitBreaker) when the circuit
+// breaker is open and the action was not attempted.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// StateClosed indicates that actions are allowed and their outcomes are being recorded.
+	StateClosed BreakerState = iota
+	// StateOpen indicates that the failure rate exceeded the threshold and actions are being rejected until the
+	// cool-down period has elapsed.
+	StateOpen
+	// StateHalfOpen indicates that the cool-down period has elapsed and a limited number of trial actions are allowed
+	// to determine whether the breaker should close.
+	StateHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerOption configures a CircuitBreaker.
+type BreakerOption func(o *breakerOptions)
+
+// WithFailureRateThreshold sets the fraction (between 0 and 1) of failed actions in the window at or above which the
+// breaker opens.
+//
+// If failure rate threshold option is not used, then default value of 0.5 is used.
+func WithFailureRateThreshold(threshold float64) BreakerOption {
+	return func(o *breakerOptions) {
+		o.failureRateThreshold = threshold
+	}
+}
+
+// WithWindowSize sets the number of most recent outcomes that are used to compute the failure rate. The breaker does
+// not open until at least this many outcomes have been recorded.
+//
+// If window size option is not used, then default value of 10 is used.
+func WithWindowSize(size int) BreakerOption {
+	return func(o *breakerOptions) {
+		o.windowSize = size
+	}
+}
+
+// WithCoolDown sets the amount of time the breaker stays open before allowing trial actions.
+//
+// If cool-down option is not used, then default value of 30 seconds is used.
+func WithCoolDown(coolDown time.Duration) BreakerOption {
+	return func(o *breakerOptions) {
+		o.coolDown = coolDown
+	}
+}
+
+// WithHalfOpenRequests sets the number of trial actions that are allowed when the breaker is half-open. The breaker
+// closes once this many trial actions succeed and opens again as soon as one fails.
+//
+// If half-open requests option is not used, then default value of 1 is used.
+func WithHalfOpenRequests(n int) BreakerOption {
+	return func(o *breakerOptions) {
+		o.halfOpenRequests = n
+	}
+}
+
+const (
+	defaultFailureRateThreshold = 0.5
+	defaultWindowSize           = 10
+	defaultCoolDown             = 30 * time.Second
+	defaultHalfOpenRequests     = 1
+)
+
+type breakerOptions struct {
+	failureRateThreshold float64       // Fraction of failures at or above which the breaker opens.
+	windowSize           int           // Number of most recent outcomes considered.
+	coolDown             time.Duration // Time spent in the open state before transitioning to half-open.
+	halfOpenRequests     int           // Number of trial actions allowed in the half-open state.
+}
+
+// CircuitBreaker tracks the outcomes of actions and stops allowing actions once the rate of failures exceeds a
+// threshold. This allows a program that performs many operations against a failing dependency to back off globally
+// rather than retrying every operation individually.
+//
+// A CircuitBreaker starts closed. It opens when the failure rate over the most recent outcomes reaches the threshold.
+// Once the cool-down period has elapsed, it becomes half-open and allows a limited number of trial actions: if they all
+// succeed the breaker closes, and if any fails the breaker opens again.
+//
+// A CircuitBreaker is safe for concurrent use and is typically shared by all of the operations against a particular
+// dependency. It can be combined with Do using WithCircuitBreaker.
+type CircuitBreaker struct {
+	options breakerOptions
+
+	mu               sync.Mutex
+	state            BreakerState
+	outcomes         []bool // ring buffer of outcomes: true indicates failure
+	next             int
+	openedAt         time.Time
+	halfOpenInFlight int
+	halfOpenSuccess  int
+}
+
+// NewCircuitBreaker returns a new closed CircuitBreaker.
+func NewCircuitBreaker(opts ...BreakerOption) *CircuitBreaker {
+	b := &CircuitBreaker{
+		options: breakerOptions{
+			failureRateThreshold: defaultFailureRateThreshold,
+			windowSize:           defaultWindowSize,
+			coolDown:             defaultCoolDown,
+			halfOpenRequests:     defaultHalfOpenRequests,
+		},
+	}
+	for _, option := range opts {
+		option(&b.options)
+	}
+	if b.options.windowSize < 1 {
+		b.options.windowSize = 1
+	}
+	if b.options.halfOpenRequests < 1 {
+		b.options.halfOpenRequests = 1
+	}
+	return b
+}
+
+// State returns the current state of the breaker.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.updateState()
+	return b.state
+}
+
+// Do runs the provided action if the breaker allows it and records its outcome (a non-nil error is a failure).
+// Returns ErrCircuitOpen without running the action if the breaker does not allow it.
+func (b *CircuitBreaker) Do(action func() error) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+	err = action()
+	done(err == nil)
+	return err
+}
+
+// Allow returns ErrCircuitOpen if the breaker does not currently allow actions. Otherwise, it returns a function that
+// must be called exactly once with the outcome of the action.
+func (b *CircuitBreaker) Allow() (done func(success bool), err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.updateState()
+	switch b.state {
+	case StateOpen:
+		return nil, ErrCircuitOpen
+	case StateHalfOpen:
+		if b.halfOpenInFlight+b.halfOpenSuccess >= b.options.halfOpenRequests {
+			return nil, ErrCircuitOpen
+		}
+		b.halfOpenInFlight++
+	}
+
+	state := b.state
+	var once sync.Once
+	return func(success bool) {
+		once.Do(func() {
+			b.record(state, success)
+		})
+	}, nil
+}
+
+func (b *CircuitBreaker) record(allowedIn BreakerState, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if allowedIn == StateHalfOpen {
+		if b.state != StateHalfOpen {
+			// breaker transitioned while the trial was in flight: outcome is no longer relevant
+			return
+		}
+		b.halfOpenInFlight--
+		if !success {
+			b.open()
+			return
+		}
+		b.halfOpenSuccess++
+		if b.halfOpenSuccess >= b.options.halfOpenRequests {
+			b.close()
+		}
+		return
+	}
+
+	if b.state != StateClosed {
+		return
+	}
+	if len(b.outcomes) < b.options.windowSize {
+		b.outcomes = append(b.outcomes, !success)
+	} else {
+		b.outcomes[b.next] = !success
+		b.next = (b.next + 1) % b.options.windowSize
+	}
+	if len(b.outcomes) < b.options.windowSize {
+		return
+	}
+	failures := 0
+	for _, failed := range b.outcomes {
+		if failed {
+			failures++
+		}
+	}
+	if float64(failures)/float64(len(b.outcomes)) >= b.options.failureRateThreshold {
+		b.open()
+	}
+}
+
+// updateState transitions an open breaker to half-open once the cool-down has elapsed. Must be called with mu held.
+func (b *CircuitBreaker) updateState() {
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.options.coolDown {
+		b.state = StateHalfOpen
+		b.halfOpenInFlight = 0
+		b.halfOpenSuccess = 0
+	}
+}
+
+func (b *CircuitBreaker) open() {
+	b.state = StateOpen
+	b.openedAt = time.Now()
+}
+
+func (b *CircuitBreaker) close() {
+	b.state = StateClosed
+	b.outcomes = nil
+	b.next = 0
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package retry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_OpensAtFailureRate(t *testing.T) {
+	b := NewCircuitBreaker(WithWindowSize(4), WithFailureRateThreshold(0.5), WithCoolDown(time.Hour))
+	failure := fmt.Errorf("failure")
+
+	for i, err := range []error{nil, failure, nil} {
+		if got := b.Do(func() error { return err }); got != err {
+			t.Fatalf("attempt %d: expected err %v, got %v", i, err, got)
+		}
+		if b.State() != StateClosed {
+			t.Fatalf("attempt %d: expected breaker to be closed, was %v", i, b.State())
+		}
+	}
+	// fourth outcome fills the window with a failure rate of 0.5
+	_ = b.Do(func() error { return failure })
+	if b.State() != StateOpen {
+		t.Fatalf("expected breaker to be open, was %v", b.State())
+	}
+	if got := b.Do(func() error {
+		t.Fatalf("action should not be invoked when breaker is open")
+		return nil
+	}); got != ErrCircuitOpen {
+		t.Fatalf("expected err %v, got %v", ErrCircuitOpen, got)
+	}
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	const coolDown = 10 * time.Millisecond
+	b := NewCircuitBreaker(WithWindowSize(1), WithCoolDown(coolDown), WithHalfOpenRequests(2))
+	failure := fmt.Errorf("failure")
+
+	_ = b.Do(func() error { return failure })
+	if b.State() != StateOpen {
+		t.Fatalf("expected breaker to be open, was %v", b.State())
+	}
+
+	// trial failure re-opens breaker
+	time.Sleep(coolDown)
+	if b.State() != StateHalfOpen {
+		t.Fatalf("expected breaker to be half-open, was %v", b.State())
+	}
+	_ = b.Do(func() error { return failure })
+	if b.State() != StateOpen {
+		t.Fatalf("expected breaker to be open, was %v", b.State())
+	}
+
+	// only the configured number of trials are allowed concurrently
+	time.Sleep(coolDown)
+	done1, err := b.Allow()
+	if err != nil {
+		t.Fatalf("expected first trial to be allowed: %v", err)
+	}
+	done2, err := b.Allow()
+	if err != nil {
+		t.Fatalf("expected second trial to be allowed: %v", err)
+	}
+	if _, err := b.Allow(); err != ErrCircuitOpen {
+		t.Fatalf("expected err %v, got %v", ErrCircuitOpen, err)
+	}
+	done1(true)
+	if b.State() != StateHalfOpen {
+		t.Fatalf("expected breaker to be half-open, was %v", b.State())
+	}
+	done2(true)
+	if b.State() != StateClosed {
+		t.Fatalf("expected breaker to be closed, was %v", b.State())
+	}
+}
+
+func TestDo_WithCircuitBreaker(t *testing.T) {
+	b := NewCircuitBreaker(WithWindowSize(2), WithCoolDown(time.Hour))
+	failure := fmt.Errorf("failure")
+
+	attempts := 0
+	err := Do(context.Background(), func() error {
+		attempts++
+		return failure
+	}, WithInitialBackoff(time.Microsecond), WithMaxAttempts(10), WithCircuitBreaker(b))
+	if err != ErrCircuitOpen {
+		t.Fatalf("expected err %v, got %v", ErrCircuitOpen, err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d attempts", attempts)
+	}
+
+	// other operations sharing the breaker fail immediately
+	err = Do(context.Background(), func() error {
+		t.Fatalf("action should not be invoked when breaker is open")
+		return nil
+	}, WithCircuitBreaker(b))
+	if err != ErrCircuitOpen {
+		t.Fatalf("expected err %v, got %v", ErrCircuitOpen, err)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package retry
+
+import (
+	"time"
+)
+
+// WithBackoffHint returns an error that wraps the provided error and carries a backoff hint: the minimum amount of
+// time that should elapse before the failed action is attempted again. This is typically used to propagate a delay
+// requested by a server (for example, using the "Retry-After" HTTP header). Do honors backoff hints. Returns nil if
+// err is nil.
+func WithBackoffHint(err error, hint time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &backoffHintError{
+		error: err,
+		hint:  hint,
+	}
+}
+
+// BackoffHint returns the backoff hint carried by the provided error (or by any error it wraps) and true, or false if
+// the error does not carry a hint.
+func BackoffHint(err error) (time.Duration, bool) {
+	for err != nil {
+		if hintErr, ok := err.(*backoffHintError); ok {
+			return hintErr.hint, true
+		}
+		unwrapper, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return 0, false
+		}
+		err = unwrapper.Unwrap()
+	}
+	return 0, false
+}
+
+type backoffHintError struct {
+	error
+	hint time.Duration
+}
+
+func (e *backoffHintError) Unwrap() error {
+	return e.error
+}
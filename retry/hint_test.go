@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package retry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBackoffHint(t *testing.T) {
+	base := fmt.Errorf("rate limited")
+	err := WithBackoffHint(base, time.Second)
+	if err.Error() != "rate limited" {
+		t.Errorf("expected error message %q, got %q", "rate limited", err.Error())
+	}
+	if hint, ok := BackoffHint(err); !ok || hint != time.Second {
+		t.Errorf("expected hint %v, got %v (%v)", time.Second, hint, ok)
+	}
+	if hint, ok := BackoffHint(fmt.Errorf("wrapped: %w", err)); !ok || hint != time.Second {
+		t.Errorf("expected hint %v for wrapped error, got %v (%v)", time.Second, hint, ok)
+	}
+	if _, ok := BackoffHint(base); ok {
+		t.Errorf("expected no hint for error without hint")
+	}
+	if WithBackoffHint(nil, time.Second) != nil {
+		t.Errorf("expected nil error for nil input")
+	}
+}
+
+func TestDo_HonorsBackoffHint(t *testing.T) {
+	const hint = 50 * time.Millisecond
+	var attemptTimes []time.Time
+	err := Do(context.Background(), func() error {
+		attemptTimes = append(attemptTimes, time.Now())
+		if len(attemptTimes) == 1 {
+			return WithBackoffHint(fmt.Errorf("rate limited"), hint)
+		}
+		return nil
+	}, WithInitialBackoff(time.Microsecond), WithMaxBackoff(time.Second))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(attemptTimes) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(attemptTimes))
+	}
+	if elapsed := attemptTimes[1].Sub(attemptTimes[0]); elapsed < hint {
+		t.Errorf("expected at least %v between attempts, was %v", hint, elapsed)
+	}
+}
+
+func TestDo_ClampsBackoffHint(t *testing.T) {
+	for i, tc := range []struct {
+		name    string
+		timeout time.Duration
+		options []Option
+	}{
+		{"max backoff", 0, []Option{WithInitialBackoff(time.Microsecond), WithMaxBackoff(10 * time.Millisecond)}},
+		{"context deadline", 50 * time.Millisecond, []Option{WithInitialBackoff(time.Microsecond), WithMaxBackoff(0)}},
+	} {
+		ctx := context.Background()
+		if tc.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+			defer cancel()
+		}
+		start := time.Now()
+		attempts := 0
+		_ = Do(ctx, func() error {
+			attempts++
+			if attempts == 1 {
+				return WithBackoffHint(fmt.Errorf("rate limited"), time.Hour)
+			}
+			return nil
+		}, tc.options...)
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Case %d: %s: expected hint to be clamped, but Do took %v", i, tc.name, elapsed)
+		}
+	}
+}
@@ -70,13 +70,32 @@ import (
 //
 // Returns nil if action eventually succeeded, otherwise returns last action error or ctx.Err()
 // if action was never executed.
+//
+// If the error returned by action carries a backoff hint (see WithBackoffHint), the next attempt is not made until at
+// least the hinted duration has elapsed. The hint is capped at the maximum backoff (if any) and at the time remaining
+// until the deadline of the context (if any).
+//
+// If a circuit breaker is configured using WithCircuitBreaker, every attempt is made through the breaker and Do returns
+// ErrCircuitOpen immediately (without further retries) if the breaker rejects an attempt.
 func Do(ctx context.Context, action func() error, options ...Option) error {
 	var lastActionErr error
-	for r := Start(ctx, options...); r.Next(); {
-		lastActionErr = action()
+	r := Start(ctx, options...)
+	breaker := r.(*retrier).options.breaker
+	for r.Next() {
+		if breaker != nil {
+			lastActionErr = breaker.Do(action)
+			if lastActionErr == ErrCircuitOpen {
+				return lastActionErr
+			}
+		} else {
+			lastActionErr = action()
+		}
 		if lastActionErr == nil {
 			return nil
 		}
+		if hint, ok := BackoffHint(lastActionErr); ok {
+			r.(*retrier).minNextBackoff = hint
+		}
 	}
 	if lastActionErr == nil { // Context was done before action executed.
 		return ctx.Err()
@@ -160,11 +179,21 @@ func WithRandomizationFactor(randomizationFactor float64) Option {
 	}
 }
 
+// WithCircuitBreaker sets a circuit breaker that is used by Do: every attempt is made through the breaker and Do stops
+// retrying as soon as the breaker rejects an attempt. Sharing a single breaker across many calls to Do allows all of
+// them to back off together when a dependency is failing. Has no effect on retry loops that use Start directly.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(o *options) {
+		o.breaker = breaker
+	}
+}
+
 // Start returns a new initialized retrier.
 //
 // If the provided context is canceled (see Context.Done), then Next() will eagerly return false and
 // the retry loop will do no iterations.
 func Start(ctx context.Context, opts ...Option) Retrier {
+	deadline, _ := ctx.Deadline()
 	r := &retrier{
 		options: options{
 			maxAttempts:         defaultMaxAttempts,
@@ -174,6 +203,7 @@ func Start(ctx context.Context, opts ...Option) Retrier {
 			randomizationFactor: defaultRandomizationFactor,
 		},
 		ctxDoneChan:    ctx.Done(),
+		ctxDeadline:    deadline,
 		currentAttempt: 0,
 		isReset:        false,
 	}
@@ -203,16 +233,19 @@ const (
 type retrier struct {
 	options        options
 	ctxDoneChan    <-chan struct{}
+	ctxDeadline    time.Time // Deadline of the context (zero if it has no deadline).
 	currentAttempt int
 	isReset        bool
+	minNextBackoff time.Duration // Minimum duration of the next backoff (hint provided by the last attempt).
 }
 
 type options struct {
-	maxAttempts         int           // Maximum number of attempts (0 for infinite).
-	initialBackoff      time.Duration // Default retry backoff interval.
-	maxBackoff          time.Duration // Maximum retry backoff interval (0 for no max backoff).
-	multiplier          float64       // Default backoff constant.
-	randomizationFactor float64       // Randomize the backoff interval by constant.
+	maxAttempts         int             // Maximum number of attempts (0 for infinite).
+	initialBackoff      time.Duration   // Default retry backoff interval.
+	maxBackoff          time.Duration   // Maximum retry backoff interval (0 for no max backoff).
+	multiplier          float64         // Default backoff constant.
+	randomizationFactor float64         // Randomize the backoff interval by constant.
+	breaker             *CircuitBreaker // Circuit breaker used by Do (nil for none).
 }
 
 func (r *retrier) Reset() {
@@ -235,8 +268,13 @@ func (r *retrier) Next() bool {
 		return false
 	}
 	// Wait before retry.
+	backoff := r.retryIn()
+	if hint := r.clampHint(r.minNextBackoff); hint > backoff {
+		backoff = hint
+	}
+	r.minNextBackoff = 0
 	select {
-	case <-time.After(r.retryIn()):
+	case <-time.After(backoff):
 		r.currentAttempt++
 		return true
 	case <-r.ctxDoneChan:
@@ -256,6 +294,20 @@ func (r retrier) retryIn() time.Duration {
 	return time.Duration(backoff)
 }
 
+// clampHint returns the provided backoff hint capped at the maximum backoff and at the time remaining until the
+// deadline of the context, so that a hint cannot delay the next attempt beyond the limits of the retry policy.
+func (r retrier) clampHint(hint time.Duration) time.Duration {
+	if r.options.maxBackoff != 0 && hint > r.options.maxBackoff {
+		hint = r.options.maxBackoff
+	}
+	if !r.ctxDeadline.IsZero() {
+		if remaining := time.Until(r.ctxDeadline); hint > remaining {
+			hint = remaining
+		}
+	}
+	return hint
+}
+
 func (r retrier) CurrentAttempt() int {
 	return r.currentAttempt
 }
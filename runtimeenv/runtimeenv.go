@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package runtimeenv detects properties of the environment in which the process runs that affect how it should behave:
+// whether it runs in a container, the CPU and memory limits imposed on it by control groups (cgroups) and whether it
+// is the init process (PID 1) of its PID namespace. Detection is best-effort: it is based on environment variables and
+// on files in the /proc and /sys filesystems, so it only detects containers and limits on Linux.
+//
+// Both cgroup v1 and cgroup v2 are supported. The limits are read from the cgroup filesystem mounted at /sys/fs/cgroup,
+// which is the cgroup of the process when it runs in a container with its own cgroup namespace.
+package runtimeenv
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Container runtimes returned by Container.
+const (
+	Kubernetes = "kubernetes"
+	Docker     = "docker"
+	Podman     = "podman"
+	Containerd = "containerd"
+	LXC        = "lxc"
+)
+
+// Detector detects the properties of the environment by reading files relative to a root directory. The zero value
+// reads the files of the running system. A Detector with a different Root is useful for testing code that depends on
+// the environment.
+type Detector struct {
+	// Root is the directory relative to which the files that describe the environment (such as "/proc/1/cgroup" and
+	// "/sys/fs/cgroup/cpu.max") are read. If empty, "/" is used.
+	Root string
+}
+
+// Container returns the container runtime of the container in which the process runs (one of Kubernetes, Docker,
+// Podman, Containerd and LXC), or the empty string if the process does not run in a container.
+func Container() string {
+	return Detector{}.Container()
+}
+
+// InContainer returns true if the process runs in a container.
+func InContainer() bool {
+	return Container() != ""
+}
+
+// CPULimit returns the number of CPUs that the cgroup of the process is limited to (rounded up) and true, or false if
+// the number of CPUs is not limited.
+func CPULimit() (int, bool) {
+	return Detector{}.CPULimit()
+}
+
+// MemoryLimit returns the number of bytes of memory that the cgroup of the process is limited to and true, or false if
+// the memory is not limited.
+func MemoryLimit() (int64, bool) {
+	return Detector{}.MemoryLimit()
+}
+
+// IsPID1 returns true if the process is the init process (PID 1) of its PID namespace, as is the case for the
+// entrypoint of a container. Such a process is responsible for reaping orphaned child processes and does not get the
+// default handling of signals such as SIGTERM and SIGINT.
+func IsPID1() bool {
+	return os.Getpid() == 1
+}
+
+// Container returns the container runtime of the container in which the process runs, or the empty string if the
+// process does not run in a container. See the package-level Container function.
+func (d Detector) Container() string {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		return Kubernetes
+	}
+	for _, marker := range []struct {
+		path    string
+		runtime string
+	}{
+		{"/.dockerenv", Docker},
+		{"/run/.containerenv", Podman},
+	} {
+		if _, err := os.Stat(d.path(marker.path)); err == nil {
+			return marker.runtime
+		}
+	}
+	if cgroup, err := ioutil.ReadFile(d.path("/proc/1/cgroup")); err == nil {
+		for _, marker := range []struct {
+			substr  string
+			runtime string
+		}{
+			{"kubepods", Kubernetes},
+			{"docker", Docker},
+			{"containerd", Containerd},
+			{"lxc", LXC},
+		} {
+			if strings.Contains(string(cgroup), marker.substr) {
+				return marker.runtime
+			}
+		}
+	}
+	return ""
+}
+
+// CPULimit returns the number of CPUs that the cgroup of the process is limited to (rounded up) and true, or false if
+// the number of CPUs is not limited. Both cgroup v2 ("cpu.max") and cgroup v1 ("cpu.cfs_quota_us" and
+// "cpu.cfs_period_us") are supported.
+func (d Detector) CPULimit() (int, bool) {
+	var quota, period string
+	if fields := strings.Fields(d.readCgroupFile("cpu.max")); len(fields) == 2 {
+		quota, period = fields[0], fields[1]
+	} else {
+		quota, period = d.readCgroupFile("cpu/cpu.cfs_quota_us"), d.readCgroupFile("cpu/cpu.cfs_period_us")
+	}
+	quotaVal, err := strconv.ParseFloat(quota, 64)
+	if err != nil || quotaVal <= 0 {
+		return 0, false
+	}
+	periodVal, err := strconv.ParseFloat(period, 64)
+	if err != nil || periodVal <= 0 {
+		return 0, false
+	}
+	return int(math.Ceil(quotaVal / periodVal)), true
+}
+
+// MemoryLimit returns the number of bytes of memory that the cgroup of the process is limited to and true, or false if
+// the memory is not limited. Both cgroup v2 ("memory.max") and cgroup v1 ("memory.limit_in_bytes") are supported.
+func (d Detector) MemoryLimit() (int64, bool) {
+	limit := d.readCgroupFile("memory.max")
+	if limit == "" {
+		limit = d.readCgroupFile("memory/memory.limit_in_bytes")
+	}
+	limitVal, err := strconv.ParseInt(limit, 10, 64)
+	// cgroup v1 reports an unlimited limit as a very large number rather than "max"
+	if err != nil || limitVal <= 0 || limitVal >= 1<<60 {
+		return 0, false
+	}
+	return limitVal, true
+}
+
+func (d Detector) path(p string) string {
+	root := d.Root
+	if root == "" {
+		root = "/"
+	}
+	return filepath.Join(root, filepath.FromSlash(p))
+}
+
+func (d Detector) readCgroupFile(name string) string {
+	b, err := ioutil.ReadFile(d.path("/sys/fs/cgroup/" + name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
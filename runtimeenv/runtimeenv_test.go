@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package runtimeenv_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/runtimeenv"
+)
+
+func TestContainer(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	origK8sHost, hadK8sHost := os.LookupEnv("KUBERNETES_SERVICE_HOST")
+	require.NoError(t, os.Unsetenv("KUBERNETES_SERVICE_HOST"))
+	defer func() {
+		if hadK8sHost {
+			_ = os.Setenv("KUBERNETES_SERVICE_HOST", origK8sHost)
+		}
+	}()
+
+	for i, tc := range []struct {
+		k8sHost string
+		files   map[string]string
+		want    string
+	}{
+		{"", nil, ""},
+		{"", map[string]string{"proc/1/cgroup": "0::/\n"}, ""},
+		{"10.0.0.1", nil, runtimeenv.Kubernetes},
+		{"", map[string]string{".dockerenv": ""}, runtimeenv.Docker},
+		{"", map[string]string{"run/.containerenv": ""}, runtimeenv.Podman},
+		{"", map[string]string{"proc/1/cgroup": "0::/kubepods/burstable/pod1234\n"}, runtimeenv.Kubernetes},
+		{"", map[string]string{"proc/1/cgroup": "12:cpu:/docker/0123abc\n"}, runtimeenv.Docker},
+		{"", map[string]string{"proc/1/cgroup": "0::/system.slice/containerd.service\n"}, runtimeenv.Containerd},
+	} {
+		caseDir := writeFiles(t, filepath.Join(tmpDir, fmt.Sprint(i)), tc.files)
+		if tc.k8sHost != "" {
+			require.NoError(t, os.Setenv("KUBERNETES_SERVICE_HOST", tc.k8sHost), "Case %d", i)
+		} else {
+			require.NoError(t, os.Unsetenv("KUBERNETES_SERVICE_HOST"), "Case %d", i)
+		}
+
+		assert.Equal(t, tc.want, runtimeenv.Detector{Root: caseDir}.Container(), "Case %d", i)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	for i, tc := range []struct {
+		name       string
+		files      map[string]string
+		wantCPUs   int
+		wantCPUOK  bool
+		wantMemory int64
+		wantMemOK  bool
+	}{
+		{"no cgroup files", nil, 0, false, 0, false},
+		{"cgroup v2 unlimited", map[string]string{"cpu.max": "max 100000\n", "memory.max": "max\n"}, 0, false, 0, false},
+		{"cgroup v2 limits", map[string]string{"cpu.max": "150000 100000\n", "memory.max": "1073741824\n"}, 2, true, 1 << 30, true},
+		{"cgroup v1 limits", map[string]string{
+			"cpu/cpu.cfs_quota_us":         "400000\n",
+			"cpu/cpu.cfs_period_us":        "100000\n",
+			"memory/memory.limit_in_bytes": "536870912\n",
+		}, 4, true, 512 << 20, true},
+		{"cgroup v1 unlimited", map[string]string{
+			"cpu/cpu.cfs_quota_us":         "-1\n",
+			"cpu/cpu.cfs_period_us":        "100000\n",
+			"memory/memory.limit_in_bytes": "9223372036854771712\n",
+		}, 0, false, 0, false},
+	} {
+		files := make(map[string]string)
+		for name, content := range tc.files {
+			files["sys/fs/cgroup/"+name] = content
+		}
+		detector := runtimeenv.Detector{Root: writeFiles(t, filepath.Join(tmpDir, fmt.Sprint(i)), files)}
+
+		cpus, ok := detector.CPULimit()
+		assert.Equal(t, tc.wantCPUs, cpus, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantCPUOK, ok, "Case %d: %s", i, tc.name)
+		memory, ok := detector.MemoryLimit()
+		assert.Equal(t, tc.wantMemory, memory, "Case %d: %s", i, tc.name)
+		assert.Equal(t, tc.wantMemOK, ok, "Case %d: %s", i, tc.name)
+	}
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) string {
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
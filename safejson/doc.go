@@ -21,4 +21,10 @@
 // behavior enabled. This means that all numeric values are unmarshaled as a float64.
 // This behavior is generally less flexible, so safejson sets "UseNumber" to "true",
 // which ensures that all numbers are unmarshaled as a json.Number.
+//
+// Errors returned by the json package when input does not match the structure
+// of the target value identify the offending value only by its Go field. The
+// Unmarshal and Decode functions of this package return a *DecodeError that
+// includes the full JSON path of the value (for example,
+// "spec.containers[2].ports[0].port") along with its line and column.
 package safejson
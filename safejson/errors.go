@@ -0,0 +1,395 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package safejson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DecodeError is the error returned by the decoding functions of this package when JSON input does not match the
+// structure of the value being decoded into. It describes the location of the problem using the path of the value in
+// the JSON document (for example, "spec.containers[2].ports[0].port") along with the line and column.
+type DecodeError struct {
+	// Path is the path to the value in the JSON document that caused the error. Empty if the error occurred at the
+	// root of the document or if the path could not be determined.
+	Path string
+	// Line and Column are the 1-based position in the input at which the error was detected. 0 if unknown.
+	Line   int
+	Column int
+	// Msg describes the problem.
+	Msg string
+	// Err is the error returned by the json package.
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	msg := e.Msg
+	if e.Path != "" {
+		msg = e.Path + ": " + msg
+	}
+	if e.Line > 0 {
+		msg += fmt.Sprintf(" (line %d, column %d)", e.Line, e.Column)
+	}
+	return msg
+}
+
+// Unwrap returns the error returned by the json package.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+var unknownFieldRegexp = regexp.MustCompile(`^json: unknown field (".*")$`)
+
+// annotateError converts an error returned when decoding the provided JSON into v into a *DecodeError that includes
+// the location of the problem. Errors that are not type mismatch, unknown field or syntax errors are returned as-is.
+func annotateError(data []byte, v interface{}, err error) error {
+	switch typedErr := err.(type) {
+	case *json.UnmarshalTypeError:
+		// the offset of a type error is relative to the start of the value rather than the start of the input
+		leadingSpace := len(data) - len(bytes.TrimLeft(data, " \t\r\n"))
+		path, offset := valuePathBefore(data, typedErr.Offset+int64(leadingSpace))
+		line, col := lineAndColumn(data, offset)
+		return &DecodeError{
+			Path:   path,
+			Line:   line,
+			Column: col,
+			Msg:    typeErrorMsg(typedErr),
+			Err:    err,
+		}
+	case *json.SyntaxError:
+		// the offset of a syntax error is just after the offending byte
+		offset := typedErr.Offset
+		if offset > 0 {
+			offset--
+		}
+		line, col := lineAndColumn(data, offset)
+		return &DecodeError{
+			Line:   line,
+			Column: col,
+			Msg:    typedErr.Error(),
+			Err:    err,
+		}
+	}
+	if err == nil {
+		return nil
+	}
+	if match := unknownFieldRegexp.FindStringSubmatch(err.Error()); match != nil {
+		name, unquoteErr := strconv.Unquote(match[1])
+		if unquoteErr != nil {
+			return err
+		}
+		decodeErr := &DecodeError{
+			Msg: "unknown field",
+			Err: err,
+		}
+		if loc, ok := unknownFieldLocation(data, reflect.TypeOf(v), name); ok {
+			decodeErr.Path = loc.path.String()
+			decodeErr.Line, decodeErr.Column = lineAndColumn(data, loc.offset)
+		} else {
+			decodeErr.Msg = fmt.Sprintf("unknown field %q", name)
+		}
+		return decodeErr
+	}
+	return err
+}
+
+func typeErrorMsg(err *json.UnmarshalTypeError) string {
+	got := err.Value
+	if got == "bool" {
+		got = "boolean"
+	}
+	expected := jsonTypeName(err.Type)
+	if expected == "" || strings.HasPrefix(got, expected) {
+		return fmt.Sprintf("cannot unmarshal %s into %v", got, err.Type)
+	}
+	return fmt.Sprintf("expected %s, got %s", expected, got)
+}
+
+// jsonTypeName returns the name of the JSON type that is decoded into the provided Go type.
+func jsonTypeName(t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return ""
+	}
+}
+
+// lineAndColumn returns the 1-based line and column of the provided byte offset in the provided data.
+func lineAndColumn(data []byte, offset int64) (int, int) {
+	if offset < 0 {
+		return 0, 0
+	}
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	prefix := data[:offset]
+	line := bytes.Count(prefix, []byte("\n")) + 1
+	col := len(prefix) - bytes.LastIndexByte(prefix, '\n')
+	return line, col
+}
+
+// jsonPath is the path to a value in a JSON document. Elements are either strings (object keys) or ints (array
+// indices).
+type jsonPath []interface{}
+
+func (p jsonPath) String() string {
+	var sb strings.Builder
+	for _, elem := range p {
+		switch typed := elem.(type) {
+		case int:
+			sb.WriteString("[" + strconv.Itoa(typed) + "]")
+		case string:
+			if typed == "" || strings.ContainsAny(typed, `.[]" `) {
+				sb.WriteString("[" + strconv.Quote(typed) + "]")
+				continue
+			}
+			if sb.Len() > 0 {
+				sb.WriteString(".")
+			}
+			sb.WriteString(typed)
+		}
+	}
+	return sb.String()
+}
+
+func (p jsonPath) with(elem interface{}) jsonPath {
+	return append(append(jsonPath{}, p...), elem)
+}
+
+type location struct {
+	path   jsonPath
+	offset int64
+}
+
+// walkTokens calls onValue for the start of every value and onKey for every object key in the provided JSON in
+// document order. The provided offsets are the offsets of the first byte of the value or key. Walking stops at the
+// first syntax error.
+func walkTokens(data []byte, onValue func(loc location), onKey func(parent jsonPath, key string, loc location)) {
+	type frame struct {
+		path       jsonPath
+		isObject   bool
+		expectKey  bool
+		pendingKey string
+		index      int
+	}
+	var stack []*frame
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	for {
+		offset := dec.InputOffset()
+		tok, err := dec.Token()
+		if err != nil {
+			return
+		}
+		offset = skipSeparators(data, offset)
+		var top *frame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+		if delim, ok := tok.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		if top != nil && top.isObject && top.expectKey {
+			key := tok.(string)
+			top.expectKey = false
+			top.pendingKey = key
+			if onKey != nil {
+				onKey(top.path, key, location{path: top.path.with(key), offset: offset})
+			}
+			continue
+		}
+
+		var path jsonPath
+		switch {
+		case top == nil:
+			path = jsonPath{}
+		case top.isObject:
+			path = top.path.with(top.pendingKey)
+			top.expectKey = true
+		default:
+			path = top.path.with(top.index)
+			top.index++
+		}
+		if onValue != nil {
+			onValue(location{path: path, offset: offset})
+		}
+		if delim, ok := tok.(json.Delim); ok {
+			stack = append(stack, &frame{
+				path:      path,
+				isObject:  delim == '{',
+				expectKey: delim == '{',
+			})
+		}
+	}
+}
+
+// skipSeparators returns the offset of the first byte at or after the provided offset that is not whitespace or a
+// separator (":" or ",").
+func skipSeparators(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ':', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// valuePathBefore returns the path and offset of the last value in the provided JSON that starts before the provided
+// offset. The json package reports type errors at an offset just after the start of the value (for objects and arrays)
+// or at the end of the value (for literals), so this is the value that caused the error.
+func valuePathBefore(data []byte, offset int64) (string, int64) {
+	var last *location
+	walkTokens(data, func(loc location) {
+		if loc.offset < offset {
+			curr := loc
+			last = &curr
+		}
+	}, nil)
+	if last == nil {
+		return "", offset
+	}
+	return last.path.String(), last.offset
+}
+
+// unknownFieldLocation returns the location of the first key with the provided name in the provided JSON that
+// corresponds to an object that is decoded into a struct that does not have a field for the key.
+func unknownFieldLocation(data []byte, t reflect.Type, name string) (location, bool) {
+	var found *location
+	walkTokens(data, nil, func(parent jsonPath, key string, loc location) {
+		if found != nil || key != name {
+			return
+		}
+		parentType, ok := typeAtPath(t, parent)
+		if !ok || parentType.Kind() != reflect.Struct || hasJSONField(parentType, key) {
+			return
+		}
+		curr := loc
+		found = &curr
+	})
+	if found == nil {
+		return location{}, false
+	}
+	return *found, true
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// typeAtPath returns the Go type that the value at the provided path is decoded into when the document is decoded
+// into the provided type. Returns false if the type cannot be determined (for example, because a value along the path
+// is decoded into an interface or has a custom UnmarshalJSON implementation).
+func typeAtPath(t reflect.Type, path jsonPath) (reflect.Type, bool) {
+	for {
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t == nil || reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+			return nil, false
+		}
+		if len(path) == 0 {
+			return t, true
+		}
+		switch elem := path[0].(type) {
+		case string:
+			switch t.Kind() {
+			case reflect.Struct:
+				field, ok := jsonField(t, elem)
+				if !ok {
+					return nil, false
+				}
+				t = field.Type
+			case reflect.Map:
+				t = t.Elem()
+			default:
+				return nil, false
+			}
+		case int:
+			if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+				return nil, false
+			}
+			t = t.Elem()
+		}
+		path = path[1:]
+	}
+}
+
+func hasJSONField(t reflect.Type, name string) bool {
+	_, ok := jsonField(t, name)
+	return ok
+}
+
+// jsonField returns the field of the provided struct type that a JSON object key with the provided name is decoded
+// into. Matches the rules of the json package: the name specified by the "json" tag or the field name is used, fields
+// of embedded structs are promoted and names are matched case-insensitively.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	var caseInsensitiveMatch *reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		tagName := strings.Split(tag, ",")[0]
+		if field.Anonymous && tagName == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if f, ok := jsonField(embedded, name); ok {
+					return f, true
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+		fieldName := field.Name
+		if tagName != "" {
+			fieldName = tagName
+		}
+		if fieldName == name {
+			return field, true
+		}
+		if caseInsensitiveMatch == nil && strings.EqualFold(fieldName, name) {
+			curr := field
+			caseInsensitiveMatch = &curr
+		}
+	}
+	if caseInsensitiveMatch != nil {
+		return *caseInsensitiveMatch, true
+	}
+	return reflect.StructField{}, false
+}
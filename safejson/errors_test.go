@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package safejson_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/safejson"
+)
+
+type testPort struct {
+	Port int `json:"port"`
+}
+
+type testContainer struct {
+	Name  string     `json:"name"`
+	Ports []testPort `json:"ports"`
+}
+
+type testSpec struct {
+	Spec struct {
+		Containers []testContainer        `json:"containers"`
+		Labels     map[string]testPort    `json:"labels"`
+		Extra      map[string]interface{} `json:"extra"`
+	} `json:"spec"`
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for i, tc := range []struct {
+		in      string
+		strict  bool
+		wantErr string
+	}{
+		{
+			`{"spec":{"containers":[{},{},{"ports":[{"port":"80"}]}]}}`,
+			false,
+			`spec.containers[2].ports[0].port: expected number, got string (line 1, column 48)`,
+		},
+		{
+			`{"spec":{"containers":5}}`,
+			false,
+			`spec.containers: expected array, got number (line 1, column 23)`,
+		},
+		{
+			`{"spec":{"containers":[{"ports":[{"port":{"a":1}}]}]}}`,
+			false,
+			`spec.containers[0].ports[0].port: expected number, got object (line 1, column 42)`,
+		},
+		{
+			`{"spec":{"labels":{"a.b":{"port":true}}}}`,
+			false,
+			`spec.labels["a.b"].port: expected number, got boolean (line 1, column 34)`,
+		},
+		{
+			"{\n  \"spec\": {\n    \"containers\": [\n      {\"name\": \"a\", \"extra\": 1}\n    ]\n  }\n}",
+			true,
+			`spec.containers[0].extra: unknown field (line 4, column 21)`,
+		},
+		{
+			// keys of maps and unknown keys in values decoded into interfaces are not unknown fields
+			`{"spec":{"extra":{"unknown":1},"labels":{"unknown":{}},"unknown":2}}`,
+			true,
+			`spec.unknown: unknown field (line 1, column 56)`,
+		},
+		{
+			`{"spec":{"containers":[1,}}`,
+			false,
+			`invalid character '}' looking for beginning of value (line 1, column 26)`,
+		},
+	} {
+		var got testSpec
+		unmarshal, decode := safejson.Unmarshal, safejson.Decode
+		if tc.strict {
+			unmarshal, decode = safejson.UnmarshalStrict, safejson.DecodeStrict
+		}
+
+		err := unmarshal([]byte(tc.in), &got)
+		assert.EqualError(t, err, tc.wantErr, "Case %d", i)
+
+		err = decode(strings.NewReader(tc.in), &got)
+		assert.EqualError(t, err, tc.wantErr, "Case %d", i)
+	}
+}
+
+func TestDecodeErrorPositionWithLeadingWhitespace(t *testing.T) {
+	in := "\n\n  " + `{"spec":{"containers":5}}` + "\n{}"
+	var got testSpec
+	err := safejson.Decode(strings.NewReader(in), &got)
+	assert.EqualError(t, err, `spec.containers: expected array, got number (line 3, column 25)`)
+}
+
+func TestDecodeErrorUnwrap(t *testing.T) {
+	var got testSpec
+	err := safejson.Unmarshal([]byte(`{"spec":{"containers":[{"name":1}]}}`), &got)
+	require.Error(t, err)
+
+	decodeErr, ok := err.(*safejson.DecodeError)
+	require.True(t, ok, "unexpected error type %T", err)
+	assert.Equal(t, "spec.containers[0].name", decodeErr.Path)
+
+	typeErr, ok := decodeErr.Unwrap().(*json.UnmarshalTypeError)
+	require.True(t, ok, "unexpected error type %T", decodeErr.Unwrap())
+	assert.Equal(t, "number", typeErr.Value)
+}
+
+func TestDecodeLenient(t *testing.T) {
+	var got testSpec
+	require.NoError(t, safejson.Decode(strings.NewReader(`{"spec":{"containers":[{"name":"a","other":1}]}}`), &got))
+	assert.Equal(t, "a", got.Spec.Containers[0].Name)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package safejson
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// StreamEncoder writes a JSON array to a writer one element at a time so that arbitrarily large arrays can be encoded
+// using a constant amount of memory. Elements are encoded in the same manner as Marshal. The output written for a
+// complete array is identical to the output of encoding the equivalent slice using Encoder.
+//
+// WriteArrayStart must be called before the first call to WriteElement and Close must be called after the last
+// element has been written.
+type StreamEncoder struct {
+	w           io.Writer
+	started     bool
+	closed      bool
+	numElements int
+}
+
+// NewStreamEncoder returns a new StreamEncoder that writes to the provided writer.
+func NewStreamEncoder(w io.Writer) *StreamEncoder {
+	return &StreamEncoder{
+		w: w,
+	}
+}
+
+// WriteArrayStart writes the start of the array.
+func (e *StreamEncoder) WriteArrayStart() error {
+	if e.started {
+		return fmt.Errorf("array has already been started")
+	}
+	e.started = true
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+// WriteElement encodes the provided value and writes it as the next element of the array.
+func (e *StreamEncoder) WriteElement(v interface{}) error {
+	if !e.started {
+		return fmt.Errorf("WriteArrayStart must be called before WriteElement")
+	}
+	if e.closed {
+		return fmt.Errorf("cannot write element after Close has been called")
+	}
+	b, err := Marshal(v)
+	if err != nil {
+		return err
+	}
+	if e.numElements > 0 {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.numElements++
+	_, err = e.w.Write(b)
+	return err
+}
+
+// Close writes the end of the array followed by a newline. If WriteArrayStart was never called, an empty array is
+// written. Calling Close more than once is a no-op.
+func (e *StreamEncoder) Close() error {
+	if e.closed {
+		return nil
+	}
+	if !e.started {
+		if err := e.WriteArrayStart(); err != nil {
+			return err
+		}
+	}
+	e.closed = true
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
+
+// DecodeArray reads a JSON array from the provided reader and calls fn once for every element of the array in order.
+// The decode function provided to fn decodes the current element into the provided value using the same semantics as
+// Unmarshal. Only a single element is held in memory at a time, so arbitrarily large arrays can be processed using a
+// constant amount of memory. If fn returns an error, decoding stops and the error is returned. If fn does not call
+// decode, the element is skipped.
+func DecodeArray(r io.Reader, fn func(decode func(v interface{}) error) error) error {
+	dec := Decoder(r)
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+	for dec.More() {
+		var elem json.RawMessage
+		if err := dec.Decode(&elem); err != nil {
+			return err
+		}
+		if err := fn(func(v interface{}) error {
+			return Unmarshal(elem, v)
+		}); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %q but found %v", want, tok)
+	}
+	return nil
+}
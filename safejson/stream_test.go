@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package safejson_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/safejson"
+)
+
+func TestStreamEncoder(t *testing.T) {
+	for i, tc := range [][]interface{}{
+		{},
+		{"<a & b>"},
+		{1, "two", map[string]interface{}{"three": 3}, []int{4}},
+	} {
+		got := &bytes.Buffer{}
+		enc := safejson.NewStreamEncoder(got)
+		require.NoError(t, enc.WriteArrayStart(), "Case %d", i)
+		for _, elem := range tc {
+			require.NoError(t, enc.WriteElement(elem), "Case %d", i)
+		}
+		require.NoError(t, enc.Close(), "Case %d", i)
+
+		want := &bytes.Buffer{}
+		require.NoError(t, safejson.Encoder(want).Encode(tc), "Case %d", i)
+		assert.Equal(t, want.String(), got.String(), "Case %d", i)
+	}
+}
+
+func TestStreamEncoderErrors(t *testing.T) {
+	enc := safejson.NewStreamEncoder(&bytes.Buffer{})
+	assert.EqualError(t, enc.WriteElement(1), "WriteArrayStart must be called before WriteElement")
+	require.NoError(t, enc.WriteArrayStart())
+	assert.EqualError(t, enc.WriteArrayStart(), "array has already been started")
+	require.NoError(t, enc.Close())
+	assert.EqualError(t, enc.WriteElement(1), "cannot write element after Close has been called")
+}
+
+func TestDecodeArray(t *testing.T) {
+	type elem struct {
+		Name  string      `json:"name"`
+		Value interface{} `json:"value"`
+	}
+	var got []elem
+	err := safejson.DecodeArray(strings.NewReader(`[{"name":"a","value":1},{"name":"b","value":"two"}]`), func(decode func(v interface{}) error) error {
+		var e elem
+		if err := decode(&e); err != nil {
+			return err
+		}
+		got = append(got, e)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []elem{{"a", json.Number("1")}, {"b", "two"}}, got)
+}
+
+func TestDecodeArrayErrors(t *testing.T) {
+	noop := func(decode func(v interface{}) error) error { return nil }
+
+	err := safejson.DecodeArray(strings.NewReader(`{"a":1}`), noop)
+	assert.EqualError(t, err, `expected "[" but found {`)
+
+	count := 0
+	err = safejson.DecodeArray(strings.NewReader(`[1,2,3]`), func(decode func(v interface{}) error) error {
+		count++
+		if count == 2 {
+			return fmt.Errorf("stop")
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "stop")
+	assert.Equal(t, 2, count)
+}
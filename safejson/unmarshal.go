@@ -6,10 +6,59 @@ package safejson
 
 import (
 	"bytes"
+	"encoding/json"
+	"io"
 )
 
 // Unmarshal unmarshals the provided bytes (which should be valid JSON)
-// into "v" using safejson.Decoder.
+// into "v" using safejson.Decoder. If the JSON does not match the structure
+// of "v", the returned error is a *DecodeError that describes the path and
+// position of the value that could not be decoded.
 func Unmarshal(data []byte, v interface{}) error {
-	return Decoder(bytes.NewReader(data)).Decode(v)
+	return unmarshal(data, v, false)
+}
+
+// UnmarshalStrict is like Unmarshal, but returns an error if the JSON
+// contains an object key that does not match any field of the struct it
+// is decoded into.
+func UnmarshalStrict(data []byte, v interface{}) error {
+	return unmarshal(data, v, true)
+}
+
+// Decode decodes the next JSON value from the provided reader into "v".
+// Errors are reported in the same manner as Unmarshal. The reader may be
+// read past the end of the decoded value.
+func Decode(r io.Reader, v interface{}) error {
+	return decode(r, v, false)
+}
+
+// DecodeStrict is like Decode, but returns an error if the JSON contains an
+// object key that does not match any field of the struct it is decoded into.
+func DecodeStrict(r io.Reader, v interface{}) error {
+	return decode(r, v, true)
+}
+
+func unmarshal(data []byte, v interface{}, strict bool) error {
+	decoder := Decoder(bytes.NewReader(data))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(v); err != nil {
+		return annotateError(data, v, err)
+	}
+	return nil
+}
+
+func decode(r io.Reader, v interface{}, strict bool) error {
+	// read the raw value first so that the input is available to determine
+	// the position of any error
+	buf := &bytes.Buffer{}
+	decoder := Decoder(io.TeeReader(r, buf))
+	var raw json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return annotateError(buf.Bytes(), nil, err)
+	}
+	// data consists of any whitespace that precedes the value followed by
+	// the value itself, so positions match those of the input.
+	return unmarshal(buf.Bytes()[:decoder.InputOffset()], v, strict)
 }
@@ -0,0 +1,185 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package safeyaml
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Limits are the resource limits enforced when decoding YAML. YAML aliases allow a small document to describe a very
+// large value (the "billion laughs" attack), so these limits should be enforced when decoding untrusted input. A limit
+// that is 0 or negative is not enforced.
+type Limits struct {
+	// MaxNodes is the maximum number of nodes (scalars, sequences and mappings, including the keys of mappings) in the
+	// decoded value. Every expansion of an alias counts the nodes of the aliased value again, so this bounds the total
+	// amount of alias expansion. MaxBytes does not: a document of a few hundred bytes can expand to billions of nodes.
+	MaxNodes int
+	// MaxDepth is the maximum nesting depth of sequences and mappings in the decoded value.
+	MaxDepth int
+	// MaxBytes is the maximum size of the YAML input in bytes.
+	MaxBytes int
+}
+
+// DefaultLimits are the limits enforced by Unmarshal, YAMLtoJSONBytes and DecodeDocuments. They are large enough for
+// any reasonable configuration file.
+var DefaultLimits = Limits{
+	MaxNodes: 1000000,
+	MaxDepth: 1000,
+	MaxBytes: 64 * 1024 * 1024,
+}
+
+// LimitError is the error returned when YAML input exceeds a limit.
+type LimitError struct {
+	// Limit is the name of the limit that was exceeded ("MaxNodes", "MaxDepth" or "MaxBytes").
+	Limit string
+	// Value is the value of the limit that was exceeded.
+	Value int
+}
+
+func (e *LimitError) Error() string {
+	switch e.Limit {
+	case "MaxNodes":
+		return fmt.Sprintf("YAML document exceeds the limit of %d nodes (this may be caused by excessive alias expansion)", e.Value)
+	case "MaxDepth":
+		return fmt.Sprintf("YAML document exceeds the maximum nesting depth of %d", e.Value)
+	default:
+		return fmt.Sprintf("YAML document exceeds the maximum size of %d bytes", e.Value)
+	}
+}
+
+// Unmarshal decodes the provided YAML into out using yaml.Unmarshal after verifying that the input does not exceed
+// DefaultLimits.
+func Unmarshal(data []byte, out interface{}) error {
+	return UnmarshalWithLimits(data, out, DefaultLimits)
+}
+
+// UnmarshalWithLimits decodes the provided YAML into out using yaml.Unmarshal after verifying that the input does not
+// exceed the provided limits. Returns a *LimitError if a limit is exceeded.
+func UnmarshalWithLimits(data []byte, out interface{}, limits Limits) error {
+	if err := CheckLimits(data, limits); err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, out)
+}
+
+// CheckLimits returns a *LimitError if decoding the provided YAML would exceed the provided limits. The check stops as
+// soon as a limit is exceeded, so aliases are never fully expanded. Returns an error if the input is not valid YAML.
+func CheckLimits(data []byte, limits Limits) error {
+	if limits.MaxBytes > 0 && len(data) > limits.MaxBytes {
+		return &LimitError{Limit: "MaxBytes", Value: limits.MaxBytes}
+	}
+	if limits.MaxNodes <= 0 && limits.MaxDepth <= 0 {
+		return nil
+	}
+	err := yaml.Unmarshal(data, &limitNode{
+		state: &limitState{
+			limits: limits,
+		},
+	})
+	if _, ok := err.(*yaml.TypeError); ok {
+		// type errors are reported when the document is actually decoded
+		return nil
+	}
+	return err
+}
+
+type limitState struct {
+	limits Limits
+	nodes  int
+	depth  int
+}
+
+// limitNode counts the nodes of a YAML document as it is decoded. The children of sequences and mappings are decoded
+// by explicitly decoding them into limitNodes, so UnmarshalYAML is called for every node of the document (including
+// every node of an aliased value each time the alias is expanded). The count therefore reflects the size of the fully
+// expanded document, but decoding is aborted as soon as a limit is exceeded.
+type limitNode struct {
+	state *limitState
+}
+
+func (n *limitNode) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	s := n.state
+	s.nodes++
+	if s.limits.MaxNodes > 0 && s.nodes > s.limits.MaxNodes {
+		return &LimitError{Limit: "MaxNodes", Value: s.limits.MaxNodes}
+	}
+	// decoding into a string only succeeds for scalars
+	var scalar string
+	if err := unmarshal(&scalar); err == nil {
+		return nil
+	} else if _, ok := err.(*yaml.TypeError); !ok {
+		return err
+	}
+
+	// decoding into these types only succeeds for sequences and mappings respectively, so type errors are expected
+	// and ignored for other kinds of nodes. Decoding into them does not decode the children: it only captures the
+	// functions that decode them.
+	isCollection := false
+	var children []deferredNode
+	var seq []deferredNode
+	if err := unmarshal(&seq); err == nil && seq != nil {
+		isCollection = true
+		children = append(children, seq...)
+	} else if _, ok := err.(*yaml.TypeError); err != nil && !ok {
+		return err
+	}
+	if !isCollection {
+		// keys are decoded as pointers so that every entry of the mapping is kept (including entries with duplicate
+		// keys, which are decoded again by yaml.Unmarshal) and so that keys are counted as well: a key can be an alias
+		// whose value is expanded by yaml.Unmarshal before it is rejected as a map key.
+		var m map[*deferredNode]deferredNode
+		if err := unmarshal(&m); err == nil && m != nil {
+			isCollection = true
+			for k, v := range m {
+				if k == nil {
+					// null keys are not decoded into pointers, so entries with duplicate null keys are collapsed into
+					// one and their values cannot be counted
+					var scalar string
+					if v.unmarshal != nil && v.unmarshal(&scalar) != nil {
+						return fmt.Errorf("YAML mappings with a null key and a non-scalar value are not supported")
+					}
+				} else {
+					children = append(children, *k)
+				}
+				children = append(children, v)
+			}
+		} else if _, ok := err.(*yaml.TypeError); err != nil && !ok {
+			return err
+		}
+	}
+	if !isCollection {
+		return nil
+	}
+
+	s.depth++
+	defer func() {
+		s.depth--
+	}()
+	if s.limits.MaxDepth > 0 && s.depth > s.limits.MaxDepth {
+		return &LimitError{Limit: "MaxDepth", Value: s.limits.MaxDepth}
+	}
+
+	for _, child := range children {
+		if child.unmarshal == nil {
+			continue
+		}
+		if err := child.unmarshal(&limitNode{state: s}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deferredNode captures the function that decodes a node so that the node can be decoded later.
+type deferredNode struct {
+	unmarshal func(interface{}) error
+}
+
+func (n *deferredNode) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	n.unmarshal = unmarshal
+	return nil
+}
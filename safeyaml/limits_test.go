@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package safeyaml_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/safeyaml"
+)
+
+const billionLaughs = `a: &a ["lol","lol","lol","lol","lol","lol","lol","lol","lol"]
+b: &b [*a,*a,*a,*a,*a,*a,*a,*a,*a]
+c: &c [*b,*b,*b,*b,*b,*b,*b,*b,*b]
+d: &d [*c,*c,*c,*c,*c,*c,*c,*c,*c]
+e: &e [*d,*d,*d,*d,*d,*d,*d,*d,*d]
+f: &f [*e,*e,*e,*e,*e,*e,*e,*e,*e]
+g: &g [*f,*f,*f,*f,*f,*f,*f,*f,*f]
+h: &h [*g,*g,*g,*g,*g,*g,*g,*g,*g]
+i: &i [*h,*h,*h,*h,*h,*h,*h,*h,*h]
+`
+
+// billionLaughsKeys is a variant of billionLaughs in which the aliases are expanded in keys rather than in values.
+const billionLaughsKeys = `? &a ["lol","lol","lol","lol","lol","lol","lol","lol","lol"]
+: lol
+? &b [*a,*a,*a,*a,*a,*a,*a,*a,*a]
+: lol
+? &c [*b,*b,*b,*b,*b,*b,*b,*b,*b]
+: lol
+? &d [*c,*c,*c,*c,*c,*c,*c,*c,*c]
+: lol
+? &e [*d,*d,*d,*d,*d,*d,*d,*d,*d]
+: lol
+? &f [*e,*e,*e,*e,*e,*e,*e,*e,*e]
+: lol
+? &g [*f,*f,*f,*f,*f,*f,*f,*f,*f]
+: lol
+? &h [*g,*g,*g,*g,*g,*g,*g,*g,*g]
+: lol
+? &i [*h,*h,*h,*h,*h,*h,*h,*h,*h]
+: lol
+`
+
+func TestCheckLimits(t *testing.T) {
+	for i, tc := range []struct {
+		in      string
+		limits  safeyaml.Limits
+		wantErr string
+	}{
+		{"a: [1, 2, {b: c}]", safeyaml.Limits{MaxNodes: 8, MaxDepth: 3}, ""},
+		{"a: [1, 2, {b: c}]", safeyaml.Limits{MaxNodes: 7}, "YAML document exceeds the limit of 7 nodes (this may be caused by excessive alias expansion)"},
+		{"a: [1, 2, {b: c}]", safeyaml.Limits{MaxDepth: 2}, "YAML document exceeds the maximum nesting depth of 2"},
+		{"a: [1, 2, {b: c}]", safeyaml.Limits{MaxBytes: 10}, "YAML document exceeds the maximum size of 10 bytes"},
+		{"a: &a [1, 2]\nb: *a\nc: *a\n", safeyaml.Limits{MaxNodes: 13}, ""},
+		{"a: &a [1, 2]\nb: *a\nc: *a\n", safeyaml.Limits{MaxNodes: 12}, "YAML document exceeds the limit of 12 nodes (this may be caused by excessive alias expansion)"},
+		// every entry with a duplicate key is counted because yaml.Unmarshal decodes all of them
+		{"a: &a [1, 2]\nb: {x: *a, x: *a, x: *a}\n", safeyaml.Limits{MaxNodes: 19}, ""},
+		{"a: &a [1, 2]\nb: {x: *a, x: *a, x: *a}\n", safeyaml.Limits{MaxNodes: 18}, "YAML document exceeds the limit of 18 nodes (this may be caused by excessive alias expansion)"},
+		{billionLaughs, safeyaml.DefaultLimits, "YAML document exceeds the limit of 1000000 nodes (this may be caused by excessive alias expansion)"},
+		{billionLaughsKeys, safeyaml.DefaultLimits, "YAML document exceeds the limit of 1000000 nodes (this may be caused by excessive alias expansion)"},
+		{"a: &a [1, 2]\nb: {null: *a}\n", safeyaml.DefaultLimits, "YAML mappings with a null key and a non-scalar value are not supported"},
+		{"a: {null: 1}\n", safeyaml.DefaultLimits, ""},
+		{strings.Repeat("[", 2000) + strings.Repeat("]", 2000), safeyaml.DefaultLimits, "YAML document exceeds the maximum nesting depth of 1000"},
+	} {
+		err := safeyaml.CheckLimits([]byte(tc.in), tc.limits)
+		if tc.wantErr == "" {
+			assert.NoError(t, err, "Case %d", i)
+			continue
+		}
+		assert.EqualError(t, err, tc.wantErr, "Case %d", i)
+	}
+}
+
+func TestUnmarshalLimits(t *testing.T) {
+	var out map[string]interface{}
+	require.NoError(t, safeyaml.Unmarshal([]byte("a: &a [1, 2]\nb: *a\n"), &out))
+	assert.Equal(t, map[string]interface{}{
+		"a": []interface{}{1, 2},
+		"b": []interface{}{1, 2},
+	}, out)
+
+	for i, in := range []string{
+		billionLaughs,
+		// aliases used as keys are expanded before they are rejected as map keys
+		billionLaughsKeys,
+	} {
+		_, err := safeyaml.YAMLtoJSONBytes([]byte(in))
+		_, ok := err.(*safeyaml.LimitError)
+		assert.True(t, ok, "Case %d: expected *LimitError, got %v", i, err)
+	}
+}
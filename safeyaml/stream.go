@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package safeyaml
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// StreamEncoder writes a YAML sequence to a writer one element at a time so that arbitrarily large sequences can be
+// encoded using a constant amount of memory. Elements are encoded in the same manner as Marshal. The output written for
+// a complete sequence decodes to the same value as the output of calling Marshal on the equivalent slice.
+//
+// WriteArrayStart must be called before the first call to WriteElement and Close must be called after the last
+// element has been written.
+type StreamEncoder struct {
+	w           io.Writer
+	started     bool
+	closed      bool
+	numElements int
+}
+
+// NewStreamEncoder returns a new StreamEncoder that writes to the provided writer.
+func NewStreamEncoder(w io.Writer) *StreamEncoder {
+	return &StreamEncoder{
+		w: w,
+	}
+}
+
+// WriteArrayStart starts the sequence. YAML block sequences do not have a start marker, so nothing is written.
+func (e *StreamEncoder) WriteArrayStart() error {
+	if e.started {
+		return fmt.Errorf("array has already been started")
+	}
+	e.started = true
+	return nil
+}
+
+// WriteElement encodes the provided value and writes it as the next element of the sequence.
+func (e *StreamEncoder) WriteElement(v interface{}) error {
+	if !e.started {
+		return fmt.Errorf("WriteArrayStart must be called before WriteElement")
+	}
+	if e.closed {
+		return fmt.Errorf("cannot write element after Close has been called")
+	}
+	// marshal the element as a sequence with a single element rather than indenting the marshaled element so that
+	// the emitter formats it (including any block scalars that it contains) as an entry of a sequence
+	b, err := Marshal([]interface{}{v})
+	if err != nil {
+		return err
+	}
+	e.numElements++
+	_, err = e.w.Write(b)
+	return err
+}
+
+// Close ends the sequence. If no elements were written, an empty flow sequence ("[]") is written. Calling Close more
+// than once is a no-op.
+func (e *StreamEncoder) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.started = true
+	e.closed = true
+	if e.numElements > 0 {
+		return nil
+	}
+	_, err := io.WriteString(e.w, "[]\n")
+	return err
+}
+
+// DecodeDocuments reads a stream of YAML documents separated by "---" from the provided reader and calls fn once for
+// every non-empty document in order. The decode function provided to fn unmarshals the current document into the
+// provided value using Unmarshal, so DefaultLimits are enforced for every document. Directives (such as "%YAML 1.1" or
+// "%TAG") apply to the document that follows them. Only a single document is held in memory at a time, so arbitrarily
+// long streams can be processed using a constant amount of memory: a *LimitError is returned as soon as a document
+// exceeds DefaultLimits.MaxBytes, before it is read in full. If fn returns an error, decoding stops and the error is
+// returned. If fn does not call decode, the document is skipped.
+func DecodeDocuments(r io.Reader, fn func(decode func(v interface{}) error) error) error {
+	br := bufio.NewReader(r)
+	var doc, directives bytes.Buffer
+	docDirectives := ""
+	flush := func() error {
+		defer doc.Reset()
+		if isEmptyDocument(doc.Bytes()) {
+			return nil
+		}
+		docBytes := doc.Bytes()
+		if docDirectives != "" {
+			// the parser requires directives to be followed by a document start marker
+			docBytes = append([]byte(docDirectives+"---\n"), docBytes...)
+		}
+		return fn(func(v interface{}) error {
+			return Unmarshal(docBytes, v)
+		})
+	}
+
+	for {
+		line, err := readLine(br, doc.Len()+directives.Len(), DefaultLimits)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if rest, ok := documentStart(line); ok {
+			if flushErr := flush(); flushErr != nil {
+				return flushErr
+			}
+			docDirectives = directives.String()
+			directives.Reset()
+			// content can follow the marker on the same line (for example, "--- !tag" or "--- {a: 1}")
+			doc.WriteString(rest)
+		} else if isDocumentEnd(line) {
+			if flushErr := flush(); flushErr != nil {
+				return flushErr
+			}
+		} else if strings.HasPrefix(line, "%") {
+			// directives precede the document start marker of the document to which they apply
+			directives.WriteString(line)
+		} else {
+			doc.WriteString(line)
+		}
+		if err == io.EOF {
+			return flush()
+		}
+	}
+}
+
+// readLine reads the next line from the provided reader, including its line terminator. Returns a *LimitError if the
+// line would increase the size of the current document from the provided number of bytes beyond the MaxBytes limit,
+// without reading the rest of the line.
+func readLine(br *bufio.Reader, docBytes int, limits Limits) (string, error) {
+	var line []byte
+	for {
+		chunk, err := br.ReadSlice('\n')
+		line = append(line, chunk...)
+		if limits.MaxBytes > 0 && docBytes+len(line) > limits.MaxBytes {
+			return "", &LimitError{Limit: "MaxBytes", Value: limits.MaxBytes}
+		}
+		if err != bufio.ErrBufferFull {
+			return string(line), err
+		}
+	}
+}
+
+// documentStart returns the content that follows the marker on the same line and true if the provided line is a
+// document start marker ("---"), or false otherwise.
+func documentStart(line string) (string, bool) {
+	if strings.TrimRight(line, "\r\n") == "---" {
+		return "", true
+	}
+	if strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "---\t") {
+		return strings.TrimLeft(line[len("---"):], " \t"), true
+	}
+	return "", false
+}
+
+// isDocumentEnd returns true if the provided line is a document end marker ("..."). Only comments can follow the marker
+// on the same line.
+func isDocumentEnd(line string) bool {
+	line = strings.TrimRight(line, "\r\n")
+	return line == "..." || strings.HasPrefix(line, "... ") || strings.HasPrefix(line, "...\t")
+}
+
+// isEmptyDocument returns true if the provided document consists only of whitespace and comments.
+func isEmptyDocument(doc []byte) bool {
+	for _, line := range bytes.Split(doc, []byte("\n")) {
+		if line = bytes.TrimSpace(line); len(line) > 0 && line[0] != '#' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package safeyaml_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/palantir/pkg/safeyaml"
+)
+
+func TestStreamEncoder(t *testing.T) {
+	for i, tc := range [][]interface{}{
+		{},
+		{"one"},
+		{
+			1,
+			"two",
+			yaml.MapSlice{{Key: "three", Value: 3}, {Key: "four", Value: []int{4, 4}}},
+			[]string{"five", "six"},
+			"multi\nline\n",
+			map[string]interface{}{},
+		},
+		// block scalars with leading spaces, empty lines and lines that contain only whitespace
+		{
+			"  indented\n\n   \nlast\n",
+			map[string]interface{}{"script": "set -e\n\n  echo hello\n"},
+		},
+	} {
+		got := &bytes.Buffer{}
+		enc := safeyaml.NewStreamEncoder(got)
+		require.NoError(t, enc.WriteArrayStart(), "Case %d", i)
+		for _, elem := range tc {
+			require.NoError(t, enc.WriteElement(elem), "Case %d", i)
+		}
+		require.NoError(t, enc.Close(), "Case %d", i)
+
+		want, err := safeyaml.Marshal(tc)
+		require.NoError(t, err, "Case %d", i)
+		var wantVal, gotVal []interface{}
+		require.NoError(t, yaml.Unmarshal(want, &wantVal), "Case %d", i)
+		require.NoError(t, yaml.Unmarshal(got.Bytes(), &gotVal), "Case %d", i)
+		assert.Equal(t, wantVal, gotVal, "Case %d", i)
+	}
+}
+
+func TestStreamEncoderOutput(t *testing.T) {
+	got := &bytes.Buffer{}
+	enc := safeyaml.NewStreamEncoder(got)
+	require.NoError(t, enc.WriteArrayStart())
+	require.NoError(t, enc.WriteElement(yaml.MapSlice{{Key: "name", Value: "a"}, {Key: "tags", Value: []string{"x", "z"}}}))
+	require.NoError(t, enc.WriteElement("b"))
+	require.NoError(t, enc.Close())
+	assert.Equal(t, "- name: a\n  tags:\n  - x\n  - z\n- b\n", got.String())
+}
+
+func TestDecodeDocuments(t *testing.T) {
+	const input = `# leading comment
+name: a
+---
+name: b
+value: |
+  ---
+  not a separator
+--- # trailing comment
+...
+---
+name: c
+--- {name: d}
+--- !!map
+name: e
+`
+	type doc struct {
+		Name  string `yaml:"name"`
+		Value string `yaml:"value"`
+	}
+	var got []doc
+	err := safeyaml.DecodeDocuments(strings.NewReader(input), func(decode func(v interface{}) error) error {
+		var d doc
+		if err := decode(&d); err != nil {
+			return err
+		}
+		got = append(got, d)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []doc{
+		{Name: "a"},
+		{Name: "b", Value: "---\nnot a separator\n"},
+		{Name: "c"},
+		{Name: "d"},
+		{Name: "e"},
+	}, got)
+}
+
+func TestDecodeDocumentsRootScalar(t *testing.T) {
+	var got []interface{}
+	err := safeyaml.DecodeDocuments(strings.NewReader("--- |\n  line one\n  line two\n--- plain\n"), func(decode func(v interface{}) error) error {
+		var v interface{}
+		if err := decode(&v); err != nil {
+			return err
+		}
+		got = append(got, v)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"line one\nline two\n", "plain"}, got)
+}
+
+func TestDecodeDocumentsDirectives(t *testing.T) {
+	for i, tc := range []struct {
+		input    string
+		wantDocs []string
+		wantErr  string
+	}{
+		{"name: a\n...\n%YAML 1.1\n---\nname: b\n", []string{"a", "b"}, ""},
+		{"%YAML 1.1\n# comment\n--- {name: a}\n--- {name: b}\n", []string{"a", "b"}, ""},
+		{"name: a\n...\n%YAML 1.2\n---\nname: b\n", []string{"a"}, "document 1: yaml: found incompatible YAML document"},
+	} {
+		var got []string
+		err := safeyaml.DecodeDocuments(strings.NewReader(tc.input), func(decode func(v interface{}) error) error {
+			var d struct {
+				Name string `yaml:"name"`
+			}
+			if err := decode(&d); err != nil {
+				return fmt.Errorf("document %d: %v", len(got), err)
+			}
+			got = append(got, d.Name)
+			return nil
+		})
+		if tc.wantErr == "" {
+			assert.NoError(t, err, "Case %d", i)
+		} else {
+			assert.EqualError(t, err, tc.wantErr, "Case %d", i)
+		}
+		assert.Equal(t, tc.wantDocs, got, "Case %d", i)
+	}
+}
+
+func TestDecodeDocumentsMaxBytes(t *testing.T) {
+	origLimits := safeyaml.DefaultLimits
+	defer func() {
+		safeyaml.DefaultLimits = origLimits
+	}()
+	safeyaml.DefaultLimits.MaxBytes = 64
+
+	// the second document never ends, so it must be rejected while it is being read
+	r := io.MultiReader(strings.NewReader("name: a\n---\nname: "), repeatReader('b'))
+	var got []string
+	err := safeyaml.DecodeDocuments(r, func(decode func(v interface{}) error) error {
+		var d struct {
+			Name string `yaml:"name"`
+		}
+		if err := decode(&d); err != nil {
+			return err
+		}
+		got = append(got, d.Name)
+		return nil
+	})
+	assert.EqualError(t, err, "YAML document exceeds the maximum size of 64 bytes")
+	assert.Equal(t, []string{"a"}, got)
+}
+
+// repeatReader is a reader that returns an infinite stream of the same byte.
+type repeatReader byte
+
+func (r repeatReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r)
+	}
+	return len(p), nil
+}
@@ -7,8 +7,6 @@ package safeyaml
 import (
 	"bytes"
 	"encoding/json"
-
-	"gopkg.in/yaml.v2"
 )
 
 // UnmarshalerToJSONBytes decodes YAML bytes using the provided unmarshal function, converts the object
@@ -51,9 +49,10 @@ func UnmarshalerToJSONBytes(unmarshal func(interface{}) error) ([]byte, error) {
 
 // YAMLtoJSONBytes converts YAML content to JSON.
 // Returns an error if it encounters types that are invalid in JSON but
-// valid in YAML (e.g. non-string map keys).
+// valid in YAML (e.g. non-string map keys) or if the content exceeds
+// DefaultLimits.
 func YAMLtoJSONBytes(yamlBytes []byte) ([]byte, error) {
 	return UnmarshalerToJSONBytes(func(i interface{}) error {
-		return yaml.Unmarshal(yamlBytes, *&i)
+		return Unmarshal(yamlBytes, *&i)
 	})
 }
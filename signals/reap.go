@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package signals
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultReapGracePeriod is the grace period used by StartReaper if the provided grace period is not positive.
+const DefaultReapGracePeriod = time.Second
+
+// StartReaper starts a goroutine that reaps child processes of the current process that have exited but have not been
+// waited for ("zombie" processes). Returns a function that stops the goroutine.
+//
+// This is necessary when the process runs as the init process (PID 1) of a container: processes whose parents exit
+// are re-parented to PID 1, which must wait for them when they exit. Otherwise, they remain in the process table as
+// zombies, which can exhaust the PIDs available to the container during long runs that spawn subprocesses.
+//
+// Child processes started by the program itself (for example, using os/exec) must be waited for by the code that
+// started them: if the reaper waited for such a process, a later call to exec.Cmd.Wait would fail. Such processes
+// should be excluded from reaping using ExcludeFromReaping. A zombie that is not excluded is only reaped once it has
+// been a zombie for the provided grace period, so child processes that are not excluded can still be waited for within
+// the grace period after they exit. Reaping is only supported on Linux: on other platforms, this function does nothing.
+func StartReaper(gracePeriod time.Duration) (stop func()) {
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultReapGracePeriod
+	}
+	return startReaper(gracePeriod)
+}
+
+var (
+	excludedPIDsMutex sync.Mutex
+	excludedPIDs      = make(map[int]int)
+)
+
+// ExcludeFromReaping excludes the child process with the provided PID from reaping by StartReaper until the returned
+// function is called. It should be called with the PID of every child process that the program starts itself as soon
+// as the process is started (for example, with cmd.Process.Pid after cmd.Start returns), and the returned function
+// should be called once the process has been waited for.
+func ExcludeFromReaping(pid int) (include func()) {
+	excludedPIDsMutex.Lock()
+	excludedPIDs[pid]++
+	excludedPIDsMutex.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			excludedPIDsMutex.Lock()
+			defer excludedPIDsMutex.Unlock()
+			if excludedPIDs[pid]--; excludedPIDs[pid] <= 0 {
+				delete(excludedPIDs, pid)
+			}
+		})
+	}
+}
+
+// isExcludedFromReaping returns true if the process with the provided PID was excluded using ExcludeFromReaping.
+func isExcludedFromReaping(pid int) bool {
+	excludedPIDsMutex.Lock()
+	defer excludedPIDsMutex.Unlock()
+	return excludedPIDs[pid] > 0
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package signals
+
+import (
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+func startReaper(gracePeriod time.Duration) (stop func()) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGCHLD)
+	ticker := time.NewTicker(gracePeriod / 2)
+	done := make(chan struct{})
+	go func() {
+		firstSeen := make(map[int]time.Time)
+		for {
+			reapZombies(firstSeen, gracePeriod, time.Now())
+			select {
+			case <-sigCh:
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sigCh)
+		ticker.Stop()
+		close(done)
+	}
+}
+
+// reapZombies waits for the zombie child processes of the current process that have been zombies for at least the
+// provided grace period and that are not excluded using ExcludeFromReaping. firstSeen records the time at which each
+// zombie was first seen and is updated.
+func reapZombies(firstSeen map[int]time.Time, gracePeriod time.Duration, now time.Time) {
+	zombies := zombieChildren(os.Getpid())
+	for pid := range firstSeen {
+		if !zombies[pid] {
+			delete(firstSeen, pid)
+		}
+	}
+	for pid := range zombies {
+		if isExcludedFromReaping(pid) {
+			// the process was started by the program itself, which waits for it
+			delete(firstSeen, pid)
+			continue
+		}
+		seen, ok := firstSeen[pid]
+		if !ok {
+			firstSeen[pid] = now
+			continue
+		}
+		if now.Sub(seen) < gracePeriod {
+			continue
+		}
+		var status syscall.WaitStatus
+		_, _ = syscall.Wait4(pid, &status, syscall.WNOHANG, nil)
+		delete(firstSeen, pid)
+	}
+}
+
+// zombieChildren returns the PIDs of the zombie processes whose parent is the process with the provided PID.
+func zombieChildren(ppid int) map[int]bool {
+	zombies := make(map[int]bool)
+	entries, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return zombies
+	}
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		stat, err := ioutil.ReadFile(filepath.Join("/proc", entry.Name(), "stat"))
+		if err != nil {
+			continue
+		}
+		// the format is "pid (comm) state ppid ...": comm may contain spaces and parentheses
+		idx := strings.LastIndexByte(string(stat), ')')
+		if idx < 0 {
+			continue
+		}
+		fields := strings.Fields(string(stat[idx+1:]))
+		if len(fields) < 2 || fields[0] != "Z" {
+			continue
+		}
+		if parent, err := strconv.Atoi(fields[1]); err == nil && parent == ppid {
+			zombies[pid] = true
+		}
+	}
+	return zombies
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+// +build linux
+
+package signals_test
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/signals"
+)
+
+func TestStartReaper(t *testing.T) {
+	truePath, err := exec.LookPath("true")
+	require.NoError(t, err)
+
+	stop := signals.StartReaper(50 * time.Millisecond)
+	defer stop()
+
+	// start a child process that is never waited for, so it becomes a zombie when it exits
+	pid, err := syscall.ForkExec(truePath, []string{truePath}, nil)
+	require.NoError(t, err)
+
+	// child processes that are waited for within the grace period are not affected
+	require.NoError(t, exec.Command(truePath).Run())
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat("/proc/" + strconv.Itoa(pid)); os.IsNotExist(err) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	_, err = os.Stat("/proc/" + strconv.Itoa(pid))
+	assert.True(t, os.IsNotExist(err), "zombie process %d was not reaped", pid)
+}
+
+func TestExcludeFromReaping(t *testing.T) {
+	truePath, err := exec.LookPath("true")
+	require.NoError(t, err)
+
+	stop := signals.StartReaper(20 * time.Millisecond)
+	defer stop()
+
+	cmd := exec.Command(truePath)
+	require.NoError(t, cmd.Start())
+	include := signals.ExcludeFromReaping(cmd.Process.Pid)
+	defer include()
+
+	// wait for longer than the grace period after the process exits before waiting for it
+	time.Sleep(200 * time.Millisecond)
+	assert.NoError(t, cmd.Wait())
+}
@@ -0,0 +1,16 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package signals
+
+import (
+	"time"
+)
+
+func startReaper(gracePeriod time.Duration) (stop func()) {
+	return func() {}
+}
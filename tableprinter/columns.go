@@ -0,0 +1,41 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tableprinter
+
+import (
+	"time"
+
+	"github.com/palantir/pkg/locale"
+)
+
+// IntColumn returns a ColumnGetter that formats the integer returned by get
+// using the conventions of the provided locale.
+func IntColumn(l locale.Locale, get func(row interface{}) int64) ColumnGetter {
+	return func(row interface{}) string {
+		return l.FormatInt(get(row))
+	}
+}
+
+// FloatColumn returns a ColumnGetter that formats the number returned by get
+// with the provided number of decimal digits using the conventions of the
+// provided locale.
+func FloatColumn(l locale.Locale, decimals int, get func(row interface{}) float64) ColumnGetter {
+	return func(row interface{}) string {
+		return l.FormatFloat(get(row), decimals)
+	}
+}
+
+// TimeColumn returns a ColumnGetter that formats the date and time returned by
+// get using the conventions of the provided locale. The zero time is written
+// as an empty value.
+func TimeColumn(l locale.Locale, get func(row interface{}) time.Time) ColumnGetter {
+	return func(row interface{}) string {
+		t := get(row)
+		if t.IsZero() {
+			return ""
+		}
+		return l.FormatDateTime(t)
+	}
+}
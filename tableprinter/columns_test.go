@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tableprinter
+
+import (
+	"bytes"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"github.com/palantir/pkg/locale"
+)
+
+type download struct {
+	name     string
+	bytes    int64
+	ratio    float64
+	finished time.Time
+}
+
+func TestLocaleColumns(t *testing.T) {
+	rows := []interface{}{
+		download{name: "a", bytes: 1234567, ratio: 0.5, finished: time.Date(2026, 3, 14, 15, 9, 0, 0, time.UTC)},
+		download{name: "b", bytes: 12, ratio: 1234.125},
+	}
+	deDE, _ := locale.Lookup("de-DE")
+
+	for i, tc := range []struct {
+		locale locale.Locale
+		want   string
+	}{
+		{locale.C, "a 1234567 0.50    2026-03-14 15:09:00\nb 12      1234.12 \n"},
+		{deDE, "a 1.234.567 0,50     14.03.2026 15:09\nb 12        1.234,12 \n"},
+	} {
+		buf := new(bytes.Buffer)
+		p := New(tabwriter.NewWriter(buf, 0, 0, 1, ' ', uint(0)), map[string]ColumnGetter{
+			"name": func(row interface{}) string { return row.(download).name },
+			"bytes": IntColumn(tc.locale, func(row interface{}) int64 {
+				return row.(download).bytes
+			}),
+			"ratio": FloatColumn(tc.locale, 2, func(row interface{}) float64 {
+				return row.(download).ratio
+			}),
+			"finished": TimeColumn(tc.locale, func(row interface{}) time.Time {
+				return row.(download).finished
+			}),
+		}, false, false, false)
+		if err := p.Print([]string{"name", "bytes", "ratio", "finished"}, rows); err != nil {
+			t.Fatalf("Case %d: %v", i, err)
+		}
+		if got := buf.String(); got != tc.want {
+			t.Errorf("Case %d: got %q, want %q", i, got, tc.want)
+		}
+	}
+}
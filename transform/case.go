@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transform
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Words splits the provided identifier into its words. Any character that is not a letter or digit separates words,
+// so identifiers in kebab-case, snake_case and SCREAMING_SNAKE_CASE are split at the separators. Words are also split
+// at case transitions, so camelCase and PascalCase identifiers are split as well:
+//
+//   - an uppercase letter that follows a lowercase letter or a digit starts a new word ("userName" -> "user", "Name")
+//   - in a run of uppercase letters, the last letter starts a new word if it is followed by a lowercase letter, so
+//     acronyms are kept together ("HTTPServer" -> "HTTP", "Server")
+//   - digits are part of the word that precedes them ("base64Encode" -> "base64", "Encode")
+//
+// The case of the returned words matches the input.
+func Words(s string) []string {
+	var words []string
+	var curr []rune
+	flush := func() {
+		if len(curr) > 0 {
+			words = append(words, string(curr))
+			curr = nil
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(curr) > 0 {
+			prev := curr[len(curr)-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+		curr = append(curr, r)
+	}
+	flush()
+	return words
+}
+
+// KebabCase converts the provided identifier to kebab-case ("userID" -> "user-id").
+func KebabCase(s string) string {
+	return joinLower(Words(s), "-")
+}
+
+// SnakeCase converts the provided identifier to snake_case ("userID" -> "user_id").
+func SnakeCase(s string) string {
+	return joinLower(Words(s), "_")
+}
+
+// ScreamingSnakeCase converts the provided identifier to SCREAMING_SNAKE_CASE ("userID" -> "USER_ID").
+func ScreamingSnakeCase(s string) string {
+	return strings.ToUpper(SnakeCase(s))
+}
+
+// CamelCase converts the provided identifier to camelCase ("user-name" -> "userName"). The first word is lowercase.
+// Acronyms in identifiers that are not entirely uppercase are preserved ("UserID" -> "userID").
+func CamelCase(s string) string {
+	words := capitalizedWords(s)
+	if len(words) > 0 {
+		words[0] = strings.ToLower(words[0])
+	}
+	return strings.Join(words, "")
+}
+
+// PascalCase converts the provided identifier to PascalCase ("user-name" -> "UserName"). Acronyms in identifiers that
+// are not entirely uppercase are preserved ("userID" -> "UserID").
+func PascalCase(s string) string {
+	return strings.Join(capitalizedWords(s), "")
+}
+
+func joinLower(words []string, sep string) string {
+	return strings.ToLower(strings.Join(words, sep))
+}
+
+// capitalizedWords returns the words of the provided identifier with the first letter of every word in uppercase.
+// Words that are entirely uppercase are treated as acronyms and are returned as-is unless the identifier does not
+// contain any lowercase letters, in which case every word is capitalized ("USER_ID" -> "User", "Id").
+func capitalizedWords(s string) []string {
+	words := Words(s)
+	hasLower := strings.IndexFunc(s, unicode.IsLower) != -1
+	for i, word := range words {
+		if hasLower && strings.ToUpper(word) == word {
+			continue
+		}
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return words
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWords(t *testing.T) {
+	for i, tc := range []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"user", []string{"user"}},
+		{"user-name", []string{"user", "name"}},
+		{"user_name", []string{"user", "name"}},
+		{"USER_NAME", []string{"USER", "NAME"}},
+		{"userName", []string{"user", "Name"}},
+		{"UserName", []string{"User", "Name"}},
+		{"userID", []string{"user", "ID"}},
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"getHTTPResponseCode", []string{"get", "HTTP", "Response", "Code"}},
+		{"base64Encode", []string{"base64", "Encode"}},
+		{"ipv4Address", []string{"ipv4", "Address"}},
+		{"v2", []string{"v2"}},
+		{"--some..flag__", []string{"some", "flag"}},
+	} {
+		assert.Equal(t, tc.want, Words(tc.in), "Case %d: %s", i, tc.in)
+	}
+}
+
+func TestCaseConversions(t *testing.T) {
+	for i, tc := range []struct {
+		in             string
+		kebab          string
+		snake          string
+		screamingSnake string
+		camel          string
+		pascal         string
+	}{
+		{"", "", "", "", "", ""},
+		{"user-name", "user-name", "user_name", "USER_NAME", "userName", "UserName"},
+		{"user_name", "user-name", "user_name", "USER_NAME", "userName", "UserName"},
+		{"USER_NAME", "user-name", "user_name", "USER_NAME", "userName", "UserName"},
+		{"userName", "user-name", "user_name", "USER_NAME", "userName", "UserName"},
+		{"UserName", "user-name", "user_name", "USER_NAME", "userName", "UserName"},
+		{"userID", "user-id", "user_id", "USER_ID", "userID", "UserID"},
+		{"HTTPServer", "http-server", "http_server", "HTTP_SERVER", "httpServer", "HTTPServer"},
+		{"http-server", "http-server", "http_server", "HTTP_SERVER", "httpServer", "HttpServer"},
+		{"base64-encode", "base64-encode", "base64_encode", "BASE64_ENCODE", "base64Encode", "Base64Encode"},
+		{"tls-v1.2", "tls-v1-2", "tls_v1_2", "TLS_V1_2", "tlsV12", "TlsV12"},
+	} {
+		assert.Equal(t, tc.kebab, KebabCase(tc.in), "Case %d: %s", i, tc.in)
+		assert.Equal(t, tc.snake, SnakeCase(tc.in), "Case %d: %s", i, tc.in)
+		assert.Equal(t, tc.screamingSnake, ScreamingSnakeCase(tc.in), "Case %d: %s", i, tc.in)
+		assert.Equal(t, tc.camel, CamelCase(tc.in), "Case %d: %s", i, tc.in)
+		assert.Equal(t, tc.pascal, PascalCase(tc.in), "Case %d: %s", i, tc.in)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package typenames derives stable, human-readable names for Go types. The names do not depend on whether a package
+// is vendored and are derived consistently for instantiations of generic types and for unnamed types such as
+// anonymous structs, so they are suitable for use as identifiers in error messages, metrics and logs.
+//
+// Name returns names in which types are qualified by the name of their package (for example, "objmatcher.Matcher"),
+// while QualifiedName qualifies types using their full import path (for example,
+// "github.com/palantir/pkg/objmatcher.Matcher"). Type arguments of generic types are qualified in the same manner:
+//
+//	typenames.Name(reflect.TypeOf(Pair[int, foo.Bar]{})) // "pkg.Pair[int,foo.Bar]"
+//
+// Struct tags are not included in the names of anonymous structs.
+package typenames
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Of returns the name of the dynamic type of the provided value as returned by Name. Returns "<nil>" if the
+// provided value is nil.
+func Of(v interface{}) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return Name(reflect.TypeOf(v))
+}
+
+// Name returns the name of the provided type in which named types are qualified by the name of their package. The
+// package name is derived from the last element of the import path of the package, ignoring major version suffixes
+// such as "/v2" and ".v2".
+func Name(t reflect.Type) string {
+	return typeName(t, packageName)
+}
+
+// QualifiedName returns the name of the provided type in which named types are qualified by the full import path of
+// their package. Any vendor directory prefix is removed from the import path.
+func QualifiedName(t reflect.Type) string {
+	return typeName(t, stripVendor)
+}
+
+var (
+	// matches an identifier qualified by an import path within the type arguments of an instantiated generic type.
+	// Import paths may contain dots, so the last dot-separated identifier is the type name.
+	qualifiedIdentRegexp = regexp.MustCompile(`((?:[\w\-.~%]+/)*[\w\-.~%]+)\.([A-Za-z_]\w*)`)
+	majorVersionRegexp   = regexp.MustCompile(`^v[0-9]+$`)
+	gopkgVersionRegexp   = regexp.MustCompile(`\.v[0-9]+$`)
+)
+
+func typeName(t reflect.Type, qualify func(pkgPath string) string) string {
+	if t == nil {
+		return "<nil>"
+	}
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			// predeclared type
+			return t.Name()
+		}
+		name := t.Name()
+		if idx := strings.Index(name, "["); idx != -1 {
+			// instantiated generic type: type arguments are always qualified by their full import path
+			args := qualifiedIdentRegexp.ReplaceAllStringFunc(name[idx:], func(match string) string {
+				parts := qualifiedIdentRegexp.FindStringSubmatch(match)
+				return qualify(unescapePath(parts[1])) + "." + parts[2]
+			})
+			name = name[:idx] + args
+		}
+		return qualify(t.PkgPath()) + "." + name
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + typeName(t.Elem(), qualify)
+	case reflect.Slice:
+		return "[]" + typeName(t.Elem(), qualify)
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), typeName(t.Elem(), qualify))
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", typeName(t.Key(), qualify), typeName(t.Elem(), qualify))
+	case reflect.Chan:
+		switch t.ChanDir() {
+		case reflect.RecvDir:
+			return "<-chan " + typeName(t.Elem(), qualify)
+		case reflect.SendDir:
+			return "chan<- " + typeName(t.Elem(), qualify)
+		default:
+			elem := typeName(t.Elem(), qualify)
+			if t.Elem().Kind() == reflect.Chan && t.Elem().Name() == "" && t.Elem().ChanDir() == reflect.RecvDir {
+				elem = "(" + elem + ")"
+			}
+			return "chan " + elem
+		}
+	case reflect.Func:
+		return "func" + signature(t, qualify)
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "interface {}"
+		}
+		methods := make([]string, t.NumMethod())
+		for i := range methods {
+			m := t.Method(i)
+			methods[i] = m.Name + signature(m.Type, qualify)
+		}
+		return "interface { " + strings.Join(methods, "; ") + " }"
+	case reflect.Struct:
+		if t.NumField() == 0 {
+			return "struct {}"
+		}
+		fields := make([]string, t.NumField())
+		for i := range fields {
+			f := t.Field(i)
+			fieldType := typeName(f.Type, qualify)
+			if f.Anonymous {
+				fields[i] = fieldType
+				continue
+			}
+			fields[i] = f.Name + " " + fieldType
+		}
+		return "struct { " + strings.Join(fields, "; ") + " }"
+	default:
+		return t.String()
+	}
+}
+
+// signature returns the parameters and results of the provided function type in the form "(A, B) (C, D)".
+func signature(t reflect.Type, qualify func(pkgPath string) string) string {
+	params := make([]string, t.NumIn())
+	for i := range params {
+		if t.IsVariadic() && i == len(params)-1 {
+			params[i] = "..." + typeName(t.In(i).Elem(), qualify)
+			continue
+		}
+		params[i] = typeName(t.In(i), qualify)
+	}
+	out := "(" + strings.Join(params, ", ") + ")"
+
+	results := make([]string, t.NumOut())
+	for i := range results {
+		results[i] = typeName(t.Out(i), qualify)
+	}
+	switch len(results) {
+	case 0:
+		return out
+	case 1:
+		return out + " " + results[0]
+	default:
+		return out + " (" + strings.Join(results, ", ") + ")"
+	}
+}
+
+// unescapePath reverses the escaping applied to import paths in the names of type arguments, in which some characters
+// of the last element of the path (such as ".") are escaped using "%xx".
+func unescapePath(pkgPath string) string {
+	if unescaped, err := url.PathUnescape(pkgPath); err == nil {
+		return unescaped
+	}
+	return pkgPath
+}
+
+func stripVendor(pkgPath string) string {
+	if idx := strings.LastIndex(pkgPath, "/vendor/"); idx != -1 {
+		return pkgPath[idx+len("/vendor/"):]
+	}
+	return pkgPath
+}
+
+func packageName(pkgPath string) string {
+	elems := strings.Split(stripVendor(pkgPath), "/")
+	name := elems[len(elems)-1]
+	if majorVersionRegexp.MatchString(name) && len(elems) > 1 {
+		name = elems[len(elems)-2]
+	}
+	return gopkgVersionRegexp.ReplaceAllString(name, "")
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package typenames_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+
+	"github.com/palantir/pkg/typenames"
+)
+
+type pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+func TestNameGeneric(t *testing.T) {
+	for i, tc := range []struct {
+		in            interface{}
+		want          string
+		wantQualified string
+	}{
+		{pair[string, int]{}, "typenames_test.pair[string,int]", "github.com/palantir/pkg/typenames_test.pair[string,int]"},
+		{pair[string, yaml.MapItem]{}, "typenames_test.pair[string,yaml.MapItem]", "github.com/palantir/pkg/typenames_test.pair[string,gopkg.in/yaml.v2.MapItem]"},
+		{
+			[]pair[int, pair[string, []*testStruct]]{},
+			"[]typenames_test.pair[int,typenames_test.pair[string,[]*typenames_test.testStruct]]",
+			"[]github.com/palantir/pkg/typenames_test.pair[int,github.com/palantir/pkg/typenames_test.pair[string,[]*github.com/palantir/pkg/typenames_test.testStruct]]",
+		},
+	} {
+		assert.Equal(t, tc.want, typenames.Name(reflect.TypeOf(tc.in)), "Case %d", i)
+		assert.Equal(t, tc.wantQualified, typenames.QualifiedName(reflect.TypeOf(tc.in)), "Case %d", i)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typenames_test
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+
+	"github.com/palantir/pkg/typenames"
+)
+
+type testStruct struct {
+	Name string
+}
+
+func TestName(t *testing.T) {
+	for i, tc := range []struct {
+		in            interface{}
+		want          string
+		wantQualified string
+	}{
+		{nil, "<nil>", "<nil>"},
+		{1, "int", "int"},
+		{testStruct{}, "typenames_test.testStruct", "github.com/palantir/pkg/typenames_test.testStruct"},
+		{&testStruct{}, "*typenames_test.testStruct", "*github.com/palantir/pkg/typenames_test.testStruct"},
+		{yaml.MapItem{}, "yaml.MapItem", "gopkg.in/yaml.v2.MapItem"},
+		{[]yaml.MapSlice{}, "[]yaml.MapSlice", "[]gopkg.in/yaml.v2.MapSlice"},
+		{[2]int{}, "[2]int", "[2]int"},
+		{map[string]*testStruct{}, "map[string]*typenames_test.testStruct", "map[string]*github.com/palantir/pkg/typenames_test.testStruct"},
+		{make(<-chan int), "<-chan int", "<-chan int"},
+		{make(chan (<-chan int)), "chan (<-chan int)", "chan (<-chan int)"},
+		{func(string, ...int) (bool, error) { return false, nil }, "func(string, ...int) (bool, error)", "func(string, ...int) (bool, error)"},
+		{[]interface{}{}, "[]interface {}", "[]interface {}"},
+		{[]io.Reader{}, "[]io.Reader", "[]io.Reader"},
+		{[]interface{ Close() error }{}, "[]interface { Close() error }", "[]interface { Close() error }"},
+		{struct{}{}, "struct {}", "struct {}"},
+		{struct {
+			testStruct
+			Count int            `json:"count"`
+			Items []yaml.MapItem `json:"items"`
+		}{}, "struct { typenames_test.testStruct; Count int; Items []yaml.MapItem }", "struct { github.com/palantir/pkg/typenames_test.testStruct; Count int; Items []gopkg.in/yaml.v2.MapItem }"},
+	} {
+		assert.Equal(t, tc.want, typenames.Of(tc.in), "Case %d", i)
+		assert.Equal(t, tc.want, typenames.Name(reflect.TypeOf(tc.in)), "Case %d", i)
+		assert.Equal(t, tc.wantQualified, typenames.QualifiedName(reflect.TypeOf(tc.in)), "Case %d", i)
+	}
+}
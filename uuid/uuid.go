@@ -7,6 +7,7 @@ package uuid
 import (
 	"encoding"
 	"fmt"
+	"io"
 
 	"github.com/palantir/pkg/uuid/internal/uuid"
 )
@@ -15,6 +16,19 @@ func NewUUID() UUID {
 	return [16]byte(uuid.New())
 }
 
+// NewUUIDFromReader returns a new version 4 UUID whose random bits are read from the provided reader rather than from
+// crypto/rand. Providing a seeded source of randomness makes the generated UUIDs reproducible, which is useful in
+// tests. Returns an error if reading from the reader fails.
+func NewUUIDFromReader(r io.Reader) (UUID, error) {
+	var u UUID
+	if _, err := io.ReadFull(r, u[:]); err != nil {
+		return UUID{}, err
+	}
+	u[6] = (u[6] & 0x0f) | 0x40 // version 4
+	u[8] = (u[8] & 0x3f) | 0x80 // variant RFC 4122
+	return u, nil
+}
+
 var (
 	_ fmt.Stringer             = UUID{}
 	_ encoding.TextMarshaler   = UUID{}
@@ -5,8 +5,10 @@
 package uuid_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -54,3 +56,28 @@ func TestNewUUID(t *testing.T) {
 	u2 := uuid.NewUUID()
 	require.NotEqual(t, u1.String(), u2.String(), "Two UUIDs should not be equal.")
 }
+
+func TestNewUUIDFromReader(t *testing.T) {
+	u, err := uuid.NewUUIDFromReader(bytes.NewReader(bytes.Repeat([]byte{0xff}, 16)))
+	require.NoError(t, err)
+	assert.Equal(t, "ffffffff-ffff-4fff-bfff-ffffffffffff", u.String())
+
+	_, err = uuid.NewUUIDFromReader(bytes.NewReader([]byte{0x00}))
+	assert.Error(t, err)
+}
+
+func TestNewUUIDv7(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	u1 := uuid.NewUUIDv7()
+	time.Sleep(2 * time.Millisecond)
+	u2 := uuid.NewUUIDv7()
+	after := time.Now()
+
+	for _, u := range []uuid.UUID{u1, u2} {
+		assert.Equal(t, byte(0x70), u[6]&0xf0, "version must be 7")
+		assert.Equal(t, byte(0x80), u[8]&0xc0, "variant must be RFC 4122")
+		assert.False(t, u.Time().Before(before))
+		assert.False(t, u.Time().After(after))
+	}
+	assert.True(t, u1.String() < u2.String(), "version 7 UUIDs should sort by creation time")
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+// NewUUIDv7 returns a new version 7 UUID as defined in RFC 9562. The first 48 bits of a version 7 UUID are the number
+// of milliseconds since the Unix epoch, so UUIDs generated by this function sort by creation time. The remaining bits
+// (other than the version and variant) are random.
+func NewUUIDv7() UUID {
+	return newUUIDv7(time.Now(), rand.Reader)
+}
+
+func newUUIDv7(now time.Time, r io.Reader) UUID {
+	var u UUID
+	if _, err := io.ReadFull(r, u[6:]); err != nil {
+		panic(err)
+	}
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(now.UnixNano()/int64(time.Millisecond)))
+	copy(u[:6], ts[2:])
+	u[6] = (u[6] & 0x0f) | 0x70 // version 7
+	u[8] = (u[8] & 0x3f) | 0x80 // variant RFC 4122
+	return u
+}
+
+// Time returns the creation time encoded in a version 7 UUID with millisecond precision. The result is not meaningful
+// for UUIDs of other versions.
+func (u UUID) Time() time.Time {
+	var ts [8]byte
+	copy(ts[2:], u[:6])
+	ms := int64(binary.BigEndian.Uint64(ts[:]))
+	return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond))
+}
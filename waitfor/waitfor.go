@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package waitfor provides functions that wait for a resource to become ready: a TCP port that accepts connections, an
+// HTTP endpoint that reports that it is healthy, or a file that exists. This is useful for programs and tests that
+// start a server or sidecar process and must wait until it is ready before proceeding.
+//
+// Every function checks the resource repeatedly using retry.Do until it is ready or the provided context is done.
+// Checks are made with exponential backoff starting at 50ms and capped at 1s: the backoff can be customized by
+// providing retry options.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	if err := waitfor.HTTP(ctx, nil, "http://localhost:8080/health"); err != nil {
+//		return err
+//	}
+package waitfor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/palantir/pkg/retry"
+)
+
+const (
+	defaultInitialBackoff = 50 * time.Millisecond
+	defaultMaxBackoff     = time.Second
+)
+
+// Port waits until a TCP connection to the provided address (in the form "host:port") can be established.
+func Port(ctx context.Context, addr string, opts ...retry.Option) error {
+	var dialer net.Dialer
+	return wait(ctx, fmt.Sprintf("%s to accept connections", addr), func() error {
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}, opts)
+}
+
+// HTTP waits until a GET request to the provided URL returns a response with a 2xx status code. If client is nil,
+// http.DefaultClient is used.
+func HTTP(ctx context.Context, client *http.Client, url string, opts ...retry.Option) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	return wait(ctx, fmt.Sprintf("%s to become healthy", url), func() error {
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		return nil
+	}, opts)
+}
+
+// File waits until a file or directory exists at the provided path.
+func File(ctx context.Context, path string, opts ...retry.Option) error {
+	return wait(ctx, fmt.Sprintf("%s to exist", path), func() error {
+		_, err := os.Stat(path)
+		return err
+	}, opts)
+}
+
+func wait(ctx context.Context, desc string, check func() error, opts []retry.Option) error {
+	opts = append([]retry.Option{
+		retry.WithInitialBackoff(defaultInitialBackoff),
+		retry.WithMaxBackoff(defaultMaxBackoff),
+	}, opts...)
+	if err := retry.Do(ctx, check, opts...); err != nil {
+		return fmt.Errorf("failed waiting for %s: %v", desc, err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package waitfor_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nmiyake/pkg/dirs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/retry"
+	"github.com/palantir/pkg/waitfor"
+)
+
+func TestPort(t *testing.T) {
+	// reserve a free port and release it so that nothing is listening on it
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			return
+		}
+		defer func() {
+			_ = lis.Close()
+		}()
+		conn, err := lis.Accept()
+		if err == nil {
+			_ = conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, waitfor.Port(ctx, addr))
+}
+
+func TestHTTP(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, waitfor.HTTP(ctx, nil, server.URL+"/health", retry.WithInitialBackoff(time.Millisecond)))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestHTTPFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := waitfor.HTTP(context.Background(), nil, server.URL, retry.WithInitialBackoff(time.Millisecond), retry.WithMaxAttempts(2))
+	assert.EqualError(t, err, "failed waiting for "+server.URL+" to become healthy: unexpected status 503 Service Unavailable")
+}
+
+func TestFile(t *testing.T) {
+	tmpDir, cleanup, err := dirs.TempDir("", "")
+	defer cleanup()
+	require.NoError(t, err)
+
+	path := filepath.Join(tmpDir, "ready")
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = ioutil.WriteFile(path, nil, 0644)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, waitfor.File(ctx, path))
+}
+
+func TestFileContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := waitfor.File(ctx, "/path/that/does/not/exist")
+	assert.EqualError(t, err, "failed waiting for /path/that/does/not/exist to exist: stat /path/that/does/not/exist: no such file or directory")
+}
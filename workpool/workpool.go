@@ -0,0 +1,260 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package workpool runs a function on a set of inputs using a bounded number of concurrent workers. It is intended for
+// commands that operate on many files or resources at once:
+//
+//	err := workpool.Run(ctx, paths, func(ctx context.Context, path string) (int64, error) {
+//		return upload(ctx, path)
+//	}, func(r workpool.Result[string, int64]) error {
+//		if r.Err != nil {
+//			fmt.Printf("%s: failed: %v\n", r.Input, r.Err)
+//			return nil
+//		}
+//		fmt.Printf("%s: uploaded %d bytes\n", r.Input, r.Output)
+//		return nil
+//	}, workpool.WithWorkers(8), workpool.WithErrorPolicy(workpool.CollectErrors), workpool.WithOrderedResults())
+package workpool
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrorPolicy specifies how a pool handles inputs for which the function returns an error.
+type ErrorPolicy int
+
+const (
+	// StopOnFirstError stops processing when the function returns an error for any input: inputs that have not been
+	// started are not processed, the context provided to running functions is cancelled and the error is returned.
+	// Results for inputs that fail are not provided to the result handler.
+	StopOnFirstError ErrorPolicy = iota
+	// CollectErrors processes all of the inputs regardless of errors. Results for inputs that fail are provided to the
+	// result handler and the errors are returned as an Errors.
+	CollectErrors
+)
+
+// Result is the result of running the function of a pool on an input.
+type Result[In, Out any] struct {
+	// Index is the index of the input.
+	Index int
+	// Input is the input.
+	Input In
+	// Output is the output returned by the function.
+	Output Out
+	// Err is the error returned by the function.
+	Err error
+}
+
+// Errors is the error returned by Run when the CollectErrors policy is used and the function returns an error for one
+// or more inputs. The errors are ordered by the index of their input.
+type Errors []error
+
+func (e Errors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = "\t" + strings.Replace(err.Error(), "\n", "\n\t", -1)
+	}
+	return fmt.Sprintf("%d errors occurred:\n%s", len(e), strings.Join(msgs, "\n"))
+}
+
+// Option configures the behavior of Run.
+type Option func(o *options)
+
+type options struct {
+	workers       int
+	errorPolicy   ErrorPolicy
+	orderedResult bool
+	progress      func(completed, total int)
+}
+
+// WithWorkers sets the maximum number of inputs that are processed concurrently. The default is the value of
+// runtime.GOMAXPROCS. Values that are not positive are ignored.
+func WithWorkers(workers int) Option {
+	return func(o *options) {
+		if workers > 0 {
+			o.workers = workers
+		}
+	}
+}
+
+// WithErrorPolicy sets the policy for handling errors. The default is StopOnFirstError.
+func WithErrorPolicy(policy ErrorPolicy) Option {
+	return func(o *options) {
+		o.errorPolicy = policy
+	}
+}
+
+// WithOrderedResults configures Run to provide results to the result handler in the order of the inputs rather than
+// in the order in which they complete. Results that complete out of order are buffered until all of the results that
+// precede them have been handled.
+func WithOrderedResults() Option {
+	return func(o *options) {
+		o.orderedResult = true
+	}
+}
+
+// WithProgress sets a function that is called every time an input has been processed with the number of inputs that
+// have been processed and the total number of inputs. It is called from the goroutine that called Run.
+func WithProgress(progress func(completed, total int)) Option {
+	return func(o *options) {
+		o.progress = progress
+	}
+}
+
+// Run calls fn on every input using a bounded number of concurrent workers and provides each result to handle (which
+// may be nil). handle is called from the goroutine that called Run, so it does not need to be safe for concurrent use.
+// If handle returns an error, processing stops as it does for StopOnFirstError and the error is returned. If ctx is
+// cancelled, inputs that have not been started are not processed and the error of the context is returned.
+func Run[In, Out any](ctx context.Context, inputs []In, fn func(ctx context.Context, in In) (Out, error), handle func(Result[In, Out]) error, opts ...Option) error {
+	o := options{
+		workers: runtime.GOMAXPROCS(0),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	parentCtx := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	indices := make(chan int)
+	go func() {
+		defer close(indices)
+		for idx := range inputs {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case indices <- idx:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make(chan Result[In, Out])
+	var wg sync.WaitGroup
+	for i := 0; i < o.workers && i < len(inputs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indices {
+				out, err := fn(ctx, inputs[idx])
+				results <- Result[In, Out]{
+					Index:  idx,
+					Input:  inputs[idx],
+					Output: out,
+					Err:    err,
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var (
+		stopErr   error
+		errs      Errors
+		errIdxs   []int
+		completed int
+		next      int
+		pending   = make(map[int]Result[In, Out])
+	)
+	stop := func(err error) {
+		stopErr = err
+		cancel()
+	}
+	for r := range results {
+		// results must be drained after stopping so that workers do not block
+		if stopErr != nil {
+			continue
+		}
+		completed++
+		if o.progress != nil {
+			o.progress(completed, len(inputs))
+		}
+		if r.Err != nil {
+			if o.errorPolicy == StopOnFirstError {
+				stop(r.Err)
+				continue
+			}
+			errs = append(errs, r.Err)
+			errIdxs = append(errIdxs, r.Index)
+		}
+
+		ready := []Result[In, Out]{r}
+		if o.orderedResult {
+			pending[r.Index] = r
+			ready = ready[:0]
+			for {
+				nextResult, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				ready = append(ready, nextResult)
+				next++
+			}
+		}
+		if handle == nil {
+			continue
+		}
+		for _, readyResult := range ready {
+			if err := handle(readyResult); err != nil {
+				stop(err)
+				break
+			}
+		}
+	}
+
+	if stopErr != nil {
+		return stopErr
+	}
+	if completed < len(inputs) {
+		return parentCtx.Err()
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Sort(byIndex{errs: errs, idxs: errIdxs})
+	return errs
+}
+
+// Map calls fn on every input using Run and returns the outputs in the order of the inputs. If an error is returned,
+// the outputs of inputs that were not processed successfully are zero values.
+func Map[In, Out any](ctx context.Context, inputs []In, fn func(ctx context.Context, in In) (Out, error), opts ...Option) ([]Out, error) {
+	outputs := make([]Out, len(inputs))
+	err := Run(ctx, inputs, fn, func(r Result[In, Out]) error {
+		outputs[r.Index] = r.Output
+		return nil
+	}, opts...)
+	return outputs, err
+}
+
+type byIndex struct {
+	errs Errors
+	idxs []int
+}
+
+func (s byIndex) Len() int {
+	return len(s.errs)
+}
+
+func (s byIndex) Less(i, j int) bool {
+	return s.idxs[i] < s.idxs[j]
+}
+
+func (s byIndex) Swap(i, j int) {
+	s.errs[i], s.errs[j] = s.errs[j], s.errs[i]
+	s.idxs[i], s.idxs[j] = s.idxs[j], s.idxs[i]
+}
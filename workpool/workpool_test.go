@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package workpool_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/workpool"
+)
+
+func TestRunBoundsConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	inputs := make([]int, 20)
+	err := workpool.Run(context.Background(), inputs, func(ctx context.Context, in int) (struct{}, error) {
+		curr := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			prevMax := atomic.LoadInt32(&maxRunning)
+			if curr <= prevMax || atomic.CompareAndSwapInt32(&maxRunning, prevMax, curr) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return struct{}{}, nil
+	}, nil, workpool.WithWorkers(3))
+	require.NoError(t, err)
+	assert.True(t, maxRunning <= 3, "at most 3 inputs should be processed concurrently, was %d", maxRunning)
+}
+
+func TestRunOrderedResults(t *testing.T) {
+	inputs := []int{5, 4, 3, 2, 1, 0}
+	var handled []int
+	var progress []int
+	err := workpool.Run(context.Background(), inputs, func(ctx context.Context, in int) (int, error) {
+		// later inputs complete first
+		time.Sleep(time.Duration(in) * 5 * time.Millisecond)
+		return in * 10, nil
+	}, func(r workpool.Result[int, int]) error {
+		assert.Equal(t, inputs[r.Index], r.Input)
+		handled = append(handled, r.Output)
+		return nil
+	}, workpool.WithWorkers(len(inputs)), workpool.WithOrderedResults(), workpool.WithProgress(func(completed, total int) {
+		assert.Equal(t, len(inputs), total)
+		progress = append(progress, completed)
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []int{50, 40, 30, 20, 10, 0}, handled)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, progress)
+}
+
+func TestRunStopOnFirstError(t *testing.T) {
+	var calls int32
+	inputs := make([]int, 100)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	err := workpool.Run(context.Background(), inputs, func(ctx context.Context, in int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		if in == 2 {
+			return 0, fmt.Errorf("failed on %d", in)
+		}
+		return in, nil
+	}, func(r workpool.Result[int, int]) error {
+		assert.NoError(t, r.Err)
+		return nil
+	}, workpool.WithWorkers(1))
+	assert.EqualError(t, err, "failed on 2")
+	assert.True(t, atomic.LoadInt32(&calls) < int32(len(inputs)), "processing should stop after the first error")
+}
+
+func TestRunCollectErrors(t *testing.T) {
+	inputs := []int{0, 1, 2, 3, 4}
+	outputs, err := workpool.Map(context.Background(), inputs, func(ctx context.Context, in int) (int, error) {
+		if in%2 == 1 {
+			// odd inputs fail in reverse order of their index
+			time.Sleep(time.Duration(5-in) * 5 * time.Millisecond)
+			return 0, fmt.Errorf("failed on %d", in)
+		}
+		return in * 10, nil
+	}, workpool.WithWorkers(5), workpool.WithErrorPolicy(workpool.CollectErrors))
+	assert.Equal(t, []int{0, 0, 20, 0, 40}, outputs)
+	require.Error(t, err)
+	assert.Equal(t, workpool.Errors{fmt.Errorf("failed on 1"), fmt.Errorf("failed on 3")}, err)
+	assert.EqualError(t, err, "2 errors occurred:\n\tfailed on 1\n\tfailed on 3")
+}
+
+func TestRunHandlerError(t *testing.T) {
+	err := workpool.Run(context.Background(), []int{0, 1, 2}, func(ctx context.Context, in int) (int, error) {
+		return in, nil
+	}, func(r workpool.Result[int, int]) error {
+		return fmt.Errorf("handler failed")
+	}, workpool.WithWorkers(1))
+	assert.EqualError(t, err, "handler failed")
+}
+
+func TestRunContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls int32
+	err := workpool.Run(ctx, make([]int, 10), func(ctx context.Context, in int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		return in, nil
+	}, nil, workpool.WithWorkers(1))
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+}
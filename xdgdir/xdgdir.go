@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package xdgdir resolves the base directories in which applications store configuration, cache, state and data files
+// as defined by the XDG Base Directory Specification. If the environment variable for a directory is set to an
+// absolute path, it is used. Otherwise, the default for the platform is used: on Windows, the defaults are based on
+// the APPDATA and LOCALAPPDATA environment variables, and on all other platforms the defaults are the ones defined by
+// the specification (for example, "~/.config" for configuration).
+package xdgdir
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// ConfigHome returns the base directory for user-specific configuration files ($XDG_CONFIG_HOME).
+func ConfigHome() (string, error) {
+	return baseDir("XDG_CONFIG_HOME", "APPDATA", ".config")
+}
+
+// CacheHome returns the base directory for user-specific non-essential (cached) data ($XDG_CACHE_HOME).
+func CacheHome() (string, error) {
+	return baseDir("XDG_CACHE_HOME", "LOCALAPPDATA", ".cache")
+}
+
+// StateHome returns the base directory for user-specific state data that should persist between restarts, such as
+// logs and history ($XDG_STATE_HOME).
+func StateHome() (string, error) {
+	return baseDir("XDG_STATE_HOME", "LOCALAPPDATA", filepath.Join(".local", "state"))
+}
+
+// DataHome returns the base directory for user-specific data files ($XDG_DATA_HOME).
+func DataHome() (string, error) {
+	return baseDir("XDG_DATA_HOME", "APPDATA", filepath.Join(".local", "share"))
+}
+
+// Dirs are the directories of a specific application.
+type Dirs struct {
+	Config string
+	Cache  string
+	State  string
+	Data   string
+}
+
+// AppDirs returns the directories for the application with the provided name: each directory is the directory with
+// that name in the corresponding base directory. The directories are not created.
+func AppDirs(appName string) (Dirs, error) {
+	var dirs Dirs
+	for _, curr := range []struct {
+		dst  *string
+		base func() (string, error)
+	}{
+		{&dirs.Config, ConfigHome},
+		{&dirs.Cache, CacheHome},
+		{&dirs.State, StateHome},
+		{&dirs.Data, DataHome},
+	} {
+		base, err := curr.base()
+		if err != nil {
+			return Dirs{}, err
+		}
+		*curr.dst = filepath.Join(base, appName)
+	}
+	return dirs, nil
+}
+
+func baseDir(xdgVar, windowsVar, homeRelPath string) (string, error) {
+	// the specification requires relative paths to be ignored
+	if dir := os.Getenv(xdgVar); dir != "" && filepath.IsAbs(dir) {
+		return dir, nil
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv(windowsVar); dir != "" {
+			return dir, nil
+		}
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine %s: %v", xdgVar, err)
+	}
+	return filepath.Join(homeDir, homeRelPath), nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package xdgdir_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/pkg/xdgdir"
+)
+
+func TestAppDirs(t *testing.T) {
+	for _, name := range []string{"HOME", "XDG_CONFIG_HOME", "XDG_CACHE_HOME", "XDG_STATE_HOME", "XDG_DATA_HOME"} {
+		val, ok := os.LookupEnv(name)
+		defer func(name, val string, ok bool) {
+			if ok {
+				_ = os.Setenv(name, val)
+			} else {
+				_ = os.Unsetenv(name)
+			}
+		}(name, val, ok)
+		require.NoError(t, os.Unsetenv(name))
+	}
+	require.NoError(t, os.Setenv("HOME", "/home/user"))
+	require.NoError(t, os.Setenv("XDG_CONFIG_HOME", "/etc/user-config"))
+	// relative paths are ignored
+	require.NoError(t, os.Setenv("XDG_CACHE_HOME", "relative/cache"))
+
+	dirs, err := xdgdir.AppDirs("my-app")
+	require.NoError(t, err)
+	assert.Equal(t, xdgdir.Dirs{
+		Config: "/etc/user-config/my-app",
+		Cache:  filepath.Join("/home/user", ".cache", "my-app"),
+		State:  filepath.Join("/home/user", ".local", "state", "my-app"),
+		Data:   filepath.Join("/home/user", ".local", "share", "my-app"),
+	}, dirs)
+}